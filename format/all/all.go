@@ -3,34 +3,77 @@
 package all
 
 import (
+	_ "github.com/wader/fq/format/android"
 	_ "github.com/wader/fq/format/ape"
+	_ "github.com/wader/fq/format/arrow"
+	_ "github.com/wader/fq/format/audit"
 	_ "github.com/wader/fq/format/av1"
+	_ "github.com/wader/fq/format/bcf"
+	_ "github.com/wader/fq/format/brotli"
 	_ "github.com/wader/fq/format/bzip2"
+	_ "github.com/wader/fq/format/cbor"
+	_ "github.com/wader/fq/format/cpio"
+	_ "github.com/wader/fq/format/crx"
+	_ "github.com/wader/fq/format/cuesheet"
+	_ "github.com/wader/fq/format/dicom"
+	_ "github.com/wader/fq/format/director"
 	_ "github.com/wader/fq/format/dns"
 	_ "github.com/wader/fq/format/elf"
 	_ "github.com/wader/fq/format/flac"
+	_ "github.com/wader/fq/format/fnt"
+	_ "github.com/wader/fq/format/fsevents"
 	_ "github.com/wader/fq/format/gif"
+	_ "github.com/wader/fq/format/grib"
 	_ "github.com/wader/fq/format/gzip"
 	_ "github.com/wader/fq/format/icc"
 	_ "github.com/wader/fq/format/id3"
+	_ "github.com/wader/fq/format/iff"
+	_ "github.com/wader/fq/format/indx"
 	_ "github.com/wader/fq/format/inet"
 	_ "github.com/wader/fq/format/jpeg"
 	_ "github.com/wader/fq/format/json"
+	_ "github.com/wader/fq/format/kafka"
+	_ "github.com/wader/fq/format/las"
+	_ "github.com/wader/fq/format/luks"
+	_ "github.com/wader/fq/format/lzw"
 	_ "github.com/wader/fq/format/matroska"
+	_ "github.com/wader/fq/format/mobileprovision"
+	_ "github.com/wader/fq/format/mozlz4"
 	_ "github.com/wader/fq/format/mp3"
 	_ "github.com/wader/fq/format/mp4"
 	_ "github.com/wader/fq/format/mpeg"
+	_ "github.com/wader/fq/format/nifti"
 	_ "github.com/wader/fq/format/ogg"
+	_ "github.com/wader/fq/format/opentype"
 	_ "github.com/wader/fq/format/opus"
 	_ "github.com/wader/fq/format/pcap"
+	_ "github.com/wader/fq/format/pcd"
+	_ "github.com/wader/fq/format/pcf"
+	_ "github.com/wader/fq/format/pcx"
+	_ "github.com/wader/fq/format/plist"
 	_ "github.com/wader/fq/format/png"
+	_ "github.com/wader/fq/format/prefetch"
 	_ "github.com/wader/fq/format/protobuf"
+	_ "github.com/wader/fq/format/psd"
 	_ "github.com/wader/fq/format/raw"
+	_ "github.com/wader/fq/format/snappy"
+	_ "github.com/wader/fq/format/snss"
+	_ "github.com/wader/fq/format/speex"
+	_ "github.com/wader/fq/format/spotlight"
+	_ "github.com/wader/fq/format/sqlite"
+	_ "github.com/wader/fq/format/ssh"
 	_ "github.com/wader/fq/format/tar"
+	_ "github.com/wader/fq/format/theora"
 	_ "github.com/wader/fq/format/tiff"
+	_ "github.com/wader/fq/format/tracev3"
+	_ "github.com/wader/fq/format/usnjournal"
 	_ "github.com/wader/fq/format/vorbis"
 	_ "github.com/wader/fq/format/vpx"
+	_ "github.com/wader/fq/format/wasm"
 	_ "github.com/wader/fq/format/wav"
 	_ "github.com/wader/fq/format/webp"
+	_ "github.com/wader/fq/format/xml"
+	_ "github.com/wader/fq/format/xmp"
 	_ "github.com/wader/fq/format/zip"
+	_ "github.com/wader/fq/format/zlib"
 )
@@ -0,0 +1,128 @@
+package android
+
+// https://source.android.com/docs/core/architecture/bootloader/boot-image-header
+// https://android.googlesource.com/platform/system/tools/mkbootimg/+/refs/heads/master/include/bootimg/bootimg.h
+// TODO: vendor_boot.img (VNDRBOOT)
+
+import (
+	"fmt"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.ANDROID_BOOT,
+		Description: "Android boot image",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    bootDecode,
+	})
+}
+
+// version 3 and later always use 4096 byte pages
+const bootV3PageSize = 4096
+
+// os version is 7 bit a.b.c version and 7 bit year since 2000 and 4 bit month patch level
+var osVersion = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	v, ok := s.Actual.(uint64)
+	if !ok || v == 0 {
+		return s, nil
+	}
+	s.Sym = fmt.Sprintf("%d.%d.%d %04d-%02d",
+		(v>>25)&0x7f, (v>>18)&0x7f, (v>>11)&0x7f,
+		2000+((v>>4)&0x7f), v&0xf,
+	)
+	return s, nil
+})
+
+func bootDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	// header version is at the same offset in all versions
+	d.SeekAbs(40 * 8)
+	headerVersion := d.U32()
+	d.SeekAbs(0)
+
+	var kernelSize, ramdiskSize, secondSize uint64
+	var recoveryDTBOSize, dtbSize, signatureSize uint64
+	var pageSize uint64
+
+	d.FieldUTF8("magic", 8, d.AssertStr("ANDROID!"))
+	if headerVersion >= 3 {
+		kernelSize = d.FieldU32("kernel_size")
+		ramdiskSize = d.FieldU32("ramdisk_size")
+		d.FieldU32("os_version", osVersion)
+		d.FieldU32("header_size")
+		d.FieldArray("reserved", func(d *decode.D) {
+			for i := 0; i < 4; i++ {
+				d.FieldU32("reserved")
+			}
+		})
+		d.FieldU32("header_version")
+		d.FieldUTF8NullFixedLen("cmdline", 1536)
+		if headerVersion >= 4 {
+			signatureSize = d.FieldU32("signature_size")
+		}
+		pageSize = bootV3PageSize
+	} else {
+		kernelSize = d.FieldU32("kernel_size")
+		d.FieldU32("kernel_addr", scalar.Hex)
+		ramdiskSize = d.FieldU32("ramdisk_size")
+		d.FieldU32("ramdisk_addr", scalar.Hex)
+		secondSize = d.FieldU32("second_size")
+		d.FieldU32("second_addr", scalar.Hex)
+		d.FieldU32("tags_addr", scalar.Hex)
+		pageSize = d.FieldU32("page_size")
+		d.FieldU32("header_version")
+		d.FieldU32("os_version", osVersion)
+		d.FieldUTF8NullFixedLen("name", 16)
+		d.FieldUTF8NullFixedLen("cmdline", 512)
+		d.FieldRawLen("id", 32*8, scalar.RawHex)
+		d.FieldUTF8NullFixedLen("extra_cmdline", 1024)
+		if headerVersion >= 1 {
+			recoveryDTBOSize = d.FieldU32("recovery_dtbo_size")
+			d.FieldU64("recovery_dtbo_offset")
+			d.FieldU32("header_size")
+		}
+		if headerVersion >= 2 {
+			dtbSize = d.FieldU32("dtb_size")
+			d.FieldU64("dtb_addr", scalar.Hex)
+		}
+	}
+	if pageSize == 0 || pageSize&(pageSize-1) != 0 {
+		d.Fatalf("invalid page size %d", pageSize)
+	}
+
+	// each part starts at a page boundary after the header page
+	pagesLen := func(size uint64) int64 {
+		return int64((size+pageSize-1)/pageSize*pageSize) * 8
+	}
+	pos := pagesLen(uint64(d.Pos() / 8))
+	for _, p := range []struct {
+		name string
+		size uint64
+	}{
+		{"kernel", kernelSize},
+		{"ramdisk", ramdiskSize},
+		{"second", secondSize},
+		{"recovery_dtbo", recoveryDTBOSize},
+		{"dtb", dtbSize},
+		{"signature", signatureSize},
+	} {
+		if p.size == 0 {
+			continue
+		}
+		if pos+int64(p.size)*8 > d.Len() {
+			d.Fatalf("%s outside of file", p.name)
+		}
+		d.RangeFn(pos, int64(p.size)*8, func(d *decode.D) {
+			d.FieldRawLen(p.name, int64(p.size)*8)
+		})
+		pos += pagesLen(p.size)
+	}
+
+	return nil
+}
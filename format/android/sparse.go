@@ -0,0 +1,98 @@
+package android
+
+// https://android.googlesource.com/platform/system/core/+/master/libsparse/sparse_format.h
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.ANDROID_SPARSE,
+		Description: "Android sparse image",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    sparseDecode,
+	})
+}
+
+const (
+	chunkTypeRaw      = 0xcac1
+	chunkTypeFill     = 0xcac2
+	chunkTypeDontCare = 0xcac3
+	chunkTypeCRC32    = 0xcac4
+)
+
+var chunkTypeNames = scalar.UToSymStr{
+	chunkTypeRaw:      "raw",
+	chunkTypeFill:     "fill",
+	chunkTypeDontCare: "dont_care",
+	chunkTypeCRC32:    "crc32",
+}
+
+func sparseDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	var fileHeaderSize uint64
+	var chunkHeaderSize uint64
+	var blockSize uint64
+	var totalChunks uint64
+	d.FieldStruct("header", func(d *decode.D) {
+		d.FieldU32("magic", d.AssertU(0xed26ff3a), scalar.Hex)
+		d.FieldU16("major_version", d.AssertU(1))
+		d.FieldU16("minor_version")
+		fileHeaderSize = d.FieldU16("file_header_size")
+		chunkHeaderSize = d.FieldU16("chunk_header_size")
+		blockSize = d.FieldU32("block_size")
+		d.FieldU32("total_blocks")
+		totalChunks = d.FieldU32("total_chunks")
+		d.FieldU32("image_checksum", scalar.Hex)
+		// header might be extended in later minor versions
+		if fileHeaderSize < 28 {
+			d.Fatalf("file header size %d too small", fileHeaderSize)
+		}
+		if fileHeaderSize > 28 {
+			d.FieldRawLen("unknown", int64(fileHeaderSize-28)*8)
+		}
+	})
+	if chunkHeaderSize < 12 {
+		d.Fatalf("chunk header size %d too small", chunkHeaderSize)
+	}
+
+	d.FieldArrayCount("chunks", totalChunks, func(d *decode.D) {
+		d.FieldStruct("chunk", func(d *decode.D) {
+			chunkType := d.FieldU16("chunk_type", chunkTypeNames, scalar.Hex)
+			d.FieldU16("reserved")
+			chunkBlocks := d.FieldU32("chunk_size")
+			totalSize := d.FieldU32("total_size")
+			if chunkHeaderSize > 12 {
+				d.FieldRawLen("unknown", int64(chunkHeaderSize-12)*8)
+			}
+			if totalSize < chunkHeaderSize {
+				d.Fatalf("chunk total size %d smaller than header", totalSize)
+			}
+			dataLen := int64(totalSize-chunkHeaderSize) * 8
+
+			d.LenFn(dataLen, func(d *decode.D) {
+				switch chunkType {
+				case chunkTypeRaw:
+					if uint64(dataLen/8) != chunkBlocks*blockSize {
+						d.Errorf("raw chunk size %d does not match %d blocks", dataLen/8, chunkBlocks)
+					}
+					d.FieldRawLen("data", dataLen)
+				case chunkTypeFill:
+					d.FieldU32("fill_value", scalar.Hex)
+				case chunkTypeCRC32:
+					d.FieldU32("crc32", scalar.Hex)
+				case chunkTypeDontCare:
+				default:
+					d.FieldRawLen("data", dataLen)
+				}
+			})
+		})
+	})
+
+	return nil
+}
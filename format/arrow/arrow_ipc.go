@@ -0,0 +1,246 @@
+package arrow
+
+// https://arrow.apache.org/docs/format/Columnar.html#serialization-and-interprocess-communication-ipc
+// https://github.com/apache/arrow/blob/master/format/Message.fbs
+// https://github.com/apache/arrow/blob/master/format/Schema.fbs
+// https://github.com/apache/arrow/blob/master/format/File.fbs
+// TODO: decode type specific tables, custom metadata and tensors
+
+import (
+	"bytes"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.ARROW_IPC,
+		Description: "Apache Arrow IPC file and stream",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    arrowIPCDecode,
+	})
+}
+
+var fileMagic = []byte("ARROW1")
+
+const continuationMarker = 0xff_ff_ff_ff
+
+const (
+	headerSchema          = 1
+	headerDictionaryBatch = 2
+	headerRecordBatch     = 3
+)
+
+var metadataVersionNames = scalar.UToSymStr{
+	0: "v1",
+	1: "v2",
+	2: "v3",
+	3: "v4",
+	4: "v5",
+}
+
+var messageHeaderNames = scalar.UToSymStr{
+	0:                     "none",
+	headerSchema:          "schema",
+	headerDictionaryBatch: "dictionary_batch",
+	headerRecordBatch:     "record_batch",
+	4:                     "tensor",
+	5:                     "sparse_tensor",
+}
+
+var endiannessNames = scalar.UToSymStr{
+	0: "little",
+	1: "big",
+}
+
+var typeNames = scalar.UToSymStr{
+	0:  "none",
+	1:  "null",
+	2:  "int",
+	3:  "floating_point",
+	4:  "binary",
+	5:  "utf8",
+	6:  "bool",
+	7:  "decimal",
+	8:  "date",
+	9:  "time",
+	10: "timestamp",
+	11: "interval",
+	12: "list",
+	13: "struct",
+	14: "union",
+	15: "fixed_size_binary",
+	16: "fixed_size_list",
+	17: "map",
+	18: "duration",
+	19: "large_binary",
+	20: "large_utf8",
+	21: "large_list",
+	22: "run_end_encoded",
+	23: "binary_view",
+	24: "utf8_view",
+	25: "list_view",
+	26: "large_list_view",
+}
+
+func decodeField(d *decode.D, t fbTable) {
+	t.fieldString(d, 0, "name")
+	if t.seekField(d, 1) {
+		d.FieldBoolFn("nullable", func(d *decode.D) bool { return d.U8() != 0 })
+	}
+	if t.seekField(d, 2) {
+		d.FieldU8("type_type", typeNames)
+	}
+	t.fieldTables(d, 5, "children", "field", decodeField)
+}
+
+func decodeSchema(d *decode.D, t fbTable) {
+	if t.seekField(d, 0) {
+		d.FieldU16("endianness", endiannessNames)
+	}
+	t.fieldTables(d, 1, "fields", "field", decodeField)
+}
+
+func decodeRecordBatch(d *decode.D, t fbTable) {
+	if t.seekField(d, 0) {
+		d.FieldS64("length")
+	}
+	t.fieldStructs(d, 1, "nodes", "node", 16, func(d *decode.D) {
+		d.FieldS64("length")
+		d.FieldS64("null_count")
+	})
+	t.fieldStructs(d, 2, "buffers", "buffer", 16, func(d *decode.D) {
+		d.FieldS64("offset")
+		d.FieldS64("length")
+	})
+}
+
+func decodeDictionaryBatch(d *decode.D, t fbTable) {
+	if t.seekField(d, 0) {
+		d.FieldS64("id")
+	}
+	if rt, ok := t.table(d, 1); ok {
+		d.FieldStruct("data", func(d *decode.D) { decodeRecordBatch(d, rt) })
+	}
+	if t.seekField(d, 2) {
+		d.FieldBoolFn("is_delta", func(d *decode.D) bool { return d.U8() != 0 })
+	}
+}
+
+// decodeMessage decodes flatbuffers Message table and returns body length
+func decodeMessage(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	m := fbReadTable(d, int64(d.FieldU32("root_offset")))
+	if m.seekField(d, 0) {
+		d.FieldU16("version", metadataVersionNames)
+	}
+	var headerType uint64
+	if m.seekField(d, 1) {
+		headerType = d.FieldU8("header_type", messageHeaderNames)
+	}
+	var bodyLength int64
+	if m.seekField(d, 3) {
+		bodyLength = d.FieldS64("body_length")
+	}
+	if ht, ok := m.table(d, 2); ok {
+		switch headerType {
+		case headerSchema:
+			d.FieldStruct("header", func(d *decode.D) { decodeSchema(d, ht) })
+		case headerDictionaryBatch:
+			d.FieldStruct("header", func(d *decode.D) { decodeDictionaryBatch(d, ht) })
+		case headerRecordBatch:
+			d.FieldStruct("header", func(d *decode.D) { decodeRecordBatch(d, ht) })
+		}
+	}
+
+	return bodyLength
+}
+
+func decodeBlock(d *decode.D) {
+	d.FieldS64("offset")
+	d.FieldS32("meta_data_length")
+	d.FieldPadding("padding", 64)
+	d.FieldS64("body_length")
+}
+
+// decodeFooter decodes flatbuffers Footer table
+func decodeFooter(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	f := fbReadTable(d, int64(d.FieldU32("root_offset")))
+	if f.seekField(d, 0) {
+		d.FieldU16("version", metadataVersionNames)
+	}
+	if st, ok := f.table(d, 1); ok {
+		d.FieldStruct("schema", func(d *decode.D) { decodeSchema(d, st) })
+	}
+	f.fieldStructs(d, 2, "dictionaries", "block", 24, decodeBlock)
+	f.fieldStructs(d, 3, "record_batches", "block", 24, decodeBlock)
+
+	return nil
+}
+
+func arrowIPCDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	// file format has magic at start and end, stream format is just messages
+	isFile := bytes.Equal(d.PeekBytes(len(fileMagic)), fileMagic)
+	if !isFile && d.PeekBits(32) != continuationMarker {
+		d.Fatalf("no file magic or stream continuation marker")
+	}
+
+	messagesEnd := d.Len()
+	var footerLength int64
+	if isFile {
+		d.FieldRawMagic("magic", fileMagic)
+		d.FieldPadding("padding", 64)
+
+		trailerLen := int64(4+len(fileMagic)) * 8
+		if !bytes.Equal(d.BytesRange(d.Len()-int64(len(fileMagic))*8, len(fileMagic)), fileMagic) {
+			d.Fatalf("no end magic")
+		}
+		pos := d.Pos()
+		d.SeekAbs(d.Len() - trailerLen)
+		footerLength = int64(d.U32())
+		d.SeekAbs(pos)
+		messagesEnd = d.Len() - trailerLen - footerLength*8
+	}
+
+	d.FieldArray("messages", func(d *decode.D) {
+		for d.Pos() < messagesEnd {
+			endOfStream := false
+			d.FieldStruct("message", func(d *decode.D) {
+				// continuation marker was added in 0.15.0
+				if d.PeekBits(32) == continuationMarker {
+					d.FieldU32("continuation", scalar.Hex)
+				}
+				metadataLength := d.FieldS32("metadata_length")
+				if metadataLength == 0 {
+					endOfStream = true
+					return
+				}
+				_, v := d.FieldFormatLen("metadata", metadataLength*8, decode.FormatFn(decodeMessage), nil)
+				bodyLength, _ := v.(int64)
+				if bodyLength > 0 {
+					d.FieldRawLen("body", bodyLength*8)
+				}
+			})
+			if endOfStream {
+				break
+			}
+		}
+	})
+
+	if isFile {
+		d.SeekAbs(messagesEnd)
+		d.FieldFormatLen("footer", footerLength*8, decode.FormatFn(decodeFooter), nil)
+		d.FieldS32("footer_length")
+		d.FieldRawMagic("end_magic", fileMagic)
+	}
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package arrow
+
+// Minimal schema-less flatbuffers table reading, enough to find fields of
+// arrow metadata tables.
+// https://google.github.io/flatbuffers/flatbuffers_internals.html
+
+import (
+	"github.com/wader/fq/pkg/decode"
+)
+
+// fbTable is a flatbuffers table, positions are in bytes
+type fbTable struct {
+	pos    int64
+	fields []int64 // field offsets relative to table, 0 if not present
+}
+
+func fbU32(d *decode.D, pos int64) int64 {
+	d.SeekAbs(pos * 8)
+	return int64(d.U32())
+}
+
+func fbReadTable(d *decode.D, pos int64) fbTable {
+	// vtable position is table position minus a signed offset
+	vtPos := pos - int64(int32(fbU32(d, pos)))
+	d.SeekAbs(vtPos * 8)
+	vtLen := int64(d.U16())
+	d.U16() // inline table length
+	t := fbTable{pos: pos}
+	for i := int64(4); i < vtLen; i += 2 {
+		t.fields = append(t.fields, int64(d.U16()))
+	}
+	return t
+}
+
+// seekField seeks to field i, returns false if not present
+func (t fbTable) seekField(d *decode.D, i int) bool {
+	if i >= len(t.fields) || t.fields[i] == 0 {
+		return false
+	}
+	d.SeekAbs((t.pos + t.fields[i]) * 8)
+	return true
+}
+
+// ref returns position of the table, vector or string field i refers to
+func (t fbTable) ref(d *decode.D, i int) (int64, bool) {
+	if !t.seekField(d, i) {
+		return 0, false
+	}
+	p := t.pos + t.fields[i]
+	return p + fbU32(d, p), true
+}
+
+func (t fbTable) table(d *decode.D, i int) (fbTable, bool) {
+	p, ok := t.ref(d, i)
+	if !ok {
+		return fbTable{}, false
+	}
+	return fbReadTable(d, p), true
+}
+
+// vector returns position of first element and number of elements
+func (t fbTable) vector(d *decode.D, i int) (int64, int64, bool) {
+	p, ok := t.ref(d, i)
+	if !ok {
+		return 0, 0, false
+	}
+	return p + 4, fbU32(d, p), true
+}
+
+// fieldTables adds an array of structs decoded by fn for a vector of tables
+func (t fbTable) fieldTables(d *decode.D, i int, name string, elemName string, fn func(d *decode.D, t fbTable)) {
+	p, n, ok := t.vector(d, i)
+	if !ok {
+		return
+	}
+	d.FieldArray(name, func(d *decode.D) {
+		for j := int64(0); j < n; j++ {
+			ep := p + j*4
+			et := fbReadTable(d, ep+fbU32(d, ep))
+			d.FieldStruct(elemName, func(d *decode.D) { fn(d, et) })
+		}
+	})
+}
+
+// fieldStructs adds an array of structs decoded by fn for a vector of inline structs of size bytes
+func (t fbTable) fieldStructs(d *decode.D, i int, name string, elemName string, size int64, fn func(d *decode.D)) {
+	p, n, ok := t.vector(d, i)
+	if !ok {
+		return
+	}
+	d.FieldArray(name, func(d *decode.D) {
+		for j := int64(0); j < n; j++ {
+			d.SeekAbs((p + j*size) * 8)
+			d.FieldStruct(elemName, fn)
+		}
+	})
+}
+
+// fieldString adds string field i, range includes the length
+func (t fbTable) fieldString(d *decode.D, i int, name string) string {
+	p, ok := t.ref(d, i)
+	if !ok {
+		return ""
+	}
+	d.SeekAbs(p * 8)
+	return d.FieldStrFn(name, func(d *decode.D) string {
+		l := d.U32()
+		if int64(l) > d.BitsLeft()/8 {
+			d.Fatalf("string length %d larger than rest of input", l)
+		}
+		return d.UTF8(int(l))
+	})
+}
@@ -0,0 +1,113 @@
+# generates a small arrow ipc file and stream with columns a int32 and b utf8
+# uses a minimal flatbuffers builder that writes tables front to back
+import struct
+
+SIZES = {"u8": 1, "i16": 2, "i32": 4, "i64": 8}
+FORMATS = {"u8": "<B", "i16": "<h", "i32": "<i", "i64": "<q"}
+
+
+class Builder:
+    def __init__(self):
+        self.buf = bytearray()
+
+    def pad(self, align, extra=0):
+        while (len(self.buf) + extra) % align:
+            self.buf.append(0)
+
+    def put(self, b):
+        p = len(self.buf)
+        self.buf += b
+        return p
+
+    def patch(self, at, target):
+        struct.pack_into("<I", self.buf, at, target - at)
+
+    def table(self, fields):
+        nfields = max(fields.keys()) + 1 if fields else 0
+        layout = {}
+        off = 4
+        for idx in sorted(fields, key=lambda i: -SIZES.get(fields[i][0], 4)):
+            size = SIZES.get(fields[idx][0], 4)
+            off += -off % size
+            layout[idx] = off
+            off += size
+        self.pad(2)
+        vt = self.put(struct.pack("<HH", 4 + 2 * nfields, off) +
+                      b"".join(struct.pack("<H", layout.get(i, 0)) for i in range(nfields)))
+        self.pad(8)
+        tp = self.put(bytes(off))
+        struct.pack_into("<i", self.buf, tp, tp - vt)
+        refs = []
+        for idx, (kind, v) in fields.items():
+            at = tp + layout[idx]
+            if kind in FORMATS:
+                struct.pack_into(FORMATS[kind], self.buf, at, v)
+            else:
+                refs.append((at, kind, v))
+        for at, kind, v in refs:
+            self.patch(at, self.obj(kind, v))
+        return tp
+
+    def obj(self, kind, v):
+        if kind == "table":
+            return self.table(v)
+        if kind == "string":
+            self.pad(4)
+            return self.put(struct.pack("<I", len(v)) + v.encode() + b"\x00")
+        if kind == "vec_tables":
+            self.pad(4)
+            p = self.put(struct.pack("<I", len(v)) + bytes(4 * len(v)))
+            for i, t in enumerate(v):
+                self.patch(p + 4 + 4 * i, self.table(t))
+            return p
+        if kind == "vec_structs":
+            self.pad(8, 4)
+            return self.put(struct.pack("<I", len(v)) + b"".join(v))
+        raise Exception(kind)
+
+    def finish(self, root):
+        self.buf += bytes(8)
+        struct.pack_into("<I", self.buf, 0, self.table(root))
+        self.pad(8)
+        return bytes(self.buf)
+
+
+def field(name, type_type, type_table):
+    return {0: ("string", name), 1: ("u8", 1), 2: ("u8", type_type), 3: ("table", type_table), 5: ("vec_tables", [])}
+
+
+schema = {0: ("i16", 0), 1: ("vec_tables", [
+    field("a", 2, {0: ("i32", 32), 1: ("u8", 1)}),
+    field("b", 5, {}),
+])}
+
+body = struct.pack("<iii4x", 1, 2, 3) + struct.pack("<iiii", 0, 3, 6, 9) + b"foobarbaz" + bytes(7)
+record_batch = {
+    0: ("i64", 3),
+    1: ("vec_structs", [struct.pack("<qq", 3, 0)] * 2),
+    2: ("vec_structs", [struct.pack("<qq", o, l) for o, l in [(0, 0), (0, 12), (16, 0), (16, 16), (32, 9)]]),
+}
+
+
+def message(header_type, header, body):
+    meta = Builder().finish({0: ("i16", 4), 1: ("u8", header_type), 2: ("table", header), 3: ("i64", len(body))})
+    return struct.pack("<Ii", 0xffffffff, len(meta)) + meta + body
+
+
+schema_msg = message(1, schema, b"")
+rb_msg = message(3, record_batch, body)
+eos = struct.pack("<Ii", 0xffffffff, 0)
+
+open("test.arrows", "wb").write(schema_msg + rb_msg + eos)
+
+b = b"ARROW1\x00\x00" + schema_msg
+rb_offset = len(b)
+b += rb_msg + eos
+footer = Builder().finish({
+    0: ("i16", 4),
+    1: ("table", schema),
+    2: ("vec_structs", []),
+    3: ("vec_structs", [struct.pack("<qi4xq", rb_offset, len(rb_msg) - len(body), len(body))]),
+})
+b += footer + struct.pack("<i", len(footer)) + b"ARROW1"
+open("test.arrow", "wb").write(b)
@@ -0,0 +1,179 @@
+package audit
+
+// https://github.com/linux-audit/audit-documentation/wiki/SPEC-Writing-Good-Events
+// https://github.com/linux-audit/audit-userspace/blob/master/auparse/interpret.c
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+// TODO: binary netlink audit messages
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.AUDIT,
+		Description: "Linux audit log",
+		DecodeFn:    decodeAudit,
+	})
+}
+
+// fields that auditd writes hex encoded if the value has spaces, quotes or control characters
+var encodedFields = map[string]bool{
+	"acct":        true,
+	"cmd":         true,
+	"comm":        true,
+	"cwd":         true,
+	"data":        true,
+	"dir":         true,
+	"exe":         true,
+	"file":        true,
+	"grp":         true,
+	"key":         true,
+	"name":        true,
+	"new-disk":    true,
+	"new_group":   true,
+	"ocomm":       true,
+	"old-disk":    true,
+	"path":        true,
+	"proctitle":   true,
+	"root_dir":    true,
+	"sw":          true,
+	"vm":          true,
+	"watch":       true,
+	"new-chardev": true,
+	"old-chardev": true,
+}
+
+var msgRe = regexp.MustCompile(`msg=audit\((\d+)\.(\d+):(\d+)\):?`)
+
+func isSpace(c byte) bool {
+	// 0x1d separates raw and interpreted fields in enriched logs
+	return c == ' ' || c == '\t' || c == 0x1d
+}
+
+// parseFields parses key=value pairs, values can be "double" or 'single' quoted
+func parseFields(s string) (map[string]interface{}, error) {
+	m := map[string]interface{}{}
+	for {
+		for len(s) > 0 && isSpace(s[0]) {
+			s = s[1:]
+		}
+		if s == "" {
+			return m, nil
+		}
+		i := strings.IndexByte(s, '=')
+		if i <= 0 {
+			return nil, fmt.Errorf("expected key=value at %q", s)
+		}
+		key := s[0:i]
+		s = s[i+1:]
+
+		if len(s) > 0 && (s[0] == '"' || s[0] == '\'') {
+			q := s[0]
+			end := strings.IndexByte(s[1:], q)
+			if end == -1 {
+				return nil, fmt.Errorf("%s: unterminated quote", key)
+			}
+			value := s[1 : end+1]
+			s = s[end+2:]
+			if q == '\'' {
+				// single quoted value is a nested message with own fields
+				nm, err := parseFields(value)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", key, err)
+				}
+				m[key] = nm
+				continue
+			}
+			m[key] = value
+			continue
+		}
+
+		end := 0
+		for end < len(s) && !isSpace(s[end]) {
+			end++
+		}
+		m[key] = decodeValue(key, s[0:end])
+		s = s[end:]
+	}
+}
+
+func decodeValue(key string, value string) string {
+	if !encodedFields[key] || value == "" || len(value)%2 != 0 {
+		return value
+	}
+	b, err := hex.DecodeString(value)
+	if err != nil {
+		return value
+	}
+	if key == "proctitle" {
+		// arguments are separated by null
+		b = bytes.ReplaceAll(bytes.TrimRight(b, "\x00"), []byte{0}, []byte{' '})
+	}
+	return string(b)
+}
+
+func parseRecord(line string) (map[string]interface{}, error) {
+	// some records like USER_* has a msg='...' field with own fields after msg=audit(...)
+	loc := msgRe.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return nil, fmt.Errorf("no msg=audit(...)")
+	}
+	m, err := parseFields(line[0:loc[0]] + " " + line[loc[1]:])
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := m["type"].(string); !ok {
+		return nil, fmt.Errorf("no type")
+	}
+
+	timestamp, _ := strconv.ParseFloat(line[loc[2]:loc[3]]+"."+line[loc[4]:loc[5]], 64)
+	serial, _ := strconv.Atoi(line[loc[6]:loc[7]])
+	m["timestamp"] = timestamp
+	m["serial"] = serial
+
+	return m, nil
+}
+
+func decodeAudit(d *decode.D, in interface{}) interface{} {
+	bb := d.RawLen(d.Len())
+
+	var records []interface{}
+	s := bufio.NewScanner(bb)
+	s.Buffer(nil, 1024*1024)
+	lineNr := 0
+	for s.Scan() {
+		lineNr++
+		line := strings.TrimRight(s.Text(), "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		r, err := parseRecord(line)
+		if err != nil {
+			d.Fatalf("line %d: %s", lineNr, err)
+		}
+		records = append(records, r)
+	}
+	if err := s.Err(); err != nil {
+		d.Fatalf(err.Error())
+	}
+	if len(records) == 0 {
+		d.Fatalf("no records")
+	}
+
+	d.Value.V = &scalar.S{Actual: map[string]interface{}{"records": records}}
+	d.Value.Range.Len = d.Len()
+
+	return nil
+}
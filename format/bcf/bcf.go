@@ -0,0 +1,254 @@
+package bcf
+
+// https://samtools.github.io/hts-specs/VCFv4.3.pdf section 6 "BCF specification"
+// TODO: float missing/end of vector values
+// TODO: decode GT genotype encoding
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.BCF,
+		Description: "Binary variant call format",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    bcfDecode,
+	})
+}
+
+const (
+	typeMissing = 0
+	typeInt8    = 1
+	typeInt16   = 2
+	typeInt32   = 3
+	typeFloat   = 5
+	typeChar    = 7
+)
+
+var typeNames = scalar.UToSymStr{
+	typeMissing: "missing",
+	typeInt8:    "int8",
+	typeInt16:   "int16",
+	typeInt32:   "int32",
+	typeFloat:   "float",
+	typeChar:    "char",
+}
+
+var typeBits = map[uint64]int{
+	typeInt8:  8,
+	typeInt16: 16,
+	typeInt32: 32,
+	typeFloat: 32,
+	typeChar:  8,
+}
+
+var intSpecialNames = map[int]scalar.SToScalar{
+	8: {
+		-128: {Sym: "missing"},
+		-127: {Sym: "end_of_vector"},
+	},
+	16: {
+		-32768: {Sym: "missing"},
+		-32767: {Sym: "end_of_vector"},
+	},
+	32: {
+		-2147483648: {Sym: "missing"},
+		-2147483647: {Sym: "end_of_vector"},
+	},
+}
+
+// count is in a typed int following the type descriptor
+const countOverflow = 15
+
+type header struct {
+	contigs    scalar.SToSymStr
+	dictionary scalar.SToSymStr
+}
+
+var headerLineRe = regexp.MustCompile(`^##(contig|FILTER|INFO|FORMAT)=<ID=([^,>]+)(?:.*[,<]IDX=(\d+))?`)
+
+// parseHeader builds the contig and string dictionaries from the VCF text header,
+// index is order of appearance unless there is an IDX attribute
+func parseHeader(text string) header {
+	h := header{
+		contigs:    scalar.SToSymStr{},
+		dictionary: scalar.SToSymStr{0: "PASS"},
+	}
+	seen := map[string]bool{"PASS": true}
+	nextContig := int64(0)
+	nextDict := int64(1)
+
+	for _, l := range strings.Split(text, "\n") {
+		sm := headerLineRe.FindStringSubmatch(l)
+		if sm == nil {
+			continue
+		}
+		kind, id, idx := sm[1], sm[2], sm[3]
+		if kind == "contig" {
+			if idx != "" {
+				nextContig, _ = strconv.ParseInt(idx, 10, 64)
+			}
+			h.contigs[nextContig] = id
+			nextContig++
+			continue
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		if idx != "" {
+			nextDict, _ = strconv.ParseInt(idx, 10, 64)
+		}
+		h.dictionary[nextDict] = id
+		nextDict++
+	}
+
+	return h
+}
+
+func decodeTypeDescriptor(d *decode.D) (uint64, uint64) {
+	var typ uint64
+	var count uint64
+	d.FieldStruct("type", func(d *decode.D) {
+		count = d.FieldU4("count")
+		typ = d.FieldU4("type", typeNames)
+	})
+	if count == countOverflow {
+		count = decodeTypedInt(d, "count", nil)
+	}
+	return typ, count
+}
+
+func decodeValue(d *decode.D, name string, typ uint64, sms ...scalar.Mapper) {
+	nBits := typeBits[typ]
+	switch typ {
+	case typeInt8, typeInt16, typeInt32:
+		d.FieldS(name, nBits, append([]scalar.Mapper{intSpecialNames[nBits]}, sms...)...)
+	case typeFloat:
+		d.FieldF32(name)
+	default:
+		d.FieldRawLen(name, int64(nBits))
+	}
+}
+
+func decodeValues(d *decode.D, typ uint64, count uint64, sms ...scalar.Mapper) {
+	switch {
+	case typ == typeMissing || count == 0:
+	case typ == typeChar:
+		d.FieldUTF8NullFixedLen("value", int(count))
+	case count == 1:
+		decodeValue(d, "value", typ, sms...)
+	default:
+		d.FieldArray("values", func(d *decode.D) {
+			for i := uint64(0); i < count; i++ {
+				decodeValue(d, "value", typ, sms...)
+			}
+		})
+	}
+}
+
+// typed int used for dictionary keys and counts
+func decodeTypedInt(d *decode.D, name string, sm scalar.Mapper) uint64 {
+	var v int64
+	d.FieldStruct(name, func(d *decode.D) {
+		typ, _ := decodeTypeDescriptor(d)
+		switch typ {
+		case typeInt8, typeInt16, typeInt32:
+			var sms []scalar.Mapper
+			if sm != nil {
+				sms = append(sms, sm)
+			}
+			v = d.FieldS("value", typeBits[typ], sms...)
+		default:
+			d.Fatalf("expected typed int found %d", typ)
+		}
+	})
+	return uint64(v)
+}
+
+func decodeTypedValue(d *decode.D, name string, sms ...scalar.Mapper) {
+	d.FieldStruct(name, func(d *decode.D) {
+		typ, count := decodeTypeDescriptor(d)
+		decodeValues(d, typ, count, sms...)
+	})
+}
+
+func decodeRecord(d *decode.D, h header) {
+	lShared := d.FieldU32("l_shared")
+	lIndiv := d.FieldU32("l_indiv")
+
+	var nInfo uint64
+	var nAllele uint64
+	var nSample uint64
+	var nFmt uint64
+
+	d.LenFn(int64(lShared)*8, func(d *decode.D) {
+		d.FieldS32("chrom", h.contigs)
+		d.FieldS32("pos", scalar.Description("0-based"))
+		d.FieldS32("rlen")
+		d.FieldF32("qual")
+		nInfo = d.FieldU16("n_info")
+		nAllele = d.FieldU16("n_allele")
+		nSample = d.FieldU24("n_sample")
+		nFmt = d.FieldU8("n_fmt")
+		decodeTypedValue(d, "id")
+		d.FieldArray("alleles", func(d *decode.D) {
+			for i := uint64(0); i < nAllele; i++ {
+				decodeTypedValue(d, "allele")
+			}
+		})
+		decodeTypedValue(d, "filter", h.dictionary)
+		d.FieldArray("info", func(d *decode.D) {
+			for i := uint64(0); i < nInfo; i++ {
+				d.FieldStruct("info", func(d *decode.D) {
+					decodeTypedInt(d, "key", h.dictionary)
+					decodeTypedValue(d, "value")
+				})
+			}
+		})
+	})
+
+	d.LenFn(int64(lIndiv)*8, func(d *decode.D) {
+		d.FieldArray("format", func(d *decode.D) {
+			for i := uint64(0); i < nFmt; i++ {
+				d.FieldStruct("format", func(d *decode.D) {
+					decodeTypedInt(d, "key", h.dictionary)
+					typ, count := decodeTypeDescriptor(d)
+					d.FieldArray("samples", func(d *decode.D) {
+						for j := uint64(0); j < nSample; j++ {
+							d.FieldStruct("sample", func(d *decode.D) {
+								decodeValues(d, typ, count)
+							})
+						}
+					})
+				})
+			}
+		})
+	})
+}
+
+func bcfDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldUTF8("magic", 3, d.AssertStr("BCF"))
+	d.FieldU8("major_version", d.AssertU(2))
+	d.FieldU8("minor_version")
+	lText := d.FieldU32("l_text")
+	text := d.FieldUTF8NullFixedLen("text", int(lText))
+
+	h := parseHeader(text)
+
+	d.FieldStructArrayLoop("records", "record", d.NotEnd, func(d *decode.D) {
+		decodeRecord(d, h)
+	})
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package brotli
+
+// https://datatracker.ietf.org/doc/html/rfc7932
+// TODO: compressed meta-blocks, requires huffman decoding to know where they end
+
+import (
+	"fmt"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.BROTLI,
+		Description: "Brotli compression",
+		DecodeFn:    brotliDecode,
+	})
+}
+
+func windowBits(d *decode.D) uint64 {
+	if d.U1() == 0 {
+		return 16
+	}
+	if n := d.U3(); n != 0 {
+		return 17 + n
+	}
+	switch m := d.U3(); m {
+	case 0:
+		return 17
+	case 1:
+		d.Fatalf("large window brotli not supported")
+		return 0
+	default:
+		return 8 + m
+	}
+}
+
+var mnibblesNames = scalar.UToScalar{
+	0: {Sym: uint64(4)},
+	1: {Sym: uint64(5)},
+	2: {Sym: uint64(6)},
+	3: {Sym: uint64(0), Description: "metadata"},
+}
+
+func brotliDecode(d *decode.D, in interface{}) interface{} {
+	// brotli is LSB first
+	d.LSBBitOrder(decodeBrotli)
+
+	return nil
+}
+
+func decodeBrotli(d *decode.D) {
+	d.FieldStruct("header", func(d *decode.D) {
+		d.FieldUFn("window_bits", windowBits, scalar.Fn(func(s scalar.S) (scalar.S, error) {
+			s.Description = fmt.Sprintf("window size %d", (uint64(1)<<s.ActualU())-16)
+			return s, nil
+		}))
+	})
+
+	d.FieldArray("meta_blocks", func(d *decode.D) {
+		done := false
+		for !done && d.NotEnd() {
+			d.FieldStruct("meta_block", func(d *decode.D) {
+				isLast := d.FieldBool("is_last")
+				done = isLast
+				if isLast && d.FieldBool("is_last_empty") {
+					d.FieldPadding("padding", 8)
+					return
+				}
+				mnibbles := d.FieldU2("mnibbles", mnibblesNames)
+				if mnibbles == 3 {
+					d.FieldU1("reserved", d.ValidateU(0))
+					skipBytes := d.FieldU2("mskipbytes")
+					var skipLen uint64
+					if skipBytes > 0 {
+						skipLen = d.FieldUFn("mskiplen", func(d *decode.D) uint64 { return d.U(int(skipBytes)*8) + 1 })
+					}
+					d.FieldPadding("padding", 8)
+					if skipLen > 0 {
+						d.FieldRawLen("metadata", int64(skipLen)*8)
+					}
+					return
+				}
+				nibbles := int(mnibbles) + 4
+				mlen := d.FieldUFn("mlen", func(d *decode.D) uint64 { return d.U(nibbles*4) + 1 })
+				isUncompressed := false
+				if !isLast {
+					isUncompressed = d.FieldBool("is_uncompressed")
+				}
+				if isUncompressed {
+					d.FieldPadding("padding", 8)
+					d.FieldRawLen("data", int64(mlen)*8)
+					return
+				}
+				// don't know where compressed data ends
+				d.FieldRawLen("compressed", d.BitsLeft())
+				done = true
+			})
+		}
+	})
+}
@@ -0,0 +1,65 @@
+#!/usr/bin/env python3
+# generates brotli streams with metadata and uncompressed meta-blocks
+# brotli is LSB first so bits are packed from least significant bit
+
+
+class BitWriter:
+    def __init__(self):
+        self.bits = []
+
+    def write(self, v, n):
+        for i in range(n):
+            self.bits.append((v >> i) & 1)
+
+    def align(self):
+        while len(self.bits) % 8 != 0:
+            self.bits.append(0)
+
+    def write_bytes(self, b):
+        self.align()
+        for c in b:
+            self.write(c, 8)
+
+    def bytes(self):
+        self.align()
+        return bytes(
+            sum(self.bits[i + j] << j for j in range(8))
+            for i in range(0, len(self.bits), 8)
+        )
+
+
+def window_bits(w, wbits):
+    # 22 = 1 + 3 bits (17 + n)
+    w.write(1, 1)
+    w.write(wbits - 17, 3)
+
+
+def metadata(w, data):
+    w.write(0, 1)  # ISLAST
+    w.write(3, 2)  # MNIBBLES 0
+    w.write(0, 1)  # reserved
+    w.write(1, 2)  # MSKIPBYTES
+    w.write(len(data) - 1, 8)
+    w.write_bytes(data)
+
+
+def uncompressed(w, data):
+    w.write(0, 1)  # ISLAST
+    w.write(0, 2)  # MNIBBLES 4
+    w.write(len(data) - 1, 16)
+    w.write(1, 1)  # ISUNCOMPRESSED
+    w.write_bytes(data)
+
+
+def last_empty(w):
+    w.write(1, 1)  # ISLAST
+    w.write(1, 1)  # ISLASTEMPTY
+
+
+w = BitWriter()
+window_bits(w, 22)
+metadata(w, b"meta")
+uncompressed(w, b"hello ")
+uncompressed(w, b"brotli\n")
+last_empty(w)
+open("test.br", "wb").write(w.bytes())
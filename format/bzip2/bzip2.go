@@ -139,7 +139,7 @@ func bzip2Decode(d *decode.D, in interface{}) interface{} {
 			d.FieldU48("magic", d.AssertU(footerMagic), scalar.Hex)
 			// TODO: crc of block crcs
 			d.FieldU32("crc", scalar.Hex, d.ValidateU(uint64(streamCRCN)))
-			d.FieldRawLen("padding", int64(d.ByteAlignBits()))
+			d.FieldPadding("padding", 8)
 		})
 	}
 
@@ -0,0 +1,252 @@
+package cbor
+
+// https://www.rfc-editor.org/rfc/rfc8949.html
+// TODO: bignum tags as numbers
+
+import (
+	"math"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.CBOR,
+		Description: "Concise Binary Object Representation",
+		DecodeFn:    cborDecode,
+	})
+}
+
+const (
+	majorTypePositiveInt = 0
+	majorTypeNegativeInt = 1
+	majorTypeBytes       = 2
+	majorTypeUTF8        = 3
+	majorTypeArray       = 4
+	majorTypeMap         = 5
+	majorTypeTag         = 6
+	majorTypeSpecial     = 7
+)
+
+var majorTypeNames = scalar.UToSymStr{
+	majorTypePositiveInt: "positive_int",
+	majorTypeNegativeInt: "negative_int",
+	majorTypeBytes:       "bytes",
+	majorTypeUTF8:        "utf8",
+	majorTypeArray:       "array",
+	majorTypeMap:         "map",
+	majorTypeTag:         "tag",
+	majorTypeSpecial:     "special",
+}
+
+const (
+	shortCountU8         = 24
+	shortCountU16        = 25
+	shortCountU32        = 26
+	shortCountU64        = 27
+	shortCountIndefinite = 31
+)
+
+var shortCountNames = scalar.UToSymStr{
+	shortCountU8:         "8bit",
+	shortCountU16:        "16bit",
+	shortCountU32:        "32bit",
+	shortCountU64:        "64bit",
+	shortCountIndefinite: "indefinite",
+}
+
+const (
+	specialFalse     = 20
+	specialTrue      = 21
+	specialNull      = 22
+	specialUndefined = 23
+	specialSimpleU8  = 24
+	specialFloat16   = 25
+	specialFloat32   = 26
+	specialFloat64   = 27
+	specialBreak     = 31
+)
+
+var specialNames = scalar.UToSymStr{
+	specialFalse:     "false",
+	specialTrue:      "true",
+	specialNull:      "null",
+	specialUndefined: "undefined",
+	specialSimpleU8:  "simple",
+	specialFloat16:   "float16",
+	specialFloat32:   "float32",
+	specialFloat64:   "float64",
+	specialBreak:     "break",
+}
+
+var tagNames = scalar.UToScalar{
+	0:     {Sym: "date_time", Description: "Standard date/time string"},
+	1:     {Sym: "epoch_date_time", Description: "Epoch-based date/time"},
+	2:     {Sym: "unsigned_bignum", Description: "Unsigned bignum"},
+	3:     {Sym: "negative_bignum", Description: "Negative bignum"},
+	4:     {Sym: "decimal_fraction", Description: "Decimal fraction"},
+	5:     {Sym: "bigfloat", Description: "Bigfloat"},
+	21:    {Sym: "base64url", Description: "Expected conversion to base64url encoding"},
+	22:    {Sym: "base64", Description: "Expected conversion to base64 encoding"},
+	23:    {Sym: "base16", Description: "Expected conversion to base16 encoding"},
+	24:    {Sym: "encoded_cbor", Description: "Encoded CBOR data item"},
+	32:    {Sym: "uri", Description: "URI"},
+	33:    {Sym: "base64url_text", Description: "base64url text"},
+	34:    {Sym: "base64_text", Description: "base64 text"},
+	36:    {Sym: "mime", Description: "MIME message"},
+	55799: {Sym: "self_described", Description: "Self-described CBOR"},
+}
+
+const breakByte = 0xff
+
+func isBreak(d *decode.D) bool {
+	return d.PeekBits(8) == breakByte
+}
+
+// decodeCount reads the argument following the initial byte, immediate values are in short count
+func decodeCount(d *decode.D, shortCount uint64) uint64 {
+	switch {
+	case shortCount < shortCountU8:
+		return shortCount
+	case shortCount == shortCountU8:
+		return d.FieldU8("count")
+	case shortCount == shortCountU16:
+		return d.FieldU16("count")
+	case shortCount == shortCountU32:
+		return d.FieldU32("count")
+	case shortCount == shortCountU64:
+		return d.FieldU64("count")
+	default:
+		d.Fatalf("invalid short count %d", shortCount)
+		return 0
+	}
+}
+
+// arrays, maps, tags and chunks nested deeper than this fails the decode
+const maxItemDepth = 1000
+
+func decodeItem(d *decode.D, depth int) {
+	if depth > maxItemDepth {
+		d.Fatalf("items nested deeper than %d", maxItemDepth)
+	}
+	item := func(d *decode.D) { decodeItem(d, depth+1) }
+
+	majorType := d.FieldU3("major_type", majorTypeNames)
+
+	if majorType == majorTypeSpecial {
+		shortCount := d.FieldU5("short_count", specialNames)
+		switch shortCount {
+		case specialFalse:
+			d.FieldValueBool("value", false)
+		case specialTrue:
+			d.FieldValueBool("value", true)
+		case specialNull, specialUndefined:
+		case specialSimpleU8:
+			d.FieldU8("value")
+		case specialFloat16:
+			d.FieldF16("value")
+		case specialFloat32:
+			d.FieldF32("value")
+		case specialFloat64:
+			d.FieldF64("value")
+		case specialBreak:
+			d.Fatalf("unexpected break")
+		default:
+			if shortCount < specialFalse {
+				d.FieldValueU("value", shortCount)
+			} else {
+				d.Fatalf("invalid simple value %d", shortCount)
+			}
+		}
+		return
+	}
+
+	shortCount := d.FieldU5("short_count", shortCountNames)
+	indefinite := shortCount == shortCountIndefinite
+	var count uint64
+	if !indefinite {
+		count = decodeCount(d, shortCount)
+	}
+
+	switch majorType {
+	case majorTypePositiveInt:
+		if indefinite {
+			d.Fatalf("indefinite length integer")
+		}
+		d.FieldValueU("value", count)
+	case majorTypeNegativeInt:
+		if indefinite {
+			d.Fatalf("indefinite length integer")
+		}
+		if count > math.MaxInt64 {
+			d.Fatalf("negative integer -1-%d does not fit int64", count)
+		}
+		d.FieldValueS("value", -1-int64(count))
+	case majorTypeBytes, majorTypeUTF8:
+		if indefinite {
+			// chunks are definite length strings of same major type
+			d.FieldArray("chunks", func(d *decode.D) {
+				for !isBreak(d) {
+					d.FieldStruct("chunk", func(d *decode.D) {
+						if d.PeekBits(3) != majorType {
+							d.Fatalf("chunk major type differs from string")
+						}
+						item(d)
+					})
+				}
+			})
+			d.FieldU8("break")
+			return
+		}
+		if int64(count) > d.BitsLeft()/8 {
+			d.Fatalf("string length %d larger than rest of input", count)
+		}
+		if majorType == majorTypeBytes {
+			d.FieldRawLen("value", int64(count)*8)
+		} else {
+			d.FieldUTF8("value", int(count))
+		}
+	case majorTypeArray:
+		if indefinite {
+			d.FieldArray("elements", func(d *decode.D) {
+				for !isBreak(d) {
+					d.FieldStruct("element", item)
+				}
+			})
+			d.FieldU8("break")
+			return
+		}
+		d.FieldArrayCount("elements", count, func(d *decode.D) { d.FieldStruct("element", item) })
+	case majorTypeMap:
+		decodePair := func(d *decode.D) {
+			d.FieldStruct("pair", func(d *decode.D) {
+				d.FieldStruct("key", item)
+				d.FieldStruct("value", item)
+			})
+		}
+		if indefinite {
+			d.FieldArray("pairs", func(d *decode.D) {
+				for !isBreak(d) {
+					decodePair(d)
+				}
+			})
+			d.FieldU8("break")
+			return
+		}
+		d.FieldArrayCount("pairs", count, decodePair)
+	case majorTypeTag:
+		if indefinite {
+			d.Fatalf("indefinite length tag")
+		}
+		d.FieldValueU("tag", count, tagNames)
+		d.FieldStruct("item", item)
+	}
+}
+
+func cborDecode(d *decode.D, in interface{}) interface{} {
+	decodeItem(d, 1)
+	return nil
+}
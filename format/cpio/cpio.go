@@ -0,0 +1,185 @@
+package cpio
+
+// https://www.mkssoftware.com/docs/man4/cpio.4.asp
+// https://www.kernel.org/doc/Documentation/early-userspace/buffer-format.txt
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var probeFormat decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.CPIO,
+		Description: "Unix CPIO archive",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    cpioDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.PROBE}, Group: &probeFormat},
+		},
+	})
+}
+
+const trailerName = "TRAILER!!!"
+
+var (
+	magicNewc = []byte("070701")
+	magicCRC  = []byte("070702")
+	magicODC  = []byte("070707")
+	// 0o070707 as 16 bit integer
+	magicBinaryLE = []byte{0xc7, 0x71}
+	magicBinaryBE = []byte{0x71, 0xc7}
+)
+
+func mapStrToSymU(base int) scalar.Mapper {
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		n, err := strconv.ParseUint(s.ActualStr(), base, 64)
+		if err != nil {
+			return s, err
+		}
+		s.Sym = n
+		return s, nil
+	})
+}
+
+var mapOctStrToSymU = mapStrToSymU(8)
+var mapHexStrToSymU = mapStrToSymU(16)
+
+// field for a ASCII number, returns value
+func fieldStrU(d *decode.D, name string, nBytes int, m scalar.Mapper) uint64 {
+	s := d.FieldScalarUTF8(name, nBytes, m)
+	if s.Sym == nil {
+		d.Fatalf("%s: could not decode number", name)
+	}
+	return s.SymU()
+}
+
+// pad to align bytes from start of archive
+func fieldAlignPadding(d *decode.D, name string, align int64) {
+	alignBits := align * 8
+	if n := (alignBits - d.Pos()%alignBits) % alignBits; n > 0 {
+		d.FieldRawLen(name, n, d.BitBufIsZero())
+	}
+}
+
+func fieldData(d *decode.D, size int64) {
+	if size == 0 {
+		return
+	}
+	dv, _, _ := d.TryFieldFormatLen("data", size*8, probeFormat, nil)
+	if dv == nil {
+		d.FieldRawLen("data", size*8)
+	}
+}
+
+// "new" portable ASCII format, 070701 and 070702 with checksum
+func decodeNewc(d *decode.D) string {
+	d.FieldUTF8("magic", 6, scalar.StrToSymStr{
+		string(magicNewc): "newc",
+		string(magicCRC):  "crc",
+	})
+	d.FieldUTF8("ino", 8, mapHexStrToSymU)
+	d.FieldUTF8("mode", 8, mapHexStrToSymU)
+	d.FieldUTF8("uid", 8, mapHexStrToSymU)
+	d.FieldUTF8("gid", 8, mapHexStrToSymU)
+	d.FieldUTF8("nlink", 8, mapHexStrToSymU)
+	d.FieldUTF8("mtime", 8, mapHexStrToSymU)
+	fileSize := fieldStrU(d, "filesize", 8, mapHexStrToSymU)
+	d.FieldUTF8("devmajor", 8, mapHexStrToSymU)
+	d.FieldUTF8("devminor", 8, mapHexStrToSymU)
+	d.FieldUTF8("rdevmajor", 8, mapHexStrToSymU)
+	d.FieldUTF8("rdevminor", 8, mapHexStrToSymU)
+	nameSize := fieldStrU(d, "namesize", 8, mapHexStrToSymU)
+	d.FieldUTF8("check", 8, mapHexStrToSymU)
+	name := d.FieldUTF8NullFixedLen("name", int(nameSize))
+	fieldAlignPadding(d, "name_padding", 4)
+	fieldData(d, int64(fileSize))
+	fieldAlignPadding(d, "data_padding", 4)
+
+	return name
+}
+
+// old portable ASCII format
+func decodeODC(d *decode.D) string {
+	d.FieldUTF8("magic", 6, scalar.StrToSymStr{string(magicODC): "odc"})
+	d.FieldUTF8("dev", 6, mapOctStrToSymU)
+	d.FieldUTF8("ino", 6, mapOctStrToSymU)
+	d.FieldUTF8("mode", 6, mapOctStrToSymU)
+	d.FieldUTF8("uid", 6, mapOctStrToSymU)
+	d.FieldUTF8("gid", 6, mapOctStrToSymU)
+	d.FieldUTF8("nlink", 6, mapOctStrToSymU)
+	d.FieldUTF8("rdev", 6, mapOctStrToSymU)
+	d.FieldUTF8("mtime", 11, mapOctStrToSymU)
+	nameSize := fieldStrU(d, "namesize", 6, mapOctStrToSymU)
+	fileSize := fieldStrU(d, "filesize", 11, mapOctStrToSymU)
+	name := d.FieldUTF8NullFixedLen("name", int(nameSize))
+	fieldData(d, int64(fileSize))
+
+	return name
+}
+
+// old binary format, 32 bit values are two 16 bit words with most significant first
+func decodeBinary(d *decode.D) string {
+	u32 := func(d *decode.D) uint64 { return d.U16()<<16 | d.U16() }
+
+	d.FieldU16("magic", scalar.UToSymStr{0o070707: "binary"}, scalar.Oct)
+	d.FieldU16("dev")
+	d.FieldU16("ino")
+	d.FieldU16("mode", scalar.Oct)
+	d.FieldU16("uid")
+	d.FieldU16("gid")
+	d.FieldU16("nlink")
+	d.FieldU16("rdev")
+	d.FieldUFn("mtime", u32)
+	nameSize := d.FieldU16("namesize")
+	fileSize := d.FieldUFn("filesize", u32)
+	name := d.FieldUTF8NullFixedLen("name", int(nameSize))
+	fieldAlignPadding(d, "name_padding", 2)
+	fieldData(d, int64(fileSize))
+	fieldAlignPadding(d, "data_padding", 2)
+
+	return name
+}
+
+func cpioDecode(d *decode.D, in interface{}) interface{} {
+	var decodeFn func(d *decode.D) string
+	magic := d.PeekBytes(6)
+	switch {
+	case bytes.Equal(magic, magicNewc), bytes.Equal(magic, magicCRC):
+		decodeFn = decodeNewc
+	case bytes.Equal(magic, magicODC):
+		decodeFn = decodeODC
+	case bytes.Equal(magic[0:2], magicBinaryLE):
+		d.Endian = decode.LittleEndian
+		decodeFn = decodeBinary
+	case bytes.Equal(magic[0:2], magicBinaryBE):
+		decodeFn = decodeBinary
+	default:
+		d.Errorf("unknown magic")
+	}
+
+	foundTrailer := false
+	d.FieldArray("files", func(d *decode.D) {
+		for !foundTrailer && !d.End() {
+			d.FieldStruct("file", func(d *decode.D) {
+				foundTrailer = decodeFn(d) == trailerName
+			})
+		}
+	})
+	if !foundTrailer {
+		d.Errorf("no trailer found")
+	}
+	// archives are usually padded to block size
+	if d.BitsLeft() > 0 {
+		d.FieldRawLen("padding", d.BitsLeft(), d.BitBufIsZero())
+	}
+
+	return nil
+}
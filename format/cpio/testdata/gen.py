@@ -0,0 +1,50 @@
+import struct
+
+files = [("a.txt", 0o100644, b"hello cpio\n")]
+
+
+def entries():
+    for ino, (name, mode, data) in enumerate(files, 1):
+        yield ino, name, mode, data
+    yield 0, "TRAILER!!!", 0, b""
+
+
+def pad(b, align):
+    return b + b"\x00" * ((align - len(b) % align) % align)
+
+
+def newc():
+    out = b""
+    for ino, name, mode, data in entries():
+        n = name.encode() + b"\x00"
+        fields = [ino, mode, 1000, 1000, 1, 1600000000, len(data), 0, 0, 0, 0, len(n), 0]
+        out = pad(out + b"070701" + b"".join(b"%08x" % f for f in fields) + n, 4)
+        out = pad(out + data, 4)
+    return pad(out, 512)
+
+
+def odc():
+    out = b""
+    for ino, name, mode, data in entries():
+        n = name.encode() + b"\x00"
+        out += b"070707" + b"%06o%06o%06o%06o%06o%06o%06o%011o%06o%011o" % (
+            0, ino, mode, 1000, 1000, 1, 0, 1600000000, len(n), len(data))
+        out += n + data
+    return pad(out, 512)
+
+
+def binary(endian):
+    out = b""
+    for ino, name, mode, data in entries():
+        n = name.encode() + b"\x00"
+        out += struct.pack(endian + "13H", 0o070707, 0, ino, mode, 1000, 1000, 1, 0,
+                           1600000000 >> 16, 1600000000 & 0xffff, len(n),
+                           len(data) >> 16, len(data) & 0xffff)
+        out = pad(out + n, 2)
+        out = pad(out + data, 2)
+    return pad(out, 512)
+
+
+for name, b in [("newc.cpio", newc()), ("odc.cpio", odc()), ("bin_le.cpio", binary("<")), ("bin_be.cpio", binary(">"))]:
+    with open(name, "wb") as f:
+        f.write(b)
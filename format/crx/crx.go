@@ -0,0 +1,62 @@
+package crx
+
+// https://source.chromium.org/chromium/chromium/src/+/main:components/crx_file/crx3.proto
+// https://source.chromium.org/chromium/chromium/src/+/main:components/crx_file/crx_verifier.cc
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var protobufFormat decode.Group
+var zipFormat decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.CRX,
+		Description: "Chrome extension package",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    crxDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.PROTOBUF}, Group: &protobufFormat},
+			{Names: []string{format.ZIP}, Group: &zipFormat},
+		},
+	})
+}
+
+var asymmetricKeyProofPb = format.ProtoBufMessage{
+	1: {Type: format.ProtoBufTypeBytes, Name: "public_key"},
+	2: {Type: format.ProtoBufTypeBytes, Name: "signature"},
+}
+
+var crxFileHeaderPb = format.ProtoBufMessage{
+	2: {Type: format.ProtoBufTypeMessage, Name: "sha256_with_rsa", Message: asymmetricKeyProofPb},
+	3: {Type: format.ProtoBufTypeMessage, Name: "sha256_with_ecdsa", Message: asymmetricKeyProofPb},
+	10000: {Type: format.ProtoBufTypeMessage, Name: "signed_header_data", Message: format.ProtoBufMessage{
+		1: {Type: format.ProtoBufTypeBytes, Name: "crx_id"},
+	}},
+}
+
+func crxDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldRawMagic("magic", []byte("Cr24"))
+	version := d.FieldU32("version", d.AssertU(2, 3))
+
+	switch version {
+	case 2:
+		publicKeyLen := d.FieldU32("public_key_length")
+		signatureLen := d.FieldU32("signature_length")
+		d.FieldRawLen("public_key", int64(publicKeyLen)*8)
+		d.FieldRawLen("signature", int64(signatureLen)*8, scalar.RawHex)
+	case 3:
+		headerLen := d.FieldU32("header_length")
+		d.FieldFormatLen("header", int64(headerLen)*8, protobufFormat, format.ProtoBufIn{Message: crxFileHeaderPb})
+	}
+
+	d.FieldFormatLen("zip", d.BitsLeft(), zipFormat, nil)
+
+	return nil
+}
@@ -0,0 +1,193 @@
+package cuesheet
+
+// https://wiki.hydrogenaud.io/index.php?title=Cue_sheet
+// Decodes into an object with lower case command names as keys. FILE
+// commands becomes "files" with "tracks" that has "indexes". Unknown commands are
+// added to "unknown_commands" with command name and arguments.
+// TODO: ranges for commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.CUESHEET,
+		Description: "CUE sheet",
+		DecodeFn:    decodeCuesheet,
+	})
+}
+
+// splitArgs splits on white space but keeps quoted strings together without quotes
+func splitArgs(s string) ([]string, error) {
+	var args []string
+	s = strings.TrimSpace(s)
+	for s != "" {
+		if s[0] == '"' {
+			i := strings.IndexByte(s[1:], '"')
+			if i == -1 {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			args = append(args, s[1:i+1])
+			s = s[i+2:]
+		} else {
+			i := strings.IndexAny(s, " \t")
+			if i == -1 {
+				i = len(s)
+			}
+			args = append(args, s[:i])
+			s = s[i:]
+		}
+		s = strings.TrimLeft(s, " \t")
+	}
+	return args, nil
+}
+
+// parseTime parses mm:ss:ff and returns number of frames (75 per second)
+func parseTime(s string) (int, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	var ns [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		ns[i] = n
+	}
+	return (ns[0]*60+ns[1])*75 + ns[2], nil
+}
+
+func decodeCuesheet(d *decode.D, in interface{}) interface{} {
+	b := d.BytesRange(0, int(d.Len()/8))
+	// skip UTF-8 BOM
+	b = bytes.TrimPrefix(b, []byte("\xef\xbb\xbf"))
+
+	sheet := map[string]interface{}{}
+	files := []interface{}{}
+	var file map[string]interface{}
+	var track map[string]interface{}
+	// commands before first TRACK applies to the sheet, after to the track
+	current := sheet
+
+	scanner := bufio.NewScanner(bytes.NewReader(b))
+	lineNr := 0
+	for scanner.Scan() {
+		lineNr++
+		args, err := splitArgs(scanner.Text())
+		if err != nil {
+			d.Fatalf("line %d: %s", lineNr, err)
+		}
+		if len(args) == 0 {
+			continue
+		}
+		command := strings.ToUpper(args[0])
+		args = args[1:]
+
+		needArgs := func(n int) {
+			if len(args) < n {
+				d.Fatalf("line %d: %s expected %d arguments", lineNr, command, n)
+			}
+		}
+
+		switch command {
+		case "REM":
+			needArgs(1)
+			rem, _ := current["rem"].(map[string]interface{})
+			if rem == nil {
+				rem = map[string]interface{}{}
+				current["rem"] = rem
+			}
+			rem[args[0]] = strings.Join(args[1:], " ")
+		case "CATALOG", "CDTEXTFILE", "TITLE", "PERFORMER", "SONGWRITER", "ISRC", "PREGAP", "POSTGAP",
+			// CD-TEXT
+			"ARRANGER", "COMPOSER", "MESSAGE", "DISC_ID", "GENRE", "TOC_INFO1", "TOC_INFO2", "UPC_EAN", "SIZE_INFO":
+			needArgs(1)
+			current[strings.ToLower(command)] = args[0]
+		case "FLAGS":
+			var flags []interface{}
+			for _, a := range args {
+				flags = append(flags, a)
+			}
+			current["flags"] = flags
+		case "FILE":
+			needArgs(2)
+			file = map[string]interface{}{
+				"name":   args[0],
+				"type":   args[1],
+				"tracks": []interface{}{},
+			}
+			files = append(files, file)
+		case "TRACK":
+			needArgs(2)
+			if file == nil {
+				d.Fatalf("line %d: TRACK before FILE", lineNr)
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				d.Fatalf("line %d: invalid track number %q", lineNr, args[0])
+			}
+			track = map[string]interface{}{
+				"number":  n,
+				"type":    args[1],
+				"indexes": []interface{}{},
+			}
+			file["tracks"] = append(file["tracks"].([]interface{}), track)
+			current = track
+		case "INDEX":
+			needArgs(2)
+			if track == nil {
+				d.Fatalf("line %d: INDEX before TRACK", lineNr)
+			}
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				d.Fatalf("line %d: invalid index number %q", lineNr, args[0])
+			}
+			frames, err := parseTime(args[1])
+			if err != nil {
+				d.Fatalf("line %d: %s", lineNr, err)
+			}
+			track["indexes"] = append(track["indexes"].([]interface{}), map[string]interface{}{
+				"number": n,
+				"time":   args[1],
+				"frames": frames,
+			})
+		default:
+			// keep unknown commands as is with arguments as they were in the line
+			var rawArgs string
+			line := strings.TrimSpace(scanner.Text())
+			if i := strings.IndexAny(line, " \t"); i != -1 {
+				rawArgs = strings.TrimSpace(line[i:])
+			}
+			commands, _ := current["unknown_commands"].([]interface{})
+			current["unknown_commands"] = append(commands, map[string]interface{}{
+				"command": command,
+				"args":    rawArgs,
+			})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		d.Fatalf("%s", err)
+	}
+	if len(files) == 0 {
+		d.Fatalf("no FILE found")
+	}
+	sheet["files"] = files
+
+	s := scalar.S{Actual: sheet}
+	d.Value.V = &s
+	d.Value.Range.Len = d.Len()
+
+	return nil
+}
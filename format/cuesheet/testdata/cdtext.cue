@@ -0,0 +1,13 @@
+REM DISCID 860B640B
+PERFORMER "fq"
+TITLE "CD-TEXT Album"
+COMPOSER "Composer"
+ARRANGER "Arranger"
+MESSAGE "A message"
+DISC_ID "XY12345"
+FILE "cdtext.flac" WAVE
+  TRACK 01 AUDIO
+    TITLE "First"
+    COMPOSER "Track Composer"
+    X_CUSTOM some args "quoted"
+    INDEX 01 00:00:00
@@ -0,0 +1,15 @@
+REM GENRE Electronic
+REM DATE 2021
+PERFORMER "fq"
+TITLE "Test Album"
+FILE "test.flac" WAVE
+  TRACK 01 AUDIO
+    TITLE "First"
+    PERFORMER "fq"
+    ISRC USXXX2100001
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    TITLE "Second Track"
+    FLAGS DCP PRE
+    INDEX 00 03:12:40
+    INDEX 01 03:14:00
@@ -0,0 +1,346 @@
+package dicom
+
+// https://dicom.nema.org/medical/dicom/current/output/chtml/part05/PS3.5.html
+// https://dicom.nema.org/medical/dicom/current/output/chtml/part10/chapter_7.html
+// TODO: deflated explicit VR little endian
+// TODO: specific character set
+
+import (
+	"fmt"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.DICOM,
+		Description: "Digital Imaging and Communications in Medicine",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    dicomDecode,
+	})
+}
+
+const (
+	tagTransferSyntaxUID        = 0x0002_0010
+	tagPixelData                = 0x7fe0_0010
+	tagItemDelimitationItem     = 0xfffe_e00d
+	tagSequenceDelimitationItem = 0xfffe_e0dd
+
+	groupFileMeta = 0x0002
+
+	undefinedLength = 0xffff_ffff
+)
+
+const (
+	transferSyntaxImplicitVRLittleEndian = "1.2.840.10008.1.2"
+	transferSyntaxExplicitVRBigEndian    = "1.2.840.10008.1.2.2"
+	transferSyntaxDeflated               = "1.2.840.10008.1.2.1.99"
+)
+
+var transferSyntaxNames = map[string]string{
+	transferSyntaxImplicitVRLittleEndian: "Implicit VR Little Endian",
+	"1.2.840.10008.1.2.1":                "Explicit VR Little Endian",
+	transferSyntaxDeflated:               "Deflated Explicit VR Little Endian",
+	transferSyntaxExplicitVRBigEndian:    "Explicit VR Big Endian",
+	"1.2.840.10008.1.2.4.50":             "JPEG Baseline",
+	"1.2.840.10008.1.2.4.51":             "JPEG Extended",
+	"1.2.840.10008.1.2.4.57":             "JPEG Lossless",
+	"1.2.840.10008.1.2.4.70":             "JPEG Lossless SV1",
+	"1.2.840.10008.1.2.4.80":             "JPEG-LS Lossless",
+	"1.2.840.10008.1.2.4.81":             "JPEG-LS Near-Lossless",
+	"1.2.840.10008.1.2.4.90":             "JPEG 2000 Lossless",
+	"1.2.840.10008.1.2.4.91":             "JPEG 2000",
+	"1.2.840.10008.1.2.5":                "RLE Lossless",
+}
+
+// explicit VRs with 2 reserved bytes and a 32 bit length
+var longLengthVRs = map[string]bool{
+	"OB": true,
+	"OD": true,
+	"OF": true,
+	"OL": true,
+	"OV": true,
+	"OW": true,
+	"SQ": true,
+	"SV": true,
+	"UC": true,
+	"UN": true,
+	"UR": true,
+	"UT": true,
+	"UV": true,
+}
+
+var stringVRs = map[string]bool{
+	"AE": true,
+	"AS": true,
+	"CS": true,
+	"DA": true,
+	"DS": true,
+	"DT": true,
+	"IS": true,
+	"LO": true,
+	"LT": true,
+	"PN": true,
+	"SH": true,
+	"ST": true,
+	"TM": true,
+	"UC": true,
+	"UI": true,
+	"UR": true,
+	"UT": true,
+}
+
+type syntax struct {
+	explicitVR bool
+	endian     decode.Endian
+}
+
+var tagMapper = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	t := s.ActualU()
+	s.Sym = fmt.Sprintf("(%04X,%04X)", t>>16, t&0xffff)
+	if e, ok := tagEntries[uint32(t)]; ok {
+		s.Description = e.name
+	}
+	return s, nil
+})
+
+var dateMapper = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	v := s.ActualStr()
+	if len(v) == 8 {
+		s.Description = v[0:4] + "-" + v[4:6] + "-" + v[6:8]
+	}
+	return s, nil
+})
+
+func fieldTag(d *decode.D) uint32 {
+	return uint32(d.FieldUFn("tag", func(d *decode.D) uint64 {
+		group := d.U16()
+		element := d.U16()
+		return group<<16 | element
+	}, tagMapper))
+}
+
+func peekTag(d *decode.D) uint32 {
+	p := d.Pos()
+	group := d.U16()
+	element := d.U16()
+	d.SeekAbs(p)
+	return uint32(group<<16 | element)
+}
+
+func decodeNumbers(d *decode.D, length uint64, nBytes int, fn func(d *decode.D)) {
+	count := length / uint64(nBytes)
+	if count == 1 {
+		fn(d)
+		return
+	}
+	d.FieldArray("value", func(d *decode.D) {
+		for i := uint64(0); i < count; i++ {
+			fn(d)
+		}
+	})
+	if rest := length % uint64(nBytes); rest != 0 {
+		d.FieldRawLen("unknown", int64(rest)*8)
+	}
+}
+
+func decodeValue(d *decode.D, vr string, length uint64) {
+	switch {
+	case vr == "DA":
+		d.FieldUTF8("value", int(length), scalar.Trim(" \x00"), dateMapper)
+	case stringVRs[vr]:
+		d.FieldUTF8("value", int(length), scalar.Trim(" \x00"))
+	case vr == "US":
+		decodeNumbers(d, length, 2, func(d *decode.D) { d.FieldU16("value") })
+	case vr == "SS":
+		decodeNumbers(d, length, 2, func(d *decode.D) { d.FieldS16("value") })
+	case vr == "UL":
+		decodeNumbers(d, length, 4, func(d *decode.D) { d.FieldU32("value") })
+	case vr == "SL":
+		decodeNumbers(d, length, 4, func(d *decode.D) { d.FieldS32("value") })
+	case vr == "UV":
+		decodeNumbers(d, length, 8, func(d *decode.D) { d.FieldU64("value") })
+	case vr == "SV":
+		decodeNumbers(d, length, 8, func(d *decode.D) { d.FieldS64("value") })
+	case vr == "FL":
+		decodeNumbers(d, length, 4, func(d *decode.D) { d.FieldF32("value") })
+	case vr == "FD":
+		decodeNumbers(d, length, 8, func(d *decode.D) { d.FieldF64("value") })
+	case vr == "AT":
+		decodeNumbers(d, length, 4, func(d *decode.D) { fieldTag(d) })
+	default:
+		d.FieldRawLen("value", int64(length)*8)
+	}
+}
+
+func decodeItem(d *decode.D, fn func(d *decode.D, length uint64)) {
+	fieldTag(d)
+	length := d.FieldU32("length", scalar.UToSymStr{undefinedLength: "undefined"})
+	fn(d, length)
+}
+
+// items are either a nested data set or for encapsulated pixel data a fragment
+func decodeItems(d *decode.D, length uint64, name string, itemFn func(d *decode.D, length uint64)) {
+	itemsFn := func(d *decode.D) {
+		d.FieldArray(name, func(d *decode.D) {
+			for d.NotEnd() {
+				if length == undefinedLength && peekTag(d) == tagSequenceDelimitationItem {
+					break
+				}
+				d.FieldStruct("item", func(d *decode.D) {
+					decodeItem(d, itemFn)
+				})
+			}
+		})
+	}
+
+	if length == undefinedLength {
+		itemsFn(d)
+		d.FieldStruct("delimitation", func(d *decode.D) {
+			decodeItem(d, func(d *decode.D, length uint64) {})
+		})
+		return
+	}
+	d.LenFn(int64(length)*8, itemsFn)
+}
+
+func decodeDataSet(d *decode.D, s syntax, length uint64) {
+	d.Endian = s.endian
+	elementsFn := func(d *decode.D) {
+		d.FieldArray("elements", func(d *decode.D) {
+			for d.NotEnd() {
+				if length == undefinedLength && peekTag(d) == tagItemDelimitationItem {
+					break
+				}
+				d.FieldStruct("element", func(d *decode.D) {
+					decodeElement(d, s)
+				})
+			}
+		})
+	}
+
+	if length == undefinedLength {
+		elementsFn(d)
+		d.FieldStruct("delimitation", func(d *decode.D) {
+			decodeItem(d, func(d *decode.D, length uint64) {})
+		})
+		return
+	}
+	d.LenFn(int64(length)*8, elementsFn)
+}
+
+func decodeElement(d *decode.D, s syntax) string {
+	tag := fieldTag(d)
+	entry, entryOk := tagEntries[tag]
+	if entryOk {
+		d.FieldValueStr("name", entry.name)
+	}
+
+	var vr string
+	var length uint64
+	if s.explicitVR {
+		vr = d.FieldUTF8("vr", 2)
+		if longLengthVRs[vr] {
+			d.FieldU16("reserved")
+			length = d.FieldU32("length", scalar.UToSymStr{undefinedLength: "undefined"})
+		} else {
+			length = d.FieldU16("length")
+		}
+	} else {
+		switch {
+		case entryOk && entry.vr != "":
+			vr = entry.vr
+		case tag&0xffff == 0:
+			// group length
+			vr = "UL"
+		default:
+			vr = "UN"
+		}
+		d.FieldValueStr("vr", vr, scalar.Description("implicit"))
+		length = d.FieldU32("length", scalar.UToSymStr{undefinedLength: "undefined"})
+	}
+
+	var value string
+	switch {
+	case vr == "SQ" || (vr == "UN" && length == undefinedLength && tag != tagPixelData):
+		// UN with undefined length is a sequence in implicit VR little endian
+		itemSyntax := s
+		if vr == "UN" {
+			itemSyntax = syntax{explicitVR: false, endian: decode.LittleEndian}
+		}
+		decodeItems(d, length, "items", func(d *decode.D, length uint64) {
+			decodeDataSet(d, itemSyntax, length)
+		})
+	case tag == tagPixelData && length == undefinedLength:
+		// encapsulated, first item is basic offset table followed by fragments
+		decodeItems(d, length, "fragments", func(d *decode.D, length uint64) {
+			d.FieldRawLen("value", int64(length)*8)
+		})
+	case length == undefinedLength:
+		d.Fatalf("undefined length for VR %s", vr)
+	default:
+		d.LenFn(int64(length)*8, func(d *decode.D) {
+			if tag == tagTransferSyntaxUID {
+				value = d.FieldUTF8("value", int(length), scalar.Trim(" \x00"), scalar.Fn(func(s scalar.S) (scalar.S, error) {
+					s.Description = transferSyntaxNames[s.ActualStr()]
+					return s, nil
+				}))
+				return
+			}
+			decodeValue(d, vr, length)
+		})
+	}
+
+	return value
+}
+
+func dicomDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldRawLen("preamble", 128*8)
+	d.FieldUTF8("magic", 4, d.AssertStr("DICM"))
+
+	// file meta information is always explicit VR little endian, transfer syntax for
+	// rest of the data set is in (0002,0010)
+	transferSyntax := ""
+	elements := 0
+	d.FieldArray("elements", func(d *decode.D) {
+		for d.NotEnd() && peekTag(d)>>16 == groupFileMeta {
+			d.FieldStruct("element", func(d *decode.D) {
+				if v := decodeElement(d, syntax{explicitVR: true, endian: decode.LittleEndian}); v != "" {
+					transferSyntax = v
+				}
+			})
+			elements++
+		}
+
+		s := syntax{explicitVR: true, endian: decode.LittleEndian}
+		switch transferSyntax {
+		case transferSyntaxImplicitVRLittleEndian:
+			s.explicitVR = false
+		case transferSyntaxExplicitVRBigEndian:
+			s.endian = decode.BigEndian
+		case transferSyntaxDeflated:
+			return
+		}
+		d.Endian = s.endian
+
+		for d.NotEnd() {
+			d.FieldStruct("element", func(d *decode.D) {
+				decodeElement(d, s)
+			})
+			elements++
+		}
+	})
+	if elements == 0 {
+		d.Errorf("no elements found")
+	}
+	if transferSyntax == transferSyntaxDeflated {
+		d.FieldRawLen("deflated", d.BitsLeft())
+	}
+
+	return nil
+}
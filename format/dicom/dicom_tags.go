@@ -0,0 +1,95 @@
+package dicom
+
+// subset of the data dictionary from https://dicom.nema.org/medical/dicom/current/output/chtml/part06/chapter_6.html
+
+type tagEntry struct {
+	name string
+	vr   string
+}
+
+var tagEntries = map[uint32]tagEntry{
+	// file meta information
+	0x0002_0000: {"FileMetaInformationGroupLength", "UL"},
+	0x0002_0001: {"FileMetaInformationVersion", "OB"},
+	0x0002_0002: {"MediaStorageSOPClassUID", "UI"},
+	0x0002_0003: {"MediaStorageSOPInstanceUID", "UI"},
+	0x0002_0010: {"TransferSyntaxUID", "UI"},
+	0x0002_0012: {"ImplementationClassUID", "UI"},
+	0x0002_0013: {"ImplementationVersionName", "SH"},
+	0x0002_0016: {"SourceApplicationEntityTitle", "AE"},
+
+	0x0008_0005: {"SpecificCharacterSet", "CS"},
+	0x0008_0008: {"ImageType", "CS"},
+	0x0008_0012: {"InstanceCreationDate", "DA"},
+	0x0008_0013: {"InstanceCreationTime", "TM"},
+	0x0008_0016: {"SOPClassUID", "UI"},
+	0x0008_0018: {"SOPInstanceUID", "UI"},
+	0x0008_0020: {"StudyDate", "DA"},
+	0x0008_0021: {"SeriesDate", "DA"},
+	0x0008_0022: {"AcquisitionDate", "DA"},
+	0x0008_0023: {"ContentDate", "DA"},
+	0x0008_002a: {"AcquisitionDateTime", "DT"},
+	0x0008_0030: {"StudyTime", "TM"},
+	0x0008_0031: {"SeriesTime", "TM"},
+	0x0008_0032: {"AcquisitionTime", "TM"},
+	0x0008_0033: {"ContentTime", "TM"},
+	0x0008_0050: {"AccessionNumber", "SH"},
+	0x0008_0060: {"Modality", "CS"},
+	0x0008_0070: {"Manufacturer", "LO"},
+	0x0008_0080: {"InstitutionName", "LO"},
+	0x0008_0090: {"ReferringPhysicianName", "PN"},
+	0x0008_1030: {"StudyDescription", "LO"},
+	0x0008_103e: {"SeriesDescription", "LO"},
+	0x0008_1090: {"ManufacturerModelName", "LO"},
+	0x0008_1140: {"ReferencedImageSequence", "SQ"},
+	0x0008_1150: {"ReferencedSOPClassUID", "UI"},
+	0x0008_1155: {"ReferencedSOPInstanceUID", "UI"},
+
+	0x0010_0010: {"PatientName", "PN"},
+	0x0010_0020: {"PatientID", "LO"},
+	0x0010_0030: {"PatientBirthDate", "DA"},
+	0x0010_0040: {"PatientSex", "CS"},
+	0x0010_1010: {"PatientAge", "AS"},
+	0x0010_1020: {"PatientSize", "DS"},
+	0x0010_1030: {"PatientWeight", "DS"},
+
+	0x0018_0015: {"BodyPartExamined", "CS"},
+	0x0018_0050: {"SliceThickness", "DS"},
+	0x0018_0060: {"KVP", "DS"},
+	0x0018_0088: {"SpacingBetweenSlices", "DS"},
+	0x0018_1020: {"SoftwareVersions", "LO"},
+	0x0018_5100: {"PatientPosition", "CS"},
+
+	0x0020_000d: {"StudyInstanceUID", "UI"},
+	0x0020_000e: {"SeriesInstanceUID", "UI"},
+	0x0020_0010: {"StudyID", "SH"},
+	0x0020_0011: {"SeriesNumber", "IS"},
+	0x0020_0012: {"AcquisitionNumber", "IS"},
+	0x0020_0013: {"InstanceNumber", "IS"},
+	0x0020_0032: {"ImagePositionPatient", "DS"},
+	0x0020_0037: {"ImageOrientationPatient", "DS"},
+	0x0020_0052: {"FrameOfReferenceUID", "UI"},
+	0x0020_1041: {"SliceLocation", "DS"},
+
+	0x0028_0002: {"SamplesPerPixel", "US"},
+	0x0028_0004: {"PhotometricInterpretation", "CS"},
+	0x0028_0006: {"PlanarConfiguration", "US"},
+	0x0028_0008: {"NumberOfFrames", "IS"},
+	0x0028_0010: {"Rows", "US"},
+	0x0028_0011: {"Columns", "US"},
+	0x0028_0030: {"PixelSpacing", "DS"},
+	0x0028_0100: {"BitsAllocated", "US"},
+	0x0028_0101: {"BitsStored", "US"},
+	0x0028_0102: {"HighBit", "US"},
+	0x0028_0103: {"PixelRepresentation", "US"},
+	0x0028_1050: {"WindowCenter", "DS"},
+	0x0028_1051: {"WindowWidth", "DS"},
+	0x0028_1052: {"RescaleIntercept", "DS"},
+	0x0028_1053: {"RescaleSlope", "DS"},
+
+	0x7fe0_0010: {"PixelData", "OW"},
+
+	0xfffe_e000: {"Item", ""},
+	0xfffe_e00d: {"ItemDelimitationItem", ""},
+	0xfffe_e0dd: {"SequenceDelimitationItem", ""},
+}
@@ -0,0 +1,189 @@
+package director
+
+// https://github.com/scummvm/scummvm/tree/master/engines/director
+// https://github.com/Earthquake-Project/Format-Documentation
+// TODO: afterburner ABMP resource map and FGEI inline streams
+// TODO: decode more chunk types, ex CASt, Lscr, VWSC
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var zlibGroup decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.DIRECTOR,
+		Description: "Macromedia Director movie and Shockwave",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    directorDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.ZLIB}, Group: &zlibGroup},
+		},
+	})
+}
+
+var formTypeNames = scalar.StrToSymStr{
+	"MV93": "Director movie",
+	"MC95": "Director cast",
+	"FGDM": "Shockwave movie (Afterburner)",
+	"FGDC": "Shockwave cast (Afterburner)",
+}
+
+// fourccs stored as integers so little endian files have them byte reversed
+func fieldFourCC(d *decode.D, name string, reverse bool, sms ...scalar.Mapper) string {
+	return d.FieldStrFn(name, func(d *decode.D) string {
+		b := d.BytesLen(4)
+		if reverse {
+			b[0], b[1], b[2], b[3] = b[3], b[2], b[1], b[0]
+		}
+		return string(b)
+	}, sms...)
+}
+
+// big endian 7 bit groups, high bit set if more bytes follow, at most 10 bytes to fit 64 bits
+func varint(d *decode.D) uint64 {
+	var n uint64
+	for i := 0; i < 10; i++ {
+		b := d.U8()
+		if n>>57 != 0 {
+			d.Fatalf("varint overflows 64 bits")
+		}
+		n = n<<7 | b&0x7f
+		if b&0x80 == 0 {
+			return n
+		}
+	}
+	d.Fatalf("varint longer than 10 bytes")
+	return 0
+}
+
+func fieldVarint(d *decode.D, name string, sms ...scalar.Mapper) uint64 {
+	return d.FieldUFn(name, varint, sms...)
+}
+
+var chunks = map[string]decode.ChunkFn{
+	"imap": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldU32("memory_map_count")
+		d.FieldU32("memory_map_offset", scalar.Hex)
+		d.FieldU32("map_version")
+	},
+	"mmap": func(d *decode.D, opts decode.ChunkOpts) {
+		start := d.Pos()
+		headerLen := d.FieldU16("header_length")
+		entryLen := d.FieldU16("entry_length")
+		countMax := d.FieldU32("count_max")
+		d.FieldU32("count_used")
+		d.FieldS32("junk_head")
+		d.FieldS32("junk_head2")
+		d.FieldS32("free_head")
+		d.SeekAbs(start + int64(headerLen)*8)
+		d.FieldArray("entries", func(d *decode.D) {
+			for i := uint64(0); i < countMax && d.BitsLeft() >= int64(entryLen)*8; i++ {
+				d.FieldStruct("entry", func(d *decode.D) {
+					d.LenFn(int64(entryLen)*8, func(d *decode.D) {
+						fieldFourCC(d, "fourcc", opts.ReverseID)
+						d.FieldU32("length")
+						d.FieldU32("offset", scalar.Hex)
+						d.FieldU16("flags")
+						d.FieldU16("unused")
+						d.FieldS32("next")
+					})
+				})
+			}
+		})
+	},
+	"KEY*": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldU16("entry_size")
+		d.FieldU16("entry_size2")
+		d.FieldU32("count_max")
+		countUsed := d.FieldU32("count_used")
+		d.FieldArray("entries", func(d *decode.D) {
+			for i := uint64(0); i < countUsed; i++ {
+				d.FieldStruct("entry", func(d *decode.D) {
+					d.FieldS32("section_id")
+					d.FieldS32("cast_id")
+					fieldFourCC(d, "fourcc", opts.ReverseID)
+				})
+			}
+		})
+	},
+	"CAS*": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldArray("members", func(d *decode.D) {
+			for d.NotEnd() {
+				d.FieldU32("section_id")
+			}
+		})
+	},
+}
+
+// afterburner chunks has varint lengths
+func decodeAfterburnerChunks(d *decode.D, reverse bool) {
+	d.FieldArray("chunks", func(d *decode.D) {
+		for d.NotEnd() {
+			d.FieldStruct("chunk", func(d *decode.D) {
+				id := fieldFourCC(d, "id", reverse)
+				if id == "FGEI" {
+					// inline resource streams to end of file
+					fieldVarint(d, "unused")
+					d.FieldRawLen("data", d.BitsLeft())
+					return
+				}
+				size := fieldVarint(d, "size")
+				d.LenFn(int64(size)*8, func(d *decode.D) {
+					switch id {
+					case "Fver":
+						fieldVarint(d, "version", scalar.Hex)
+						if d.NotEnd() {
+							d.FieldRawLen("data", d.BitsLeft())
+						}
+					case "Fcdr":
+						d.FieldFormatLen("data", d.BitsLeft(), zlibGroup, nil)
+					case "ABMP":
+						fieldVarint(d, "compression_type")
+						fieldVarint(d, "uncompressed_length")
+						d.FieldFormatLen("data", d.BitsLeft(), zlibGroup, nil)
+					default:
+						d.FieldRawLen("data", d.BitsLeft())
+					}
+				})
+			})
+		}
+	})
+}
+
+func directorDecode(d *decode.D, in interface{}) interface{} {
+	var opts decode.ChunkOpts
+	switch string(d.PeekBytes(4)) {
+	case "RIFX":
+		opts.Endian = decode.BigEndian
+	case "XFIR":
+		opts.Endian = decode.LittleEndian
+		opts.ReverseID = true
+	default:
+		d.Fatalf("no RIFX or XFIR header")
+	}
+
+	opts.Chunks = map[string]decode.ChunkFn{
+		"RIFX": func(d *decode.D, _ decode.ChunkOpts) {
+			formType := fieldFourCC(d, "form_type", opts.ReverseID, formTypeNames)
+			switch formType {
+			case "FGDM", "FGDC":
+				decodeAfterburnerChunks(d, opts.ReverseID)
+			default:
+				d.FieldChunks("chunks", decode.ChunkOpts{
+					Endian:    opts.Endian,
+					ReverseID: opts.ReverseID,
+					Align:     2,
+					Chunks:    chunks,
+				})
+			}
+		},
+	}
+	d.FieldChunk(opts, "RIFX")
+
+	return nil
+}
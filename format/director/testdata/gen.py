@@ -0,0 +1,81 @@
+#!/usr/bin/env python3
+# generates small director movie files with fake but consistent memory map
+# movie_be.dir big endian RIFX, movie_le.dir little endian XFIR, movie.dcr afterburner,
+# long_varint.dcr afterburner with a too long varint
+import struct
+import zlib
+
+
+def fourcc(e, s):
+    return s.encode() if e == ">" else s.encode()[::-1]
+
+
+def chunk(e, id, data):
+    b = fourcc(e, id) + struct.pack(e + "I", len(data)) + data
+    if len(data) % 2:
+        b += b"\0"
+    return b
+
+
+def movie(e):
+    key = struct.pack(e + "HHII", 12, 12, 2, 1) + struct.pack(e + "ii", 4, 1024) + fourcc(e, "CAS*")
+    cas = struct.pack(e + "II", 5, 6)
+    junk = b"abc"
+
+    # offsets: RIFX header 12, imap 8+12, mmap after
+    mmap_ids = ["RIFX", "imap", "mmap", "KEY*", "CAS*", "junk"]
+    mmap_len = 24 + 20 * len(mmap_ids)
+    offsets = [0, 12]
+    offsets.append(offsets[1] + 8 + 12)
+    offsets.append(offsets[2] + 8 + mmap_len)
+    offsets.append(offsets[3] + 8 + len(key))
+    offsets.append(offsets[4] + 8 + len(cas))
+    lens = [0, 12, mmap_len, len(key), len(cas), len(junk)]
+    total = offsets[5] + 8 + len(junk) + 1
+    lens[0] = total - 8
+
+    imap = struct.pack(e + "III", 1, offsets[2], 0x4c1)
+    mmap = struct.pack(e + "HHIIiii", 24, 20, len(mmap_ids), len(mmap_ids), -1, -1, -1)
+    for id, l, o in zip(mmap_ids, lens, offsets):
+        mmap += fourcc(e, id) + struct.pack(e + "IIHHi", l, o, 0, 0, -1)
+
+    body = fourcc(e, "MV93") + chunk(e, "imap", imap) + chunk(e, "mmap", mmap) + \
+        chunk(e, "KEY*", key) + chunk(e, "CAS*", cas) + chunk(e, "junk", junk)
+    return fourcc(e, "RIFX") + struct.pack(e + "I", len(body)) + body
+
+
+def varint(n):
+    b = [n & 0x7f]
+    n >>= 7
+    while n:
+        b.append(0x80 | (n & 0x7f))
+        n >>= 7
+    return bytes(reversed(b))
+
+
+def ab_chunk(e, id, data):
+    return fourcc(e, id) + varint(len(data)) + data
+
+
+def afterburner():
+    e = "<"
+    fver = varint(0x4c1) + b"\x00"
+    fcdr = zlib.compress(b"fcdr data")
+    abmp_data = b"resource map"
+    abmp = varint(0) + varint(len(abmp_data)) + zlib.compress(abmp_data)
+    body = fourcc(e, "FGDM") + ab_chunk(e, "Fver", fver) + ab_chunk(e, "Fcdr", fcdr) + \
+        ab_chunk(e, "ABMP", abmp) + fourcc(e, "FGEI") + varint(0) + zlib.compress(b"inline data")
+    return fourcc(e, "RIFX") + struct.pack(e + "I", len(body)) + body
+
+
+def long_varint():
+    e = "<"
+    # Fver chunk size varint with more than 10 bytes
+    body = fourcc(e, "FGDM") + fourcc(e, "Fver") + b"\x80" * 11 + b"\x01"
+    return fourcc(e, "RIFX") + struct.pack(e + "I", len(body)) + body
+
+
+open("movie_be.dir", "wb").write(movie(">"))
+open("movie_le.dir", "wb").write(movie("<"))
+open("movie.dcr", "wb").write(afterburner())
+open("long_varint.dcr", "wb").write(long_varint())
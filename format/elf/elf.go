@@ -139,7 +139,7 @@ func elfDecode(d *decode.D, in interface{}) interface{} {
 	var endian uint64
 
 	d.FieldStruct("ident", func(d *decode.D) {
-		d.FieldRawLen("magic", 4*8, d.AssertBitBuf([]byte("\x7fELF")))
+		d.FieldRawMagic("magic", []byte("\x7fELF"))
 		class = d.FieldU8("class", classBits)
 		endian = d.FieldU8("data", endianNames)
 		d.FieldU8("version")
@@ -264,7 +264,10 @@ func elfDecode(d *decode.D, in interface{}) interface{} {
 			_ = strIndexTable
 		})
 
-		strIndexTable = string(d.BytesRange(int64(strTableOffset*8), int(strTableSize)*8))
+		if int64(strTableOffset+strTableSize)*8 > d.Len() {
+			d.Fatalf("shstrtab outside of file")
+		}
+		strIndexTable = string(d.BytesRange(int64(strTableOffset*8), int(strTableSize)))
 	}
 
 	// d.DecodeRangeFn(int64(phoff)*8, int64(phnum*phsize*8), func(d *decode.D) {
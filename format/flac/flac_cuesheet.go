@@ -0,0 +1,55 @@
+package flac
+
+// https://xiph.org/flac/format.html#metadata_block_cuesheet
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.FLAC_CUESHEET,
+		Description: "FLAC metadatablock cuesheet",
+		DecodeFn:    cuesheetDecode,
+	})
+}
+
+var trackTypeNames = scalar.UToSymStr{
+	0: "audio",
+	1: "non_audio",
+}
+
+func cuesheetDecode(d *decode.D, in interface{}) interface{} {
+	d.FieldUTF8NullFixedLen("media_catalog_number", 128)
+	d.FieldU64("lead_in_samples")
+	d.FieldBool("is_cd")
+	d.FieldRawLen("reserved", 7+258*8)
+	numberOfTracks := d.FieldU8("number_of_tracks")
+	d.FieldArray("tracks", func(d *decode.D) {
+		for i := uint64(0); i < numberOfTracks; i++ {
+			d.FieldStruct("track", func(d *decode.D) {
+				d.FieldU64("offset")
+				d.FieldU8("number", scalar.UToSymStr{170: "lead_out"})
+				d.FieldUTF8NullFixedLen("isrc", 12)
+				d.FieldU1("type", trackTypeNames)
+				d.FieldBool("pre_emphasis")
+				d.FieldRawLen("reserved", 6+13*8)
+				numberOfIndexPoints := d.FieldU8("number_of_index_points")
+				d.FieldArray("index_points", func(d *decode.D) {
+					for j := uint64(0); j < numberOfIndexPoints; j++ {
+						d.FieldStruct("index_point", func(d *decode.D) {
+							d.FieldU64("offset")
+							d.FieldU8("number")
+							d.FieldRawLen("reserved", 3*8)
+						})
+					}
+				})
+			})
+		}
+	})
+
+	return nil
+}
@@ -1,7 +1,6 @@
 package flac
 
 // TODO: 24 bit picture length truncate warning
-// TODO: Cuesheet
 
 import (
 	"fmt"
@@ -14,6 +13,7 @@ import (
 
 var flacStreaminfoFormat decode.Group
 var flacPicture decode.Group
+var flacCuesheet decode.Group
 var vorbisCommentFormat decode.Group
 
 func init() {
@@ -24,6 +24,7 @@ func init() {
 		Dependencies: []decode.Dependency{
 			{Names: []string{format.FLAC_STREAMINFO}, Group: &flacStreaminfoFormat},
 			{Names: []string{format.FLAC_PICTURE}, Group: &flacPicture},
+			{Names: []string{format.FLAC_CUESHEET}, Group: &flacCuesheet},
 			{Names: []string{format.VORBIS_COMMENT}, Group: &vorbisCommentFormat},
 		},
 	})
@@ -69,6 +70,8 @@ func metadatablockDecode(d *decode.D, in interface{}) interface{} {
 		d.FieldFormatLen("comment", int64(length*8), vorbisCommentFormat, nil)
 	case MetadataBlockPicture:
 		d.FieldFormatLen("picture", int64(length*8), flacPicture, nil)
+	case MetadataBlockCuesheet:
+		d.FieldFormatLen("cuesheet", int64(length*8), flacCuesheet, nil)
 	case MetadataBlockSeektable:
 		seektableCount := length / 18
 		d.FieldArray("seekpoints", func(d *decode.D) {
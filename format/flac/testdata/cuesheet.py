@@ -0,0 +1,43 @@
+#!/usr/bin/env python3
+# generates cuesheet.flac, mono16.flac with a CUESHEET metadata block
+import struct
+
+
+def cuesheet():
+    out = b"1234567890123".ljust(128, b"\0")
+    out += struct.pack(">Q", 88200)  # lead-in samples
+    out += bytes([0x80]) + bytes(258)  # is CD
+    tracks = [
+        # offset, number, isrc, non-audio, pre-emphasis, index points
+        (0, 1, b"USXXX2100001", 0, 0, [(0, 1)]),
+        (588 * 10, 2, b"", 0, 1, [(0, 0), (588, 1)]),
+        (588 * 20, 170, b"", 0, 0, []),  # lead-out
+    ]
+    out += bytes([len(tracks)])
+    for offset, number, isrc, non_audio, pre_emphasis, indexes in tracks:
+        out += struct.pack(">QB", offset, number) + isrc.ljust(12, b"\0")
+        out += bytes([non_audio << 7 | pre_emphasis << 6]) + bytes(13)
+        out += bytes([len(indexes)])
+        for index_offset, index_number in indexes:
+            out += struct.pack(">QB", index_offset, index_number) + bytes(3)
+    return out
+
+
+flac = open("mono16.flac", "rb").read()
+pos = 4
+blocks = []
+while True:
+    header = flac[pos]
+    length = int.from_bytes(flac[pos + 1:pos + 4], "big")
+    blocks.append((header & 0x7f, flac[pos + 4:pos + 4 + length]))
+    pos += 4 + length
+    if header & 0x80:
+        break
+blocks.append((5, cuesheet()))
+
+out = b"fLaC"
+for i, (typ, data) in enumerate(blocks):
+    last = 0x80 if i == len(blocks) - 1 else 0
+    out += bytes([last | typ]) + len(data).to_bytes(3, "big") + data
+out += flac[pos:]
+open("cuesheet.flac", "wb").write(out)
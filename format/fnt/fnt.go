@@ -0,0 +1,199 @@
+package fnt
+
+// https://web.archive.org/web/20150412160628/http://support.microsoft.com/kb/65123
+// TODO: FON files, NE executable with FNT resources
+// TODO: 3.0 ABC spacing and color glyph tables
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.FNT,
+		Description: "Windows font",
+		DecodeFn:    fntDecode,
+	})
+}
+
+const (
+	version2 = 0x0200
+	version3 = 0x0300
+)
+
+var versionNames = scalar.UToSymStr{
+	version2: "2.0",
+	version3: "3.0",
+}
+
+var charsetNames = scalar.UToSymStr{
+	0:   "ansi",
+	1:   "default",
+	2:   "symbol",
+	77:  "mac",
+	128: "shiftjis",
+	129: "hangul",
+	130: "johab",
+	134: "gb2312",
+	136: "chinesebig5",
+	161: "greek",
+	162: "turkish",
+	163: "vietnamese",
+	177: "hebrew",
+	178: "arabic",
+	186: "baltic",
+	204: "russian",
+	222: "thai",
+	238: "easteurope",
+	255: "oem",
+}
+
+var familyNames = scalar.UToSymStr{
+	0: "dont_care",
+	1: "roman",
+	2: "swiss",
+	3: "modern",
+	4: "script",
+	5: "decorative",
+}
+
+var weightNames = scalar.UToSymStr{
+	100: "thin",
+	200: "extralight",
+	300: "light",
+	400: "normal",
+	500: "medium",
+	600: "semibold",
+	700: "bold",
+	800: "extrabold",
+	900: "heavy",
+}
+
+type header struct {
+	version   uint64
+	vector    bool
+	pixHeight uint64
+	firstChar uint64
+	lastChar  uint64
+	device    uint64
+	face      uint64
+}
+
+func decodeHeader(d *decode.D) header {
+	var h header
+
+	h.version = d.FieldU16("version", d.AssertU(version2, version3), versionNames, scalar.Hex)
+	size := d.FieldU32("size")
+	if int64(size)*8 > d.Len() {
+		d.Fatalf("size %d larger than file", size)
+	}
+	d.FieldUTF8NullFixedLen("copyright", 60)
+	typ := d.FieldU16("type", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		// bit 0 set for vector fonts
+		if s.ActualU()&1 != 0 {
+			s.Sym = "vector"
+		} else {
+			s.Sym = "raster"
+		}
+		return s, nil
+	}))
+	h.vector = typ&1 != 0
+	d.FieldU16("points")
+	d.FieldU16("vertical_resolution")
+	d.FieldU16("horizontal_resolution")
+	d.FieldU16("ascent")
+	d.FieldU16("internal_leading")
+	d.FieldU16("external_leading")
+	d.FieldU8("italic")
+	d.FieldU8("underline")
+	d.FieldU8("strike_out")
+	d.FieldU16("weight", weightNames)
+	d.FieldU8("charset", charsetNames)
+	d.FieldU16("pixel_width")
+	h.pixHeight = d.FieldU16("pixel_height")
+	d.FieldStruct("pitch_and_family", func(d *decode.D) {
+		d.FieldU4("family", familyNames)
+		d.FieldU3("reserved")
+		d.FieldBool("variable_pitch")
+	})
+	d.FieldU16("average_width")
+	d.FieldU16("max_width")
+	h.firstChar = d.FieldU8("first_char")
+	h.lastChar = d.FieldU8("last_char")
+	if h.lastChar < h.firstChar {
+		d.Fatalf("last_char %d before first_char %d", h.lastChar, h.firstChar)
+	}
+	d.FieldU8("default_char", scalar.UAdd(int(h.firstChar)))
+	d.FieldU8("break_char", scalar.UAdd(int(h.firstChar)))
+	d.FieldU16("width_bytes")
+	h.device = d.FieldU32("device_offset")
+	h.face = d.FieldU32("face_offset")
+	d.FieldU32("bits_pointer")
+	d.FieldU32("bits_offset")
+	d.FieldU8("reserved")
+
+	if h.version == version3 {
+		d.FieldU32("flags", scalar.Hex)
+		d.FieldU16("a_space")
+		d.FieldU16("b_space")
+		d.FieldU16("c_space")
+		d.FieldU32("color_pointer")
+		d.FieldRawLen("reserved1", 16*8)
+	}
+
+	return h
+}
+
+func fntDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	var h header
+	d.FieldStruct("header", func(d *decode.D) { h = decodeHeader(d) })
+
+	// has one extra sentinel entry after last char
+	nGlyphs := h.lastChar - h.firstChar + 2
+	d.FieldArray("glyphs", func(d *decode.D) {
+		for i := uint64(0); i < nGlyphs; i++ {
+			d.FieldStruct("glyph", func(d *decode.D) {
+				d.FieldValueU("char", h.firstChar+i)
+				if h.vector {
+					d.FieldU16("offset")
+					d.FieldU16("width")
+					return
+				}
+
+				width := d.FieldU16("width")
+				var offset uint64
+				if h.version == version3 {
+					offset = d.FieldU32("offset")
+				} else {
+					offset = d.FieldU16("offset")
+				}
+
+				// bitmap is stored as columns of 8 pixels wide and pixel_height bytes high
+				bitmapLen := int64((width+7)/8*h.pixHeight) * 8
+				if i < nGlyphs-1 && bitmapLen > 0 && int64(offset)*8+bitmapLen <= d.Len() {
+					d.RangeFn(int64(offset)*8, bitmapLen, func(d *decode.D) {
+						d.FieldRawLen("bitmap", bitmapLen)
+					})
+				}
+			})
+		}
+	})
+
+	if h.device != 0 && int64(h.device)*8 < d.Len() {
+		d.RangeFn(int64(h.device)*8, d.Len()-int64(h.device)*8, func(d *decode.D) {
+			d.FieldUTF8Null("device_name")
+		})
+	}
+	if h.face != 0 && int64(h.face)*8 < d.Len() {
+		d.RangeFn(int64(h.face)*8, d.Len()-int64(h.face)*8, func(d *decode.D) {
+			d.FieldUTF8Null("face_name")
+		})
+	}
+
+	return nil
+}
@@ -1,5 +1,7 @@
 package format
 
+import "github.com/wader/fq/pkg/bitio"
+
 //nolint:revive
 const (
 	ALL = "all"
@@ -11,6 +13,7 @@ const (
 
 	RAW  = "raw"
 	JSON = "json"
+	XML  = "xml"
 
 	DNS             = "dns"
 	DNS_TCP         = "dns_tcp"
@@ -25,11 +28,25 @@ const (
 	AAC_FRAME           = "aac_frame"
 	ADTS                = "adts"
 	ADTS_FRAME          = "adts_frame"
+	ANDROID_BOOT        = "android_boot"
+	ANDROID_SPARSE      = "android_sparse"
 	APEV2               = "apev2"
+	ARROW_IPC           = "arrow_ipc"
+	AUDIT               = "audit"
 	AV1_CCR             = "av1_ccr"
 	AV1_FRAME           = "av1_frame"
 	AV1_OBU             = "av1_obu"
+	BCF                 = "bcf"
+	BGZF                = "bgzf"
+	BROTLI              = "brotli"
 	BZIP2               = "bzip2"
+	CBOR                = "cbor"
+	CPIO                = "cpio"
+	CRX                 = "crx"
+	CUESHEET            = "cuesheet"
+	DEFLATE             = "deflate"
+	DICOM               = "dicom"
+	DIRECTOR            = "director"
 	ELF                 = "elf"
 	EXIF                = "exif"
 	FLAC                = "flac"
@@ -38,15 +55,27 @@ const (
 	FLAC_METADATABLOCKS = "flac_metadatablocks"
 	FLAC_STREAMINFO     = "flac_streaminfo"
 	FLAC_PICTURE        = "flac_picture"
+	FLAC_CUESHEET       = "flac_cuesheet"
 	FLV                 = "flv" // TODO:
+	FNT                 = "fnt"
+	FSEVENTS            = "fsevents"
 	GIF                 = "gif"
+	GRIB2               = "grib2"
 	GZIP                = "gzip"
 	ICC_PROFILE         = "icc_profile"
+	ILBM                = "ilbm"
 	ID3V1               = "id3v1"
 	ID3V11              = "id3v11"
 	ID3V2               = "id3v2"
+	INDX                = "indx"
 	JPEG                = "jpeg"
+	KAFKA               = "kafka"
+	LAS                 = "las"
+	LUKS                = "luks"
+	LZW_COMPRESS        = "lzw_compress"
 	MATROSKA            = "matroska"
+	MOBILEPROVISION     = "mobileprovision"
+	MOZLZ4              = "mozlz4"
 	MP3                 = "mp3"
 	MP3_FRAME           = "mp3_frame"
 	XING                = "xing"
@@ -68,26 +97,47 @@ const (
 	MPEG_PES_PACKET     = "mpeg_pes_packet"
 	MPEG_SPU            = "mpeg_spu"
 	MPEG_TS             = "mpeg_ts"
+	NIFTI               = "nifti"
+	OPENSSH_KEY         = "openssh_key"
+	OPENTYPE            = "opentype"
 	OGG                 = "ogg"
 	OGG_PAGE            = "ogg_page"
 	OPUS_PACKET         = "opus_packet"
 	PCAP                = "pcap"
+	PCD                 = "pcd"
+	PCF                 = "pcf"
+	PCX                 = "pcx"
 	PCAPNG              = "pcapng"
+	PLIST               = "plist"
 	PNG                 = "png"
+	PPK                 = "ppk"
+	PREFETCH            = "prefetch"
 	PROTOBUF            = "protobuf"
 	PROTOBUF_WIDEVINE   = "protobuf_widevine"
+	PSD                 = "psd"
 	PSSH_PLAYREADY      = "pssh_playready"
+	SNAPPY              = "snappy"
+	SNSS                = "snss"
+	SPEEX_PACKET        = "speex_packet"
+	SPOTLIGHT_STORE     = "spotlight_store"
+	SQLITE_WAL          = "sqlite_wal"
 	TAR                 = "tar"
+	THEORA_PACKET       = "theora_packet"
 	TIFF                = "tiff"
+	TRACEV3             = "tracev3"
+	USN_JOURNAL         = "usn_journal"
 	VORBIS_COMMENT      = "vorbis_comment"
 	VORBIS_PACKET       = "vorbis_packet"
 	VP8_FRAME           = "vp8_frame"
 	VP9_FRAME           = "vp9_frame"
 	VP9_CFM             = "vp9_cfm"
 	VPX_CCR             = "vpx_ccr"
+	WASM                = "wasm"
 	WAV                 = "wav"
 	WEBP                = "webp"
+	XMP                 = "xmp"
 	ZIP                 = "zip"
+	ZLIB                = "zlib"
 )
 
 // below are data types used to communicate between formats <FormatName>In/Out
@@ -196,3 +246,11 @@ type TCPStreamIn struct {
 	SourcePort      int
 	DestinationPort int
 }
+
+type DeflateOut struct {
+	Uncompressed *bitio.Buffer
+}
+
+type ZlibIn struct {
+	Dictionary []byte
+}
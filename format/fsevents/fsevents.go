@@ -0,0 +1,137 @@
+package fsevents
+
+// https://github.com/dlcowen/FSEventsParser
+// http://nicoleibrahim.com/apple-fsevents-forensics/
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.FSEVENTS,
+		Description: "macOS FSEvents database",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    fseventsDecode,
+	})
+}
+
+const pageHeaderSize = 12
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+var versionMagics = map[string]int{
+	"1SLD": 1,
+	"2SLD": 2,
+	"3SLD": 3,
+}
+
+// bit index to name, on disk flags differ from FSEventStreamEventFlags
+var eventFlagNames = map[int]string{
+	0:  "is_dir",
+	1:  "mount",
+	2:  "unmount",
+	5:  "end_of_transaction",
+	11: "last_hard_link_removed",
+	12: "is_hard_link",
+	14: "is_symlink",
+	15: "is_file",
+	16: "permission_change",
+	17: "extended_attr_modified",
+	18: "extended_attr_removed",
+	20: "document_revisioning",
+	22: "item_cloned",
+	24: "created",
+	25: "removed",
+	26: "inode_meta_mod",
+	27: "renamed",
+	28: "modified",
+	29: "exchange",
+	30: "finder_info_mod",
+	31: "folder_created",
+}
+
+func fieldFlags32(d *decode.D, name string, names map[int]string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		for byteI := 0; byteI < 4; byteI++ {
+			for bitI := 7; bitI >= 0; bitI-- {
+				bit := byteI*8 + bitI
+				if n, ok := names[bit]; ok {
+					d.FieldBool(n)
+				} else {
+					d.FieldBool(fmt.Sprintf("unknown%d", bit))
+				}
+			}
+		}
+	})
+}
+
+func decodePages(d *decode.D) {
+	for d.NotEnd() {
+		d.FieldStruct("page", func(d *decode.D) {
+			pageStart := d.Pos()
+			magic := d.FieldUTF8("magic", 4, d.AssertStr("1SLD", "2SLD", "3SLD"))
+			version := versionMagics[magic]
+			d.FieldU32("unknown0", scalar.Hex)
+			pageSize := d.FieldU32("page_size")
+			if pageSize < pageHeaderSize {
+				d.Fatalf("invalid page size %d", pageSize)
+			}
+
+			d.LenFn(int64(pageSize-pageHeaderSize)*8, func(d *decode.D) {
+				d.FieldArray("records", func(d *decode.D) {
+					for d.NotEnd() {
+						d.FieldStruct("record", func(d *decode.D) {
+							d.FieldUTF8Null("path")
+							d.FieldU64("event_id", scalar.Hex)
+							fieldFlags32(d, "flags", eventFlagNames)
+							if version >= 2 {
+								d.FieldU64("node_id")
+							}
+							if version >= 3 {
+								d.FieldU32("unknown0", scalar.Hex)
+							}
+						})
+					}
+				})
+			})
+			d.SeekAbs(pageStart + int64(pageSize)*8)
+		})
+	}
+}
+
+func fseventsDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	if !bytes.Equal(d.PeekBytes(len(gzipMagic)), gzipMagic) {
+		d.FieldArray("pages", decodePages)
+		return nil
+	}
+
+	// files in /.fseventsd are gzip compressed, check magic before decompressing everything
+	zr, err := gzip.NewReader(d.BitBufRange(0, d.Len()))
+	if err != nil {
+		d.Fatalf("gzip: %s", err)
+	}
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(zr, magic); err != nil {
+		d.Fatalf("gzip: %s", err)
+	}
+	if _, ok := versionMagics[string(magic)]; !ok {
+		d.Fatalf("unknown magic %q", magic)
+	}
+	uncompressedBB := d.MustNewBitBufFromReader(io.MultiReader(bytes.NewReader(magic), zr))
+
+	d.FieldRawLen("compressed", d.BitsLeft())
+	d.FieldArrayRootBitBufFn("pages", uncompressedBB, decodePages)
+
+	return nil
+}
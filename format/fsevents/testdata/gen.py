@@ -0,0 +1,35 @@
+# generates fsevents test files, v2 is gzip compressed like files in /.fseventsd
+import gzip
+import struct
+
+CREATED = 0x01000000
+REMOVED = 0x02000000
+RENAMED = 0x08000000
+MODIFIED = 0x10000000
+IS_DIR = 0x00000001
+IS_FILE = 0x00008000
+
+
+def page(version, records):
+    b = b""
+    for path, event_id, flags, node_id in records:
+        b += path.encode("utf-8") + b"\x00" + struct.pack("<QI", event_id, flags)
+        if version >= 2:
+            b += struct.pack("<Q", node_id)
+    return b"%dSLD" % version + struct.pack("<II", 0x3b1f2e9a, 12 + len(b)) + b
+
+
+v2 = page(2, [
+    ("Users/user/Documents", 0x1a2b00, CREATED | IS_DIR, 1001),
+    ("Users/user/Documents/notes.txt", 0x1a2b01, CREATED | MODIFIED | IS_FILE, 1002),
+]) + page(2, [
+    ("Users/user/Documents/old.txt", 0x1a2b02, RENAMED | IS_FILE, 1003),
+    ("Users/user/Documents/new.txt", 0x1a2b03, RENAMED | IS_FILE, 1003),
+    ("private/var/tmp/a", 0x1a2b04, CREATED | REMOVED | IS_FILE, 1004),
+])
+open("v2_gzip", "wb").write(gzip.compress(v2, mtime=0))
+
+v1 = page(1, [
+    ("Volumes/disk/file", 0x10, MODIFIED | IS_FILE, 0),
+])
+open("v1", "wb").write(v1)
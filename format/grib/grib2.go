@@ -0,0 +1,265 @@
+package grib
+
+// https://www.nco.ncep.noaa.gov/pmb/docs/grib2/grib2_doc/
+// https://library.wmo.int/idurl/4/35625 Manual on Codes, FM 92 GRIB edition 2
+// TODO: grid and product definition templates
+// TODO: GRIB edition 1
+
+import (
+	"fmt"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.GRIB2,
+		Description: "General Regularly-distributed Information in Binary form edition 2",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    grib2Decode,
+	})
+}
+
+const (
+	sectionIndicator          = 0
+	sectionIdentification     = 1
+	sectionLocalUse           = 2
+	sectionGridDefinition     = 3
+	sectionProductDefinition  = 4
+	sectionDataRepresentation = 5
+	sectionBitmap             = 6
+	sectionData               = 7
+	sectionEnd                = 8
+)
+
+// number is kept as value to make it easy to select on
+var sectionNames = scalar.UToScalar{
+	sectionIndicator:          {Description: "Indicator"},
+	sectionIdentification:     {Description: "Identification"},
+	sectionLocalUse:           {Description: "Local use"},
+	sectionGridDefinition:     {Description: "Grid definition"},
+	sectionProductDefinition:  {Description: "Product definition"},
+	sectionDataRepresentation: {Description: "Data representation"},
+	sectionBitmap:             {Description: "Bitmap"},
+	sectionData:               {Description: "Data"},
+	sectionEnd:                {Description: "End"},
+}
+
+// code table 0.0
+var disciplineNames = scalar.UToSymStr{
+	0:   "Meteorological products",
+	1:   "Hydrological products",
+	2:   "Land surface products",
+	3:   "Satellite remote sensing products",
+	4:   "Space weather products",
+	10:  "Oceanographic products",
+	20:  "Health and socioeconomic impacts",
+	209: "Multi-Radar/Multi-Sensor",
+}
+
+// common code table 0 (C-11)
+var centerNames = scalar.UToSymStr{
+	7:  "US National Weather Service, National Centres for Environmental Prediction (NCEP)",
+	34: "Japanese Meteorological Agency",
+	54: "Canadian Meteorological Service",
+	74: "UK Meteorological Office",
+	78: "Offenbach (RSMC)",
+	85: "French Weather Service",
+	98: "European Centre for Medium-Range Weather Forecasts",
+}
+
+// code table 1.2
+var referenceTimeSignificanceNames = scalar.UToSymStr{
+	0: "Analysis",
+	1: "Start of forecast",
+	2: "Verifying time of forecast",
+	3: "Observation time",
+}
+
+// code table 1.3
+var productionStatusNames = scalar.UToSymStr{
+	0: "Operational products",
+	1: "Operational test products",
+	2: "Research products",
+	3: "Re-analysis products",
+	4: "THORPEX Interactive Grand Global Ensemble (TIGGE)",
+	5: "THORPEX Interactive Grand Global Ensemble (TIGGE) test",
+	6: "S2S operational products",
+	7: "S2S test products",
+}
+
+// code table 1.4
+var dataTypeNames = scalar.UToSymStr{
+	0: "Analysis products",
+	1: "Forecast products",
+	2: "Analysis and forecast products",
+	3: "Control forecast products",
+	4: "Perturbed forecast products",
+	5: "Control and perturbed forecast products",
+	6: "Processed satellite observations",
+	7: "Processed radar observations",
+	8: "Event probability",
+}
+
+// code table 5.0
+var dataRepresentationTemplateNames = scalar.UToSymStr{
+	0:  "Grid point data - simple packing",
+	2:  "Grid point data - complex packing",
+	3:  "Grid point data - complex packing and spatial differencing",
+	40: "Grid point data - JPEG 2000 code stream format",
+	41: "Grid point data - Portable Network Graphics (PNG)",
+	50: "Spectral data - simple packing",
+	51: "Spherical harmonics data - complex packing",
+}
+
+// code table 6.0
+var bitmapIndicatorNames = scalar.URangeToScalar{
+	{0, 0}:     {Sym: "bitmap_follows"},
+	{1, 253}:   {Sym: "predefined"},
+	{254, 254}: {Sym: "previous"},
+	{255, 255}: {Sym: "none"},
+}
+
+// grib uses sign and magnitude for signed integers
+var signMagnitude = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	u := s.ActualU()
+	v := int64(u & 0x7fff)
+	if u&0x8000 != 0 {
+		v = -v
+	}
+	s.Sym = v
+	return s, nil
+})
+
+func decodeIdentification(d *decode.D) {
+	d.FieldU16("center", centerNames)
+	d.FieldU16("subcenter")
+	d.FieldU8("master_tables_version")
+	d.FieldU8("local_tables_version")
+	d.FieldU8("reference_time_significance", referenceTimeSignificanceNames)
+	year := d.FieldU16("year")
+	month := d.FieldU8("month")
+	day := d.FieldU8("day")
+	hour := d.FieldU8("hour")
+	minute := d.FieldU8("minute")
+	second := d.FieldU8("second")
+	d.FieldValueStr("reference_time", fmt.Sprintf("%04d-%02d-%02dT%02d:%02d:%02dZ", year, month, day, hour, minute, second))
+	d.FieldU8("production_status", productionStatusNames)
+	d.FieldU8("data_type", dataTypeNames)
+	if d.NotEnd() {
+		d.FieldRawLen("reserved", d.BitsLeft())
+	}
+}
+
+func decodeGridDefinition(d *decode.D) {
+	d.FieldU8("source")
+	d.FieldU32("number_of_data_points")
+	d.FieldU8("optional_list_octets")
+	d.FieldU8("optional_list_interpretation")
+	d.FieldU16("template_number")
+	d.FieldRawLen("template", d.BitsLeft())
+}
+
+func decodeProductDefinition(d *decode.D) {
+	d.FieldU16("coordinate_values")
+	templateNumber := d.FieldU16("template_number")
+	// templates 4.0 to 4.15 all start with parameter category and number
+	if templateNumber <= 15 && d.BitsLeft() >= 16 {
+		d.FieldU8("parameter_category")
+		d.FieldU8("parameter_number")
+	}
+	d.FieldRawLen("template", d.BitsLeft())
+}
+
+func decodeDataRepresentation(d *decode.D) {
+	d.FieldU32("number_of_data_points")
+	templateNumber := d.FieldU16("template_number", dataRepresentationTemplateNames)
+	// templates 5.0, 5.2, 5.3, 5.40 and 5.41 start the same
+	switch templateNumber {
+	case 0, 2, 3, 40, 41:
+		d.FieldF32("reference_value")
+		d.FieldU16("binary_scale_factor", signMagnitude)
+		d.FieldU16("decimal_scale_factor", signMagnitude)
+		d.FieldU8("bits_per_value")
+		d.FieldU8("original_field_type", scalar.UToSymStr{0: "Floating point", 1: "Integer"})
+	}
+	if d.NotEnd() {
+		d.FieldRawLen("template", d.BitsLeft())
+	}
+}
+
+func decodeSection(d *decode.D) {
+	// end section is just "7777"
+	if d.PeekBits(32) == 0x37373737 {
+		d.FieldValueU("number", sectionEnd, sectionNames)
+		d.FieldRawMagic("magic", []byte("7777"))
+		return
+	}
+
+	length := d.FieldU32("length")
+	number := d.FieldU8("number", sectionNames)
+	if length < 5 {
+		d.Fatalf("section length %d too small", length)
+	}
+	d.LenFn(int64(length-5)*8, func(d *decode.D) {
+		switch number {
+		case sectionIdentification:
+			decodeIdentification(d)
+		case sectionGridDefinition:
+			decodeGridDefinition(d)
+		case sectionProductDefinition:
+			decodeProductDefinition(d)
+		case sectionDataRepresentation:
+			decodeDataRepresentation(d)
+		case sectionBitmap:
+			d.FieldU8("indicator", bitmapIndicatorNames)
+			if d.NotEnd() {
+				d.FieldRawLen("bitmap", d.BitsLeft())
+			}
+		default:
+			d.FieldRawLen("data", d.BitsLeft())
+		}
+	})
+}
+
+func decodeMessage(d *decode.D) {
+	var totalLength uint64
+	d.FieldArray("sections", func(d *decode.D) {
+		d.FieldStruct("section", func(d *decode.D) {
+			d.FieldValueU("number", sectionIndicator, sectionNames)
+			d.FieldRawMagic("magic", []byte("GRIB"))
+			d.FieldU16("reserved")
+			d.FieldU8("discipline", disciplineNames)
+			d.FieldU8("edition", d.AssertU(2))
+			totalLength = d.FieldU64("total_length")
+		})
+		// indicator section is 16 bytes
+		if totalLength < 16 {
+			d.Fatalf("total length %d too small", totalLength)
+		}
+
+		d.LenFn(int64(totalLength-16)*8, func(d *decode.D) {
+			for d.NotEnd() {
+				d.FieldStruct("section", decodeSection)
+			}
+		})
+	})
+}
+
+func grib2Decode(d *decode.D, in interface{}) interface{} {
+	messages := 0
+	d.FieldArray("messages", func(d *decode.D) {
+		for d.NotEnd() {
+			d.FieldStruct("message", decodeMessage)
+			messages++
+		}
+	})
+	if messages == 0 {
+		d.Errorf("no messages found")
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package gz
+
+// https://samtools.github.io/hts-specs/SAMv1.pdf section 4.1 "The BGZF compression format"
+
+import (
+	"bytes"
+	"compress/flate"
+	"hash/crc32"
+	"io"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.BGZF,
+		Description: "Blocked GNU Zip Format",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    bgzfDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.PROBE}, Group: &probeFormat},
+		},
+	})
+}
+
+// fixed part of a block: ID1 to XLEN (12) and CRC32, ISIZE (8) minus one as BSIZE is total block size minus one
+const bgzfBlockFixedSize = 19
+
+func bgzfDecodeBlock(d *decode.D, uncompressedOffset uint64, uncompressed *bytes.Buffer) uint64 {
+	coffset := uint64(d.Pos() / 8)
+
+	d.FieldRawMagic("identification", []byte("\x1f\x8b"))
+	d.FieldU8("compression_method", compressionMethodNames, d.AssertU(delfateMethod))
+	d.FieldStruct("flags", func(d *decode.D) {
+		// FLG bits are numbered from least significant bit
+		d.FieldU3("reserved")
+		d.FieldBool("comment")
+		d.FieldBool("name")
+		d.FieldBool("extra", d.AssertBool(true))
+		d.FieldBool("header_crc")
+		d.FieldBool("text")
+	})
+	d.FieldU32("mtime")
+	d.FieldU8("extra_flags", deflateExtraFlagsNames)
+	d.FieldUEnum("os", 8, osNames)
+	xLen := d.FieldU16("xlen", d.AssertURange(6, 0xffff))
+	d.FieldU8("si1", d.AssertU('B'))
+	d.FieldU8("si2", d.AssertU('C'))
+	d.FieldU16("slen", d.AssertU(2))
+	bSize := d.FieldU16("bsize", scalar.Description("total block size minus one"))
+	if xLen > 6 {
+		d.FieldRawLen("extra_fields", int64(xLen-6)*8)
+	}
+	if bSize < xLen+bgzfBlockFixedSize {
+		d.Fatalf("bsize %d too small for xlen %d", bSize, xLen)
+	}
+
+	cdataLen := int64(bSize-xLen-bgzfBlockFixedSize) * 8
+	cdataBB := d.BitBufRange(d.Pos(), cdataLen)
+	d.FieldRawLen("cdata", cdataLen)
+
+	crc32W := crc32.NewIEEE()
+	d.MustCopy(io.MultiWriter(crc32W, uncompressed), flate.NewReader(cdataBB))
+	d.FieldU32("crc32", d.ValidateUBytes(crc32W.Sum(nil)), scalar.Hex)
+	iSize := d.FieldU32("isize")
+
+	d.FieldValueU("coffset", coffset)
+	d.FieldValueU("uncompressed_offset", uncompressedOffset)
+	// virtual offset of first uncompressed byte in block as used by BAI, tabix etc
+	d.FieldValueU("virtual_offset", coffset<<16, scalar.Hex)
+
+	return uncompressedOffset + iSize
+}
+
+func bgzfDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	var uncompressedOffset uint64
+	uncompressed := &bytes.Buffer{}
+	blocks := 0
+	d.FieldStructArrayLoop("blocks", "block", d.NotEnd, func(d *decode.D) {
+		uncompressedOffset = bgzfDecodeBlock(d, uncompressedOffset, uncompressed)
+		blocks++
+	})
+	if blocks == 0 {
+		d.Errorf("no blocks found")
+	}
+
+	// blocks are compressed independently, probe concatenated uncompressed data (BAM, BCF etc)
+	uncompressedBB := bitio.NewBufferFromBytes(uncompressed.Bytes(), -1)
+	if dv, _, _ := d.TryFieldFormatBitBuf("uncompressed", uncompressedBB, probeFormat, nil); dv == nil {
+		d.FieldRootBitBuf("uncompressed", uncompressedBB)
+	}
+
+	return nil
+}
@@ -60,7 +60,7 @@ var deflateExtraFlagsNames = scalar.UToSymStr{
 func gzDecode(d *decode.D, in interface{}) interface{} {
 	d.Endian = decode.LittleEndian
 
-	d.FieldRawLen("identification", 2*8, d.AssertBitBuf([]byte("\x1f\x8b")))
+	d.FieldRawMagic("identification", []byte("\x1f\x8b"))
 	compressionMethod := d.FieldU8("compression_method", compressionMethodNames)
 	hasHeaderCRC := false
 	hasExtra := false
@@ -81,7 +81,7 @@ func gzDecode(d *decode.D, in interface{}) interface{} {
 	default:
 		d.FieldU8("extra_flags")
 	}
-	d.FieldU8("os", osNames)
+	d.FieldUEnum("os", 8, osNames)
 	if hasExtra {
 		// TODO:
 		xLen := d.FieldU16("xlen")
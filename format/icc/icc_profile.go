@@ -19,9 +19,9 @@ func init() {
 }
 
 func xyzType(_ int64, d *decode.D) {
-	d.FieldFP32("X")
-	d.FieldFP32("Y")
-	d.FieldFP32("Z")
+	d.FieldSFP32("X")
+	d.FieldSFP32("Y")
+	d.FieldSFP32("Z")
 }
 
 func textType(_ int64, d *decode.D) {
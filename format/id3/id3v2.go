@@ -198,7 +198,7 @@ type unsyncReader struct {
 	lastFF bool
 }
 
-func (r unsyncReader) Read(p []byte) (n int, err error) {
+func (r *unsyncReader) Read(p []byte) (n int, err error) {
 	n, err = r.Reader.Read(p)
 
 	ni := 0
@@ -312,7 +312,7 @@ func decodeFrame(d *decode.D, version int) uint64 {
 	var id string
 	var size uint64
 	var dataSize uint64
-	// TODO: global tag unsync?
+	// tag unsynchronisation for version 2 and 3 is handled in id3v2Decode
 	unsyncFlag := false
 
 	switch version {
@@ -533,7 +533,7 @@ func decodeFrame(d *decode.D, version int) uint64 {
 	if unsyncFlag {
 		// TODO: DecodeFn
 		// TODO: unknown after frame decode
-		unsyncedBb := d.MustNewBitBufFromReader(unsyncReader{Reader: d.BitBufRange(d.Pos(), int64(dataSize)*8)})
+		unsyncedBb := d.MustNewBitBufFromReader(&unsyncReader{Reader: d.BitBufRange(d.Pos(), int64(dataSize)*8)})
 		d.FieldFormatBitBuf("unsync", unsyncedBb, decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
 			if fn, ok := frames[idNormalized]; ok {
 				fn(d)
@@ -575,6 +575,112 @@ func decodeFrames(d *decode.D, version int, size uint64) {
 	}
 }
 
+func decodeExtendedHeaderV3(d *decode.D) uint64 {
+	// size excludes itself
+	size := d.FieldU32("size")
+	d.LenFn(int64(size)*8, func(d *decode.D) {
+		var crcPresent bool
+		d.FieldStruct("flags", func(d *decode.D) {
+			crcPresent = d.FieldBool("crc_data_present")
+			d.FieldU15("unused")
+		})
+		d.FieldU32("padding_size")
+		if crcPresent {
+			d.FieldU32("crc", scalar.Hex)
+		}
+		if d.NotEnd() {
+			d.FieldRawLen("unknown", d.BitsLeft())
+		}
+	})
+	return size + 4
+}
+
+func decodeExtendedHeaderV4(d *decode.D) uint64 {
+	// size includes itself
+	size := d.FieldUFn("size", decodeSyncSafeU32)
+	if size < 6 {
+		d.Fatalf("extended header size %d too small", size)
+	}
+	d.LenFn(int64(size-4)*8, func(d *decode.D) {
+		d.FieldU8("number_of_flag_bytes", d.AssertU(1))
+		var update, crcPresent, restrictions bool
+		d.FieldStruct("flags", func(d *decode.D) {
+			// %0bcd0000
+			d.FieldU1("unused0")
+			update = d.FieldBool("tag_is_update")
+			crcPresent = d.FieldBool("crc_data_present")
+			restrictions = d.FieldBool("tag_restrictions")
+			d.FieldU4("unused1")
+		})
+		// flag data is in flag order and each is prefixed with a length byte
+		if update {
+			d.FieldStruct("tag_is_update", func(d *decode.D) {
+				d.FieldU8("length", d.AssertU(0))
+			})
+		}
+		if crcPresent {
+			d.FieldStruct("crc_data", func(d *decode.D) {
+				d.FieldU8("length", d.AssertU(5))
+				// 35 bit synchsafe integer
+				d.FieldUFn("crc", func(d *decode.D) uint64 {
+					return d.U8()<<28 | decodeSyncSafeU32(d)
+				}, scalar.Hex)
+			})
+		}
+		if restrictions {
+			d.FieldStruct("tag_restrictions", func(d *decode.D) {
+				d.FieldU8("length", d.AssertU(1))
+				// %ppqrrstt
+				d.FieldU2("tag_size", scalar.UToSymStr{
+					0: "max_128_frames_1mb",
+					1: "max_64_frames_128kb",
+					2: "max_32_frames_40kb",
+					3: "max_32_frames_4kb",
+				})
+				d.FieldU1("text_encoding", scalar.UToSymStr{0: "no_restrictions", 1: "iso_8859_1_or_utf8"})
+				d.FieldU2("text_fields_size", scalar.UToSymStr{
+					0: "no_restrictions",
+					1: "max_1024_chars",
+					2: "max_128_chars",
+					3: "max_30_chars",
+				})
+				d.FieldU1("image_encoding", scalar.UToSymStr{0: "no_restrictions", 1: "png_or_jpeg"})
+				d.FieldU2("image_size", scalar.UToSymStr{
+					0: "no_restrictions",
+					1: "max_256x256",
+					2: "max_64x64",
+					3: "exactly_64x64",
+				})
+			})
+		}
+		if d.NotEnd() {
+			d.FieldRawLen("unknown", d.BitsLeft())
+		}
+	})
+	return size
+}
+
+// decode optional extended header, frames and padding, size is tag size excluding header
+func decodeBody(d *decode.D, version int, extendedHeader bool, size uint64) {
+	if extendedHeader {
+		var extHeaderSize uint64
+		d.FieldStruct("extended_header", func(d *decode.D) {
+			switch version {
+			case 3:
+				extHeaderSize = decodeExtendedHeaderV3(d)
+			case 4:
+				extHeaderSize = decodeExtendedHeaderV4(d)
+			}
+		})
+		if extHeaderSize > size {
+			d.Fatalf("extended header size %d larger than tag size %d", extHeaderSize, size)
+		}
+		size -= extHeaderSize
+	}
+
+	decodeFrames(d, version, size)
+}
+
 func id3v2Decode(d *decode.D, in interface{}) interface{} {
 	d.AssertAtLeastBitsLeft(4 * 8)
 	d.FieldUTF8("magic", 3, d.ValidateStr("ID3"))
@@ -585,31 +691,27 @@ func id3v2Decode(d *decode.D, in interface{}) interface{} {
 	}
 
 	d.FieldU8("revision")
+	var unsync bool
 	var extendedHeader bool
 	d.FieldStruct("flags", func(d *decode.D) {
-		d.FieldBool("unsynchronisation")
+		unsync = d.FieldBool("unsynchronisation")
 		extendedHeader = d.FieldBool("extended_header")
 		d.FieldBool("experimental_indicator")
 		d.FieldU5("unused")
 	})
 	size := d.FieldUFn("size", decodeSyncSafeU32)
 
-	var extHeaderSize uint64
-	if extendedHeader {
-		d.FieldStruct("extended_header", func(d *decode.D) {
-			switch version {
-			case 3:
-				extHeaderSize = d.FieldU32("size")
-				d.FieldRawLen("data", int64(extHeaderSize)*8)
-			case 4:
-				extHeaderSize = d.FieldUFn("size", decodeSyncSafeU32)
-				// in v4 synchsafe integer includes itself
-				d.FieldRawLen("data", (int64(extHeaderSize)-4)*8)
-			}
+	if unsync && version < 4 {
+		// in version 2 and 3 the whole tag after the header is unsynchronised and
+		// sizes are for the resynchronised data
+		unsyncedBB := d.MustNewBitBufFromReader(&unsyncReader{Reader: d.BitBufRange(d.Pos(), int64(size)*8)})
+		d.FieldStructRootBitBufFn("unsync", unsyncedBB, func(d *decode.D) {
+			decodeBody(d, version, extendedHeader, uint64(d.BitsLeft()/8))
 		})
+		d.FieldRawLen("data", int64(size)*8)
+	} else {
+		decodeBody(d, version, extendedHeader, size)
 	}
 
-	decodeFrames(d, version, size)
-
 	return nil
 }
@@ -0,0 +1,20 @@
+package iff
+
+// Shared chunk options for big endian EA IFF 85 based formats
+// https://wiki.amigaos.net/wiki/EA_IFF_85_Standard_for_Interchange_Format_Files
+
+import (
+	"github.com/wader/fq/pkg/decode"
+)
+
+func chunkOpts(chunks map[string]decode.ChunkFn) decode.ChunkOpts {
+	return decode.ChunkOpts{
+		Endian: decode.BigEndian,
+		Align:  2,
+		Chunks: chunks,
+	}
+}
+
+func decodeTextChunk(d *decode.D, opts decode.ChunkOpts) {
+	d.FieldUTF8NullFixedLen("text", int(d.BitsLeft()/8))
+}
@@ -0,0 +1,106 @@
+package iff
+
+// https://wiki.amigaos.net/wiki/ILBM_IFF_Interleaved_Bitmap
+// TODO: decompress ByteRun1 BODY
+// TODO: CRNG, DEST, SPRT etc chunks
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.ILBM,
+		Description: "Amiga IFF Interleaved Bitmap image",
+		Groups:      []string{format.PROBE, format.IMAGE},
+		DecodeFn:    ilbmDecode,
+	})
+}
+
+var maskingNames = scalar.UToSymStr{
+	0: "none",
+	1: "has_mask",
+	2: "has_transparent_color",
+	3: "lasso",
+}
+
+var compressionNames = scalar.UToSymStr{
+	0: "none",
+	1: "byterun1",
+}
+
+var ilbmChunks = map[string]decode.ChunkFn{
+	"BMHD": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldU16("width")
+		d.FieldU16("height")
+		d.FieldS16("x")
+		d.FieldS16("y")
+		d.FieldU8("planes")
+		d.FieldU8("masking", maskingNames)
+		d.FieldU8("compression", compressionNames)
+		d.FieldU8("pad1")
+		d.FieldU16("transparent_color")
+		d.FieldU8("x_aspect")
+		d.FieldU8("y_aspect")
+		d.FieldS16("page_width")
+		d.FieldS16("page_height")
+	},
+	"CMAP": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldArray("colors", func(d *decode.D) {
+			for d.BitsLeft() >= 3*8 {
+				d.FieldStruct("color", func(d *decode.D) {
+					d.FieldU8("r")
+					d.FieldU8("g")
+					d.FieldU8("b")
+				})
+			}
+		})
+		if d.NotEnd() {
+			d.FieldRawLen("unknown", d.BitsLeft())
+		}
+	},
+	"CAMG": func(d *decode.D, opts decode.ChunkOpts) {
+		// amiga graphics/view.h viewport modes, upper 16 bits is monitor id
+		d.FieldStruct("display_mode", func(d *decode.D) {
+			d.FieldU16("monitor_id", scalar.Hex)
+			d.FieldBool("hires")
+			d.FieldBool("sprites")
+			d.FieldBool("vp_hide")
+			d.FieldBool("extended_mode")
+			d.FieldBool("ham")
+			d.FieldBool("dualpf")
+			d.FieldBool("unused0")
+			d.FieldBool("genlock_audio")
+			d.FieldBool("extra_halfbrite")
+			d.FieldBool("pfba")
+			d.FieldBool("superhires")
+			d.FieldBool("unused1")
+			d.FieldBool("doublescan")
+			d.FieldBool("lace")
+			d.FieldBool("genlock_video")
+			d.FieldBool("unused2")
+		})
+	},
+	"BODY": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldRawLen("data", d.BitsLeft())
+	},
+	"ANNO": decodeTextChunk,
+	"AUTH": decodeTextChunk,
+	"NAME": decodeTextChunk,
+	"(c) ": decodeTextChunk,
+}
+
+func ilbmDecode(d *decode.D, in interface{}) interface{} {
+	d.FieldChunk(chunkOpts(map[string]decode.ChunkFn{
+		"FORM": func(d *decode.D, opts decode.ChunkOpts) {
+			// PBM is the chunky (non-planar) variant used by Deluxe Paint
+			d.FieldUTF8("format", 4, d.AssertStr("ILBM", "PBM "))
+			d.FieldChunks("chunks", chunkOpts(ilbmChunks))
+		},
+	}), "FORM")
+
+	return nil
+}
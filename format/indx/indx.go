@@ -0,0 +1,160 @@
+package indx
+
+// https://github.com/libyal/libfsntfs/blob/main/documentation/New%20Technologies%20File%20System%20(NTFS).asciidoc#index_record
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.INDX,
+		Description: "NTFS index record",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    indxDecode,
+	})
+}
+
+const sectorSize = 512
+
+// offset of index node header
+const nodeHeaderOffset = 24
+
+const (
+	entryFlagHasSubNode = 0x1
+	entryFlagLast       = 0x2
+)
+
+var namespaceNames = scalar.UToSymStr{
+	0: "posix",
+	1: "win32",
+	2: "dos",
+	3: "win32_and_dos",
+}
+
+func fieldFileReference(d *decode.D, name string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		d.FieldU48("mft_entry")
+		d.FieldU16("sequence_number")
+	})
+}
+
+func decodeFileName(d *decode.D) {
+	fieldFileReference(d, "parent_file_reference")
+	d.FieldU64("creation_time", format.WindowsFileTime)
+	d.FieldU64("modification_time", format.WindowsFileTime)
+	d.FieldU64("entry_modification_time", format.WindowsFileTime)
+	d.FieldU64("access_time", format.WindowsFileTime)
+	d.FieldU64("allocated_size")
+	d.FieldU64("size")
+	d.FieldU32("file_attributes", scalar.Hex)
+	d.FieldU32("extended_data", scalar.Hex)
+	nameLength := d.FieldU8("name_length")
+	d.FieldU8("namespace", namespaceNames)
+	d.FieldUTF16LE("name", int(nameLength)*2)
+}
+
+func decodeEntry(d *decode.D) uint64 {
+	var flags uint64
+	d.FieldStruct("entry", func(d *decode.D) {
+		entryStart := d.Pos()
+		fieldFileReference(d, "file_reference")
+		entryLength := d.FieldU16("length")
+		keyLength := d.FieldU16("key_length")
+		d.FieldStruct("flags", func(d *decode.D) {
+			flags = d.U32()
+			d.SeekRel(-32)
+			d.FieldU6("unused0")
+			d.FieldBool("last_entry")
+			d.FieldBool("has_sub_node")
+			d.FieldU24("unused1")
+		})
+		if entryLength < 16 {
+			d.Fatalf("invalid entry length %d", entryLength)
+		}
+		if keyLength > 0 {
+			d.FieldStruct("file_name", func(d *decode.D) {
+				d.LenFn(int64(keyLength)*8, decodeFileName)
+			})
+		}
+
+		entryEnd := entryStart + int64(entryLength)*8
+		subNodeStart := entryEnd
+		if flags&entryFlagHasSubNode != 0 {
+			subNodeStart -= 64
+		}
+		if paddingLen := subNodeStart - d.Pos(); paddingLen > 0 {
+			d.FieldRawLen("padding", paddingLen)
+		}
+		if flags&entryFlagHasSubNode != 0 {
+			d.SeekAbs(subNodeStart)
+			d.FieldU64("sub_node_vcn")
+		}
+	})
+	return flags
+}
+
+func indxDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldRawMagic("signature", []byte("INDX"))
+	fixupOffset := d.FieldU16("fixup_offset")
+	fixupCount := d.FieldU16("fixup_count")
+	d.FieldU64("log_sequence_number")
+	d.FieldU64("vcn")
+	var entriesOffset, indexSize, allocatedSize uint64
+	d.FieldStruct("node_header", func(d *decode.D) {
+		entriesOffset = d.FieldU32("entries_offset")
+		indexSize = d.FieldU32("index_size")
+		allocatedSize = d.FieldU32("allocated_size")
+		d.FieldU32("flags", scalar.UToSymStr{0: "leaf", 1: "has_sub_nodes"})
+	})
+
+	recordSize := int64(nodeHeaderOffset + allocatedSize)
+	if fixupCount == 0 || int64(fixupCount-1)*sectorSize != recordSize {
+		d.Fatalf("fixup count %d does not match record size %d", fixupCount, recordSize)
+	}
+	if recordSize*8 > d.Len() {
+		d.Fatalf("record size %d larger than input", recordSize)
+	}
+
+	// last two bytes of each sector are replaced by the update sequence number and the original
+	// values are stored in the fixup array
+	record := d.BytesRange(0, int(recordSize))
+	d.SeekAbs(int64(fixupOffset) * 8)
+	d.FieldStruct("fixup", func(d *decode.D) {
+		usn := d.FieldU16("update_sequence_number", scalar.Hex)
+		d.FieldArray("values", func(d *decode.D) {
+			for i := 0; i < int(fixupCount)-1; i++ {
+				sectorEnd := (i+1)*sectorSize - 2
+				trailer := uint64(record[sectorEnd]) | uint64(record[sectorEnd+1])<<8
+				v := d.FieldU16("value", scalar.Hex)
+				if trailer != usn {
+					d.Errorf("sector %d trailer %x does not match update sequence number %x", i, trailer, usn)
+				}
+				record[sectorEnd] = byte(v)
+				record[sectorEnd+1] = byte(v >> 8)
+			}
+		})
+	})
+
+	entriesStart := int64(nodeHeaderOffset+entriesOffset) * 8
+	entriesEnd := int64(nodeHeaderOffset+indexSize) * 8
+	if entriesStart > entriesEnd || entriesEnd > recordSize*8 {
+		d.Fatalf("invalid entries range %d-%d", entriesStart/8, entriesEnd/8)
+	}
+	d.FieldArrayRootBitBufFn("entries", bitio.NewBufferFromBytes(record, entriesEnd), func(d *decode.D) {
+		d.SeekAbs(entriesStart)
+		for d.NotEnd() {
+			if decodeEntry(d)&entryFlagLast != 0 {
+				break
+			}
+		}
+	})
+
+	return nil
+}
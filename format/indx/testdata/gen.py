@@ -0,0 +1,52 @@
+# generates a 4096 byte NTFS INDX record with entries crossing sector boundaries
+import struct
+
+RECORD_SIZE = 4096
+SECTOR_SIZE = 512
+USN = 0x0042
+
+
+def file_name(parent, name, t):
+    n = name.encode("utf-16-le")
+    return struct.pack("<QQQQQQQIIBB", parent, t, t + 1, t + 2, t + 3, 4096, len(name) * 100, 0x20, 0, len(name), 1) + n
+
+
+def entry(ref, key, flags, sub_node_vcn=None):
+    length = 16 + len(key)
+    length = (length + 7) & ~7
+    if sub_node_vcn is not None:
+        length += 8
+    b = struct.pack("<QHHI", ref, length, len(key), flags) + key
+    b += b"\x00" * (length - len(b) - (8 if sub_node_vcn is not None else 0))
+    if sub_node_vcn is not None:
+        b += struct.pack("<Q", sub_node_vcn)
+    return b
+
+
+t = 132000000000000000
+parent = (5 << 48) | 5
+entries = b""
+for i, name in enumerate(["$AttrDef", "$BadClus", "$Bitmap", "$Boot", "$Extend", "$LogFile", "$MFT", "$MFTMirr",
+                          "$Secure", "$UpCase", "$Volume", "a somewhat longer file name.txt",
+                          "readme.txt", "Program Files", "Users", "Windows"]):
+    entries += entry(((i + 1) << 48) | (16 + i), file_name(parent, name, t + i * 10_000_000), 0)
+entries += entry(0, b"", 0x2 | 0x1, sub_node_vcn=3)
+
+fixup_offset = 40
+fixup_count = RECORD_SIZE // SECTOR_SIZE + 1
+entries_offset = 0x28  # relative to node header at 24
+header = b"INDX" + struct.pack("<HHQQ", fixup_offset, fixup_count, 0x1234, 0)
+node = struct.pack("<IIII", entries_offset, entries_offset + len(entries), RECORD_SIZE - 24, 0)
+record = bytearray(header + node)
+record += b"\x00" * (24 + entries_offset - len(record))
+record += entries
+record += b"\x00" * (RECORD_SIZE - len(record))
+
+values = []
+for i in range(RECORD_SIZE // SECTOR_SIZE):
+    o = (i + 1) * SECTOR_SIZE - 2
+    values.append(bytes(record[o:o + 2]))
+    record[o:o + 2] = struct.pack("<H", USN)
+record[fixup_offset:fixup_offset + 2 * fixup_count] = struct.pack("<H", USN) + b"".join(values)
+
+open("indx.bin", "wb").write(record)
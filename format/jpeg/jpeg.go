@@ -16,6 +16,7 @@ import (
 
 var exifFormat decode.Group
 var iccProfileFormat decode.Group
+var xmpFormat decode.Group
 
 func init() {
 	registry.MustRegister(decode.Format{
@@ -26,6 +27,7 @@ func init() {
 		Dependencies: []decode.Dependency{
 			{Names: []string{format.EXIF}, Group: &exifFormat},
 			{Names: []string{format.ICC_PROFILE}, Group: &iccProfileFormat},
+			{Names: []string{format.XMP}, Group: &xmpFormat},
 		},
 	})
 }
@@ -276,6 +278,7 @@ func jpegDecode(d *decode.D, in interface{}) interface{} {
 							// TODO: map lookup and descriptions?
 							app0JFIFPrefix := []byte("JFIF\x00")
 							app1ExifPrefix := []byte("Exif\x00\x00")
+							app1XMPPrefix := []byte("http://ns.adobe.com/xap/1.0/\x00")
 							extendedXMPPrefix := []byte("http://ns.adobe.com/xmp/extension/\x00")
 							app2ICCProfile := []byte("ICC_PROFILE\x00")
 							// TODO: other version? generic?
@@ -297,6 +300,9 @@ func jpegDecode(d *decode.D, in interface{}) interface{} {
 							case markerCode == APP1 && d.TryHasBytes(app1ExifPrefix):
 								d.FieldUTF8("exif_prefix", len(app1ExifPrefix))
 								d.FieldFormatLen("exif", d.BitsLeft(), exifFormat, nil)
+							case markerCode == APP1 && d.TryHasBytes(app1XMPPrefix):
+								d.FieldUTF8("xmp_prefix", len(app1XMPPrefix))
+								d.FieldFormatLen("xmp", d.BitsLeft(), xmpFormat, nil)
 							case markerCode == APP1 && d.TryHasBytes(extendedXMPPrefix):
 								d.FieldStruct("extended_xmp_chunk", func(d *decode.D) {
 									d.FieldUTF8("signature", len(extendedXMPPrefix))
@@ -348,7 +354,10 @@ func jpegDecode(d *decode.D, in interface{}) interface{} {
 	}
 
 	if extendedXMP != nil {
-		d.FieldRootBitBuf("extended_xmp", bitio.NewBufferFromBytes(extendedXMP, -1))
+		extendedXMPBB := bitio.NewBufferFromBytes(extendedXMP, -1)
+		if dv, _, _ := d.TryFieldFormatBitBuf("extended_xmp", extendedXMPBB, xmpFormat, nil); dv == nil {
+			d.FieldRootBitBuf("extended_xmp", extendedXMPBB)
+		}
 	}
 
 	return nil
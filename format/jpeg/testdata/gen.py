@@ -0,0 +1,9 @@
+#!/usr/bin/env python3
+# generates xmp.jpg, 4x4.jpg with an XMP APP1 segment inserted after SOI
+import struct
+
+xmp = open("../../xmp/testdata/packet.xmp", "rb").read()
+jpg = open("4x4.jpg", "rb").read()
+payload = b"http://ns.adobe.com/xap/1.0/\x00" + xmp
+app1 = b"\xff\xe1" + struct.pack(">H", len(payload) + 2) + payload
+open("xmp.jpg", "wb").write(jpg[:2] + app1 + jpg[2:])
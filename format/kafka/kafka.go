@@ -0,0 +1,132 @@
+package kafka
+
+// https://kafka.apache.org/documentation/#recordbatch
+// TODO: decompress records
+
+import (
+	"hash/crc32"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/internal/num"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.KAFKA,
+		Description: "Apache Kafka record batch",
+		DecodeFn:    kafkaDecode,
+	})
+}
+
+const compressionNone = 0
+
+var compressionNames = scalar.UToSymStr{
+	compressionNone: "none",
+	1:               "gzip",
+	2:               "snappy",
+	3:               "lz4",
+	4:               "zstd",
+}
+
+var timestampTypeNames = scalar.UToSymStr{
+	0: "create_time",
+	1: "log_append_time",
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// fieldVarint adds a zigzag encoded varint
+func fieldVarint(d *decode.D, name string, sms ...scalar.Mapper) int64 {
+	return d.FieldSFn(name, func(d *decode.D) int64 {
+		return num.ZigZag(d.ULEB128())
+	}, sms...)
+}
+
+// checkLength fails if length in bytes is negative or larger than rest of input
+func checkLength(d *decode.D, name string, l int64) {
+	if l < 0 {
+		d.Fatalf("%s %d is negative", name, l)
+	}
+	if l > d.BitsLeft()/8 {
+		d.Fatalf("%s %d larger than rest of input", name, l)
+	}
+}
+
+// fieldVarintBytes adds varint length and bytes, negative length is null
+func fieldVarintBytes(d *decode.D, lengthName string, name string) {
+	l := fieldVarint(d, lengthName)
+	if l > 0 {
+		checkLength(d, lengthName, l)
+		d.FieldRawLen(name, l*8)
+	}
+}
+
+func decodeRecord(d *decode.D) {
+	length := fieldVarint(d, "length")
+	checkLength(d, "length", length)
+	d.LenFn(length*8, func(d *decode.D) {
+		d.FieldU8("attributes")
+		fieldVarint(d, "timestamp_delta")
+		fieldVarint(d, "offset_delta")
+		fieldVarintBytes(d, "key_length", "key")
+		fieldVarintBytes(d, "value_length", "value")
+		headersCount := fieldVarint(d, "headers_count")
+		d.FieldArray("headers", func(d *decode.D) {
+			for i := int64(0); i < headersCount; i++ {
+				d.FieldStruct("header", func(d *decode.D) {
+					// header key can't be null
+					keyLength := fieldVarint(d, "key_length")
+					checkLength(d, "key_length", keyLength)
+					d.FieldUTF8("key", int(keyLength))
+					fieldVarintBytes(d, "value_length", "value")
+				})
+			}
+		})
+	})
+}
+
+func kafkaDecode(d *decode.D, in interface{}) interface{} {
+	d.FieldS64("base_offset")
+	batchLength := d.FieldS32("batch_length")
+	checkLength(d, "batch_length", batchLength)
+	d.LenFn(batchLength*8, func(d *decode.D) {
+		d.FieldS32("partition_leader_epoch")
+		d.FieldU8("magic", d.AssertU(2))
+
+		crc32C := crc32.New(crc32cTable)
+		d.MustCopy(crc32C, d.BitBufRange(d.Pos()+32, d.BitsLeft()-32))
+		d.FieldU32("crc", d.ValidateUBytes(crc32C.Sum(nil)), scalar.Hex)
+
+		var compression uint64
+		d.FieldStruct("attributes", func(d *decode.D) {
+			d.FieldU9("unused")
+			d.FieldBool("has_delete_horizon")
+			d.FieldBool("control")
+			d.FieldBool("transactional")
+			d.FieldU1("timestamp_type", timestampTypeNames)
+			compression = d.FieldU3("compression", compressionNames)
+		})
+		d.FieldS32("last_offset_delta")
+		d.FieldS64("first_timestamp")
+		d.FieldS64("max_timestamp")
+		d.FieldS64("producer_id")
+		d.FieldS16("producer_epoch")
+		d.FieldS32("base_sequence")
+		recordCount := d.FieldS32("record_count")
+
+		if compression != compressionNone {
+			d.FieldRawLen("compressed", d.BitsLeft())
+			return
+		}
+		d.FieldArray("records", func(d *decode.D) {
+			for i := int64(0); i < recordCount; i++ {
+				d.FieldStruct("record", decodeRecord)
+			}
+		})
+	})
+
+	return nil
+}
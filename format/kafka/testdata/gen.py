@@ -0,0 +1,60 @@
+# generates an uncompressed kafka record batch with two records and
+# batches with malformed lengths
+import struct
+
+# crc32c, python has no builtin
+def crc32c(b):
+    crc = 0xffffffff
+    for c in b:
+        crc ^= c
+        for _ in range(8):
+            crc = (crc >> 1) ^ (0x82f63b78 if crc & 1 else 0)
+    return crc ^ 0xffffffff
+
+
+def varint(n):
+    z = (n << 1) ^ (n >> 63)
+    z &= 0xffffffffffffffff
+    b = b""
+    while True:
+        if z < 0x80:
+            return b + bytes([z])
+        b += bytes([z & 0x7f | 0x80])
+        z >>= 7
+
+
+def varbytes(v):
+    if v is None:
+        return varint(-1)
+    return varint(len(v)) + v
+
+
+def record(ts_delta, offset_delta, key, value, headers, length_delta=0, header_key_length=None):
+    r = b"\x00" + varint(ts_delta) + varint(offset_delta) + varbytes(key) + varbytes(value)
+    r += varint(len(headers))
+    for k, v in headers:
+        if header_key_length is not None:
+            r += varint(header_key_length) + k + varbytes(v)
+        else:
+            r += varbytes(k) + varbytes(v)
+    return varint(len(r) + length_delta) + r
+
+
+def batch(records, length_delta=0):
+    first_ts = 1650000000000
+    # attributes: no compression, create time
+    after_crc = struct.pack(">hiqqqhii", 0, 1, first_ts, first_ts + 150, 1234, 0, 0, len(records)) + b"".join(records)
+    after_length = struct.pack(">ib", 0, 2) + struct.pack(">I", crc32c(after_crc)) + after_crc
+    return struct.pack(">qi", 42, len(after_length) + length_delta) + after_length
+
+
+open("batch.bin", "wb").write(batch([
+    record(0, 0, b"key1", b"hello", [(b"trace", b"abc")]),
+    record(150, 1, None, b"world", []),
+]))
+open("negative_batch_length.bin", "wb").write(batch([record(0, 0, None, b"a", [])], length_delta=-1000))
+open("long_batch_length.bin", "wb").write(batch([record(0, 0, None, b"a", [])], length_delta=1))
+open("negative_record_length.bin", "wb").write(batch([record(0, 0, None, b"a", [], length_delta=-100)]))
+open("long_record_length.bin", "wb").write(batch([record(0, 0, None, b"a", [], length_delta=1)]))
+open("negative_header_key_length.bin", "wb").write(batch([record(0, 0, None, b"a", [(b"k", b"v")], header_key_length=-1000)]))
+open("long_header_key_length.bin", "wb").write(batch([record(0, 0, None, b"a", [(b"k", b"v")], header_key_length=100)]))
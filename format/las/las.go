@@ -0,0 +1,298 @@
+package las
+
+// https://www.asprs.org/wp-content/uploads/2019/07/LAS_1_4_r15.pdf
+// TODO: LAZ compressed points
+// TODO: GeoKeyDirectory and other well known VLRs
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.LAS,
+		Description: "ASPRS LiDAR point cloud",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    lasDecode,
+	})
+}
+
+// bit 7 in point data record format is set for LAZ compressed point data
+const lazCompressedFlag = 0x80
+
+type pointFormat struct {
+	gpsTime    bool
+	rgb        bool
+	nir        bool
+	wavePacket bool
+	extended   bool
+	length     int
+}
+
+var pointFormats = map[uint64]pointFormat{
+	0:  {length: 20},
+	1:  {gpsTime: true, length: 28},
+	2:  {rgb: true, length: 26},
+	3:  {gpsTime: true, rgb: true, length: 34},
+	4:  {gpsTime: true, wavePacket: true, length: 57},
+	5:  {gpsTime: true, rgb: true, wavePacket: true, length: 63},
+	6:  {extended: true, gpsTime: true, length: 30},
+	7:  {extended: true, gpsTime: true, rgb: true, length: 36},
+	8:  {extended: true, gpsTime: true, rgb: true, nir: true, length: 38},
+	9:  {extended: true, gpsTime: true, wavePacket: true, length: 59},
+	10: {extended: true, gpsTime: true, rgb: true, nir: true, wavePacket: true, length: 67},
+}
+
+var classificationNames = scalar.UToSymStr{
+	0:  "created_never_classified",
+	1:  "unclassified",
+	2:  "ground",
+	3:  "low_vegetation",
+	4:  "medium_vegetation",
+	5:  "high_vegetation",
+	6:  "building",
+	7:  "low_point",
+	8:  "model_key_point",
+	9:  "water",
+	10: "rail",
+	11: "road_surface",
+	12: "overlap",
+	13: "wire_guard",
+	14: "wire_conductor",
+	15: "transmission_tower",
+	16: "wire_structure_connector",
+	17: "bridge_deck",
+	18: "high_noise",
+}
+
+type header struct {
+	versionMinor      uint64
+	offsetToPointData uint64
+	numberOfVLRs      uint64
+	pointFormat       uint64
+	pointRecordLength uint64
+	numberOfPoints    uint64
+	startOfFirstEVLR  uint64
+	numberOfEVLRs     uint64
+}
+
+func decodeHeader(d *decode.D) header {
+	var h header
+
+	d.FieldRawMagic("file_signature", []byte("LASF"))
+	d.FieldU16("file_source_id")
+	d.FieldStruct("global_encoding", func(d *decode.D) {
+		// little endian 16 bit, all defined bits are in first byte
+		d.FieldU3("reserved0")
+		d.FieldBool("wkt")
+		d.FieldBool("synthetic_return_numbers")
+		d.FieldBool("waveform_data_packets_external")
+		d.FieldBool("waveform_data_packets_internal")
+		d.FieldBool("gps_time_type", scalar.BoolToSymStr{true: "adjusted_standard", false: "week"})
+		d.FieldU8("reserved1")
+	})
+	d.FieldRawLen("project_id", 16*8, scalar.RawHex)
+	d.FieldU8("version_major", d.AssertU(1))
+	h.versionMinor = d.FieldU8("version_minor")
+	d.FieldUTF8NullFixedLen("system_identifier", 32)
+	d.FieldUTF8NullFixedLen("generating_software", 32)
+	d.FieldU16("file_creation_day_of_year")
+	d.FieldU16("file_creation_year")
+	d.FieldU16("header_size")
+	h.offsetToPointData = d.FieldU32("offset_to_point_data")
+	h.numberOfVLRs = d.FieldU32("number_of_variable_length_records")
+	h.pointFormat = d.FieldU8("point_data_record_format", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		if s.ActualU()&lazCompressedFlag != 0 {
+			s.Description = "laz compressed"
+		}
+		return s, nil
+	}))
+	h.pointRecordLength = d.FieldU16("point_data_record_length")
+	h.numberOfPoints = d.FieldU32("legacy_number_of_point_records")
+	d.FieldArray("legacy_number_of_points_by_return", func(d *decode.D) {
+		for i := 0; i < 5; i++ {
+			d.FieldU32("points")
+		}
+	})
+	d.FieldF64("x_scale_factor")
+	d.FieldF64("y_scale_factor")
+	d.FieldF64("z_scale_factor")
+	d.FieldF64("x_offset")
+	d.FieldF64("y_offset")
+	d.FieldF64("z_offset")
+	d.FieldF64("max_x")
+	d.FieldF64("min_x")
+	d.FieldF64("max_y")
+	d.FieldF64("min_y")
+	d.FieldF64("max_z")
+	d.FieldF64("min_z")
+
+	if h.versionMinor >= 3 && d.NotEnd() {
+		d.FieldU64("start_of_waveform_data_packet_record")
+	}
+	if h.versionMinor >= 4 && d.NotEnd() {
+		h.startOfFirstEVLR = d.FieldU64("start_of_first_extended_variable_length_record")
+		h.numberOfEVLRs = d.FieldU32("number_of_extended_variable_length_records")
+		numberOfPoints := d.FieldU64("number_of_point_records")
+		if numberOfPoints != 0 {
+			h.numberOfPoints = numberOfPoints
+		}
+		d.FieldArray("number_of_points_by_return", func(d *decode.D) {
+			for i := 0; i < 15; i++ {
+				d.FieldU64("points")
+			}
+		})
+	}
+	if d.NotEnd() {
+		d.FieldRawLen("unknown", d.BitsLeft())
+	}
+
+	return h
+}
+
+func decodeVLR(d *decode.D, extended bool) {
+	d.FieldU16("reserved")
+	d.FieldUTF8NullFixedLen("user_id", 16)
+	d.FieldU16("record_id")
+	var length uint64
+	if extended {
+		length = d.FieldU64("record_length_after_header")
+	} else {
+		length = d.FieldU16("record_length_after_header")
+	}
+	d.FieldUTF8NullFixedLen("description", 32)
+	d.FieldRawLen("data", int64(length)*8)
+}
+
+func decodePoint(d *decode.D, pf pointFormat) {
+	d.FieldS32("x")
+	d.FieldS32("y")
+	d.FieldS32("z")
+	d.FieldU16("intensity")
+	if pf.extended {
+		d.FieldU4("number_of_returns")
+		d.FieldU4("return_number")
+		d.FieldBool("edge_of_flight_line")
+		d.FieldBool("scan_direction")
+		d.FieldU2("scanner_channel")
+		d.FieldBool("overlap")
+		d.FieldBool("withheld")
+		d.FieldBool("key_point")
+		d.FieldBool("synthetic")
+		d.FieldU8("classification", classificationNames)
+		d.FieldU8("user_data")
+		d.FieldS16("scan_angle", scalar.Description("0.006 degree increments"))
+		d.FieldU16("point_source_id")
+	} else {
+		d.FieldBool("edge_of_flight_line")
+		d.FieldBool("scan_direction")
+		d.FieldU3("number_of_returns")
+		d.FieldU3("return_number")
+		d.FieldStruct("classification", func(d *decode.D) {
+			d.FieldBool("withheld")
+			d.FieldBool("key_point")
+			d.FieldBool("synthetic")
+			d.FieldU5("class", classificationNames)
+		})
+		d.FieldS8("scan_angle_rank")
+		d.FieldU8("user_data")
+		d.FieldU16("point_source_id")
+	}
+	if pf.gpsTime {
+		d.FieldF64("gps_time")
+	}
+	if pf.rgb {
+		d.FieldU16("red")
+		d.FieldU16("green")
+		d.FieldU16("blue")
+	}
+	if pf.nir {
+		d.FieldU16("nir")
+	}
+	if pf.wavePacket {
+		d.FieldU8("wave_packet_descriptor_index")
+		d.FieldU64("byte_offset_to_waveform_data")
+		d.FieldU32("waveform_packet_size")
+		d.FieldF32("return_point_waveform_location")
+		d.FieldF32("x_t")
+		d.FieldF32("y_t")
+		d.FieldF32("z_t")
+	}
+	if d.NotEnd() {
+		d.FieldRawLen("extra_bytes", d.BitsLeft())
+	}
+}
+
+func lasDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	// header size is at a fixed offset, use it to limit header to handle unknown header extensions
+	d.SeekAbs(94 * 8)
+	headerSize := d.U16()
+	d.SeekAbs(0)
+
+	var h header
+	d.LenFn(int64(headerSize)*8, func(d *decode.D) {
+		d.FieldStruct("header", func(d *decode.D) { h = decodeHeader(d) })
+	})
+
+	d.FieldArray("variable_length_records", func(d *decode.D) {
+		for i := uint64(0); i < h.numberOfVLRs; i++ {
+			d.FieldStruct("variable_length_record", func(d *decode.D) { decodeVLR(d, false) })
+		}
+	})
+
+	pointDataStart := int64(h.offsetToPointData) * 8
+	if pointDataStart < d.Pos() {
+		d.Fatalf("offset_to_point_data %d overlaps header or records", h.offsetToPointData)
+	}
+	if pointDataStart > d.Pos() {
+		d.FieldRawLen("user_defined_bytes", pointDataStart-d.Pos())
+	}
+
+	pointDataLen := int64(h.numberOfPoints*h.pointRecordLength) * 8
+	if h.startOfFirstEVLR != 0 {
+		// waveform data and extended records can follow point data
+		pointDataLen = int64(h.startOfFirstEVLR)*8 - pointDataStart
+	}
+	if pointDataLen > d.BitsLeft() {
+		pointDataLen = d.BitsLeft()
+	}
+
+	pf, pfOk := pointFormats[h.pointFormat]
+	switch {
+	case h.pointFormat&lazCompressedFlag != 0:
+		d.FieldRawLen("compressed_points", pointDataLen)
+	case !pfOk || int(h.pointRecordLength) < pf.length:
+		d.FieldRawLen("points", pointDataLen)
+	default:
+		d.LenFn(pointDataLen, func(d *decode.D) {
+			d.FieldArray("points", func(d *decode.D) {
+				for i := uint64(0); i < h.numberOfPoints; i++ {
+					d.FieldStruct("point", func(d *decode.D) {
+						d.LenFn(int64(h.pointRecordLength)*8, func(d *decode.D) { decodePoint(d, pf) })
+					})
+				}
+			})
+			if d.NotEnd() {
+				d.FieldRawLen("unknown", d.BitsLeft())
+			}
+		})
+	}
+
+	if h.numberOfEVLRs > 0 && int64(h.startOfFirstEVLR)*8 >= d.Pos() {
+		if start := int64(h.startOfFirstEVLR) * 8; start > d.Pos() {
+			d.FieldRawLen("waveform_data", start-d.Pos())
+		}
+		d.FieldArray("extended_variable_length_records", func(d *decode.D) {
+			for i := uint64(0); i < h.numberOfEVLRs; i++ {
+				d.FieldStruct("extended_variable_length_record", func(d *decode.D) { decodeVLR(d, true) })
+			}
+		})
+	}
+
+	return nil
+}
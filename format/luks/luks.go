@@ -0,0 +1,113 @@
+package luks
+
+// https://gitlab.com/cryptsetup/cryptsetup/-/wikis/LUKS-standard/on-disk-format.pdf
+// https://gitlab.com/cryptsetup/LUKS2-docs/-/blob/master/luks2_doc_wip.pdf
+
+import (
+	"bytes"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var jsonFormat decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.LUKS,
+		Description: "Linux Unified Key Setup header",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    luksDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.JSON}, Group: &jsonFormat},
+		},
+	})
+}
+
+var primaryMagic = []byte("LUKS\xba\xbe")
+var secondaryMagic = []byte("SKUL\xba\xbe")
+
+const (
+	luks1KeySlots = 8
+	// LUKS2 binary header size, followed by JSON area
+	luks2BinaryHeaderSize = 4096
+)
+
+var keySlotActiveNames = scalar.UToSymStr{
+	0x00ac71f3: "enabled",
+	0x0000dead: "disabled",
+}
+
+func decodeLUKS1(d *decode.D) {
+	d.FieldUTF8NullFixedLen("cipher_name", 32)
+	d.FieldUTF8NullFixedLen("cipher_mode", 32)
+	d.FieldUTF8NullFixedLen("hash_spec", 32)
+	d.FieldU32("payload_offset")
+	d.FieldU32("key_bytes")
+	d.FieldRawLen("mk_digest", 20*8, scalar.RawHex)
+	d.FieldRawLen("mk_digest_salt", 32*8, scalar.RawHex)
+	d.FieldU32("mk_digest_iterations")
+	d.FieldUTF8NullFixedLen("uuid", 40)
+	d.FieldArray("key_slots", func(d *decode.D) {
+		for i := 0; i < luks1KeySlots; i++ {
+			d.FieldStruct("key_slot", func(d *decode.D) {
+				d.FieldU32("active", keySlotActiveNames, scalar.Hex)
+				d.FieldU32("iterations")
+				d.FieldRawLen("salt", 32*8, scalar.RawHex)
+				d.FieldU32("key_material_offset")
+				d.FieldU32("stripes")
+			})
+		}
+	})
+}
+
+// decodeLUKS2 decodes binary header after magic and version and the JSON area
+func decodeLUKS2(d *decode.D, headerStart int64) {
+	headerSize := d.FieldU64("header_size")
+	d.FieldU64("sequence_id")
+	d.FieldUTF8NullFixedLen("label", 48)
+	d.FieldUTF8NullFixedLen("checksum_algorithm", 32)
+	d.FieldRawLen("salt", 64*8, scalar.RawHex)
+	d.FieldUTF8NullFixedLen("uuid", 40)
+	d.FieldUTF8NullFixedLen("subsystem", 48)
+	d.FieldU64("header_offset")
+	d.FieldRawLen("padding0", 184*8)
+	d.FieldRawLen("checksum", 64*8, scalar.RawHex)
+	d.FieldRawLen("padding1", 7*512*8)
+
+	if headerSize < luks2BinaryHeaderSize {
+		d.Fatalf("invalid header size %d", headerSize)
+	}
+	jsonAreaStart := headerStart + luks2BinaryHeaderSize*8
+	jsonAreaLen := int64(headerSize-luks2BinaryHeaderSize) * 8
+	// JSON metadata is null padded to end of area
+	d.FieldFormatRange("json_area", jsonAreaStart, jsonAreaLen, jsonFormat, nil)
+	d.SeekAbs(jsonAreaStart + jsonAreaLen)
+}
+
+func luksDecode(d *decode.D, in interface{}) interface{} {
+	d.FieldRawMagic("magic", primaryMagic)
+	version := d.FieldU16("version")
+
+	switch version {
+	case 1:
+		decodeLUKS1(d)
+	case 2:
+		decodeLUKS2(d, 0)
+		// secondary header follows primary header and has reversed magic
+		if d.BitsLeft() >= luks2BinaryHeaderSize*8 && bytes.Equal(d.PeekBytes(len(secondaryMagic)), secondaryMagic) {
+			d.FieldStruct("secondary_header", func(d *decode.D) {
+				headerStart := d.Pos()
+				d.FieldRawMagic("magic", secondaryMagic)
+				d.FieldU16("version", d.AssertU(2))
+				decodeLUKS2(d, headerStart)
+			})
+		}
+	default:
+		d.Fatalf("unknown version %d", version)
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+# generates LUKS1 and LUKS2 headers without key material
+import hashlib
+import json
+import struct
+
+
+def pad(s, n):
+    b = s.encode("ascii")
+    return b + b"\x00" * (n - len(b))
+
+
+def luks1():
+    b = b"LUKS\xba\xbe" + struct.pack(">H", 1)
+    b += pad("aes", 32) + pad("xts-plain64", 32) + pad("sha256", 32)
+    b += struct.pack(">II", 4096, 64)
+    b += hashlib.sha1(b"mk").digest() + hashlib.sha256(b"salt").digest()
+    b += struct.pack(">I", 123456)
+    b += pad("c1b5a7e4-2c4e-4d1a-9a3e-2f6d3b8e1f00", 40)
+    for i in range(8):
+        if i < 2:
+            b += struct.pack(">II", 0x00ac71f3, 1000000 + i) + hashlib.sha256(b"slot%d" % i).digest() + struct.pack(">II", 8 + i * 512, 4000)
+        else:
+            b += struct.pack(">II", 0x0000dead, 0) + b"\x00" * 32 + struct.pack(">II", 8 + i * 512, 4000)
+    return b
+
+
+def luks2_header(magic, seqid, hdr_size, hdr_offset, json_area):
+    b = magic + struct.pack(">HQQ", 2, hdr_size, seqid)
+    b += pad("test", 48) + pad("sha256", 32)
+    b += hashlib.sha512(magic).digest()
+    b += pad("3e5b2f5e-8d43-4b8e-9a5b-6f0e2d1c7a11", 40)
+    b += pad("", 48)
+    b += struct.pack(">Q", hdr_offset)
+    b += b"\x00" * 184
+    csum_offset = len(b)
+    b += b"\x00" * 64
+    b += b"\x00" * (7 * 512)
+    assert len(b) == 4096
+    area = json_area + b"\x00" * (hdr_size - 4096 - len(json_area))
+    csum = hashlib.sha256(b + area).digest()
+    b = b[:csum_offset] + csum + b"\x00" * 32 + b[csum_offset + 64:]
+    return b + area
+
+
+metadata = {
+    "keyslots": {"0": {"type": "luks2", "key_size": 64, "af": {"type": "luks1", "stripes": 4000, "hash": "sha256"},
+                       "area": {"type": "raw", "offset": "32768", "size": "258048", "encryption": "aes-xts-plain64", "key_size": 64},
+                       "kdf": {"type": "argon2id", "time": 4, "memory": 1048576, "cpus": 4, "salt": "c2FsdA=="}}},
+    "tokens": {},
+    "segments": {"0": {"type": "crypt", "offset": "16777216", "size": "dynamic", "iv_tweak": "0", "encryption": "aes-xts-plain64", "sector_size": 512}},
+    "digests": {"0": {"type": "pbkdf2", "keyslots": ["0"], "segments": ["0"], "hash": "sha256", "iterations": 100000, "salt": "c2FsdA==", "digest": "ZGlnZXN0"}},
+    "config": {"json_size": "12288", "keyslots_size": "16744448"},
+}
+json_area = json.dumps(metadata, separators=(",", ":")).encode("ascii")
+hdr_size = 0x4000
+
+open("luks1", "wb").write(luks1())
+open("luks2", "wb").write(
+    luks2_header(b"LUKS\xba\xbe", 3, hdr_size, 0, json_area) +
+    luks2_header(b"SKUL\xba\xbe", 3, hdr_size, hdr_size, json_area))
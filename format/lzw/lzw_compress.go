@@ -0,0 +1,184 @@
+package lzw
+
+// https://en.wikipedia.org/wiki/Compress
+// https://github.com/vapier/ncompress/blob/main/compress.c
+// TODO: non-block mode files have not been tested
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var probeGroup decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.LZW_COMPRESS,
+		Description: "Unix compress LZW",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    lzwCompressDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.PROBE}, Group: &probeGroup},
+		},
+	})
+}
+
+const (
+	initBits  = 9
+	clearCode = 256
+	firstCode = 257
+)
+
+var blockModeCodeNames = scalar.UToSymStr{
+	clearCode: "clear",
+}
+
+// lzwDecoder decodes codes, table entries are offset and length into the
+// decoded output as an entry is always the previous entry plus one byte
+type lzwDecoder struct {
+	maxEntries int
+	startCode  int
+	offsets    []int
+	lens       []int
+	prevCode   int
+	out        []byte
+}
+
+func (l *lzwDecoder) reset() {
+	l.offsets = l.offsets[0:l.startCode]
+	l.lens = l.lens[0:l.startCode]
+	l.prevCode = -1
+}
+
+func (l *lzwDecoder) code(d *decode.D, code int) {
+	var offset, length int
+	switch {
+	case code < 256:
+		offset, length = -1, 1
+	case code < len(l.offsets):
+		offset, length = l.offsets[code], l.lens[code]
+	case code == len(l.offsets) && l.prevCode != -1:
+		// entry being defined, previous entry plus its first byte
+	default:
+		d.Fatalf("invalid code %d", code)
+	}
+
+	outStart := len(l.out)
+	if l.prevCode != -1 {
+		prevLen := l.lens[l.prevCode]
+		if l.prevCode < 256 {
+			prevLen = 1
+		}
+		if code == len(l.offsets) {
+			offset, length = outStart-prevLen, prevLen+1
+		}
+		if len(l.offsets) < l.maxEntries {
+			l.offsets = append(l.offsets, outStart-prevLen)
+			l.lens = append(l.lens, prevLen+1)
+		}
+	}
+
+	if offset == -1 {
+		l.out = append(l.out, byte(code))
+	} else {
+		for i := 0; i < length; i++ {
+			l.out = append(l.out, l.out[offset+i])
+		}
+	}
+	l.prevCode = code
+}
+
+func lzwCompressDecode(d *decode.D, in interface{}) interface{} {
+	d.FieldRawMagic("magic", []byte{0x1f, 0x9d})
+	blockMode := d.FieldBool("block_mode")
+	d.FieldU2("unused")
+	maxBits := int(d.FieldU5("max_bits", d.AssertURange(initBits, 16)))
+
+	maxMaxCode := 1 << maxBits
+	startCode := 256
+	if blockMode {
+		startCode = firstCode
+	}
+	l := &lzwDecoder{
+		maxEntries: maxMaxCode,
+		startCode:  startCode,
+		offsets:    make([]int, startCode, maxMaxCode),
+		lens:       make([]int, startCode, maxMaxCode),
+	}
+	l.reset()
+
+	var codeNames scalar.Mapper = scalar.UToSymStr{}
+	if blockMode {
+		codeNames = blockModeCodeNames
+	}
+
+	nBits := initBits
+	maxCodeFn := func() int {
+		if nBits == maxBits {
+			return maxMaxCode
+		}
+		return 1<<nBits - 1
+	}
+	maxCode := maxCodeFn()
+	// free entry as seen by the encoder, it pads and changes code width after
+	// writing a code when free entry does not fit current width
+	freeEntry := startCode
+
+	// codes are LSB first
+	d.LSBBitOrder(func(d *decode.D) {
+		d.FieldArray("segments", func(d *decode.D) {
+			for d.BitsLeft() >= int64(nBits) {
+				d.FieldStruct("segment", func(d *decode.D) {
+					segmentStart := d.Pos()
+					segmentBits := nBits
+					d.FieldValueU("code_bits", uint64(segmentBits))
+
+					switchWidth := false
+					d.FieldArray("codes", func(d *decode.D) {
+						for !switchWidth && d.BitsLeft() >= int64(nBits) {
+							code := int(d.FieldU("code", nBits, codeNames))
+
+							if blockMode && code == clearCode {
+								l.reset()
+								freeEntry = firstCode
+								nBits = initBits
+								switchWidth = true
+								continue
+							}
+							l.code(d, code)
+
+							if freeEntry > maxCode {
+								nBits++
+								switchWidth = true
+							}
+							if freeEntry < maxMaxCode {
+								freeEntry++
+							}
+						}
+					})
+					maxCode = maxCodeFn()
+
+					// codes are written in groups of 8 codes, padded on width change
+					groupBits := int64(segmentBits) * 8
+					paddingBits := (groupBits - (d.Pos()-segmentStart)%groupBits) % groupBits
+					if !switchWidth || paddingBits > d.BitsLeft() {
+						paddingBits = d.BitsLeft()
+					}
+					if paddingBits > 0 {
+						d.FieldRawLen("padding", paddingBits)
+					}
+				})
+			}
+		})
+	})
+
+	uncompressedBB := bitio.NewBufferFromBytes(l.out, -1)
+	if dv, _, _ := d.TryFieldFormatBitBuf("uncompressed", uncompressedBB, probeGroup, nil); dv == nil {
+		d.FieldRootBitBuf("uncompressed", uncompressedBB)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+#!/usr/bin/env python3
+# generates unix compress (.Z) files, same output as compress 4.0
+# verify with gzip -dc <file.Z
+# codes are written in groups of n_bits bytes (8 codes) and a group is padded
+# when code width changes or on clear
+import random
+
+CLEAR = 256
+FIRST = 257
+
+
+def compress(data, max_bits=16, block_mode=True, clear_when_full=False):
+    out = bytearray([0x1F, 0x9D, max_bits | (0x80 if block_mode else 0)])
+    bits = []
+    seg_start = 0
+    n_bits = 9
+    maxmaxcode = 1 << max_bits
+    maxcode = maxmaxcode if n_bits == max_bits else (1 << n_bits) - 1
+    free_ent = FIRST if block_mode else 256
+    table = {bytes([i]): i for i in range(256)}
+    clear_flg = False
+
+    def output(code):
+        nonlocal n_bits, maxcode, seg_start, clear_flg
+        for i in range(n_bits):
+            bits.append((code >> i) & 1)
+        if free_ent > maxcode or clear_flg:
+            group = n_bits * 8
+            while (len(bits) - seg_start) % group != 0:
+                bits.append(0)
+            seg_start = len(bits)
+            if clear_flg:
+                n_bits = 9
+                clear_flg = False
+            else:
+                n_bits += 1
+            maxcode = maxmaxcode if n_bits == max_bits else (1 << n_bits) - 1
+
+    w = data[0:1]
+    for c in data[1:]:
+        wc = w + bytes([c])
+        if wc in table:
+            w = wc
+            continue
+        output(table[w])
+        w = bytes([c])
+        if free_ent < maxmaxcode:
+            table[wc] = free_ent
+            free_ent += 1
+        elif clear_when_full and block_mode:
+            table = {bytes([i]): i for i in range(256)}
+            free_ent = FIRST
+            clear_flg = True
+            output(CLEAR)
+    output(table[w])
+
+    while len(bits) % 8 != 0:
+        bits.append(0)
+    for i in range(0, len(bits), 8):
+        out.append(sum(bits[i + j] << j for j in range(8)))
+    return bytes(out)
+
+
+open("hello.Z", "wb").write(compress(b"hello hello hello compress\n"))
+
+# enough distinct strings to grow code width and with max 10 bits to fill table and clear
+r = random.Random(1)
+text = bytes(r.choice(b"abcdefgh ") for _ in range(3000))
+open("text.Z", "wb").write(compress(text))
+open("clear.Z", "wb").write(compress(text, max_bits=10, clear_when_full=True))
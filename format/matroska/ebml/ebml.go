@@ -1,6 +1,9 @@
 package ebml
 
-import "github.com/wader/fq/pkg/scalar"
+import (
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
 
 type Type int
 
@@ -65,3 +68,41 @@ var Header = Tag{
 	DocTypeVersionID:     {Name: "DocTypeVersion", Type: Uinteger},
 	DocTypeReadVersionID: {Name: "DocTypeReadVersion", Type: Uinteger},
 }
+
+// DecodeRawVintWidth reads a variable length integer including the length descriptor bits
+// and returns it and its width in bytes
+func DecodeRawVintWidth(d *decode.D) (uint64, int) {
+	n := d.U8()
+	w := 1
+	for i := 0; i <= 7 && (n&(1<<(7-i))) == 0; i++ {
+		w++
+	}
+	for i := 1; i < w; i++ {
+		n = n<<8 | d.U8()
+	}
+	return n, w
+}
+
+// DecodeRawVint reads a variable length integer including the length descriptor bits, used for element IDs
+func DecodeRawVint(d *decode.D) uint64 {
+	n, _ := DecodeRawVintWidth(d)
+	return n
+}
+
+// DecodeVintWidth reads a variable length integer and returns the value and its width in bytes
+func DecodeVintWidth(d *decode.D) (uint64, int) {
+	n, w := DecodeRawVintWidth(d)
+	return n & VintMax(w), w
+}
+
+// DecodeVint reads a variable length integer, used for element sizes
+func DecodeVint(d *decode.D) uint64 {
+	n, _ := DecodeVintWidth(d)
+	return n
+}
+
+// VintMax is the max value of a w bytes wide variable length integer.
+// For element sizes all value bits set means unknown size.
+func VintMax(w int) uint64 {
+	return uint64(1<<(w*7)) - 1
+}
@@ -50,6 +50,7 @@ type Element struct {
 	MinOccurs      string          `xml:"minOccurs,attr"`
 	MaxOccurs      string          `xml:"maxOccurs,attr"`
 	Length         string          `xml:"length,attr"`
+	Recursive      string          `xml:"recursive,attr"`
 	Documentations []Documentation `xml:"documentation"`
 	Enums          []Enum          `xml:"restriction>enum"`
 }
@@ -182,4 +183,20 @@ func main() {
 		fmt.Printf("\n")
 	}
 
+	// recursive elements can have themselves as child, add them in init to
+	// not cause an initialization cycle
+	fmt.Printf("func init() {\n")
+	for _, e := range es.Elements {
+		if e.Recursive != "1" {
+			continue
+		}
+		parentPath := e.Path[0:strings.LastIndex(e.Path, `\`)]
+		for _, p := range es.Elements {
+			if p.Path != parentPath {
+				continue
+			}
+			fmt.Printf("\t%s[%sID] = %s[%sID]\n", e.Name, e.Name, p.Name, e.Name)
+		}
+	}
+	fmt.Printf("}\n")
 }
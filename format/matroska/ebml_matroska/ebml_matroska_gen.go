@@ -1,4 +1,5 @@
 // Code below generated from ebml_matroska.xml
+//
 //nolint:revive
 package ebml_matroska
 
@@ -2206,3 +2207,8 @@ var SimpleTag = ebml.Tag{
 		Type:       ebml.Binary,
 	},
 }
+
+func init() {
+	ChapterAtom[ChapterAtomID] = EditionEntry[ChapterAtomID]
+	SimpleTag[SimpleTagID] = Tag[SimpleTagID]
+}
@@ -5,6 +5,7 @@ package matroska
 // https://www.matroska.org/technical/basics.html
 // https://www.matroska.org/technical/codec_specs.html
 // https://wiki.xiph.org/MatroskaOpus
+// https://www.webmproject.org/docs/webm-encryption/
 
 // TODO: refactor simepleblock/block to just defer decode etc?
 // TODO: CRC
@@ -16,6 +17,7 @@ package matroska
 import (
 	"embed"
 	"fmt"
+	"math/bits"
 
 	"github.com/wader/fq/format"
 	"github.com/wader/fq/format/matroska/ebml"
@@ -98,30 +100,6 @@ func init() {
 	}
 }
 
-// TODO: smarter?
-func decodeRawVintWidth(d *decode.D) (uint64, int) {
-	n := d.U8()
-	w := 1
-	for i := 0; i <= 7 && (n&(1<<(7-i))) == 0; i++ {
-		w++
-	}
-	for i := 1; i < w; i++ {
-		n = n<<8 | d.U8()
-	}
-	return n, w
-}
-
-func decodeRawVint(d *decode.D) uint64 {
-	n, _ := decodeRawVintWidth(d)
-	return n
-}
-
-func decodeVint(d *decode.D) uint64 {
-	n, w := decodeRawVintWidth(d)
-	m := (uint64(1<<((w-1)*8+(8-w))) - 1)
-	return n & m
-}
-
 type track struct {
 	parentD             *decode.D
 	number              int
@@ -129,6 +107,8 @@ type track struct {
 	codecPrivatePos     int64
 	codecPrivateTagSize int64
 	formatInArg         interface{}
+	contentEncAlgo      uint64
+	contentCompressed   bool
 }
 
 type block struct {
@@ -143,18 +123,41 @@ type decodeContext struct {
 	blocks       []block
 }
 
+// isChildID peeks next element ID and checks if it is a valid child of tag
+func isChildID(d *decode.D, tag ebml.Tag) bool {
+	w := bits.LeadingZeros8(uint8(d.PeekBits(8))) + 1
+	if w > 8 || d.BitsLeft() < int64(w)*8 {
+		return false
+	}
+	n := d.PeekBits(w * 8)
+	if _, ok := tag[n]; ok {
+		return true
+	}
+	_, ok := ebml.Global[n]
+	return ok
+}
+
+// decodeMaster decodes child elements, bitsLimit -1 means unknown size
 func decodeMaster(d *decode.D, bitsLimit int64, tag ebml.Tag, dc *decodeContext) {
 	tagEndBit := d.Pos() + bitsLimit
+	moreElements := func() bool {
+		if bitsLimit == -1 {
+			// The end of a Master-element with unknown size is determined by the beginning of the next
+			// element that is not a valid sub-element of that Master-element
+			return d.NotEnd() && isChildID(d, tag)
+		}
+		return d.Pos() < tagEndBit && d.NotEnd()
+	}
 
 	d.FieldArray("elements", func(d *decode.D) {
 		// var crcD *decode.D
 		// var crcStart int64
 
-		for d.Pos() < tagEndBit && d.NotEnd() {
+		for moreElements() {
 			d.FieldStruct("element", func(d *decode.D) {
 				var a ebml.Attribute
 
-				tagID := d.FieldUFn("id", decodeRawVint, scalar.Fn(func(s scalar.S) (scalar.S, error) {
+				tagID := d.FieldUFn("id", ebml.DecodeRawVint, scalar.Fn(func(s scalar.S) (scalar.S, error) {
 					n := s.ActualU()
 					var ok bool
 					a, ok = tag[n]
@@ -168,18 +171,34 @@ func decodeMaster(d *decode.D, bitsLimit int64, tag ebml.Tag, dc *decodeContext)
 				}))
 				d.FieldValueU("type", uint64(a.Type), scalar.Sym(ebml.TypeNames[a.Type]))
 
-				if tagID == ebml_matroska.TrackEntryID {
+				switch {
+				case tagID == ebml_matroska.TrackEntryID:
 					dc.currentTrack = &track{}
 					dc.tracks = append(dc.tracks, dc.currentTrack)
+				case tagID == ebml_matroska.ContentCompressionID && dc.currentTrack != nil:
+					// ContentCompAlgo is optional and defaults to zlib
+					dc.currentTrack.contentCompressed = true
 				}
 
-				// tagSize could be 0xffffffffffffff which means "unknown" size, then we will read until eof
-				// TODO: should read until unknown id:
-				//    The end of a Master-element with unknown size is determined by the beginning of the next
-				//    element that is not a valid sub-element of that Master-element
+				// tagSize with all value bits set means "unknown" size, only allowed for master elements
 				// TODO: should also handle garbage between
 				const maxTagSize = 100 * 1024 * 1024
-				tagSize := d.FieldUFn("size", decodeVint, d.RequireURange(0, maxTagSize))
+				unknownSize := false
+				tagSize := d.FieldUFn("size", func(d *decode.D) uint64 {
+					n, w := ebml.DecodeVintWidth(d)
+					unknownSize = n == ebml.VintMax(w)
+					return n
+				}, scalar.Fn(func(s scalar.S) (scalar.S, error) {
+					if unknownSize {
+						s.Sym = "unknown"
+						return s, nil
+					}
+					return d.RequireURange(0, maxTagSize).MapScalar(s)
+				}))
+
+				if unknownSize && a.Type != ebml.Master {
+					d.Fatalf("unknown size for non-master type")
+				}
 
 				if tagSize > 8 &&
 					(a.Type == ebml.Integer ||
@@ -202,8 +221,13 @@ func decodeMaster(d *decode.D, bitsLimit int64, tag ebml.Tag, dc *decodeContext)
 					d.FieldS("value", int(tagSize)*8, optionalMap(a.IntegerEnums))
 				case ebml.Uinteger:
 					v := d.FieldU("value", int(tagSize)*8, optionalMap(a.UintegerEnums))
-					if dc.currentTrack != nil && tagID == ebml_matroska.TrackNumberID {
-						dc.currentTrack.number = int(v)
+					if dc.currentTrack != nil {
+						switch tagID {
+						case ebml_matroska.TrackNumberID:
+							dc.currentTrack.number = int(v)
+						case ebml_matroska.ContentEncAlgoID:
+							dc.currentTrack.contentEncAlgo = v
+						}
 					}
 				case ebml.Float:
 					d.FieldF("value", int(tagSize)*8)
@@ -270,7 +294,11 @@ func decodeMaster(d *decode.D, bitsLimit int64, tag ebml.Tag, dc *decodeContext)
 					}
 
 				case ebml.Master:
-					decodeMaster(d, int64(tagSize)*8, a.Tag, dc)
+					if unknownSize {
+						decodeMaster(d, -1, a.Tag, dc)
+					} else {
+						decodeMaster(d, int64(tagSize)*8, a.Tag, dc)
+					}
 				}
 			})
 		}
@@ -379,9 +407,11 @@ func matroskaDecode(d *decode.D, in interface{}) interface{} {
 		}
 	}
 
+	const contentEncAlgoAES = 5
+
 	for _, b := range dc.blocks {
 		b.d.RangeFn(b.r.Start, b.r.Len, func(d *decode.D) {
-			trackNumber := d.FieldUFn("track_number", decodeVint)
+			trackNumber := d.FieldUFn("track_number", ebml.DecodeVint)
 			d.FieldU16("timestamp")
 			if b.simple {
 				d.FieldStruct("flags", func(d *decode.D) {
@@ -403,7 +433,29 @@ func matroskaDecode(d *decode.D, in interface{}) interface{} {
 
 			// TODO: fixed/unknown?
 			if t, ok := trackNumberToTrack[int(trackNumber)]; ok {
-				if f, ok := codecToFormat[t.codec]; ok {
+				encrypted := false
+				if t.contentEncAlgo == contentEncAlgoAES {
+					d.FieldStruct("encryption", func(d *decode.D) {
+						d.FieldU6("reserved")
+						partitioned := d.FieldBool("partitioned")
+						encrypted = d.FieldBool("encrypted")
+						// iv and partitions are only present for encrypted frames
+						if encrypted {
+							d.FieldRawLen("iv", 64)
+							if partitioned {
+								numPartitions := d.FieldU8("num_partitions")
+								d.FieldArray("partition_offsets", func(d *decode.D) {
+									for i := uint64(0); i < numPartitions; i++ {
+										d.FieldU32("partition_offset")
+									}
+								})
+							}
+						}
+					})
+				}
+
+				// encrypted or compressed (header stripping etc) frames can't be decoded
+				if f, ok := codecToFormat[t.codec]; ok && !encrypted && !t.contentCompressed {
 					d.FieldFormat("packet", *f, t.formatInArg)
 				}
 			}
@@ -0,0 +1,105 @@
+#!/usr/bin/env python3
+# generates tags.mkv with nested chapters and simple tags, and encrypted.webm
+# with an AES encrypted track, block additions and a compressed track
+import struct
+
+
+def vint(n):
+    # size as 8 byte vint
+    return bytes([0x01]) + n.to_bytes(7, "big")
+
+
+def el(id, data):
+    return id.to_bytes((id.bit_length() + 7) // 8, "big") + vint(len(data)) + data
+
+
+def u(id, v):
+    return el(id, v.to_bytes(max(1, (v.bit_length() + 7) // 8), "big"))
+
+
+def s(id, v):
+    return el(id, v.encode())
+
+
+ebml_header = el(0x1A45DFA3, s(0x4282, "matroska") + u(0x4287, 4) + u(0x4285, 2))
+
+info = el(0x1549A966, u(0x2AD7B1, 1000000) + s(0x4D80, "fq gen.py") + s(0x5741, "fq gen.py"))
+
+
+def chapter_atom(uid, start, end, title, children=b""):
+    return el(0xB6,
+              u(0x73C4, uid) +
+              u(0x91, start) +
+              u(0x92, end) +
+              el(0x80, s(0x85, title) + s(0x437C, "eng")) +
+              children)
+
+
+chapters = el(0x1043A770, el(0x45B9,
+                             u(0x45BC, 1) +
+                             chapter_atom(1, 0, 1000000000, "Chapter 1",
+                                          chapter_atom(11, 0, 500000000, "Chapter 1.1")) +
+                             chapter_atom(2, 1000000000, 2000000000, "Chapter 2")))
+
+
+def simple_tag(name, value, children=b""):
+    return el(0x67C8, s(0x45A3, name) + s(0x447A, "und") + s(0x4487, value) + children)
+
+
+tags = el(0x1254C367, el(0x7373,
+                         el(0x63C0, u(0x68CA, 50) + s(0x63CA, "ALBUM")) +
+                         simple_tag("TITLE", "Album", simple_tag("SORT_WITH", "album")) +
+                         simple_tag("ARTIST", "fq") +
+                         el(0x67C8, s(0x45A3, "BINARY") + el(0x4485, b"\x01\x02\x03"))))
+
+segment = el(0x18538067, info + chapters + tags)
+
+open("tags.mkv", "wb").write(ebml_header + segment)
+
+
+def b(id, data):
+    return el(id, data)
+
+
+def block(track, data, flags=0x80):
+    return bytes([0x80 | track]) + struct.pack(">h", 0) + bytes([flags]) + data
+
+
+webm_header = el(0x1A45DFA3, s(0x4282, "webm") + u(0x4287, 4) + u(0x4285, 2))
+
+tracks = el(0x1654AE6B, el(0xAE,
+                          u(0xD7, 1) +
+                          u(0x83, 1) +
+                          s(0x86, "V_VP8") +
+                          el(0x6D80, el(0x6240,
+                                        u(0x5031, 0) +
+                                        u(0x5032, 1) +
+                                        u(0x5033, 1) +
+                                        el(0x5035,
+                                           u(0x47E1, 5) +
+                                           b(0x47E2, bytes(range(16))) +
+                                           el(0x47E7, u(0x47E8, 1)))))) +
+            # ContentCompression without ContentCompAlgo, defaults to zlib
+            el(0xAE,
+               u(0xD7, 2) +
+               u(0x83, 1) +
+               s(0x86, "V_VP8") +
+               el(0x6D80, el(0x6240, el(0x5034, el(0x4255, b"\x00"))))))
+
+# signal byte encrypted, 8 byte iv and encrypted data
+encrypted_frame = b"\x01" + b"\x00\x00\x00\x00\x00\x00\x00\x01" + b"\xaa\xbb\xcc\xdd"
+# signal byte encrypted and partitioned, iv, 1 partition offset and data
+partitioned_frame = b"\x03" + b"\x00\x00\x00\x00\x00\x00\x00\x02" + b"\x01" + struct.pack(">I", 2) + b"\x11\x22\x33\x44"
+# signal byte partitioned but not encrypted, no iv or partitions and a vp8 key frame header
+unencrypted_frame = b"\x02" + b"\x10\x02\x00\x9d\x01\x2a\x10\x00\x10\x00"
+
+cluster = el(0x1F43B675,
+             u(0xE7, 0) +
+             b(0xA3, block(1, encrypted_frame)) +
+             el(0xA0,
+                b(0xA1, block(1, partitioned_frame, 0)) +
+                el(0x75A1, el(0xA6, u(0xEE, 1) + b(0xA5, b"\x01\x02")))) +
+             b(0xA3, block(1, unencrypted_frame)) +
+             b(0xA3, block(2, b"\x78\x9c")))
+
+open("encrypted.webm", "wb").write(webm_header + el(0x18538067, tracks + cluster))
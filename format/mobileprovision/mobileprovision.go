@@ -0,0 +1,264 @@
+package mobileprovision
+
+// Provisioning profile is a CMS SignedData with a XML plist as encapsulated content
+// https://datatracker.ietf.org/doc/html/rfc5652
+// https://www.itu.int/rec/T-REC-X.690 BER/DER encoding
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var plistFormat decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.MOBILEPROVISION,
+		Description: "Apple provisioning profile",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    mobileprovisionDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.PLIST}, Group: &plistFormat},
+		},
+	})
+}
+
+const (
+	classUniversal = 0
+	classContext   = 2
+)
+
+var classNames = scalar.UToSymStr{
+	0: "universal",
+	1: "application",
+	2: "context",
+	3: "private",
+}
+
+const (
+	tagInteger     = 0x02
+	tagOctetString = 0x04
+	tagOID         = 0x06
+	tagSequence    = 0x10
+	tagSet         = 0x11
+)
+
+var universalTagNames = scalar.UToSymStr{
+	0x01:           "boolean",
+	tagInteger:     "integer",
+	0x03:           "bit_string",
+	tagOctetString: "octet_string",
+	0x05:           "null",
+	tagOID:         "object_identifier",
+	0x0c:           "utf8_string",
+	tagSequence:    "sequence",
+	tagSet:         "set",
+	0x13:           "printable_string",
+	0x16:           "ia5_string",
+	0x17:           "utc_time",
+	0x18:           "generalized_time",
+}
+
+var oidNames = scalar.StrToSymStr{
+	"1.2.840.113549.1.7.1": "data",
+	"1.2.840.113549.1.7.2": "signed_data",
+}
+
+const lengthIndefinite = -1
+
+type berHeader struct {
+	class       uint64
+	constructed bool
+	tag         uint64
+	length      int64 // in bytes or lengthIndefinite
+}
+
+func fieldBERHeader(d *decode.D) berHeader {
+	var h berHeader
+	h.class = d.FieldU2("class", classNames)
+	h.constructed = d.FieldBool("constructed")
+	if h.class == classUniversal {
+		h.tag = d.FieldU5("tag", universalTagNames)
+	} else {
+		h.tag = d.FieldU5("tag")
+	}
+	if h.tag == 0x1f {
+		d.Fatalf("high tag numbers not supported")
+	}
+
+	if !d.FieldBool("long_form") {
+		h.length = int64(d.FieldU7("length"))
+		return h
+	}
+	lengthBytes := d.FieldU7("length_bytes")
+	switch {
+	case lengthBytes == 0:
+		h.length = lengthIndefinite
+	case lengthBytes > 8:
+		d.Fatalf("length bytes %d too large", lengthBytes)
+	default:
+		h.length = int64(d.FieldU("length", int(lengthBytes)*8))
+	}
+
+	return h
+}
+
+// fieldBERElement decodes header and then contents using fn, for indefinite length
+// fn should stop at end of contents, see berContentsLoop
+func fieldBERElement(d *decode.D, name string, fn func(d *decode.D, h berHeader)) {
+	d.FieldStruct(name, func(d *decode.D) {
+		h := fieldBERHeader(d)
+		if h.length == lengthIndefinite {
+			if !h.constructed {
+				d.Fatalf("indefinite length for primitive element")
+			}
+			fn(d, h)
+			d.FieldU16("end_of_contents", d.AssertU(0))
+			return
+		}
+		d.LenFn(h.length*8, func(d *decode.D) { fn(d, h) })
+	})
+}
+
+func berContentsLoop(d *decode.D, h berHeader, fn func(d *decode.D)) {
+	for {
+		if h.length == lengthIndefinite {
+			if d.PeekBits(16) == 0 {
+				return
+			}
+		} else if !d.NotEnd() {
+			return
+		}
+		fn(d)
+	}
+}
+
+// constructed elements nested deeper than this fails the decode
+const maxBERDepth = 100
+
+func fieldBERAny(d *decode.D, name string, depth int) {
+	if depth > maxBERDepth {
+		d.Fatalf("elements nested deeper than %d", maxBERDepth)
+	}
+	fieldBERElement(d, name, func(d *decode.D, h berHeader) {
+		if h.constructed {
+			d.FieldArray("elements", func(d *decode.D) {
+				berContentsLoop(d, h, func(d *decode.D) { fieldBERAny(d, "element", depth+1) })
+			})
+			return
+		}
+		d.FieldRawLen("value", h.length*8)
+	})
+}
+
+func fieldBERExpectTag(d *decode.D, h berHeader, class uint64, tag uint64) {
+	if h.class != class || h.tag != tag {
+		d.Fatalf("expected %s tag %d, got %s tag %d", classNames[class], tag, classNames[h.class], h.tag)
+	}
+}
+
+func fieldBEROID(d *decode.D, name string, expected string) {
+	fieldBERElement(d, name, func(d *decode.D, h berHeader) {
+		fieldBERExpectTag(d, h, classUniversal, tagOID)
+		d.FieldStrFn("value", func(d *decode.D) string {
+			var parts []string
+			var n uint64
+			for i := int64(0); i < h.length; i++ {
+				b := d.U8()
+				n = n<<7 | b&0x7f
+				if b&0x80 != 0 {
+					continue
+				}
+				if len(parts) == 0 {
+					// first subidentifier encodes first two components
+					first := n / 40
+					if first > 2 {
+						first = 2
+					}
+					parts = append(parts, strconv.FormatUint(first, 10), strconv.FormatUint(n-first*40, 10))
+				} else {
+					parts = append(parts, strconv.FormatUint(n, 10))
+				}
+				n = 0
+			}
+			return strings.Join(parts, ".")
+		}, oidNames, d.AssertStr(expected))
+	})
+}
+
+func mobileprovisionDecode(d *decode.D, in interface{}) interface{} {
+	// encapsulated content, a primitive octet string range or chunks of a constructed one
+	var contentStart, contentLen int64
+	var contentChunks []byte
+	contentConstructed := false
+
+	// ContentInfo
+	h := fieldBERHeader(d)
+	fieldBERExpectTag(d, h, classUniversal, tagSequence)
+	fieldBEROID(d, "content_type", "1.2.840.113549.1.7.2")
+	fieldBERElement(d, "signed_data", func(d *decode.D, h berHeader) {
+		fieldBERExpectTag(d, h, classContext, 0)
+		fieldBERElement(d, "sequence", func(d *decode.D, h berHeader) {
+			fieldBERExpectTag(d, h, classUniversal, tagSequence)
+			fieldBERElement(d, "version", func(d *decode.D, h berHeader) {
+				fieldBERExpectTag(d, h, classUniversal, tagInteger)
+				d.FieldU("value", int(h.length)*8)
+			})
+			fieldBERAny(d, "digest_algorithms", 1)
+			fieldBERElement(d, "encap_content_info", func(d *decode.D, h berHeader) {
+				fieldBERExpectTag(d, h, classUniversal, tagSequence)
+				fieldBEROID(d, "content_type", "1.2.840.113549.1.7.1")
+				fieldBERElement(d, "econtent", func(d *decode.D, h berHeader) {
+					fieldBERExpectTag(d, h, classContext, 0)
+					fieldBERElement(d, "octet_string", func(d *decode.D, h berHeader) {
+						fieldBERExpectTag(d, h, classUniversal, tagOctetString)
+						if !h.constructed {
+							contentStart = d.Pos()
+							contentLen = h.length * 8
+							d.FieldRawLen("value", contentLen)
+							return
+						}
+						contentConstructed = true
+						d.FieldArray("chunks", func(d *decode.D) {
+							berContentsLoop(d, h, func(d *decode.D) {
+								fieldBERElement(d, "chunk", func(d *decode.D, h berHeader) {
+									fieldBERExpectTag(d, h, classUniversal, tagOctetString)
+									contentChunks = append(contentChunks, d.FieldBytesLen("value", int(h.length))...)
+								})
+							})
+						})
+					})
+				})
+			})
+			berContentsLoop(d, h, func(d *decode.D) {
+				switch d.PeekBits(8) {
+				case 0xa0:
+					fieldBERAny(d, "certificates", 1)
+				case 0xa1:
+					fieldBERAny(d, "crls", 1)
+				default:
+					fieldBERAny(d, "signer_infos", 1)
+				}
+			})
+		})
+	})
+	if h.length == lengthIndefinite {
+		d.FieldU16("end_of_contents", d.AssertU(0))
+	}
+
+	d.FieldStruct("content", func(d *decode.D) {
+		if contentConstructed {
+			d.FieldFormatBitBuf("plist", bitio.NewBufferFromBytes(contentChunks, -1), plistFormat, nil)
+		} else {
+			d.FieldFormatRange("plist", contentStart, contentLen, plistFormat, nil)
+		}
+	})
+
+	return nil
+}
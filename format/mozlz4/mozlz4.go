@@ -0,0 +1,125 @@
+package mozlz4
+
+// https://searchfox.org/mozilla-central/source/toolkit/components/lz4/lz4.js
+// https://github.com/lz4/lz4/blob/dev/doc/lz4_Block_format.md
+
+import (
+	"errors"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+)
+
+var jsonFormat decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.MOZLZ4,
+		Description: "Firefox mozLz4 compressed file",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    mozlz4Decode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.JSON}, Group: &jsonFormat},
+		},
+	})
+}
+
+var errLZ4Invalid = errors.New("invalid lz4 block")
+var errLZ4SizeTooLarge = errors.New("decompressed size too large for lz4 block")
+
+// max expansion is a 255 length byte producing 255 bytes of match
+const maxLZ4Ratio = 255
+
+// lz4BlockDecode decodes a raw lz4 block, sequences of literals and matches
+func lz4BlockDecode(src []byte, size int) ([]byte, error) {
+	if size > len(src)*maxLZ4Ratio {
+		return nil, errLZ4SizeTooLarge
+	}
+	dst := make([]byte, 0, size)
+	readLen := func(i int, l int) (int, int, error) {
+		if l != 15 {
+			return i, l, nil
+		}
+		for {
+			if i >= len(src) {
+				return i, 0, errLZ4Invalid
+			}
+			b := src[i]
+			i++
+			l += int(b)
+			if b != 255 {
+				return i, l, nil
+			}
+		}
+	}
+
+	for i := 0; i < len(src); {
+		token := src[i]
+		i++
+
+		var literalLen int
+		var err error
+		i, literalLen, err = readLen(i, int(token>>4))
+		if err != nil {
+			return nil, err
+		}
+		if i+literalLen > len(src) || len(dst)+literalLen > size {
+			return nil, errLZ4Invalid
+		}
+		dst = append(dst, src[i:i+literalLen]...)
+		i += literalLen
+		// last sequence has only literals
+		if i == len(src) {
+			break
+		}
+
+		if i+2 > len(src) {
+			return nil, errLZ4Invalid
+		}
+		offset := int(src[i]) | int(src[i+1])<<8
+		i += 2
+		var matchLen int
+		i, matchLen, err = readLen(i, int(token&0xf))
+		if err != nil {
+			return nil, err
+		}
+		matchLen += 4
+		if offset == 0 || offset > len(dst) || len(dst)+matchLen > size {
+			return nil, errLZ4Invalid
+		}
+		// match can overlap with itself so copy byte by byte
+		start := len(dst) - offset
+		for j := 0; j < matchLen; j++ {
+			dst = append(dst, dst[start+j])
+		}
+	}
+
+	return dst, nil
+}
+
+func mozlz4Decode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldRawMagic("magic", []byte("mozLz40\x00"))
+	decompressedSize := d.FieldU32("decompressed_size")
+
+	compressed := d.FieldBytesLen("compressed", int(d.BitsLeft()/8))
+
+	uncompressed, err := lz4BlockDecode(compressed, int(decompressedSize))
+	if err != nil {
+		d.Errorf("%s", err)
+		return nil
+	}
+	if len(uncompressed) != int(decompressedSize) {
+		d.Errorf("decompressed size %d, expected %d", len(uncompressed), decompressedSize)
+	}
+
+	bb := bitio.NewBufferFromBytes(uncompressed, -1)
+	if dv, _, _ := d.TryFieldFormatBitBuf("uncompressed", bb, jsonFormat, nil); dv == nil {
+		d.FieldRootBitBuf("uncompressed", bb)
+	}
+
+	return nil
+}
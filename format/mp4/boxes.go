@@ -107,15 +107,15 @@ var quicktimeEpoch = scalar.Fn(func(s scalar.S) (scalar.S, error) {
 
 func decodeFieldMatrix(d *decode.D, name string) {
 	d.FieldStruct(name, func(d *decode.D) {
-		d.FieldFP32("a")
-		d.FieldFP32("b")
-		d.FieldFP("u", 32, 30)
-		d.FieldFP32("c")
-		d.FieldFP32("d")
-		d.FieldFP("v", 32, 30)
-		d.FieldFP32("x")
-		d.FieldFP32("y")
-		d.FieldFP("w", 32, 30)
+		d.FieldSFP32("a")
+		d.FieldSFP32("b")
+		d.FieldSFP("u", 32, 30)
+		d.FieldSFP32("c")
+		d.FieldSFP32("d")
+		d.FieldSFP("v", 32, 30)
+		d.FieldSFP32("x")
+		d.FieldSFP32("y")
+		d.FieldSFP("w", 32, 30)
 	})
 }
 
@@ -203,8 +203,8 @@ func init() {
 			d.FieldU32("modification_time", quicktimeEpoch)
 			d.FieldU32("time_scale")
 			d.FieldU32("duration")
-			d.FieldFP32("preferred_rate")
-			d.FieldFP16("preferred_volume")
+			d.FieldSFP32("preferred_rate")
+			d.FieldSFP16("preferred_volume")
 			d.FieldUTF8("reserved", 10)
 			decodeFieldMatrix(d, "matrix_structure")
 			d.FieldU32("preview_time")
@@ -233,7 +233,7 @@ func init() {
 					}
 					return t
 				}, mediaTimeNames)
-				d.FieldFP32("media_rate")
+				d.FieldSFP32("media_rate")
 				i++
 			})
 		},
@@ -249,7 +249,7 @@ func init() {
 			d.FieldRawLen("reserved2", 8*8)
 			d.FieldU16("layer")
 			d.FieldU16("alternate_group")
-			d.FieldFP16("volume")
+			d.FieldSFP16("volume")
 			d.FieldU16("reserved3")
 			decodeFieldMatrix(d, "matrix_structure")
 			d.FieldFP32("track_width")
@@ -499,19 +499,16 @@ func init() {
 			d.FieldU8("version")
 			d.FieldU24("flags")
 			numEntries := d.FieldU32("entry_count")
-			var i uint64
-			d.FieldStructArrayLoop("entries", "entry", func() bool { return i < numEntries }, func(d *decode.D) {
+			d.FieldStructArray("entries", "entry", numEntries, func(d *decode.D) {
 				d.FieldU32("count")
 				d.FieldU32("delta")
-				i++
 			})
 		},
 		"stsc": func(ctx *decodeContext, d *decode.D) {
 			d.FieldU8("version")
 			d.FieldU24("flags")
 			entryCount := d.FieldU32("entry_count")
-			var i uint64
-			d.FieldStructArrayLoop("entries", "entry", func() bool { return i < entryCount }, func(d *decode.D) {
+			d.FieldStructArray("entries", "entry", entryCount, func(d *decode.D) {
 				firstChunk := uint32(d.FieldU32("first_chunk"))
 				samplesPerChunk := uint32(d.FieldU32("samples_per_chunk"))
 				d.FieldU32("sample_description_id")
@@ -522,7 +519,6 @@ func init() {
 						samplesPerChunk: samplesPerChunk,
 					})
 				}
-				i++
 			})
 		},
 		"stsz": func(ctx *decodeContext, d *decode.D) {
@@ -599,11 +595,9 @@ func init() {
 			d.FieldU8("version")
 			d.FieldU24("flags")
 			entryCount := d.FieldU32("entry_count")
-			var i uint64
-			d.FieldStructArrayLoop("entries", "entry", func() bool { return i < entryCount }, func(d *decode.D) {
+			d.FieldStructArray("entries", "entry", entryCount, func(d *decode.D) {
 				d.FieldS32("sample_count")
 				d.FieldS32("sample_offset")
-				i++
 			})
 		},
 		// TODO: refactor: merge with stco?
@@ -1250,7 +1244,7 @@ func init() {
 		"smhd": func(_ *decodeContext, d *decode.D) {
 			d.FieldU8("version")
 			d.FieldU24("flags")
-			d.FieldFP16("balance")
+			d.FieldSFP16("balance")
 			d.FieldU16("reserved")
 		},
 	}
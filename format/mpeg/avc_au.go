@@ -29,12 +29,21 @@ func avcAUDecode(d *decode.D, in interface{}) interface{} {
 		d.Fatalf("avcIn required")
 	}
 
-	for d.NotEnd() {
+	lengthLen := int64(avcIn.LengthSize) * 8
+	if lengthLen == 0 {
+		d.Fatalf("invalid nalu length size 0")
+	}
+	for d.BitsLeft() >= lengthLen {
+		l := int64(d.PeekBits(int(lengthLen))) * 8
+		if lengthLen+l > d.BitsLeft() {
+			break
+		}
 		d.FieldStruct("nalu", func(d *decode.D) {
-			l := d.FieldU("length", int(avcIn.LengthSize)*8)
-			d.FieldFormatLen("nalu", int64(l)*8, avcNALUFormat, nil)
+			d.FieldU("length", int(lengthLen))
+			d.FieldFormatLen("nalu", l, avcNALUFormat, nil)
 		})
 	}
+	d.FieldUnparsedTail()
 
 	return nil
 }
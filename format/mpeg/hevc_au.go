@@ -27,12 +27,24 @@ func hevcAUDecode(d *decode.D, in interface{}) interface{} {
 		d.Errorf("hevcIn required")
 	}
 
-	for d.NotEnd() {
-		d.FieldStruct("nalu", func(d *decode.D) {
-			l := d.FieldU("length", int(hevcIn.LengthSize)*8)
+	// nalus are independent so decode them lazily, can be lots of them
+	lengthLen := int64(hevcIn.LengthSize) * 8
+	if lengthLen == 0 {
+		d.Fatalf("invalid nalu length size 0")
+	}
+	for d.BitsLeft() >= lengthLen {
+		d.CheckContext()
+		l := int64(d.PeekBits(int(lengthLen))) * 8
+		if lengthLen+l > d.BitsLeft() {
+			break
+		}
+		d.FieldStructLazy("nalu", d.Pos(), lengthLen+l, func(d *decode.D) {
+			l := d.FieldU("length", int(lengthLen))
 			d.FieldFormatLen("nalu", int64(l)*8, hevcAUNALFormat, nil)
 		})
+		d.SeekRel(lengthLen + l)
 	}
+	d.FieldUnparsedTail()
 
 	return nil
 }
@@ -16,6 +16,15 @@ func init() {
 	})
 }
 
+const (
+	hevcNALBLAWLP       = 16
+	hevcNALRSVIRAPVCL23 = 23
+	hevcNALRSVVCL31     = 31
+	hevcNALVPS          = 32
+	hevcNALSPS          = 33
+	hevcNALPPS          = 34
+)
+
 var hevcNALNames = scalar.UToSymStr{
 	0:  "TRAIL_N",
 	1:  "TRAIL_R",
@@ -67,16 +76,117 @@ var hevcNALNames = scalar.UToSymStr{
 	47: "RSV_NVCL47",
 }
 
+func hevcProfileTierLevel(d *decode.D, maxSubLayersMinus1 uint64) {
+	d.FieldU2("general_profile_space")
+	d.FieldBool("general_tier_flag")
+	d.FieldU5("general_profile_idc")
+	d.FieldU32("general_profile_compatibility_flags", scalar.Hex)
+	d.FieldU48("general_constraint_indicator_flags", scalar.Hex)
+	d.FieldU8("general_level_idc")
+	if maxSubLayersMinus1 == 0 {
+		return
+	}
+
+	var profilePresent, levelPresent []bool
+	d.FieldArray("sub_layer_flags", func(d *decode.D) {
+		for i := uint64(0); i < maxSubLayersMinus1; i++ {
+			d.FieldStruct("sub_layer_flag", func(d *decode.D) {
+				profilePresent = append(profilePresent, d.FieldBool("sub_layer_profile_present_flag"))
+				levelPresent = append(levelPresent, d.FieldBool("sub_layer_level_present_flag"))
+			})
+		}
+	})
+	for i := maxSubLayersMinus1; i < 8; i++ {
+		d.FieldU2("reserved_zero_2bits")
+	}
+	d.FieldArray("sub_layers", func(d *decode.D) {
+		for i := uint64(0); i < maxSubLayersMinus1; i++ {
+			d.FieldStruct("sub_layer", func(d *decode.D) {
+				if profilePresent[i] {
+					d.FieldU2("sub_layer_profile_space")
+					d.FieldBool("sub_layer_tier_flag")
+					d.FieldU5("sub_layer_profile_idc")
+					d.FieldU32("sub_layer_profile_compatibility_flags", scalar.Hex)
+					d.FieldU48("sub_layer_constraint_indicator_flags", scalar.Hex)
+				}
+				if levelPresent[i] {
+					d.FieldU8("sub_layer_level_idc")
+				}
+			})
+		}
+	})
+}
+
+func hevcVPSDecode(d *decode.D) {
+	d.FieldU4("vps_video_parameter_set_id")
+	d.FieldBool("vps_base_layer_internal_flag")
+	d.FieldBool("vps_base_layer_available_flag")
+	d.FieldU6("vps_max_layers_minus1")
+	maxSubLayersMinus1 := d.FieldU3("vps_max_sub_layers_minus1")
+	d.FieldBool("vps_temporal_id_nesting_flag")
+	d.FieldU16("vps_reserved_0xffff_16bits")
+	d.FieldStruct("profile_tier_level", func(d *decode.D) { hevcProfileTierLevel(d, maxSubLayersMinus1) })
+	// TODO: rest of vps
+}
+
+func hevcSPSDecode(d *decode.D) {
+	d.FieldU4("sps_video_parameter_set_id")
+	maxSubLayersMinus1 := d.FieldU3("sps_max_sub_layers_minus1")
+	d.FieldBool("sps_temporal_id_nesting_flag")
+	d.FieldStruct("profile_tier_level", func(d *decode.D) { hevcProfileTierLevel(d, maxSubLayersMinus1) })
+	d.FieldUFn("sps_seq_parameter_set_id", uEV)
+	chromaFormatIdc := d.FieldUFn("chroma_format_idc", uEV)
+	if chromaFormatIdc == 3 {
+		d.FieldBool("separate_colour_plane_flag")
+	}
+	d.FieldUFn("pic_width_in_luma_samples", uEV)
+	d.FieldUFn("pic_height_in_luma_samples", uEV)
+	// TODO: rest of sps
+}
+
+func hevcPPSDecode(d *decode.D) {
+	d.FieldUFn("pps_pic_parameter_set_id", uEV)
+	d.FieldUFn("pps_seq_parameter_set_id", uEV)
+	d.FieldBool("dependent_slice_segments_enabled_flag")
+	d.FieldBool("output_flag_present_flag")
+	d.FieldU3("num_extra_slice_header_bits")
+	d.FieldBool("sign_data_hiding_enabled_flag")
+	d.FieldBool("cabac_init_present_flag")
+	d.FieldUFn("num_ref_idx_l0_default_active_minus1", uEV)
+	d.FieldUFn("num_ref_idx_l1_default_active_minus1", uEV)
+	d.FieldSFn("init_qp_minus26", sEV)
+	// TODO: rest of pps
+}
+
 func hevcNALUDecode(d *decode.D, in interface{}) interface{} {
 	d.FieldBool("forbidden_zero_bit")
 	nalType := d.FieldU6("nal_unit_type", hevcNALNames)
 	d.FieldU6("nuh_layer_id")
 	d.FieldU3("nuh_temporal_id_plus1")
-	unescapedBb := d.MustNewBitBufFromReader(decode.NALUnescapeReader{Reader: d.BitBufRange(d.Pos(), d.BitsLeft())})
 
-	_ = unescapedBb
-	_ = nalType
+	// syntax elements are in the RBSP with emulation prevention bytes removed
+	rbspBB := d.MustNewBitBufFromReader(&decode.NALUnescapeReader{Reader: d.BitBufRange(d.Pos(), d.BitsLeft())})
+	if rbspBB.Len() != d.BitsLeft() {
+		d.FieldRootBitBuf("rbsp", rbspBB)
+	}
 
+	switch {
+	case nalType <= hevcNALRSVVCL31:
+		d.FieldStructRootBitBufFn("slice_segment_header", rbspBB, func(d *decode.D) {
+			d.FieldBool("first_slice_segment_in_pic_flag")
+			if nalType >= hevcNALBLAWLP && nalType <= hevcNALRSVIRAPVCL23 {
+				d.FieldBool("no_output_of_prior_pics_flag")
+			}
+			d.FieldUFn("slice_pic_parameter_set_id", uEV)
+			// TODO: rest of slice segment header, needs pps and sps
+		})
+	case nalType == hevcNALVPS:
+		d.FieldStructRootBitBufFn("vps", rbspBB, hevcVPSDecode)
+	case nalType == hevcNALSPS:
+		d.FieldStructRootBitBufFn("sps", rbspBB, hevcSPSDecode)
+	case nalType == hevcNALPPS:
+		d.FieldStructRootBitBufFn("pps", rbspBB, hevcPPSDecode)
+	}
 	d.FieldRawLen("data", d.BitsLeft())
 
 	return nil
@@ -0,0 +1,312 @@
+package nifti
+
+// https://nifti.nimh.nih.gov/pub/dist/src/niftilib/nifti1.h
+// https://nifti.nimh.nih.gov/pub/dist/doc/nifti2.h
+// TODO: decode data per datatype?
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.NIFTI,
+		Description: "Neuroimaging Informatics Technology Initiative",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    niftiDecode,
+	})
+}
+
+const (
+	nifti1HeaderSize = 348
+	nifti2HeaderSize = 540
+)
+
+var datatypeNames = scalar.UToSymStr{
+	0:    "unknown",
+	1:    "binary",
+	2:    "uint8",
+	4:    "int16",
+	8:    "int32",
+	16:   "float32",
+	32:   "complex64",
+	64:   "float64",
+	128:  "rgb24",
+	256:  "int8",
+	512:  "uint16",
+	768:  "uint32",
+	1024: "int64",
+	1280: "uint64",
+	1536: "float128",
+	1792: "complex128",
+	2048: "complex256",
+	2304: "rgba32",
+}
+
+var intentCodeNames = scalar.UToSymStr{
+	0:    "none",
+	2:    "correl",
+	3:    "ttest",
+	4:    "ftest",
+	5:    "zscore",
+	6:    "chisq",
+	7:    "beta",
+	8:    "binom",
+	9:    "gamma",
+	10:   "poisson",
+	11:   "normal",
+	12:   "ftest_nonc",
+	13:   "chisq_nonc",
+	14:   "logistic",
+	15:   "laplace",
+	16:   "uniform",
+	17:   "ttest_nonc",
+	18:   "weibull",
+	19:   "chi",
+	20:   "invgauss",
+	21:   "extval",
+	22:   "pval",
+	23:   "logpval",
+	24:   "log10pval",
+	1001: "estimate",
+	1002: "label",
+	1003: "neuroname",
+	1004: "genmatrix",
+	1005: "symmatrix",
+	1006: "dispvect",
+	1007: "vector",
+	1008: "pointset",
+	1009: "triangle",
+	1010: "quaternion",
+	1011: "dimless",
+	2001: "time_series",
+	2002: "node_index",
+	2003: "rgb_vector",
+	2004: "rgba_vector",
+	2005: "shape",
+}
+
+var sliceCodeNames = scalar.UToSymStr{
+	0: "unknown",
+	1: "seq_inc",
+	2: "seq_dec",
+	3: "alt_inc",
+	4: "alt_dec",
+	5: "alt_inc2",
+	6: "alt_dec2",
+}
+
+var xformCodeNames = scalar.UToSymStr{
+	0: "unknown",
+	1: "scanner_anat",
+	2: "aligned_anat",
+	3: "talairach",
+	4: "mni_152",
+	5: "template_other",
+}
+
+var spaceUnitNames = scalar.UToSymStr{
+	0: "unknown",
+	1: "meter",
+	2: "mm",
+	3: "micron",
+}
+
+// time unit codes are 8, 16, ... stored in bits 3-5
+var timeUnitNames = scalar.UToSymStr{
+	0: "unknown",
+	1: "sec",
+	2: "msec",
+	3: "usec",
+	4: "hz",
+	5: "ppm",
+	6: "rads",
+}
+
+func fieldSArray(d *decode.D, name string, elmName string, n int, nBits int) {
+	d.FieldArray(name, func(d *decode.D) {
+		for i := 0; i < n; i++ {
+			d.FieldS(elmName, nBits)
+		}
+	})
+}
+
+func fieldFArray(d *decode.D, name string, elmName string, n int, nBits int) {
+	d.FieldArray(name, func(d *decode.D) {
+		for i := 0; i < n; i++ {
+			d.FieldF(elmName, nBits)
+		}
+	})
+}
+
+func decodeDimInfo(d *decode.D) {
+	d.FieldStruct("dim_info", func(d *decode.D) {
+		d.FieldU2("unused")
+		d.FieldU2("slice_dim")
+		d.FieldU2("phase_dim")
+		d.FieldU2("freq_dim")
+	})
+}
+
+func decodeXYZTUnits(d *decode.D) {
+	d.FieldStruct("xyzt_units", func(d *decode.D) {
+		d.FieldU2("unused")
+		d.FieldU3("time", timeUnitNames)
+		d.FieldU3("space", spaceUnitNames)
+	})
+}
+
+func decodeQSForm(d *decode.D, nBits int) {
+	d.FieldF("quatern_b", nBits)
+	d.FieldF("quatern_c", nBits)
+	d.FieldF("quatern_d", nBits)
+	d.FieldF("qoffset_x", nBits)
+	d.FieldF("qoffset_y", nBits)
+	d.FieldF("qoffset_z", nBits)
+	fieldFArray(d, "srow_x", "value", 4, nBits)
+	fieldFArray(d, "srow_y", "value", 4, nBits)
+	fieldFArray(d, "srow_z", "value", 4, nBits)
+}
+
+func decodeNIfTI1Header(d *decode.D) (string, int64) {
+	d.FieldU32("sizeof_hdr", d.AssertU(nifti1HeaderSize))
+	d.FieldUTF8NullFixedLen("data_type", 10)
+	d.FieldUTF8NullFixedLen("db_name", 18)
+	d.FieldS32("extents")
+	d.FieldS16("session_error")
+	d.FieldU8("regular")
+	decodeDimInfo(d)
+	fieldSArray(d, "dim", "dim", 8, 16)
+	d.FieldF32("intent_p1")
+	d.FieldF32("intent_p2")
+	d.FieldF32("intent_p3")
+	d.FieldU16("intent_code", intentCodeNames)
+	d.FieldU16("datatype", datatypeNames)
+	d.FieldS16("bitpix")
+	d.FieldS16("slice_start")
+	fieldFArray(d, "pixdim", "pixdim", 8, 32)
+	voxOffset := d.FieldF32("vox_offset")
+	d.FieldF32("scl_slope")
+	d.FieldF32("scl_inter")
+	d.FieldS16("slice_end")
+	d.FieldU8("slice_code", sliceCodeNames)
+	decodeXYZTUnits(d)
+	d.FieldF32("cal_max")
+	d.FieldF32("cal_min")
+	d.FieldF32("slice_duration")
+	d.FieldF32("toffset")
+	d.FieldS32("glmax")
+	d.FieldS32("glmin")
+	d.FieldUTF8NullFixedLen("descrip", 80)
+	d.FieldUTF8NullFixedLen("aux_file", 24)
+	d.FieldU16("qform_code", xformCodeNames)
+	d.FieldU16("sform_code", xformCodeNames)
+	decodeQSForm(d, 32)
+	d.FieldUTF8NullFixedLen("intent_name", 16)
+	magic := d.FieldUTF8NullFixedLen("magic", 4, d.AssertStr("ni1", "n+1"))
+
+	return magic, int64(voxOffset)
+}
+
+func decodeNIfTI2Header(d *decode.D) (string, int64) {
+	d.FieldU32("sizeof_hdr", d.AssertU(nifti2HeaderSize))
+	magic := d.FieldUTF8NullFixedLen("magic", 4, d.AssertStr("ni2", "n+2"))
+	d.FieldRawMagic("magic_signature", []byte("\r\n\x1a\n"))
+	d.FieldU16("datatype", datatypeNames)
+	d.FieldS16("bitpix")
+	fieldSArray(d, "dim", "dim", 8, 64)
+	d.FieldF64("intent_p1")
+	d.FieldF64("intent_p2")
+	d.FieldF64("intent_p3")
+	fieldFArray(d, "pixdim", "pixdim", 8, 64)
+	voxOffset := d.FieldS64("vox_offset")
+	d.FieldF64("scl_slope")
+	d.FieldF64("scl_inter")
+	d.FieldF64("cal_max")
+	d.FieldF64("cal_min")
+	d.FieldF64("slice_duration")
+	d.FieldF64("toffset")
+	d.FieldS64("slice_start")
+	d.FieldS64("slice_end")
+	d.FieldUTF8NullFixedLen("descrip", 80)
+	d.FieldUTF8NullFixedLen("aux_file", 24)
+	d.FieldU32("qform_code", xformCodeNames)
+	d.FieldU32("sform_code", xformCodeNames)
+	decodeQSForm(d, 64)
+	d.FieldU32("slice_code", sliceCodeNames)
+	d.FieldU32("xyzt_units", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		u := s.ActualU()
+		s.Description = timeUnitNames[(u>>3)&0x7] + "/" + spaceUnitNames[u&0x7]
+		return s, nil
+	}))
+	d.FieldU32("intent_code", intentCodeNames)
+	d.FieldUTF8NullFixedLen("intent_name", 16)
+	decodeDimInfo(d)
+	d.FieldRawLen("unused_str", 15*8)
+
+	return magic, voxOffset
+}
+
+func niftiDecode(d *decode.D, in interface{}) interface{} {
+	// sizeof_hdr is used to detect endian
+	switch d.PeekBits(32) {
+	case nifti1HeaderSize, nifti2HeaderSize:
+		d.Endian = decode.BigEndian
+	default:
+		d.Endian = decode.LittleEndian
+	}
+
+	var magic string
+	var voxOffset int64
+	switch d.U32() {
+	case nifti1HeaderSize:
+		d.SeekAbs(0)
+		magic, voxOffset = decodeNIfTI1Header(d)
+	case nifti2HeaderSize:
+		d.SeekAbs(0)
+		magic, voxOffset = decodeNIfTI2Header(d)
+	default:
+		d.Fatalf("unknown sizeof_hdr")
+	}
+	// "n+1"/"n+2" is a single .nii file, "ni1"/"ni2" a .hdr file with data in a separate .img file
+	singleFile := magic == "n+1" || magic == "n+2"
+	extensionsEnd := d.Len()
+	if singleFile {
+		extensionsEnd = voxOffset * 8
+	}
+
+	if d.NotEnd() {
+		var hasExtensions bool
+		d.FieldStruct("extension", func(d *decode.D) {
+			hasExtensions = d.FieldU8("has_extensions") != 0
+			d.FieldRawLen("unused", 3*8)
+		})
+		if hasExtensions {
+			d.FieldStructArrayLoop("extensions", "extension", func() bool { return d.Pos() < extensionsEnd }, func(d *decode.D) {
+				esize := d.FieldU32("esize")
+				d.FieldU32("ecode")
+				if esize < 8 {
+					d.Fatalf("esize %d too small", esize)
+				}
+				d.FieldRawLen("data", int64(esize-8)*8)
+			})
+		}
+	}
+
+	if !singleFile {
+		return nil
+	}
+
+	if voxOffset*8 < d.Pos() || voxOffset*8 > d.Len() {
+		d.Fatalf("invalid vox_offset %d", voxOffset)
+	}
+	if voxOffset*8 > d.Pos() {
+		d.FieldRawLen("padding", voxOffset*8-d.Pos())
+	}
+	d.FieldRawLen("data", d.BitsLeft())
+
+	return nil
+}
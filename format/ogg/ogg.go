@@ -17,6 +17,9 @@ var vorbisPacketFormat decode.Group
 var opusPacketFormat decode.Group
 var flacMetadatablockFormat decode.Group
 var flacFrameFormat decode.Group
+var theoraPacketFormat decode.Group
+var speexPacketFormat decode.Group
+var vorbisCommentFormat decode.Group
 
 func init() {
 	registry.MustRegister(decode.Format{
@@ -30,6 +33,9 @@ func init() {
 			{Names: []string{format.OPUS_PACKET}, Group: &opusPacketFormat},
 			{Names: []string{format.FLAC_METADATABLOCK}, Group: &flacMetadatablockFormat},
 			{Names: []string{format.FLAC_FRAME}, Group: &flacFrameFormat},
+			{Names: []string{format.THEORA_PACKET}, Group: &theoraPacketFormat},
+			{Names: []string{format.SPEEX_PACKET}, Group: &speexPacketFormat},
+			{Names: []string{format.VORBIS_COMMENT}, Group: &vorbisCommentFormat},
 		},
 	})
 }
@@ -38,6 +44,8 @@ var (
 	vorbisIdentification = []byte("\x01vorbis")
 	opusIdentification   = []byte("OpusHead")
 	flacIdentification   = []byte("\x7fFLAC")
+	theoraIdentification = []byte("\x80theora")
+	speexIdentification  = []byte("Speex   ")
 )
 
 type streamCodec int
@@ -47,6 +55,8 @@ const (
 	codecVorbis
 	codecOpus
 	codecFlac
+	codecTheora
+	codecSpeex
 )
 
 type stream struct {
@@ -55,6 +65,7 @@ type stream struct {
 	packetD        *decode.D
 	codec          streamCodec
 	flacStreamInfo format.FlacStreamInfo
+	packetCount    int
 }
 
 func decodeOgg(d *decode.D, in interface{}) interface{} {
@@ -114,6 +125,10 @@ func decodeOgg(d *decode.D, in interface{}) interface{} {
 							s.codec = codecOpus
 						} else if b, err := bb.PeekBytes(len(flacIdentification)); err == nil && bytes.Equal(b, flacIdentification) {
 							s.codec = codecFlac
+						} else if b, err := bb.PeekBytes(len(theoraIdentification)); err == nil && bytes.Equal(b, theoraIdentification) {
+							s.codec = codecTheora
+						} else if b, err := bb.PeekBytes(len(speexIdentification)); err == nil && bytes.Equal(b, speexIdentification) {
+							s.codec = codecSpeex
 						}
 					}
 
@@ -153,15 +168,35 @@ func decodeOgg(d *decode.D, in interface{}) interface{} {
 								s.flacStreamInfo = flacMetadatablockOut.StreamInfo
 							})
 						case firstByte == 0xff:
-							s.packetD.FieldFormatBitBuf("packet", bb, flacFrameFormat, nil)
+							// stream info from mapping header is needed for frames that refer to it
+							if _, _, err := s.packetD.TryFieldFormatBitBuf("packet", bb, flacFrameFormat, format.FlacFrameIn{StreamInfo: s.flacStreamInfo}); err != nil {
+								s.packetD.FieldRootBitBuf("packet", bb)
+							}
 						default:
-							s.packetD.FieldFormatBitBuf("packet", bb, flacMetadatablockFormat, nil)
+							if _, _, err := s.packetD.TryFieldFormatBitBuf("packet", bb, flacMetadatablockFormat, nil); err != nil {
+								s.packetD.FieldRootBitBuf("packet", bb)
+							}
+						}
+					case codecTheora:
+						// TODO: err
+						if _, _, err := s.packetD.TryFieldFormatBitBuf("packet", bb, theoraPacketFormat, nil); err != nil {
+							s.packetD.FieldRootBitBuf("packet", bb)
+						}
+					case codecSpeex:
+						// second packet is a vorbis comment without framing bit
+						packetFormat := speexPacketFormat
+						if s.packetCount == 1 {
+							packetFormat = vorbisCommentFormat
+						}
+						if _, _, err := s.packetD.TryFieldFormatBitBuf("packet", bb, packetFormat, nil); err != nil {
+							s.packetD.FieldRootBitBuf("packet", bb)
 						}
 					case codecUnknown:
 						s.packetD.FieldRootBitBuf("packet", bb)
 					}
 
 					s.packetBuf = nil
+					s.packetCount++
 				}
 			}
 
@@ -0,0 +1,87 @@
+#!/usr/bin/env python3
+# generates theora.ogg and speex.ogg with header packets and a data packet
+import struct
+
+
+def crc32(data):
+    crc = 0
+    for b in data:
+        crc ^= b << 24
+        for _ in range(8):
+            crc = (crc << 1) ^ 0x04c11db7 if crc & 0x80000000 else crc << 1
+            crc &= 0xffffffff
+    return crc
+
+
+def page(serial, seq, packet, first=False, last=False, granule=0):
+    segments = [255] * (len(packet) // 255) + [len(packet) % 255]
+    flags = (2 if first else 0) | (4 if last else 0)
+    header = b"OggS" + struct.pack("<BBqIIIB", 0, flags, granule, serial, seq, 0, len(segments)) + bytes(segments)
+    p = bytearray(header + packet)
+    p[22:26] = struct.pack("<I", crc32(p))
+    return bytes(p)
+
+
+def ogg(serial, packets):
+    return b"".join(
+        page(serial, i, p, first=i == 0, last=i == len(packets) - 1)
+        for i, p in enumerate(packets)
+    )
+
+
+def vorbis_comment(vendor, comments):
+    out = struct.pack("<I", len(vendor)) + vendor + struct.pack("<I", len(comments))
+    for c in comments:
+        out += struct.pack("<I", len(c)) + c
+    return out
+
+
+def theora_identification():
+    out = b"\x80theora" + bytes([3, 2, 1])
+    out += struct.pack(">HH", 2, 1)  # frame width/height in macroblocks
+    out += (32).to_bytes(3, "big") + (16).to_bytes(3, "big") + bytes([0, 0])
+    out += struct.pack(">II", 25, 1)  # frame rate
+    out += (1).to_bytes(3, "big") + (1).to_bytes(3, "big")
+    out += bytes([2])  # colorspace rec_470bg
+    out += (128000).to_bytes(3, "big")
+    # quality 6 bits, keyframe granule shift 5 bits, pixel format 2 bits, reserved 3 bits
+    out += struct.pack(">H", 48 << 10 | 6 << 5 | 0 << 3)
+    return out
+
+
+theora = [
+    theora_identification(),
+    b"\x81theora" + vorbis_comment(b"fq gen.py", [b"TITLE=test"]),
+    b"\x82theora" + b"\x00\x01\x02\x03",
+    b"\x40\x01\x02\x03",
+]
+open("theora.ogg", "wb").write(ogg(1, theora))
+
+
+def speex_header():
+    out = b"Speex   " + b"1.2.0".ljust(20, b"\0")
+    out += struct.pack(
+        "<13i",
+        1,  # version id
+        80,  # header size
+        16000,  # rate
+        1,  # mode wideband
+        4,  # mode bitstream version
+        1,  # channels
+        -1,  # bitrate
+        320,  # frame size
+        0,  # vbr
+        1,  # frames per packet
+        0,  # extra headers
+        0,  # reserved1
+        0,  # reserved2
+    )
+    return out
+
+
+speex = [
+    speex_header(),
+    vorbis_comment(b"fq gen.py", [b"TITLE=test"]),
+    b"\x01\x02\x03\x04",
+]
+open("speex.ogg", "wb").write(ogg(2, speex))
@@ -0,0 +1,298 @@
+package opentype
+
+// https://adobe-type-tools.github.io/font-tech-notes/pdfs/5176.CFF.pdf
+// TODO: charset, encoding and FDSelect
+// TODO: Type 2 charstrings
+// TODO: CFF2
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+// two byte operators are escape byte 12 followed by a second byte
+const dictEscape = 12
+
+func twoByteOp(b uint64) uint64 { return dictEscape<<8 | b }
+
+const (
+	opCharset     = 15
+	opEncoding    = 16
+	opCharStrings = 17
+	opPrivate     = 18
+	opSubrs       = 19
+)
+
+var topDictOpNames = scalar.UToSymStr{
+	0:             "version",
+	1:             "Notice",
+	2:             "FullName",
+	3:             "FamilyName",
+	4:             "Weight",
+	5:             "FontBBox",
+	13:            "UniqueID",
+	14:            "XUID",
+	opCharset:     "charset",
+	opEncoding:    "Encoding",
+	opCharStrings: "CharStrings",
+	opPrivate:     "Private",
+	twoByteOp(0):  "Copyright",
+	twoByteOp(1):  "isFixedPitch",
+	twoByteOp(2):  "ItalicAngle",
+	twoByteOp(3):  "UnderlinePosition",
+	twoByteOp(4):  "UnderlineThickness",
+	twoByteOp(5):  "PaintType",
+	twoByteOp(6):  "CharstringType",
+	twoByteOp(7):  "FontMatrix",
+	twoByteOp(8):  "StrokeWidth",
+	twoByteOp(20): "SyntheticBase",
+	twoByteOp(21): "PostScript",
+	twoByteOp(22): "BaseFontName",
+	twoByteOp(23): "BaseFontBlend",
+	twoByteOp(30): "ROS",
+	twoByteOp(31): "CIDFontVersion",
+	twoByteOp(32): "CIDFontRevision",
+	twoByteOp(33): "CIDFontType",
+	twoByteOp(34): "CIDCount",
+	twoByteOp(35): "UIDBase",
+	twoByteOp(36): "FDArray",
+	twoByteOp(37): "FDSelect",
+	twoByteOp(38): "FontName",
+}
+
+var privateDictOpNames = scalar.UToSymStr{
+	6:             "BlueValues",
+	7:             "OtherBlues",
+	8:             "FamilyBlues",
+	9:             "FamilyOtherBlues",
+	10:            "StdHW",
+	11:            "StdVW",
+	opSubrs:       "Subrs",
+	20:            "defaultWidthX",
+	21:            "nominalWidthX",
+	twoByteOp(9):  "BlueScale",
+	twoByteOp(10): "BlueShift",
+	twoByteOp(11): "BlueFuzz",
+	twoByteOp(12): "StemSnapH",
+	twoByteOp(13): "StemSnapV",
+	twoByteOp(14): "ForceBold",
+	twoByteOp(17): "LanguageGroup",
+	twoByteOp(18): "ExpansionFactor",
+	twoByteOp(19): "initialRandomSeed",
+}
+
+// operators with string id operands
+var sidOps = map[uint64]bool{
+	0:             true,
+	1:             true,
+	2:             true,
+	3:             true,
+	4:             true,
+	twoByteOp(0):  true,
+	twoByteOp(21): true,
+	twoByteOp(22): true,
+	twoByteOp(38): true,
+}
+
+// first string index sid, lower sids are standard strings
+const nStdStrings = 391
+
+func decodeIndex(d *decode.D, name string, fn func(d *decode.D)) [][2]int64 {
+	var objects [][2]int64
+	d.FieldStruct(name, func(d *decode.D) {
+		count := d.FieldU16("count")
+		if count == 0 {
+			return
+		}
+		offSize := d.FieldU8("off_size", d.AssertU(1, 2, 3, 4))
+		var offsets []uint64
+		d.FieldArray("offsets", func(d *decode.D) {
+			for i := uint64(0); i < count+1; i++ {
+				offsets = append(offsets, d.FieldU("offset", int(offSize)*8))
+			}
+		})
+		// offsets are relative to the byte preceding the object data
+		dataStart := d.Pos() - 8
+		d.FieldArray("data", func(d *decode.D) {
+			for i := uint64(0); i < count; i++ {
+				if offsets[i+1] < offsets[i] {
+					d.Fatalf("object %d has negative length", i)
+				}
+				start := dataStart + int64(offsets[i])*8
+				length := int64(offsets[i+1]-offsets[i]) * 8
+				objects = append(objects, [2]int64{start, length})
+				d.SeekAbs(start)
+				d.LenFn(length, fn)
+			}
+		})
+		d.SeekAbs(dataStart + int64(offsets[count])*8)
+	})
+	return objects
+}
+
+func decodeReal(d *decode.D) float64 {
+	d.U8()
+	var sb strings.Builder
+	for done := false; !done; {
+		b := d.U8()
+		for _, n := range []uint64{b >> 4, b & 0xf} {
+			switch {
+			case n <= 9:
+				sb.WriteByte(byte('0' + n))
+			case n == 0xa:
+				sb.WriteByte('.')
+			case n == 0xb:
+				sb.WriteString("E")
+			case n == 0xc:
+				sb.WriteString("E-")
+			case n == 0xe:
+				sb.WriteByte('-')
+			case n == 0xf:
+				done = true
+			default:
+				d.Fatalf("invalid real nibble %x", n)
+			}
+			if done {
+				break
+			}
+		}
+	}
+	f, err := strconv.ParseFloat(sb.String(), 64)
+	if err != nil {
+		d.Fatalf("invalid real %q", sb.String())
+	}
+	return f
+}
+
+func decodeOperand(d *decode.D) float64 {
+	b0 := d.PeekBits(8)
+	switch {
+	case b0 == 28:
+		return float64(d.FieldSFn("operand", func(d *decode.D) int64 { d.U8(); return d.S16() }))
+	case b0 == 29:
+		return float64(d.FieldSFn("operand", func(d *decode.D) int64 { d.U8(); return d.S32() }))
+	case b0 == 30:
+		return d.FieldFFn("operand", decodeReal)
+	case b0 >= 32 && b0 <= 246:
+		return float64(d.FieldSFn("operand", func(d *decode.D) int64 { return int64(d.U8()) - 139 }))
+	case b0 >= 247 && b0 <= 250:
+		return float64(d.FieldSFn("operand", func(d *decode.D) int64 { return int64(d.U8()-247)*256 + int64(d.U8()) + 108 }))
+	case b0 >= 251 && b0 <= 254:
+		return float64(d.FieldSFn("operand", func(d *decode.D) int64 { return -int64(d.U8()-251)*256 - int64(d.U8()) - 108 }))
+	default:
+		d.Fatalf("invalid operand byte %d", b0)
+		return 0
+	}
+}
+
+// returns operands by operator
+func decodeDict(d *decode.D, opNames scalar.UToSymStr, stringIndex []string) map[uint64][]float64 {
+	ops := map[uint64][]float64{}
+	for d.NotEnd() {
+		d.FieldStruct("entry", func(d *decode.D) {
+			var operands []float64
+			d.FieldArray("operands", func(d *decode.D) {
+				for d.NotEnd() && d.PeekBits(8) > 21 {
+					operands = append(operands, decodeOperand(d))
+				}
+			})
+			if d.End() {
+				d.Fatalf("operands without operator")
+			}
+			var op uint64
+			if d.PeekBits(8) == dictEscape {
+				op = d.FieldU16("operator", opNames)
+			} else {
+				op = d.FieldU8("operator", opNames)
+			}
+			ops[op] = operands
+
+			if sidOps[op] && len(operands) == 1 {
+				sid := int(operands[0])
+				if sid >= nStdStrings && sid-nStdStrings < len(stringIndex) {
+					d.FieldValueStr("string", stringIndex[sid-nStdStrings])
+				}
+			}
+		})
+	}
+	return ops
+}
+
+func decodeCFF(d *decode.D) {
+	cffStart := d.Pos()
+
+	var hdrSize uint64
+	d.FieldStruct("header", func(d *decode.D) {
+		d.FieldU8("major", d.AssertU(1))
+		d.FieldU8("minor")
+		hdrSize = d.FieldU8("hdr_size")
+		d.FieldU8("off_size")
+		if hdrSize < 4 {
+			d.Fatalf("hdr_size %d too small", hdrSize)
+		}
+		if hdrSize > 4 {
+			d.FieldRawLen("unknown", int64(hdrSize-4)*8)
+		}
+	})
+
+	decodeIndex(d, "name_index", func(d *decode.D) { d.FieldUTF8("name", int(d.BitsLeft()/8)) })
+	topDicts := decodeIndex(d, "top_dict_index", func(d *decode.D) { d.FieldRawLen("top_dict", d.BitsLeft()) })
+	var stringIndex []string
+	decodeIndex(d, "string_index", func(d *decode.D) {
+		stringIndex = append(stringIndex, d.FieldUTF8("string", int(d.BitsLeft()/8)))
+	})
+	decodeIndex(d, "global_subr_index", func(d *decode.D) { d.FieldRawLen("subr", d.BitsLeft()) })
+
+	// opentype CFF table has exactly one font
+	if len(topDicts) == 0 {
+		d.Fatalf("no top dict found")
+	}
+	var topOps map[uint64][]float64
+	d.RangeFn(topDicts[0][0], topDicts[0][1], func(d *decode.D) {
+		d.FieldArray("top_dict", func(d *decode.D) {
+			topOps = decodeDict(d, topDictOpNames, stringIndex)
+		})
+	})
+
+	// offsets are relative to start of CFF data
+	offsetPos := func(offset float64) int64 {
+		pos := cffStart + int64(offset)*8
+		if offset < 0 || pos >= d.Len() {
+			d.Fatalf("offset %v outside of CFF data", offset)
+		}
+		return pos
+	}
+
+	if operands := topOps[opCharStrings]; len(operands) == 1 {
+		charStringsStart := offsetPos(operands[0])
+		d.RangeFn(charStringsStart, d.Len()-charStringsStart, func(d *decode.D) {
+			decodeIndex(d, "char_strings_index", func(d *decode.D) { d.FieldRawLen("char_string", d.BitsLeft()) })
+		})
+	}
+	if operands := topOps[opPrivate]; len(operands) == 2 {
+		privateStart := offsetPos(operands[1])
+		privateLen := int64(operands[0]) * 8
+		if privateLen < 0 || privateStart+privateLen > d.Len() {
+			d.Fatalf("private dict outside of CFF data")
+		}
+		var privateOps map[uint64][]float64
+		d.RangeFn(privateStart, privateLen, func(d *decode.D) {
+			d.FieldArray("private_dict", func(d *decode.D) {
+				privateOps = decodeDict(d, privateDictOpNames, stringIndex)
+			})
+		})
+		// local subrs offset is relative to private dict
+		if operands := privateOps[opSubrs]; len(operands) == 1 {
+			subrsStart := privateStart + int64(operands[0])*8
+			if operands[0] < 0 || subrsStart >= d.Len() {
+				d.Fatalf("local subrs outside of CFF data")
+			}
+			d.RangeFn(subrsStart, d.Len()-subrsStart, func(d *decode.D) {
+				decodeIndex(d, "local_subr_index", func(d *decode.D) { d.FieldRawLen("subr", d.BitsLeft()) })
+			})
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package opentype
+
+// https://docs.microsoft.com/en-us/typography/opentype/spec/otff
+// TODO: more tables, glyf, cmap, name etc
+// TODO: font collections (ttcf)
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.OPENTYPE,
+		Description: "OpenType font",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    opentypeDecode,
+	})
+}
+
+const (
+	sfntVersionTrueType    = 0x00010000
+	sfntVersionCFF         = 0x4f54544f // "OTTO"
+	sfntVersionAppleTrue   = 0x74727565 // "true"
+	sfntVersionPostScript1 = 0x74797031 // "typ1"
+)
+
+var sfntVersionNames = scalar.UToSymStr{
+	sfntVersionTrueType:    "truetype",
+	sfntVersionCFF:         "cff",
+	sfntVersionAppleTrue:   "apple_truetype",
+	sfntVersionPostScript1: "postscript",
+}
+
+func opentypeDecode(d *decode.D, in interface{}) interface{} {
+	d.FieldU32("sfnt_version", d.AssertU(sfntVersionTrueType, sfntVersionCFF, sfntVersionAppleTrue, sfntVersionPostScript1), sfntVersionNames, scalar.Hex)
+	numTables := d.FieldU16("num_tables")
+	d.FieldU16("search_range")
+	d.FieldU16("entry_selector")
+	d.FieldU16("range_shift")
+
+	d.FieldArray("tables", func(d *decode.D) {
+		for i := uint64(0); i < numTables; i++ {
+			d.FieldStruct("table", func(d *decode.D) {
+				tag := d.FieldUTF8("tag", 4)
+				d.FieldU32("checksum", scalar.Hex)
+				offset := d.FieldU32("offset")
+				length := d.FieldU32("length")
+
+				if int64(offset+length)*8 > d.Len() {
+					d.Fatalf("table %q outside of file", tag)
+				}
+				d.RangeFn(int64(offset)*8, int64(length)*8, func(d *decode.D) {
+					switch tag {
+					case "CFF ":
+						decodeCFF(d)
+					default:
+						d.FieldRawLen("data", d.BitsLeft())
+					}
+				})
+			})
+		}
+	})
+
+	return nil
+}
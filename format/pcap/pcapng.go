@@ -203,7 +203,7 @@ func decoodeOptions(d *decode.D, opts scalar.UToScalar) {
 				return
 			}
 			d.FieldUTF8NullFixedLen("value", int(length))
-			d.FieldRawLen("padding", int64(d.AlignBits(32)))
+			d.FieldPadding("padding", 32)
 		})
 	}
 }
@@ -253,7 +253,7 @@ var blockFns = map[uint64]func(d *decode.D, dc *decodeContext){
 			d.FieldRawLen("packet", int64(capturedLength)*8)
 		}
 
-		d.FieldRawLen("padding", int64(d.AlignBits(32)))
+		d.FieldPadding("padding", 32)
 		d.FieldArray("options", func(d *decode.D) { decoodeOptions(d, enhancedPacketOptionsMap) })
 	},
 	blockTypeNameResolution: func(d *decode.D, _ *decodeContext) {
@@ -280,7 +280,7 @@ var blockFns = map[uint64]func(d *decode.D, dc *decodeContext){
 							d.FieldUTF8NullFixedLen("value", int(d.BitsLeft()/8))
 						}
 					})
-					d.FieldRawLen("padding", int64(d.AlignBits(32)))
+					d.FieldPadding("padding", 32)
 				})
 			}
 		})
@@ -290,7 +290,7 @@ var blockFns = map[uint64]func(d *decode.D, dc *decodeContext){
 		d.FieldU32("interface_id")
 		d.FieldU32("timestamp_high")
 		d.FieldU32("timestamp_low")
-		d.FieldRawLen("padding", int64(d.AlignBits(32)))
+		d.FieldPadding("padding", 32)
 		d.FieldArray("options", func(d *decode.D) { decoodeOptions(d, interfaceStatisticsOptionsMap) })
 	},
 }
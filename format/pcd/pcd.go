@@ -0,0 +1,321 @@
+package pcd
+
+// https://pointclouds.org/documentation/tutorials/pcd_file_format.html
+// TODO: binary_compressed LZF decompression
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.PCD,
+		Description: "Point Cloud Library point cloud data",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    pcdDecode,
+	})
+}
+
+const (
+	dataASCII            = "ascii"
+	dataBinary           = "binary"
+	dataBinaryCompressed = "binary_compressed"
+)
+
+var typeNames = map[string]string{
+	"F": "float",
+	"I": "signed",
+	"U": "unsigned",
+}
+
+// longest header line to look for, header lines are short but FIELDS etc can have many entries
+const maxLineLen = 64 * 1024
+
+const maxTokenLen = 256
+
+type field struct {
+	name  string
+	size  int
+	typ   string
+	count int
+}
+
+type header struct {
+	version   string
+	fields    []field
+	width     uint64
+	height    uint64
+	viewpoint string
+	points    uint64
+	hasPoints bool
+	data      string
+}
+
+func isSpace(b byte) bool { return b == ' ' || b == '\t' || b == '\r' || b == '\n' }
+
+func parseUints(d *decode.D, keyword string, ss []string) []int {
+	var ns []int
+	for _, s := range ss {
+		n, err := strconv.ParseUint(s, 10, 31)
+		if err != nil {
+			d.Fatalf("%s: invalid value %q", keyword, s)
+		}
+		ns = append(ns, int(n))
+	}
+	return ns
+}
+
+func decodeHeaderLine(d *decode.D, h *header) {
+	lineLen := d.PeekFindByte('\n', maxLineLen) + 1
+	line := d.PeekBytes(int(lineLen))
+	if line[len(line)-1] != '\n' {
+		d.Fatalf("header line not found")
+	}
+
+	keywordLen := 0
+	if line[0] == '#' {
+		keywordLen = 1
+	} else {
+		for keywordLen < len(line) && !isSpace(line[keywordLen]) {
+			keywordLen++
+		}
+	}
+	keyword := d.FieldUTF8("keyword", keywordLen)
+	value := d.FieldUTF8("value", int(lineLen)-keywordLen, scalar.TrimSpace)
+	values := strings.Fields(value)
+
+	switch keyword {
+	case "#":
+	case "VERSION":
+		h.version = value
+	case "FIELDS":
+		h.fields = make([]field, len(values))
+		for i, v := range values {
+			h.fields[i] = field{name: v, count: 1}
+		}
+	case "SIZE", "TYPE", "COUNT":
+		if len(values) != len(h.fields) {
+			d.Fatalf("%s has %d entries, expected %d fields", keyword, len(values), len(h.fields))
+		}
+		switch keyword {
+		case "SIZE":
+			for i, n := range parseUints(d, keyword, values) {
+				h.fields[i].size = n
+			}
+		case "TYPE":
+			for i, v := range values {
+				if _, ok := typeNames[v]; !ok {
+					d.Fatalf("TYPE: unknown type %q", v)
+				}
+				h.fields[i].typ = v
+			}
+		case "COUNT":
+			for i, n := range parseUints(d, keyword, values) {
+				h.fields[i].count = n
+			}
+		}
+	case "WIDTH", "HEIGHT", "POINTS":
+		if len(values) != 1 {
+			d.Fatalf("%s: expected one value", keyword)
+		}
+		n, err := strconv.ParseUint(values[0], 10, 64)
+		if err != nil {
+			d.Fatalf("%s: invalid value %q", keyword, values[0])
+		}
+		switch keyword {
+		case "WIDTH":
+			h.width = n
+		case "HEIGHT":
+			h.height = n
+		case "POINTS":
+			h.points = n
+			h.hasPoints = true
+		}
+	case "VIEWPOINT":
+		h.viewpoint = value
+	case "DATA":
+		switch value {
+		case dataASCII, dataBinary, dataBinaryCompressed:
+		default:
+			d.Fatalf("DATA: unknown data type %q", value)
+		}
+		h.data = value
+	default:
+		d.Fatalf("unknown header keyword %q", keyword)
+	}
+}
+
+func decodeHeader(d *decode.D) header {
+	var h header
+	d.FieldArray("header", func(d *decode.D) {
+		for h.data == "" {
+			d.FieldStruct("line", func(d *decode.D) { decodeHeaderLine(d, &h) })
+		}
+	})
+
+	if h.version == "" {
+		d.Fatalf("no VERSION found")
+	}
+	if len(h.fields) == 0 {
+		d.Fatalf("no FIELDS found")
+	}
+	for _, f := range h.fields {
+		if f.size == 0 || f.typ == "" {
+			d.Fatalf("field %q has no SIZE or TYPE", f.name)
+		}
+	}
+	if !h.hasPoints {
+		h.points = h.width * h.height
+	}
+
+	return h
+}
+
+// numeric value of ascii field, keep as string if it can't be parsed
+func asciiNumber(f field) scalar.Mapper {
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		str := s.ActualStr()
+		switch f.typ {
+		case "F":
+			if v, err := strconv.ParseFloat(str, 64); err == nil {
+				s.Sym = v
+			}
+		case "I":
+			if v, err := strconv.ParseInt(str, 10, 64); err == nil {
+				s.Sym = v
+			}
+		case "U":
+			if v, err := strconv.ParseUint(str, 10, 64); err == nil {
+				s.Sym = v
+			}
+		}
+		return s, nil
+	})
+}
+
+func decodeASCIIValue(d *decode.D, name string, f field) {
+	// token including surrounding whitespace, tokens are short so only peek a bit ahead
+	peekLen := d.BitsLeft() / 8
+	if peekLen > maxTokenLen {
+		peekLen = maxTokenLen
+	}
+	buf := d.PeekBytes(int(peekLen))
+	n := 0
+	for n < len(buf) && isSpace(buf[n]) {
+		n++
+	}
+	tokenStart := n
+	for n < len(buf) && !isSpace(buf[n]) {
+		n++
+	}
+	if n == tokenStart {
+		d.Fatalf("unexpected end of ascii data")
+	}
+	for n < len(buf) && isSpace(buf[n]) {
+		n++
+	}
+	d.FieldUTF8(name, n, scalar.TrimSpace, asciiNumber(f))
+}
+
+func decodeBinaryValue(d *decode.D, name string, f field) {
+	nBits := f.size * 8
+	switch {
+	case f.typ == "F" && (f.size == 4 || f.size == 8):
+		d.FieldF(name, nBits)
+	case f.typ == "I" && f.size <= 8:
+		d.FieldS(name, nBits)
+	case f.typ == "U" && f.size <= 8:
+		d.FieldU(name, nBits)
+	default:
+		d.FieldRawLen(name, int64(nBits))
+	}
+}
+
+func decodePoint(d *decode.D, fields []field, valueFn func(d *decode.D, name string, f field)) {
+	for _, f := range fields {
+		f := f
+		if f.count == 1 {
+			valueFn(d, f.name, f)
+			continue
+		}
+		d.FieldArray(f.name, func(d *decode.D) {
+			for i := 0; i < f.count; i++ {
+				valueFn(d, "value", f)
+			}
+		})
+	}
+}
+
+func pcdDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	// comments can only be followed by VERSION
+	if !d.TryHasBytes([]byte("#")) && !d.TryHasBytes([]byte("VERSION")) {
+		d.Fatalf("no header found")
+	}
+	h := decodeHeader(d)
+
+	pointSize := 0
+	d.FieldValueStr("version", h.version)
+	d.FieldArray("fields", func(d *decode.D) {
+		for _, f := range h.fields {
+			f := f
+			d.FieldStruct("field", func(d *decode.D) {
+				d.FieldValueStr("name", f.name)
+				d.FieldValueU("size", uint64(f.size))
+				d.FieldValueStr("type", f.typ, scalar.Description(typeNames[f.typ]))
+				d.FieldValueU("count", uint64(f.count))
+				d.FieldValueU("offset", uint64(pointSize))
+			})
+			pointSize += f.size * f.count
+		}
+	})
+	d.FieldValueU("point_size", uint64(pointSize))
+	d.FieldValueU("width", h.width)
+	d.FieldValueU("height", h.height)
+	if h.viewpoint != "" {
+		d.FieldValueStr("viewpoint", h.viewpoint)
+	}
+	d.FieldValueU("points", h.points)
+	d.FieldValueStr("data", h.data)
+
+	switch h.data {
+	case dataASCII:
+		d.FieldArray("point_data", func(d *decode.D) {
+			for i := uint64(0); i < h.points; i++ {
+				d.FieldStruct("point", func(d *decode.D) {
+					decodePoint(d, h.fields, decodeASCIIValue)
+				})
+			}
+		})
+	case dataBinary:
+		dataLen := int64(h.points) * int64(pointSize) * 8
+		if dataLen > d.BitsLeft() {
+			d.Fatalf("point data length %d bits larger than %d bits left", dataLen, d.BitsLeft())
+		}
+		d.FieldArray("point_data", func(d *decode.D) {
+			for i := uint64(0); i < h.points; i++ {
+				d.FieldStruct("point", func(d *decode.D) {
+					decodePoint(d, h.fields, decodeBinaryValue)
+				})
+			}
+		})
+	case dataBinaryCompressed:
+		// compressed data is LZF compressed field planes, each field for all points after each other
+		compressedSize := d.FieldU32("compressed_size")
+		d.FieldU32("uncompressed_size")
+		d.FieldRawLen("compressed_data", int64(compressedSize)*8)
+	}
+
+	if d.NotEnd() {
+		d.FieldRawLen("unknown", d.BitsLeft())
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+package pcf
+
+// https://fontforge.org/docs/techref/pcf-format.html
+// TODO: accelerators, bitmaps, encodings, swidths and glyph names tables
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.PCF,
+		Description: "X11 Portable Compiled Format bitmap font",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    pcfDecode,
+	})
+}
+
+const (
+	tableProperties      = 1 << 0
+	tableAccelerators    = 1 << 1
+	tableMetrics         = 1 << 2
+	tableBitmaps         = 1 << 3
+	tableInkMetrics      = 1 << 4
+	tableBDFEncodings    = 1 << 5
+	tableSWidths         = 1 << 6
+	tableGlyphNames      = 1 << 7
+	tableBDFAccelerators = 1 << 8
+)
+
+var tableTypeNames = scalar.UToSymStr{
+	tableProperties:      "properties",
+	tableAccelerators:    "accelerators",
+	tableMetrics:         "metrics",
+	tableBitmaps:         "bitmaps",
+	tableInkMetrics:      "ink_metrics",
+	tableBDFEncodings:    "bdf_encodings",
+	tableSWidths:         "swidths",
+	tableGlyphNames:      "glyph_names",
+	tableBDFAccelerators: "bdf_accelerators",
+}
+
+// format word bits 8 and up, meaning of 1 depends on table type
+const (
+	formatDefault           = 0
+	formatCompressedMetrics = 1 // also accel_w_inkbounds
+	formatInkBounds         = 2
+)
+
+type tableFormat struct {
+	typ    uint64
+	endian decode.Endian
+}
+
+// format word is always little endian, byte order for rest of table is in the format word
+func decodeFormat(d *decode.D, tableType uint64) tableFormat {
+	var f tableFormat
+	formatNames := scalar.UToSymStr{
+		formatDefault:           "default",
+		formatCompressedMetrics: "compressed_metrics",
+		formatInkBounds:         "inkbounds",
+	}
+	if tableType == tableAccelerators || tableType == tableBDFAccelerators {
+		formatNames[formatCompressedMetrics] = "accel_w_inkbounds"
+	}
+
+	d.FieldStruct("format", func(d *decode.D) {
+		d.FieldU2("unused")
+		d.FieldU2("scan_unit", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+			s.Sym = uint64(1) << s.ActualU()
+			return s, nil
+		}))
+		d.FieldBool("bit_order", scalar.BoolToSymStr{true: "msb", false: "lsb"})
+		msbByteOrder := d.FieldBool("byte_order", scalar.BoolToSymStr{true: "msb", false: "lsb"})
+		d.FieldU2("glyph_pad", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+			s.Sym = uint64(1) << s.ActualU()
+			return s, nil
+		}))
+		f.typ = d.FieldU24LE("type", formatNames)
+		f.endian = decode.LittleEndian
+		if msbByteOrder {
+			f.endian = decode.BigEndian
+		}
+	})
+
+	return f
+}
+
+func decodeProperties(d *decode.D) {
+	nProps := d.FieldU32("nprops")
+	propsLen := int64(nProps) * 9
+	// props are padded to 4 byte boundary
+	padLen := (4 - propsLen%4) % 4
+
+	// string table is after props so read it first to be able to resolve names
+	stringsStart := d.Pos() + (propsLen+padLen+4)*8
+	if stringsStart > d.Len() {
+		d.Fatalf("nprops %d too large", nProps)
+	}
+	d.SeekAbs(stringsStart - 32)
+	stringSize := int(d.U32())
+	if stringsStart+int64(stringSize)*8 > d.Len() {
+		d.Fatalf("string_size %d too large", stringSize)
+	}
+	stringTable := d.BytesRange(stringsStart, stringSize)
+	d.SeekAbs(stringsStart - (propsLen+padLen+4)*8)
+	stringAt := func(offset uint64) (string, bool) {
+		if offset >= uint64(len(stringTable)) {
+			return "", false
+		}
+		end := offset
+		for end < uint64(len(stringTable)) && stringTable[end] != 0 {
+			end++
+		}
+		return string(stringTable[offset:end]), true
+	}
+
+	d.FieldArray("props", func(d *decode.D) {
+		for i := uint64(0); i < nProps; i++ {
+			d.FieldStruct("prop", func(d *decode.D) {
+				nameOffset := d.FieldU32("name_offset")
+				if s, ok := stringAt(nameOffset); ok {
+					d.FieldValueStr("name", s)
+				}
+				isString := d.FieldU8("is_string_prop") != 0
+				if isString {
+					valueOffset := d.FieldU32("value_offset")
+					if s, ok := stringAt(valueOffset); ok {
+						d.FieldValueStr("value", s)
+					}
+				} else {
+					d.FieldS32("value")
+				}
+			})
+		}
+	})
+	if padLen > 0 {
+		d.FieldRawLen("padding", padLen*8, d.BitBufIsZero())
+	}
+	d.FieldU32("string_size")
+	d.FieldArray("strings", func(d *decode.D) {
+		for d.Pos() < stringsStart+int64(stringSize)*8 {
+			d.FieldUTF8Null("string")
+		}
+	})
+}
+
+func decodeCompressedMetric(d *decode.D) {
+	// stored as unsigned with 0x80 offset
+	offset := scalar.SAdd(-0x80)
+	d.FieldSFn("left_side_bearing", func(d *decode.D) int64 { return int64(d.U8()) }, offset)
+	d.FieldSFn("right_side_bearing", func(d *decode.D) int64 { return int64(d.U8()) }, offset)
+	d.FieldSFn("character_width", func(d *decode.D) int64 { return int64(d.U8()) }, offset)
+	d.FieldSFn("character_ascent", func(d *decode.D) int64 { return int64(d.U8()) }, offset)
+	d.FieldSFn("character_descent", func(d *decode.D) int64 { return int64(d.U8()) }, offset)
+}
+
+func decodeMetric(d *decode.D) {
+	d.FieldS16("left_side_bearing")
+	d.FieldS16("right_side_bearing")
+	d.FieldS16("character_width")
+	d.FieldS16("character_ascent")
+	d.FieldS16("character_descent")
+	d.FieldU16("character_attributes")
+}
+
+func decodeMetrics(d *decode.D, f tableFormat) {
+	if f.typ == formatCompressedMetrics {
+		count := d.FieldU16("metrics_count")
+		d.FieldArrayCount("metrics", count, func(d *decode.D) { d.FieldStruct("metric", decodeCompressedMetric) })
+		return
+	}
+
+	count := d.FieldU32("metrics_count")
+	d.FieldArrayCount("metrics", count, func(d *decode.D) { d.FieldStruct("metric", decodeMetric) })
+}
+
+func pcfDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldRawMagic("magic", []byte("\x01fcp"))
+	tableCount := d.FieldU32("table_count")
+
+	d.FieldArray("tables", func(d *decode.D) {
+		for i := uint64(0); i < tableCount; i++ {
+			d.FieldStruct("table", func(d *decode.D) {
+				tableType := d.FieldU32("type", tableTypeNames)
+				decodeFormat(d, tableType)
+				size := d.FieldU32("size")
+				offset := d.FieldU32("offset")
+
+				if int64(offset+size)*8 > d.Len() {
+					d.Fatalf("table outside of file")
+				}
+				d.RangeFn(int64(offset)*8, int64(size)*8, func(d *decode.D) {
+					d.FieldStruct("data", func(d *decode.D) {
+						f := decodeFormat(d, tableType)
+						d.Endian = f.endian
+
+						switch tableType {
+						case tableProperties:
+							decodeProperties(d)
+						case tableMetrics, tableInkMetrics:
+							decodeMetrics(d, f)
+						}
+						if d.NotEnd() {
+							d.FieldRawLen("unknown", d.BitsLeft())
+						}
+					})
+				})
+			})
+		}
+	})
+
+	return nil
+}
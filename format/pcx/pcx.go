@@ -0,0 +1,144 @@
+package pcx
+
+// https://web.archive.org/web/20100206055706/http://www.qzx.com/pc-gpe/pcx.txt
+// TODO: CGA palette interpretation
+
+import (
+	"bytes"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.PCX,
+		Description: "ZSoft PC Paintbrush image",
+		DecodeFn:    pcxDecode,
+	})
+}
+
+const (
+	encodingNone = 0
+	encodingRLE  = 1
+)
+
+var versionNames = scalar.UToScalar{
+	0: {Description: "PC Paintbrush 2.5"},
+	2: {Description: "PC Paintbrush 2.8 with palette"},
+	3: {Description: "PC Paintbrush 2.8 without palette"},
+	4: {Description: "PC Paintbrush for Windows"},
+	5: {Description: "PC Paintbrush 3.0 and later"},
+}
+
+var encodingNames = scalar.UToSymStr{
+	encodingNone: "none",
+	encodingRLE:  "rle",
+}
+
+var paletteInfoNames = scalar.UToSymStr{
+	1: "color",
+	2: "grayscale",
+}
+
+const (
+	headerLen     = 128
+	vgaPaletteLen = 1 + 256*3
+	vgaMarker     = 0x0c
+)
+
+// runs are bytes with top two bits set, low 6 bits is count followed by the byte to repeat
+func rleDecode(d *decode.D, b []byte, maxLen int) []byte {
+	out := &bytes.Buffer{}
+	for i := 0; i < len(b) && out.Len() < maxLen; i++ {
+		if b[i]&0xc0 != 0xc0 {
+			out.WriteByte(b[i])
+			continue
+		}
+		if i+1 >= len(b) {
+			d.Errorf("run at end of data")
+			break
+		}
+		out.Write(bytes.Repeat([]byte{b[i+1]}, int(b[i]&0x3f)))
+		i++
+	}
+	return out.Bytes()
+}
+
+func decodePalette(d *decode.D, n int) {
+	for i := 0; i < n; i++ {
+		d.FieldStruct("color", func(d *decode.D) {
+			d.FieldU8("r")
+			d.FieldU8("g")
+			d.FieldU8("b")
+		})
+	}
+}
+
+func pcxDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	var version, encoding, bitsPerPixel, planes, bytesPerLine uint64
+	var xMin, yMin, xMax, yMax uint64
+	d.FieldStruct("header", func(d *decode.D) {
+		d.FieldU8("manufacturer", d.AssertU(0x0a), scalar.Hex)
+		version = d.FieldU8("version", versionNames)
+		encoding = d.FieldU8("encoding", d.AssertU(encodingNone, encodingRLE), encodingNames)
+		bitsPerPixel = d.FieldU8("bits_per_pixel", d.AssertU(1, 2, 4, 8))
+		xMin = d.FieldU16("x_min")
+		yMin = d.FieldU16("y_min")
+		xMax = d.FieldU16("x_max")
+		yMax = d.FieldU16("y_max")
+		d.FieldU16("horizontal_dpi")
+		d.FieldU16("vertical_dpi")
+		d.FieldArray("ega_palette", func(d *decode.D) { decodePalette(d, 16) })
+		d.FieldU8("reserved")
+		planes = d.FieldU8("color_planes")
+		bytesPerLine = d.FieldU16("bytes_per_line")
+		d.FieldU16("palette_info", paletteInfoNames)
+		d.FieldU16("horizontal_screen_size")
+		d.FieldU16("vertical_screen_size")
+		d.FieldRawLen("filler", 54*8, d.BitBufIsZero())
+	})
+	if xMax < xMin || yMax < yMin {
+		d.Fatalf("invalid window")
+	}
+
+	// 256 color palette is appended after image data for version 5 8 bit images
+	dataEnd := d.Len()
+	hasVGAPalette := false
+	if version == 5 && bitsPerPixel == 8 && planes == 1 && d.Len() >= (headerLen+vgaPaletteLen)*8 {
+		paletteStart := d.Len() - vgaPaletteLen*8
+		d.SeekAbs(paletteStart)
+		hasVGAPalette = d.PeekBits(8) == vgaMarker
+		d.SeekAbs(headerLen * 8)
+		if hasVGAPalette {
+			dataEnd = paletteStart
+		}
+	}
+
+	dataLen := dataEnd - d.Pos()
+	uncompressedLen := int((yMax - yMin + 1) * planes * bytesPerLine)
+	switch encoding {
+	case encodingRLE:
+		compressed := d.BytesLen(int(dataLen / 8))
+		d.SeekRel(-dataLen)
+		d.FieldRawLen("compressed", dataLen)
+		uncompressed := rleDecode(d, compressed, uncompressedLen)
+		d.FieldRootBitBuf("uncompressed", bitio.NewBufferFromBytes(uncompressed, -1))
+	default:
+		d.FieldRawLen("image_data", dataLen)
+	}
+
+	if hasVGAPalette {
+		d.FieldStruct("vga_palette", func(d *decode.D) {
+			d.FieldU8("marker", d.AssertU(vgaMarker), scalar.Hex)
+			d.FieldArray("colors", func(d *decode.D) { decodePalette(d, 256) })
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package plist
+
+// https://developer.apple.com/library/archive/documentation/Cocoa/Conceptual/PropertyLists/AboutPropertyLists/AboutPropertyLists.html
+// http://www.apple.com/DTDs/PropertyList-1.0.dtd
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+// TODO: binary plist
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.PLIST,
+		Description: "Apple XML property list",
+		DecodeFn:    decodePlist,
+	})
+}
+
+// nextStart returns next start or end element skipping chardata, comments etc
+func nextStart(xd *xml.Decoder) (xml.Token, error) {
+	for {
+		t, err := xd.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := t.(type) {
+		case xml.StartElement, xml.EndElement:
+			return xml.CopyToken(t), nil
+		}
+	}
+}
+
+func text(xd *xml.Decoder, se xml.StartElement) (string, error) {
+	var s string
+	if err := xd.DecodeElement(&s, &se); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// dict and array values nested deeper than this are an error
+const maxValueDepth = 1000
+
+// decodeValue decodes a plist value to the same kind of go values as encoding/json
+func decodeValue(xd *xml.Decoder, se xml.StartElement, depth int) (interface{}, error) {
+	if depth > maxValueDepth {
+		return nil, fmt.Errorf("%s nested deeper than %d", se.Name.Local, maxValueDepth)
+	}
+
+	switch se.Name.Local {
+	case "dict":
+		m := map[string]interface{}{}
+		for {
+			t, err := nextStart(xd)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := t.(xml.EndElement); ok {
+				return m, nil
+			}
+			kse := t.(xml.StartElement)
+			if kse.Name.Local != "key" {
+				return nil, fmt.Errorf("expected key got %s", kse.Name.Local)
+			}
+			k, err := text(xd, kse)
+			if err != nil {
+				return nil, err
+			}
+			t, err = nextStart(xd)
+			if err != nil {
+				return nil, err
+			}
+			vse, ok := t.(xml.StartElement)
+			if !ok {
+				return nil, fmt.Errorf("%s: missing value", k)
+			}
+			v, err := decodeValue(xd, vse, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+	case "array":
+		a := []interface{}{}
+		for {
+			t, err := nextStart(xd)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := t.(xml.EndElement); ok {
+				return a, nil
+			}
+			v, err := decodeValue(xd, t.(xml.StartElement), depth+1)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+	case "true", "false":
+		if err := xd.Skip(); err != nil {
+			return nil, err
+		}
+		return se.Name.Local == "true", nil
+	case "string", "date":
+		return text(xd, se)
+	case "integer":
+		s, err := text(xd, se)
+		if err != nil {
+			return nil, err
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(s), 0, 64)
+		if err != nil {
+			return nil, err
+		}
+		return int(n), nil
+	case "real":
+		s, err := text(xd, se)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.ParseFloat(strings.TrimSpace(s), 64)
+	case "data":
+		// keep as base64 but without whitespace
+		s, err := text(xd, se)
+		if err != nil {
+			return nil, err
+		}
+		s = strings.Join(strings.Fields(s), "")
+		if _, err := base64.StdEncoding.DecodeString(s); err != nil {
+			return nil, err
+		}
+		return s, nil
+	default:
+		return nil, fmt.Errorf("unknown element %s", se.Name.Local)
+	}
+}
+
+func decodePlist(d *decode.D, in interface{}) interface{} {
+	bb := d.RawLen(d.Len())
+	xd := xml.NewDecoder(bb)
+	// some plists have encoding="UTF-8" but that is also what encoding/xml assumes
+	xd.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) {
+		if !strings.EqualFold(charset, "utf-8") {
+			return nil, fmt.Errorf("unsupported charset %s", charset)
+		}
+		return input, nil
+	}
+
+	t, err := nextStart(xd)
+	if err != nil {
+		d.Fatalf("%s", err)
+	}
+	se, ok := t.(xml.StartElement)
+	if !ok || se.Name.Local != "plist" {
+		d.Fatalf("root element not plist")
+	}
+	t, err = nextStart(xd)
+	if err != nil {
+		d.Fatalf("%s", err)
+	}
+	se, ok = t.(xml.StartElement)
+	if !ok {
+		d.Fatalf("empty plist")
+	}
+
+	var s scalar.S
+	if s.Actual, err = decodeValue(xd, se, 1); err != nil {
+		d.Fatalf("%s", err)
+	}
+	switch s.Actual.(type) {
+	case map[string]interface{},
+		[]interface{}:
+	default:
+		d.Fatalf("root not dict or array")
+	}
+
+	d.Value.V = &s
+	d.Value.Range.Len = d.Len()
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 
 var iccProfileFormat decode.Group
 var exifFormat decode.Group
+var xmpFormat decode.Group
 
 func init() {
 	registry.MustRegister(decode.Format{
@@ -26,6 +27,7 @@ func init() {
 		Dependencies: []decode.Dependency{
 			{Names: []string{format.ICC_PROFILE}, Group: &iccProfileFormat},
 			{Names: []string{format.EXIF}, Group: &exifFormat},
+			{Names: []string{format.XMP}, Group: &xmpFormat},
 		},
 	})
 }
@@ -80,16 +82,16 @@ func pngDecode(d *decode.D, in interface{}) interface{} {
 	iEndFound := false
 	var colorType uint64
 
-	d.FieldRawLen("signature", 8*8, d.AssertBitBuf([]byte("\x89PNG\r\n\x1a\n")))
+	d.FieldRawMagic("signature", []byte("\x89PNG\r\n\x1a\n"))
 	d.FieldStructArrayLoop("chunks", "chunk", func() bool { return d.NotEnd() && !iEndFound }, func(d *decode.D) {
 		chunkLength := d.FieldU32("length")
 		crcStartPos := d.Pos()
 		// TODO: this is a bit weird, use struct?
 		chunkType := d.FieldStrFn("type", func(d *decode.D) string {
 			chunkType := d.UTF8(4)
-			// upper/lower case in chunk type is used to set flags
+			// upper/lower case in chunk type is used to set flags, bit 5 of each byte
 			d.SeekRel(-4 * 8)
-			d.SeekRel(3)
+			d.SeekRel(2)
 			d.FieldBool("ancillary")
 			d.SeekRel(7)
 			d.FieldBool("private")
@@ -97,7 +99,7 @@ func pngDecode(d *decode.D, in interface{}) interface{} {
 			d.FieldBool("reserved")
 			d.SeekRel(7)
 			d.FieldBool("safe_to_copy")
-			d.SeekRel(4)
+			d.SeekRel(5)
 			return chunkType
 		})
 
@@ -137,6 +139,34 @@ func pngDecode(d *decode.D, in interface{}) interface{} {
 				default:
 					d.FieldRawLen("data", dataLen)
 				}
+			case "iTXt":
+				keyword := d.FieldUTF8Null("keyword")
+				compressionFlag := d.FieldU8("compression_flag", scalar.UToSymStr{0: "uncompressed", 1: "compressed"})
+				compressionMethod := d.FieldU8("compression_method", compressionNames)
+				d.FieldUTF8Null("language_tag")
+				d.FieldUTF8Null("translated_keyword")
+				dataLen := d.BitsLeft()
+
+				textFormat := decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+					d.FieldUTF8("text", int(d.BitsLeft()/8))
+					return nil
+				})
+				if keyword == "XML:com.adobe.xmp" {
+					textFormat = xmpFormat
+				}
+
+				switch {
+				case compressionFlag == 0 && keyword == "XML:com.adobe.xmp":
+					d.FieldFormatLen("xmp", dataLen, xmpFormat, nil)
+				case compressionFlag == 0:
+					d.FieldUTF8("text", int(dataLen/8))
+				case compressionMethod == compressionDeflate:
+					d.FieldRawLen("compressed", dataLen)
+					d.SeekRel(-dataLen)
+					d.FieldFormatReaderLen("uncompressed", dataLen, zlib.NewReader, textFormat)
+				default:
+					d.FieldRawLen("data", dataLen)
+				}
 			case "iCCP":
 				d.FieldUTF8Null("profile_name")
 				compressionMethod := d.FieldU8("compression_method", compressionNames)
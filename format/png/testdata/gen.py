@@ -0,0 +1,11 @@
+#!/usr/bin/env python3
+# generates xmp.png, 4x4.png with an XMP iTXt chunk inserted after IHDR
+import struct
+import zlib
+
+xmp = open("../../xmp/testdata/packet.xmp", "rb").read()
+png = open("4x4.png", "rb").read()
+data = b"XML:com.adobe.xmp\x00" + b"\x00\x00" + b"\x00" + b"\x00" + xmp
+chunk = struct.pack(">I", len(data)) + b"iTXt" + data + struct.pack(">I", zlib.crc32(b"iTXt" + data))
+ihdr_end = 8 + 8 + 13 + 4
+open("xmp.png", "wb").write(png[:ihdr_end] + chunk + png[ihdr_end:])
@@ -0,0 +1,235 @@
+package prefetch
+
+// https://github.com/libyal/libscca/blob/main/documentation/Windows%20Prefetch%20File%20(PF)%20format.asciidoc
+
+import (
+	"strings"
+	"unicode/utf16"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.PREFETCH,
+		Description: "Windows Prefetch file",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    prefetchDecode,
+	})
+}
+
+const (
+	versionXP    = 17
+	versionVista = 23
+	version8     = 26
+	version10    = 30
+)
+
+var versionNames = scalar.UToSymStr{
+	versionXP:    "windows_xp",
+	versionVista: "windows_vista",
+	version8:     "windows_8",
+	version10:    "windows_10",
+}
+
+var compressionFormatNames = scalar.UToSymStr{
+	2: "lznt1",
+	3: "lzxpress",
+	4: "lzxpress_huffman",
+}
+
+const fileHeaderSize = 84
+
+// fixed length buffers have garbage after the null terminator
+var trimNull = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	str := s.ActualStr()
+	if i := strings.IndexByte(str, 0); i != -1 {
+		s.Actual = str[:i]
+	}
+	return s, nil
+})
+
+func fieldUTF16LENull(d *decode.D, name string) string {
+	return d.FieldStrFn(name, func(d *decode.D) string {
+		var us []uint16
+		for {
+			u := uint16(d.U16())
+			if u == 0 {
+				break
+			}
+			us = append(us, u)
+		}
+		return string(utf16.Decode(us))
+	})
+}
+
+type fileInfo struct {
+	metricsOffset         int64
+	metricsCount          uint64
+	traceChainsOffset     int64
+	traceChainsCount      uint64
+	filenameStringsOffset int64
+	filenameStringsSize   int64
+	volumesOffset         int64
+	volumesCount          uint64
+	volumesSize           int64
+}
+
+func decodeFileInfo(d *decode.D, version uint64) fileInfo {
+	var fi fileInfo
+	fi.metricsOffset = int64(d.FieldU32("metrics_offset"))
+	fi.metricsCount = d.FieldU32("metrics_count")
+	fi.traceChainsOffset = int64(d.FieldU32("trace_chains_offset"))
+	fi.traceChainsCount = d.FieldU32("trace_chains_count")
+	fi.filenameStringsOffset = int64(d.FieldU32("filename_strings_offset"))
+	fi.filenameStringsSize = int64(d.FieldU32("filename_strings_size"))
+	fi.volumesOffset = int64(d.FieldU32("volumes_information_offset"))
+	fi.volumesCount = d.FieldU32("volumes_count")
+	fi.volumesSize = int64(d.FieldU32("volumes_information_size"))
+
+	switch version {
+	case versionXP:
+		d.FieldU64("last_run_time", format.WindowsFileTime)
+		d.FieldRawLen("unknown0", 16*8)
+		d.FieldU32("run_count")
+		d.FieldU32("unknown1")
+	case versionVista:
+		d.FieldU64("unknown0")
+		d.FieldU64("last_run_time", format.WindowsFileTime)
+		d.FieldRawLen("unknown1", 16*8)
+		d.FieldU32("run_count")
+		d.FieldU32("unknown2")
+		d.FieldRawLen("unknown3", 80*8)
+	default:
+		d.FieldU64("unknown0")
+		d.FieldArray("last_run_times", func(d *decode.D) {
+			for i := 0; i < 8; i++ {
+				d.FieldU64("last_run_time", format.WindowsFileTime)
+			}
+		})
+		// windows 10 has two variants, the shorter one lacks 8 unknown bytes before run count
+		if version == version10 && fi.metricsOffset == fileHeaderSize+216 {
+			d.FieldRawLen("unknown1", 8*8)
+		} else {
+			d.FieldRawLen("unknown1", 16*8)
+		}
+		d.FieldU32("run_count")
+		d.FieldU32("unknown2")
+		d.FieldU32("unknown3")
+		d.FieldRawLen("unknown4", 88*8)
+	}
+
+	return fi
+}
+
+func prefetchDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	if d.PeekBits(24) == 'M'<<16|'A'<<8|'M' {
+		// compressed prefetch, only the header is decoded
+		d.FieldRawMagic("signature", []byte("MAM"))
+		d.FieldU8("compression_format", compressionFormatNames)
+		d.FieldU32("uncompressed_size")
+		d.FieldRawLen("compressed", d.BitsLeft())
+		return nil
+	}
+
+	version := d.FieldU32("version", versionNames, d.AssertU(versionXP, versionVista, version8, version10))
+	d.FieldRawMagic("signature", []byte("SCCA"))
+	d.FieldU32("header_unknown0")
+	d.FieldU32("file_size")
+	d.FieldUTF16LE("executable_name", 60, trimNull)
+	d.FieldU32("prefetch_hash", scalar.Hex)
+	d.FieldU32("header_unknown1")
+
+	var fi fileInfo
+	d.FieldStruct("file_information", func(d *decode.D) {
+		fi = decodeFileInfo(d, version)
+	})
+
+	if fi.metricsCount > 0 {
+		d.SeekAbs(fi.metricsOffset * 8)
+		d.FieldArray("metrics", func(d *decode.D) {
+			for i := uint64(0); i < fi.metricsCount; i++ {
+				d.FieldStruct("metric", func(d *decode.D) {
+					d.FieldU32("start_time")
+					d.FieldU32("duration")
+					if version != versionXP {
+						d.FieldU32("average_duration")
+					}
+					d.FieldU32("filename_string_offset")
+					d.FieldU32("filename_string_chars")
+					d.FieldU32("flags", scalar.Hex)
+					if version != versionXP {
+						d.FieldU48("mft_entry_index")
+						d.FieldU16("sequence_number")
+					}
+				})
+			}
+		})
+	}
+
+	if fi.traceChainsCount > 0 {
+		d.SeekAbs(fi.traceChainsOffset * 8)
+		d.FieldArray("trace_chains", func(d *decode.D) {
+			for i := uint64(0); i < fi.traceChainsCount; i++ {
+				d.FieldStruct("trace_chain", func(d *decode.D) {
+					if version != version10 {
+						d.FieldU32("next_index")
+					}
+					d.FieldU32("block_load_count")
+					d.FieldU8("unknown0")
+					d.FieldU8("unknown1")
+					d.FieldU16("unknown2")
+				})
+			}
+		})
+	}
+
+	if fi.filenameStringsSize > 0 {
+		d.RangeFn(fi.filenameStringsOffset*8, fi.filenameStringsSize*8, func(d *decode.D) {
+			d.FieldArray("filenames", func(d *decode.D) {
+				// can have padding at end
+				for d.BitsLeft() >= 16 && d.PeekBits(16) != 0 {
+					fieldUTF16LENull(d, "filename")
+				}
+			})
+		})
+	}
+
+	if fi.volumesCount > 0 {
+		d.RangeFn(fi.volumesOffset*8, fi.volumesSize*8, func(d *decode.D) {
+			d.FieldArray("volumes", func(d *decode.D) {
+				for i := uint64(0); i < fi.volumesCount; i++ {
+					d.FieldStruct("volume", func(d *decode.D) {
+						devicePathOffset := int64(d.FieldU32("device_path_offset"))
+						devicePathChars := int64(d.FieldU32("device_path_chars"))
+						d.FieldU64("creation_time", format.WindowsFileTime)
+						d.FieldU32("serial_number", scalar.Hex)
+						d.FieldU32("file_references_offset")
+						d.FieldU32("file_references_size")
+						d.FieldU32("directory_strings_offset")
+						d.FieldU32("directory_strings_count")
+						switch version {
+						case versionXP:
+							d.FieldU32("unknown0")
+						case version10:
+							d.FieldRawLen("unknown0", 60*8)
+						default:
+							d.FieldRawLen("unknown0", 68*8)
+						}
+						entryEnd := d.Pos()
+						d.SeekAbs(fi.volumesOffset*8 + devicePathOffset*8)
+						d.FieldUTF16LE("device_path", int(devicePathChars)*2)
+						d.SeekAbs(entryEnd)
+					})
+				}
+			})
+		})
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+import struct
+
+# synthetic prefetch files with made up values, layout from libscca documentation
+def u16s(s): return s.encode("utf-16-le") + b"\0\0"
+
+def gen(version, name, files, device):
+    info_size = {17: 68, 23: 156, 26: 224, 30: 224}[version]
+    metric_size = 20 if version == 17 else 32
+    chain_size = 8 if version == 30 else 12
+    vol_size = {17: 40, 23: 104, 26: 104, 30: 96}[version]
+
+    metrics_off = 84 + info_size
+    chains_off = metrics_off + metric_size * len(files)
+    strings = b""
+    offs = []
+    for f in files:
+        offs.append(len(strings))
+        strings += u16s(f)
+    strings += b"\0" * ((8 - len(strings) % 8) % 8)
+    strings_off = chains_off + chain_size * len(files)
+    vol_off = strings_off + len(strings)
+    dev = u16s(device)
+    vols = b""
+    entry = struct.pack("<IIQIIIII", vol_size, len(device), 130000000000000000, 0x12345678, 0, 0, 0, 0)
+    entry += b"\0" * (vol_size - len(entry))
+    vols = entry + dev
+    size = vol_off + len(vols)
+
+    metrics = b""
+    for i, f in enumerate(files):
+        if version == 17:
+            metrics += struct.pack("<IIIII", i, 1, offs[i] // 2, len(f), 0x200)
+        else:
+            metrics += struct.pack("<IIIIII", i, 1, 1, offs[i] // 2, len(f), 0x200) + struct.pack("<Q", (1 << 48) | (100 + i))
+    chains = b""
+    for i, f in enumerate(files):
+        if version == 30:
+            chains += struct.pack("<IBBH", 1, 3, 1, 1)
+        else:
+            chains += struct.pack("<IIBBH", i + 1 if i + 1 < len(files) else 0xffffffff, 1, 3, 1, 1)
+
+    last_run = 132000000000000000
+    info = struct.pack("<9I", metrics_off, len(files), chains_off, len(files), strings_off, len(strings), vol_off, 1, len(vols))
+    if version == 17:
+        info += struct.pack("<Q", last_run) + b"\0" * 16 + struct.pack("<II", 5, 0)
+    elif version == 23:
+        info += b"\0" * 8 + struct.pack("<Q", last_run) + b"\0" * 16 + struct.pack("<II", 5, 0) + b"\0" * 80
+    else:
+        info += b"\0" * 8 + struct.pack("<8Q", last_run, last_run - 10000000, 0, 0, 0, 0, 0, 0) + b"\0" * 16 + struct.pack("<III", 5, 0, 0) + b"\0" * 88
+    assert len(info) == info_size
+
+    exe = name.encode("utf-16-le") + b"\0\0"
+    exe += b"\xaa" * (60 - len(exe))
+    header = struct.pack("<I4sII", version, b"SCCA", 17 if version == 17 else 0x11, size) + exe + struct.pack("<II", 0xdeadbeef, 0)
+    assert len(header) == 84
+    data = header + info + metrics + chains + strings + vols
+    assert len(data) == size
+    return data
+
+files = ["\\DEVICE\\HARDDISKVOLUME1\\WINDOWS\\SYSTEM32\\NTDLL.DLL", "\\DEVICE\\HARDDISKVOLUME1\\WINDOWS\\NOTEPAD.EXE"]
+open("xp.pf", "wb").write(gen(17, "NOTEPAD.EXE", files, "\\DEVICE\\HARDDISKVOLUME1"))
+open("win10.pf", "wb").write(gen(30, "NOTEPAD.EXE", files, "\\DEVICE\\HARDDISKVOLUME1"))
+# only header is decoded so compressed data is made up
+open("mam.pf", "wb").write(b"MAM\x04" + struct.pack("<I", 1234) + bytes(range(32)))
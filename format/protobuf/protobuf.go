@@ -1,6 +1,9 @@
 package protobuf
 
 import (
+	"unicode"
+	"unicode/utf8"
+
 	"github.com/wader/fq/format"
 	"github.com/wader/fq/format/registry"
 	"github.com/wader/fq/internal/num"
@@ -30,22 +33,76 @@ var wireTypeNames = scalar.UToSymStr{
 	5: "32-bit",
 }
 
-func varInt(d *decode.D) uint64 {
+func readVarInt(b []byte) (uint64, int, bool) {
 	var n uint64
-	for i := 0; ; i++ {
-		b := d.U8()
-		n = n | (b&0x7f)<<(7*i)
-		if b&0x80 == 0 {
-			break
+	for i := 0; i < len(b) && i < 10; i++ {
+		n |= uint64(b[i]&0x7f) << (7 * i)
+		if b[i]&0x80 == 0 {
+			return n, i + 1, true
 		}
 	}
+	return 0, 0, false
+}
 
-	return n
+func isPrintable(b []byte) bool {
+	if !utf8.Valid(b) {
+		return false
+	}
+	for _, r := range string(b) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
 }
 
-func protobufDecodeField(d *decode.D, pbm *format.ProtoBufMessage) {
+// guess if a length-delimited value without schema is a nested message by walking
+// the wire format, printable strings are assumed to be strings
+func isMessage(b []byte) bool {
+	if len(b) == 0 || isPrintable(b) {
+		return false
+	}
+	for len(b) > 0 {
+		keyN, n, ok := readVarInt(b)
+		if !ok || keyN>>3 == 0 {
+			return false
+		}
+		b = b[n:]
+		switch keyN & 0x7 {
+		case wireTypeVarint:
+			_, n, ok = readVarInt(b)
+			if !ok {
+				return false
+			}
+		case wireType64Bit:
+			n = 8
+		case wireTypeLengthDelimited:
+			var l uint64
+			l, n, ok = readVarInt(b)
+			if !ok || l > uint64(len(b)-n) {
+				return false
+			}
+			n += int(l)
+		case wireType32Bit:
+			n = 4
+		default:
+			return false
+		}
+		if n > len(b) {
+			return false
+		}
+		b = b[n:]
+	}
+	return true
+}
+
+// messages nested deeper than this fails the decode, each guessed nested message also
+// rescans its bytes so this also bounds the work
+const maxMessageDepth = 100
+
+func protobufDecodeField(d *decode.D, pbm *format.ProtoBufMessage, depth int) {
 	d.FieldStruct("field", func(d *decode.D) {
-		keyN := d.FieldUFn("key_n", varInt)
+		keyN := d.FieldULEB128("key_n")
 		fieldNumber := keyN >> 3
 		wireType := keyN & 0x7
 		d.FieldValueU("field_number", fieldNumber)
@@ -56,78 +113,94 @@ func protobufDecodeField(d *decode.D, pbm *format.ProtoBufMessage) {
 		var valueStart int64
 		switch wireType {
 		case wireTypeVarint:
-			value = d.FieldUFn("wire_value", varInt)
+			value = d.FieldULEB128("wire_value")
 		case wireType64Bit:
 			value = d.FieldU64("wire_value")
 		case wireTypeLengthDelimited:
-			length = d.FieldUFn("length", varInt)
+			length = d.FieldULEB128("length")
 			valueStart = d.Pos()
 			d.FieldRawLen("wire_value", int64(length)*8)
 		case wireType32Bit:
 			value = d.FieldU32("wire_value")
 		}
 
+		var pbf format.ProtoBufField
+		var hasPbf bool
 		if pbm != nil {
-			if pbf, ok := (*pbm)[int(fieldNumber)]; ok {
-				d.FieldValueStr("name", pbf.Name)
-				d.FieldValueStr("type", format.ProtoBufTypeNames[uint64(pbf.Type)])
-
-				switch pbf.Type {
-				case format.ProtoBufTypeInt32, format.ProtoBufTypeInt64:
-					v := num.ZigZag(value)
-					d.FieldValueS("value", v)
-					if len(pbf.Enums) > 0 {
-						d.FieldValueStr("enum", pbf.Enums[uint64(v)])
-					}
-				case format.ProtoBufTypeUInt32, format.ProtoBufTypeUInt64:
-					d.FieldValueU("value", value)
-					if len(pbf.Enums) > 0 {
-						d.FieldValueStr("enum", pbf.Enums[value])
-					}
-				case format.ProtoBufTypeSInt32, format.ProtoBufTypeSInt64:
-					// TODO: correct? 32 different?
-					v := num.TwosComplement(64, value)
-					d.FieldValueS("value", v)
-					if len(pbf.Enums) > 0 {
-						d.FieldValueStr("enum", pbf.Enums[uint64(v)])
-					}
-				case format.ProtoBufTypeBool:
-					d.FieldValueBool("value", value != 0)
-				case format.ProtoBufTypeEnum:
-					d.FieldValueStr("enum", pbf.Enums[value])
-				case format.ProtoBufTypeFixed64:
-					// TODO:
-				case format.ProtoBufTypeSFixed64:
-					// TODO:
-				case format.ProtoBufTypeDouble:
-					// TODO:
-				case format.ProtoBufTypeString:
-					d.FieldValueStr("value", string(d.BytesRange(valueStart, int(length))))
-				case format.ProtoBufTypeBytes:
-					d.FieldValueRaw("value", d.BytesRange(valueStart, int(length)))
-				case format.ProtoBufTypeMessage:
-					// TODO: test
-					d.LenFn(int64(length)*8, func(d *decode.D) {
-						protobufDecodeFields(d, &pbf.Message)
+			pbf, hasPbf = (*pbm)[int(fieldNumber)]
+		}
+
+		if !hasPbf {
+			if wireType == wireTypeLengthDelimited && isMessage(d.BytesRange(valueStart, int(length))) {
+				d.RangeFn(valueStart, int64(length)*8, func(d *decode.D) {
+					d.FieldStruct("message", func(d *decode.D) {
+						protobufDecodeFields(d, nil, depth+1)
 					})
-				case format.ProtoBufTypePackedRepeated:
-					// TODO:
-				case format.ProtoBufTypeFixed32:
-					// TODO:
-				case format.ProtoBufTypeSFixed32:
-					// TODO:
-				case format.ProtoBufTypeFloat:
-					// TODO:
+				})
+			}
+		} else {
+			d.FieldValueStr("name", pbf.Name)
+			d.FieldValueStr("type", format.ProtoBufTypeNames[uint64(pbf.Type)])
+
+			switch pbf.Type {
+			case format.ProtoBufTypeInt32, format.ProtoBufTypeInt64:
+				v := num.ZigZag(value)
+				d.FieldValueS("value", v)
+				if len(pbf.Enums) > 0 {
+					d.FieldValueStr("enum", pbf.Enums[uint64(v)])
+				}
+			case format.ProtoBufTypeUInt32, format.ProtoBufTypeUInt64:
+				d.FieldValueU("value", value)
+				if len(pbf.Enums) > 0 {
+					d.FieldValueStr("enum", pbf.Enums[value])
 				}
+			case format.ProtoBufTypeSInt32, format.ProtoBufTypeSInt64:
+				// TODO: correct? 32 different?
+				v := num.TwosComplement(64, value)
+				d.FieldValueS("value", v)
+				if len(pbf.Enums) > 0 {
+					d.FieldValueStr("enum", pbf.Enums[uint64(v)])
+				}
+			case format.ProtoBufTypeBool:
+				d.FieldValueBool("value", value != 0)
+			case format.ProtoBufTypeEnum:
+				d.FieldValueStr("enum", pbf.Enums[value])
+			case format.ProtoBufTypeFixed64:
+				// TODO:
+			case format.ProtoBufTypeSFixed64:
+				// TODO:
+			case format.ProtoBufTypeDouble:
+				// TODO:
+			case format.ProtoBufTypeString:
+				d.FieldValueStr("value", string(d.BytesRange(valueStart, int(length))))
+			case format.ProtoBufTypeBytes:
+				d.FieldValueRaw("value", d.BytesRange(valueStart, int(length)))
+			case format.ProtoBufTypeMessage:
+				d.RangeFn(valueStart, int64(length)*8, func(d *decode.D) {
+					d.FieldStruct("message", func(d *decode.D) {
+						protobufDecodeFields(d, &pbf.Message, depth+1)
+					})
+				})
+			case format.ProtoBufTypePackedRepeated:
+				// TODO:
+			case format.ProtoBufTypeFixed32:
+				// TODO:
+			case format.ProtoBufTypeSFixed32:
+				// TODO:
+			case format.ProtoBufTypeFloat:
+				// TODO:
 			}
 		}
 	})
 }
 
-func protobufDecodeFields(d *decode.D, pbm *format.ProtoBufMessage) {
+func protobufDecodeFields(d *decode.D, pbm *format.ProtoBufMessage, depth int) {
+	if depth > maxMessageDepth {
+		d.Fatalf("messages nested deeper than %d", maxMessageDepth)
+	}
 	d.FieldArray("fields", func(d *decode.D) {
 		for d.BitsLeft() > 0 {
-			protobufDecodeField(d, pbm)
+			protobufDecodeField(d, pbm, depth)
 		}
 	})
 }
@@ -139,7 +212,7 @@ func protobufDecode(d *decode.D, in interface{}) interface{} {
 		pbm = &pbi.Message
 	}
 
-	protobufDecodeFields(d, pbm)
+	protobufDecodeFields(d, pbm, 1)
 
 	return nil
 }
@@ -0,0 +1,362 @@
+package psd
+
+// https://www.adobe.com/devnet-apps/photoshop/fileformatashtml/
+// TODO: decode rle and zip compressed channel data
+// TODO: more image resources and additional layer information
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var iccProfileFormat decode.Group
+var exifFormat decode.Group
+var xmpFormat decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.PSD,
+		Description: "Adobe Photoshop document",
+		Groups:      []string{format.PROBE, format.IMAGE},
+		DecodeFn:    psdDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.ICC_PROFILE}, Group: &iccProfileFormat},
+			{Names: []string{format.EXIF}, Group: &exifFormat},
+			{Names: []string{format.XMP}, Group: &xmpFormat},
+		},
+	})
+}
+
+const (
+	versionPSD = 1
+	versionPSB = 2
+)
+
+var versionNames = scalar.UToSymStr{
+	versionPSD: "psd",
+	versionPSB: "psb",
+}
+
+var colorModeNames = scalar.UToSymStr{
+	0: "bitmap",
+	1: "grayscale",
+	2: "indexed",
+	3: "rgb",
+	4: "cmyk",
+	7: "multichannel",
+	8: "duotone",
+	9: "lab",
+}
+
+const (
+	resourceICCProfile = 1039
+	resourceEXIF1      = 1058
+	resourceEXIF3      = 1059
+	resourceXMP        = 1060
+)
+
+var resourceIDNames = scalar.UToSymStr{
+	1005:               "resolution_info",
+	1006:               "alpha_channel_names",
+	1010:               "background_color",
+	1011:               "print_flags",
+	1013:               "color_halftoning",
+	1016:               "color_transfer_functions",
+	1024:               "layer_state",
+	1026:               "layer_group",
+	1028:               "iptc_naa",
+	1032:               "grid_and_guides",
+	1033:               "thumbnail_old",
+	1034:               "copyright_flag",
+	1035:               "url",
+	1036:               "thumbnail",
+	1037:               "global_angle",
+	resourceICCProfile: "icc_profile",
+	1041:               "icc_untagged_profile",
+	1043:               "spot_halftone",
+	1044:               "document_ids_seed",
+	1045:               "unicode_alpha_names",
+	1049:               "global_altitude",
+	1050:               "slices",
+	1053:               "alpha_identifiers",
+	1054:               "url_list",
+	1057:               "version_info",
+	resourceEXIF1:      "exif_data_1",
+	resourceEXIF3:      "exif_data_3",
+	resourceXMP:        "xmp_metadata",
+	1061:               "caption_digest",
+	1062:               "print_scale",
+	1064:               "pixel_aspect_ratio",
+	1069:               "layer_selection_ids",
+	1072:               "layer_groups_enabled_id",
+	1077:               "display_info",
+	1082:               "print_info",
+	1083:               "print_style",
+	10000:              "print_flags_info",
+}
+
+var blendModeNames = scalar.StrToSymStr{
+	"pass": "pass_through",
+	"norm": "normal",
+	"diss": "dissolve",
+	"dark": "darken",
+	"mul ": "multiply",
+	"idiv": "color_burn",
+	"lbrn": "linear_burn",
+	"dkCl": "darker_color",
+	"lite": "lighten",
+	"scrn": "screen",
+	"div ": "color_dodge",
+	"lddg": "linear_dodge",
+	"lgCl": "lighter_color",
+	"over": "overlay",
+	"sLit": "soft_light",
+	"hLit": "hard_light",
+	"vLit": "vivid_light",
+	"lLit": "linear_light",
+	"pLit": "pin_light",
+	"hMix": "hard_mix",
+	"diff": "difference",
+	"smud": "exclusion",
+	"fsub": "subtract",
+	"fdiv": "divide",
+	"hue ": "hue",
+	"sat ": "saturation",
+	"colr": "color",
+	"lum ": "luminosity",
+}
+
+var channelIDNames = scalar.SToSymStr{
+	-1: "transparency_mask",
+	-2: "user_layer_mask",
+	-3: "real_user_layer_mask",
+}
+
+const (
+	compressionRaw           = 0
+	compressionRLE           = 1
+	compressionZIP           = 2
+	compressionZIPPrediction = 3
+)
+
+var compressionNames = scalar.UToSymStr{
+	compressionRaw:           "raw",
+	compressionRLE:           "rle",
+	compressionZIP:           "zip",
+	compressionZIPPrediction: "zip_prediction",
+}
+
+// additional layer information keys with 8 byte length in psb
+var psbLongLengthKeys = map[string]bool{
+	"LMsk": true, "Lr16": true, "Lr32": true, "Layr": true, "Mt16": true, "Mt32": true,
+	"Mtrn": true, "Alph": true, "FMsk": true, "lnk2": true, "FEid": true, "FXid": true,
+	"PxSD": true,
+}
+
+type psdContext struct {
+	version uint64
+}
+
+// length field that is 8 bytes in psb
+func (c psdContext) fieldLength(d *decode.D, name string) uint64 {
+	if c.version == versionPSB {
+		return d.FieldU64(name)
+	}
+	return d.FieldU32(name)
+}
+
+// pascal string padded so that length byte and string is a multiple of align bytes
+func fieldPascalString(d *decode.D, name string, align int64) string {
+	start := d.Pos()
+	s := d.FieldUTF8ShortString(name)
+	if n := ((d.Pos() - start) / 8) % align; n != 0 {
+		d.FieldRawLen(name+"_padding", (align-n)*8)
+	}
+	return s
+}
+
+func decodeImageResource(d *decode.D) {
+	d.FieldUTF8("signature", 4, d.AssertStr("8BIM", "MeSa", "AgHg", "PHUT", "DCSR"))
+	id := d.FieldU16("id", resourceIDNames)
+	fieldPascalString(d, "name", 2)
+	size := d.FieldU32("size")
+	switch id {
+	case resourceICCProfile:
+		d.FieldFormatLen("data", int64(size)*8, iccProfileFormat, nil)
+	case resourceEXIF1, resourceEXIF3:
+		d.FieldFormatLen("data", int64(size)*8, exifFormat, nil)
+	case resourceXMP:
+		d.FieldFormatLen("data", int64(size)*8, xmpFormat, nil)
+	default:
+		d.FieldRawLen("data", int64(size)*8)
+	}
+	if size%2 != 0 {
+		d.FieldRawLen("padding", 8)
+	}
+}
+
+func (c psdContext) decodeAdditionalLayerInfo(d *decode.D) {
+	d.FieldUTF8("signature", 4, d.AssertStr("8BIM", "8B64"))
+	key := d.FieldUTF8("key", 4)
+	var length uint64
+	if psbLongLengthKeys[key] {
+		length = c.fieldLength(d, "length")
+	} else {
+		length = d.FieldU32("length")
+	}
+	d.FieldRawLen("data", int64(length)*8)
+}
+
+type layerChannel struct {
+	length uint64
+}
+
+func (c psdContext) decodeLayerRecord(d *decode.D) []layerChannel {
+	var channels []layerChannel
+
+	d.FieldS32("top")
+	d.FieldS32("left")
+	d.FieldS32("bottom")
+	d.FieldS32("right")
+	channelCount := d.FieldU16("channel_count")
+	d.FieldArray("channels", func(d *decode.D) {
+		for i := uint64(0); i < channelCount; i++ {
+			d.FieldStruct("channel", func(d *decode.D) {
+				d.FieldS16("id", channelIDNames)
+				channels = append(channels, layerChannel{length: c.fieldLength(d, "length")})
+			})
+		}
+	})
+	d.FieldUTF8("blend_mode_signature", 4, d.AssertStr("8BIM"))
+	d.FieldUTF8("blend_mode", 4, blendModeNames)
+	d.FieldU8("opacity")
+	d.FieldU8("clipping", scalar.UToSymStr{0: "base", 1: "non_base"})
+	d.FieldStruct("flags", func(d *decode.D) {
+		d.FieldU3("unused")
+		d.FieldBool("pixel_data_irrelevant")
+		d.FieldBool("pixel_data_irrelevant_valid")
+		d.FieldBool("obsolete")
+		d.FieldBool("hidden")
+		d.FieldBool("transparency_protected")
+	})
+	d.FieldU8("filler")
+	extraLength := d.FieldU32("extra_length")
+	d.LenFn(int64(extraLength)*8, func(d *decode.D) {
+		maskLength := d.FieldU32("mask_data_length")
+		if maskLength > 0 {
+			d.FieldRawLen("mask_data", int64(maskLength)*8)
+		}
+		blendingRangesLength := d.FieldU32("blending_ranges_length")
+		if blendingRangesLength > 0 {
+			d.FieldRawLen("blending_ranges", int64(blendingRangesLength)*8)
+		}
+		fieldPascalString(d, "name", 4)
+		d.FieldStructArrayLoop("additional_info", "info", d.NotEnd, c.decodeAdditionalLayerInfo)
+	})
+
+	return channels
+}
+
+func (c psdContext) decodeLayerInfo(d *decode.D) {
+	layerCount := d.FieldS16("layer_count", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		if s.ActualS() < 0 {
+			s.Description = "first alpha channel is merged result transparency"
+		}
+		return s, nil
+	}))
+	if layerCount < 0 {
+		layerCount = -layerCount
+	}
+
+	var layersChannels [][]layerChannel
+	d.FieldArray("layers", func(d *decode.D) {
+		for i := int64(0); i < layerCount; i++ {
+			d.FieldStruct("layer", func(d *decode.D) {
+				layersChannels = append(layersChannels, c.decodeLayerRecord(d))
+			})
+		}
+	})
+
+	d.FieldArray("channel_image_data", func(d *decode.D) {
+		for _, channels := range layersChannels {
+			d.FieldArray("layer", func(d *decode.D) {
+				for _, ch := range channels {
+					d.FieldStruct("channel", func(d *decode.D) {
+						if ch.length < 2 {
+							d.Fatalf("channel length %d too small", ch.length)
+						}
+						d.FieldU16("compression", compressionNames)
+						d.FieldRawLen("data", int64(ch.length-2)*8)
+					})
+				}
+			})
+		}
+	})
+
+	if d.NotEnd() {
+		d.FieldRawLen("padding", d.BitsLeft())
+	}
+}
+
+func psdDecode(d *decode.D, in interface{}) interface{} {
+	c := psdContext{}
+
+	d.FieldStruct("header", func(d *decode.D) {
+		d.FieldUTF8("signature", 4, d.AssertStr("8BPS"))
+		c.version = d.FieldU16("version", versionNames, d.AssertU(versionPSD, versionPSB))
+		d.FieldRawLen("reserved", 6*8)
+		d.FieldU16("channels")
+		d.FieldU32("height")
+		d.FieldU32("width")
+		d.FieldU16("depth")
+		d.FieldU16("color_mode", colorModeNames)
+	})
+
+	d.FieldStruct("color_mode_data", func(d *decode.D) {
+		length := d.FieldU32("length")
+		d.FieldRawLen("data", int64(length)*8)
+	})
+
+	d.FieldStruct("image_resources", func(d *decode.D) {
+		length := d.FieldU32("length")
+		d.LenFn(int64(length)*8, func(d *decode.D) {
+			d.FieldStructArrayLoop("resources", "resource", d.NotEnd, decodeImageResource)
+		})
+	})
+
+	d.FieldStruct("layer_and_mask_information", func(d *decode.D) {
+		length := c.fieldLength(d, "length")
+		d.LenFn(int64(length)*8, func(d *decode.D) {
+			if d.BitsLeft() == 0 {
+				return
+			}
+			layerInfoLength := c.fieldLength(d, "layer_info_length")
+			d.LenFn(int64(layerInfoLength)*8, func(d *decode.D) {
+				if d.BitsLeft() == 0 {
+					return
+				}
+				c.decodeLayerInfo(d)
+			})
+			if d.BitsLeft() < 32 {
+				return
+			}
+			globalMaskLength := d.FieldU32("global_layer_mask_info_length")
+			if globalMaskLength > 0 {
+				d.FieldRawLen("global_layer_mask_info", int64(globalMaskLength)*8)
+			}
+			d.FieldStructArrayLoop("additional_info", "info", func() bool { return d.BitsLeft() >= 12*8 }, c.decodeAdditionalLayerInfo)
+			if d.NotEnd() {
+				d.FieldRawLen("padding", d.BitsLeft())
+			}
+		})
+	})
+
+	d.FieldStruct("image_data", func(d *decode.D) {
+		d.FieldU16("compression", compressionNames)
+		d.FieldRawLen("data", d.BitsLeft())
+	})
+
+	return nil
+}
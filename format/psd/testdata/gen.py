@@ -0,0 +1,83 @@
+#!/usr/bin/env python3
+# generates a small 2x2 rgb psd with two layers and raw channel data
+import struct
+
+
+def pascal(s, align):
+    b = bytes([len(s)]) + s.encode()
+    if len(b) % align:
+        b += b"\0" * (align - len(b) % align)
+    return b
+
+
+def resource(id, data, name=""):
+    b = b"8BIM" + struct.pack(">H", id) + pascal(name, 2) + struct.pack(">I", len(data)) + data
+    if len(data) % 2:
+        b += b"\0"
+    return b
+
+
+def additional_info(key, data):
+    return b"8BIM" + key.encode() + struct.pack(">I", len(data)) + data
+
+
+def unicode_name(s):
+    return struct.pack(">I", len(s)) + s.encode("utf-16-be")
+
+
+width, height = 2, 2
+pixels = width * height
+
+
+def layer(name, bounds, channel_datas, blend_mode="norm", opacity=255, flags=0, extra_infos=b""):
+    channels = b""
+    for id, _ in channel_datas:
+        channels += struct.pack(">hI", id, 2 + pixels)
+    extra = struct.pack(">I", 0) + struct.pack(">I", 0) + pascal(name, 4) + extra_infos
+    record = struct.pack(">iiiiH", *bounds, len(channel_datas)) + channels + \
+        b"8BIM" + blend_mode.encode() + struct.pack(">BBBB", opacity, 0, flags, 0) + \
+        struct.pack(">I", len(extra)) + extra
+    image = b"".join(struct.pack(">H", 0) + data for _, data in channel_datas)
+    return record, image
+
+
+bg_record, bg_image = layer(
+    "Background", (0, 0, height, width),
+    [(0, b"\xff" * pixels), (1, b"\x00" * pixels), (2, b"\x00" * pixels)],
+    flags=0x00,
+)
+l1_record, l1_image = layer(
+    "Layer 1", (0, 0, height, width),
+    [(-1, b"\x80" * pixels), (0, b"\x00" * pixels), (1, b"\xff" * pixels), (2, b"\x00" * pixels)],
+    blend_mode="mul ", opacity=128, flags=0x08,
+    extra_infos=additional_info("luni", unicode_name("Layer 1")),
+)
+
+layer_info = struct.pack(">h", 2) + bg_record + l1_record + bg_image + l1_image
+if len(layer_info) % 2:
+    layer_info += b"\0"
+layer_and_mask = struct.pack(">I", len(layer_info)) + layer_info + struct.pack(">I", 0)
+
+xmp = (
+    b'<?xpacket begin="\xef\xbb\xbf" id="W5M0MpCehiHzreSzNTczkc9d"?>\n'
+    b'<x:xmpmeta xmlns:x="adobe:ns:meta/">'
+    b'<rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">'
+    b'<rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/">'
+    b'<dc:format>application/vnd.adobe.photoshop</dc:format>'
+    b'</rdf:Description></rdf:RDF></x:xmpmeta>\n'
+    b'<?xpacket end="w"?>'
+)
+resources = \
+    resource(1005, struct.pack(">IHHIHH", 72 << 16, 1, 1, 72 << 16, 1, 1)) + \
+    resource(1037, struct.pack(">I", 30)) + \
+    resource(1060, xmp) + \
+    resource(1057, b"\x00\x00\x00\x01\x01abc", name="v")
+
+psd = b"8BPS" + struct.pack(">H", 1) + b"\0" * 6 + \
+    struct.pack(">HIIHH", 3, height, width, 8, 3) + \
+    struct.pack(">I", 0) + \
+    struct.pack(">I", len(resources)) + resources + \
+    struct.pack(">I", len(layer_and_mask)) + layer_and_mask + \
+    struct.pack(">H", 0) + b"\xff" * pixels + b"\x00" * pixels + b"\x80" * pixels
+
+open("test.psd", "wb").write(psd)
@@ -1,6 +1,10 @@
 package format
 
-import "github.com/wader/fq/pkg/scalar"
+import (
+	"time"
+
+	"github.com/wader/fq/pkg/scalar"
+)
 
 const (
 	ProtoBufTypeInt32 = iota
@@ -52,3 +56,16 @@ type ProtoBufField struct {
 }
 
 type ProtoBufMessage map[int]ProtoBufField
+
+// Windows FILETIME is 100ns intervals since 1601-01-01
+const windowsEpochUnix = -11644473600
+
+// WindowsFileTime maps a Windows FILETIME to a RFC3339 UTC time, zero is left as is
+var WindowsFileTime = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	v, ok := s.Actual.(uint64)
+	if !ok || v == 0 {
+		return s, nil
+	}
+	s.Sym = time.Unix(windowsEpochUnix+int64(v/10_000_000), int64(v%10_000_000)*100).UTC().Format(time.RFC3339Nano)
+	return s, nil
+})
@@ -0,0 +1,189 @@
+package snappy
+
+// https://github.com/google/snappy/blob/main/format_description.txt
+// https://github.com/google/snappy/blob/main/framing_format.txt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.SNAPPY,
+		Description: "Snappy framed compression",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    snappyDecode,
+	})
+}
+
+var streamIdentifier = []byte("\xff\x06\x00\x00sNaPpY")
+
+const (
+	chunkCompressed       = 0x00
+	chunkUncompressed     = 0x01
+	chunkPadding          = 0xfe
+	chunkStreamIdentifier = 0xff
+)
+
+var chunkTypeNames = scalar.UToSymStr{
+	chunkCompressed:       "compressed",
+	chunkUncompressed:     "uncompressed",
+	chunkPadding:          "padding",
+	chunkStreamIdentifier: "stream_identifier",
+}
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+func maskedCRC32C(b []byte) uint32 {
+	c := crc32.Checksum(b, crc32cTable)
+	return (c>>15 | c<<17) + 0xa282ead8
+}
+
+var errCorrupt = errors.New("corrupt input")
+
+// max uncompressed data size of a chunk in the framing format
+const maxUncompressedChunkSize = 65536
+
+// decompress decompresses a raw snappy block
+func decompress(b []byte) ([]byte, error) {
+	n, l := binary.Uvarint(b)
+	if l <= 0 || n > maxUncompressedChunkSize {
+		return nil, errCorrupt
+	}
+	b = b[l:]
+	dst := make([]byte, 0, n)
+
+	for len(b) > 0 {
+		tag := b[0]
+		var length, offset int
+		switch tag & 0x3 {
+		case 0: // literal
+			length = int(tag>>2) + 1
+			b = b[1:]
+			if length > 60 {
+				nBytes := length - 60
+				if len(b) < nBytes {
+					return nil, errCorrupt
+				}
+				length = 0
+				for i := nBytes - 1; i >= 0; i-- {
+					length = length<<8 | int(b[i])
+				}
+				length++
+				b = b[nBytes:]
+			}
+			if len(b) < length || uint64(len(dst)+length) > n {
+				return nil, errCorrupt
+			}
+			dst = append(dst, b[0:length]...)
+			b = b[length:]
+			continue
+		case 1: // copy with 1 byte offset
+			if len(b) < 2 {
+				return nil, errCorrupt
+			}
+			length = 4 + int(tag>>2)&0x7
+			offset = int(tag>>5)<<8 | int(b[1])
+			b = b[2:]
+		case 2: // copy with 2 byte offset
+			if len(b) < 3 {
+				return nil, errCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(b[1:]))
+			b = b[3:]
+		case 3: // copy with 4 byte offset
+			if len(b) < 5 {
+				return nil, errCorrupt
+			}
+			length = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(b[1:]))
+			b = b[5:]
+		}
+		if offset <= 0 || offset > len(dst) || uint64(len(dst)+length) > n {
+			return nil, errCorrupt
+		}
+		// copy byte by byte as source and destination can overlap
+		for i := 0; i < length; i++ {
+			dst = append(dst, dst[len(dst)-offset])
+		}
+	}
+	if uint64(len(dst)) != n {
+		return nil, errCorrupt
+	}
+
+	return dst, nil
+}
+
+// fieldBlock adds a raw snappy block and returns the uncompressed data or nil on error
+func fieldBlock(d *decode.D) []byte {
+	uncompressed, err := decompress(d.BytesRange(d.Pos(), int(d.BitsLeft()/8)))
+	d.FieldULEB128("uncompressed_length")
+	d.FieldRawLen("compressed", d.BitsLeft())
+	if err != nil {
+		d.Errorf("decompress: %s", err)
+		return nil
+	}
+	d.FieldRootBitBuf("uncompressed", bitio.NewBufferFromBytes(uncompressed, -1))
+
+	return uncompressed
+}
+
+func decodeChunk(d *decode.D) {
+	typ := d.FieldU8("type", chunkTypeNames)
+	length := d.FieldU24("length")
+	d.LenFn(int64(length)*8, func(d *decode.D) {
+		switch {
+		case typ == chunkStreamIdentifier:
+			d.FieldUTF8("stream_identifier", int(length), d.AssertStr("sNaPpY"))
+		case typ == chunkCompressed, typ == chunkUncompressed:
+			checksumPos := d.Pos()
+			d.SeekRel(32)
+			var data []byte
+			if typ == chunkCompressed {
+				data = fieldBlock(d)
+			} else {
+				d.FieldRawLen("data", d.BitsLeft())
+				data = d.BytesRange(checksumPos+32, int(length)-4)
+			}
+			d.SeekAbs(checksumPos)
+			if data != nil {
+				d.FieldU32("checksum", d.ValidateU(uint64(maskedCRC32C(data))), scalar.Hex)
+			} else {
+				d.FieldU32("checksum", scalar.Hex)
+			}
+			d.SeekAbs(checksumPos + int64(length)*8)
+		case typ == chunkPadding:
+			d.FieldRawLen("padding", d.BitsLeft())
+		case typ < 0x80:
+			d.Fatalf("reserved unskippable chunk type 0x%x", typ)
+		default:
+			d.FieldRawLen("data", d.BitsLeft())
+		}
+	})
+}
+
+func snappyDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	if !bytes.Equal(d.PeekBytes(len(streamIdentifier)), streamIdentifier) {
+		d.Fatalf("no stream identifier")
+	}
+
+	d.FieldArray("chunks", func(d *decode.D) {
+		for d.NotEnd() {
+			d.FieldStruct("chunk", decodeChunk)
+		}
+	})
+
+	return nil
+}
@@ -0,0 +1,76 @@
+# generates a snappy framed stream with all chunk types using a simple greedy compressor
+import struct
+
+
+def crc32c(b):
+    crc = 0xffffffff
+    for c in b:
+        crc ^= c
+        for _ in range(8):
+            crc = (crc >> 1) ^ (0x82f63b78 if crc & 1 else 0)
+    return crc ^ 0xffffffff
+
+
+def masked_crc(b):
+    c = crc32c(b)
+    return (((c >> 15) | (c << 17)) + 0xa282ead8) & 0xffffffff
+
+
+def uvarint(n):
+    b = b""
+    while n >= 0x80:
+        b += bytes([n & 0x7f | 0x80])
+        n >>= 7
+    return b + bytes([n])
+
+
+def literal(b):
+    n = len(b) - 1
+    if n < 60:
+        return bytes([n << 2]) + b
+    return bytes([60 << 2, n]) + b
+
+
+def compress(b):
+    out = uvarint(len(b))
+    last = {}
+    i = 0
+    lit = 0
+    while i < len(b):
+        k = b[i:i + 4]
+        j = last.get(k)
+        last[k] = i
+        if len(k) == 4 and j is not None:
+            n = 4
+            while i + n < len(b) and n < 64 and b[j + n] == b[i + n]:
+                n += 1
+            if lit < i:
+                out += literal(b[lit:i])
+            out += bytes([(n - 1) << 2 | 2]) + struct.pack("<H", i - j)
+            i += n
+            lit = i
+        else:
+            i += 1
+    if lit < len(b):
+        out += literal(b[lit:])
+    return out
+
+
+def chunk(typ, data):
+    return bytes([typ]) + struct.pack("<I", len(data))[0:3] + data
+
+
+text1 = b"snappy snappy snappy framing test, snappy framing test\n"
+text2 = b"uncompressed chunk\n"
+b = chunk(0xff, b"sNaPpY")
+b += chunk(0x00, struct.pack("<I", masked_crc(text1)) + compress(text1))
+b += chunk(0x01, struct.pack("<I", masked_crc(text2)) + text2)
+b += chunk(0xfe, bytes(4))
+b += chunk(0x80, b"skip")
+open("test.sz", "wb").write(b)
+
+# compressed chunk with an uncompressed length larger than the max chunk size
+corrupt = uvarint(0xffffffffffffffff) + literal(b"corrupt")
+b = chunk(0xff, b"sNaPpY")
+b += chunk(0x00, struct.pack("<I", 0) + corrupt)
+open("corrupt_length.sz", "wb").write(b)
@@ -0,0 +1,193 @@
+package snss
+
+// https://chromium.googlesource.com/chromium/src/+/refs/heads/main/components/sessions/core/command_storage_backend.cc
+// https://chromium.googlesource.com/chromium/src/+/refs/heads/main/components/sessions/core/session_service_commands.cc
+// https://chromium.googlesource.com/chromium/src/+/refs/heads/main/base/pickle.h
+// TODO: tab restore service command ids ("Current Tabs"/"Last Tabs" use other ids)
+// TODO: more command payloads
+
+import (
+	"time"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.SNSS,
+		Description: "Chrome session restore",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    snssDecode,
+	})
+}
+
+const (
+	commandSetTabWindow               = 0
+	commandSetTabIndexInWindow        = 2
+	commandUpdateTabNavigation        = 6
+	commandSetSelectedNavigationIndex = 7
+	commandSetSelectedTabInIndex      = 8
+	commandSetPinnedState             = 12
+	commandTabClosed                  = 16
+	commandWindowClosed               = 17
+	commandSetActiveWindow            = 20
+)
+
+var commandNames = scalar.UToSymStr{
+	commandSetTabWindow:               "set_tab_window",
+	1:                                 "set_window_bounds",
+	commandSetTabIndexInWindow:        "set_tab_index_in_window",
+	3:                                 "tab_closed_obsolete",
+	4:                                 "window_closed_obsolete",
+	5:                                 "tab_navigation_path_pruned_from_back",
+	commandUpdateTabNavigation:        "update_tab_navigation",
+	commandSetSelectedNavigationIndex: "set_selected_navigation_index",
+	commandSetSelectedTabInIndex:      "set_selected_tab_in_index",
+	9:                                 "set_window_type",
+	10:                                "set_window_bounds2",
+	11:                                "tab_navigation_path_pruned_from_front",
+	commandSetPinnedState:             "set_pinned_state",
+	13:                                "set_extension_app_id",
+	14:                                "set_window_bounds3",
+	15:                                "set_window_app_name",
+	commandTabClosed:                  "tab_closed",
+	commandWindowClosed:               "window_closed",
+	18:                                "set_tab_user_agent_override",
+	19:                                "session_storage_associated",
+	commandSetActiveWindow:            "set_active_window",
+	21:                                "last_active_time",
+}
+
+// timestamps are microseconds since windows epoch 1601-01-01
+const windowsEpochUnixMicro = -11644473600 * 1000 * 1000
+
+var windowsEpochMicro = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	sv, ok := s.Actual.(int64)
+	if !ok {
+		return s, nil
+	}
+	s.Sym = time.UnixMicro(sv + windowsEpochUnixMicro).UTC().Format(time.RFC3339)
+	return s, nil
+})
+
+// pickle data is 4 byte aligned
+func picklePadding(d *decode.D, n int64) {
+	if padLen := (4 - n%4) % 4; padLen > 0 {
+		d.FieldRawLen("padding", padLen*8)
+	}
+}
+
+// pickle string is a 32 bit length and bytes
+func fieldPickleString(d *decode.D, name string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		l := d.FieldU32("length")
+		if int64(l)*8 > d.BitsLeft() {
+			d.Fatalf("string length %d too large", l)
+		}
+		d.FieldUTF8("value", int(l))
+		picklePadding(d, int64(l))
+	})
+}
+
+// pickle string16 is a 32 bit length in UTF-16 code units and UTF-16LE bytes
+func fieldPickleString16(d *decode.D, name string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		l := d.FieldU32("length")
+		if int64(l)*16 > d.BitsLeft() {
+			d.Fatalf("string16 length %d too large", l)
+		}
+		d.FieldUTF16LE("value", int(l)*2)
+		picklePadding(d, int64(l)*2)
+	})
+}
+
+func decodeUpdateTabNavigation(d *decode.D) {
+	d.FieldU32("pickle_size")
+	d.FieldS32("tab_id")
+	d.FieldS32("index")
+	fieldPickleString(d, "url")
+	fieldPickleString16(d, "title")
+	fieldPickleString(d, "page_state")
+	// fields after page state has been added over time, older files might end earlier
+	for _, fn := range []func(d *decode.D){
+		func(d *decode.D) { d.FieldU32("transition_type", scalar.Hex) },
+		func(d *decode.D) { d.FieldS32("type_mask") },
+		func(d *decode.D) { fieldPickleString(d, "referrer_url") },
+		func(d *decode.D) { d.FieldS32("referrer_policy") },
+		func(d *decode.D) { fieldPickleString(d, "original_request_url") },
+		func(d *decode.D) { d.FieldS32("is_overriding_user_agent") },
+		func(d *decode.D) { d.FieldS64("timestamp", windowsEpochMicro) },
+	} {
+		if !d.NotEnd() {
+			return
+		}
+		fn(d)
+	}
+}
+
+func snssDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldRawMagic("magic", []byte("SNSS"))
+	d.FieldS32("version")
+
+	d.FieldStructArrayLoop("commands", "command", d.NotEnd, func(d *decode.D) {
+		// size includes id
+		size := d.FieldU16("size")
+		if size < 1 {
+			d.Fatalf("invalid command size %d", size)
+		}
+		id := d.FieldU8("id", commandNames)
+		dataLen := int64(size-1) * 8
+		d.LenFn(dataLen, func(d *decode.D) {
+			switch id {
+			case commandUpdateTabNavigation:
+				d.FieldStruct("data", decodeUpdateTabNavigation)
+			case commandSetTabWindow:
+				d.FieldStruct("data", func(d *decode.D) {
+					d.FieldS32("window_id")
+					d.FieldS32("tab_id")
+				})
+			case commandSetTabIndexInWindow,
+				commandSetSelectedNavigationIndex:
+				d.FieldStruct("data", func(d *decode.D) {
+					d.FieldS32("tab_id")
+					d.FieldS32("index")
+				})
+			case commandSetSelectedTabInIndex:
+				d.FieldStruct("data", func(d *decode.D) {
+					d.FieldS32("window_id")
+					d.FieldS32("index")
+				})
+			case commandTabClosed,
+				commandWindowClosed:
+				d.FieldStruct("data", func(d *decode.D) {
+					d.FieldS32("id")
+					d.FieldU32("padding")
+					d.FieldS64("close_time", windowsEpochMicro)
+				})
+			case commandSetPinnedState:
+				d.FieldStruct("data", func(d *decode.D) {
+					d.FieldS32("tab_id")
+					d.FieldU8("pinned")
+				})
+			case commandSetActiveWindow:
+				d.FieldStruct("data", func(d *decode.D) {
+					d.FieldS32("window_id")
+				})
+			default:
+				if dataLen > 0 {
+					d.FieldRawLen("data", dataLen)
+				}
+			}
+			if d.NotEnd() {
+				d.FieldRawLen("unknown", d.BitsLeft())
+			}
+		})
+	})
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package speex
+
+// https://www.speex.org/docs/manual/speex-manual/node8.html
+
+import (
+	"bytes"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.SPEEX_PACKET,
+		Description: "Speex packet",
+		DecodeFn:    speexDecode,
+	})
+}
+
+var headerPrefix = []byte("Speex   ")
+
+var modeNames = scalar.SToSymStr{
+	0: "narrowband",
+	1: "wideband",
+	2: "ultra-wideband",
+}
+
+func speexDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	var prefix []byte
+	if d.BitsLeft() >= int64(len(headerPrefix))*8 {
+		prefix = d.PeekBytes(len(headerPrefix))
+	}
+	if !bytes.Equal(prefix, headerPrefix) {
+		// TODO: decode frames
+		d.FieldRawLen("data", d.BitsLeft())
+		return nil
+	}
+
+	d.FieldUTF8("prefix", len(headerPrefix))
+	d.FieldUTF8NullFixedLen("version", 20)
+	d.FieldS32("version_id")
+	d.FieldS32("header_size")
+	d.FieldS32("rate")
+	d.FieldS32("mode", modeNames)
+	d.FieldS32("mode_bitstream_version")
+	d.FieldS32("channels")
+	d.FieldS32("bitrate", scalar.SToSymStr{-1: "unknown"})
+	d.FieldS32("frame_size")
+	d.FieldS32("vbr")
+	d.FieldS32("frames_per_packet")
+	d.FieldS32("extra_headers")
+	d.FieldS32("reserved1")
+	d.FieldS32("reserved2")
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package spotlight
+
+// https://github.com/ydkhatri/mac_apt/blob/master/plugins/helpers/spotlight_parser.py
+// https://github.com/libyal/dtformats/blob/main/documentation/Apple%20Spotlight%20store%20database%20file%20format.asciidoc
+// TODO: metadata blocks (zlib compressed) and attribute value encoding
+// TODO: index tables
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.SPOTLIGHT_STORE,
+		Description: "Apple Spotlight store database",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    spotlightDecode,
+	})
+}
+
+// block offsets are in units of 0x1000 bytes
+const blockUnit = 0x1000
+
+const blockHeaderLen = 32
+
+const (
+	blockTypeProperties = 0x11
+	blockTypeCategories = 0x21
+	blockTypeIndexes1   = 0x41
+	blockTypeIndexes2   = 0x81
+)
+
+var blockTypeNames = scalar.UToSymStr{
+	blockTypeProperties: "properties",
+	blockTypeCategories: "categories",
+	blockTypeIndexes1:   "indexes1",
+	blockTypeIndexes2:   "indexes2",
+}
+
+func decodeBlocks(d *decode.D, name string, firstIndex uint64) {
+	seen := map[uint64]bool{}
+	d.FieldArray(name, func(d *decode.D) {
+		for index := firstIndex; index != 0; {
+			if seen[index] {
+				d.Errorf("block index %d loop", index)
+				return
+			}
+			seen[index] = true
+			blockStart := int64(index) * blockUnit * 8
+			if blockStart+blockHeaderLen*8 > d.Len() {
+				d.Errorf("block index %d outside of file", index)
+				return
+			}
+
+			d.RangeFn(blockStart, d.Len()-blockStart, func(d *decode.D) {
+				d.FieldStruct("block", func(d *decode.D) {
+					d.FieldUTF8("magic", 4, d.AssertStr("pmbd"))
+					physicalSize := d.FieldU32("physical_size")
+					logicalSize := d.FieldU32("logical_size")
+					blockType := d.FieldU32("block_type", blockTypeNames, scalar.Hex)
+					d.FieldU32("unknown0")
+					index = d.FieldU32("next_block_index")
+					d.FieldU32("unknown1")
+					d.FieldU32("unknown2")
+					if logicalSize < blockHeaderLen || logicalSize > physicalSize || int64(physicalSize-blockHeaderLen)*8 > d.BitsLeft() {
+						d.Fatalf("invalid block size logical %d physical %d", logicalSize, physicalSize)
+					}
+
+					dataLen := int64(logicalSize-blockHeaderLen) * 8
+					d.FieldStructArrayLoop("entries", "entry", func() bool { return dataLen > 0 && d.NotEnd() }, func(d *decode.D) {
+						start := d.Pos()
+						switch blockType {
+						case blockTypeProperties:
+							d.FieldU32("index")
+							d.FieldU8("value_type", scalar.Hex)
+							d.FieldU8("property_type", scalar.Hex)
+							d.FieldUTF8Null("name")
+						case blockTypeCategories:
+							d.FieldU32("index")
+							d.FieldUTF8Null("name")
+						default:
+							d.FieldRawLen("data", dataLen)
+						}
+						dataLen -= d.Pos() - start
+					})
+					if unusedLen := int64(physicalSize-logicalSize) * 8; unusedLen > 0 {
+						d.FieldRawLen("unused", unusedLen)
+					}
+				})
+			})
+		}
+	})
+}
+
+func spotlightDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	var headerSize uint64
+	var tableIndexes [4]uint64
+	d.FieldStruct("header", func(d *decode.D) {
+		d.FieldUTF8("magic", 4, d.AssertStr("8tsd"))
+		d.FieldU32("flags", scalar.Hex)
+		d.FieldArray("unknown0", func(d *decode.D) {
+			for i := 0; i < 7; i++ {
+				d.FieldU32("unknown")
+			}
+		})
+		headerSize = d.FieldU32("header_size")
+		d.FieldU32("block0_size")
+		d.FieldU32("block_size")
+		tableIndexes[0] = d.FieldU32("property_table_index")
+		tableIndexes[1] = d.FieldU32("category_table_index")
+		tableIndexes[2] = d.FieldU32("index1_table_index")
+		tableIndexes[3] = d.FieldU32("index2_table_index")
+		d.FieldU32("index2_table_index2")
+		if headerSize < 0x244 || int64(headerSize)*8 > d.Len() {
+			d.Fatalf("invalid header size %d", headerSize)
+		}
+		d.FieldRawLen("unknown1", (0x144-d.Pos()/8)*8)
+		d.FieldUTF8NullFixedLen("original_path", 256)
+		d.FieldRawLen("unknown2", int64(headerSize)*8-d.Pos())
+	})
+
+	for i, name := range []string{"property_table", "category_table", "index1_table", "index2_table"} {
+		if tableIndexes[i] != 0 {
+			decodeBlocks(d, name, tableIndexes[i])
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+// https://www.sqlite.org/fileformat2.html#walformat
+// TODO: database file format
+
+import (
+	"encoding/binary"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.SQLITE_WAL,
+		Description: "SQLite write-ahead log",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    walDecode,
+	})
+}
+
+const (
+	walMagicLE = 0x377f0682
+	walMagicBE = 0x377f0683
+)
+
+var walMagicNames = scalar.UToScalar{
+	walMagicLE: {Description: "little endian checksums"},
+	walMagicBE: {Description: "big endian checksums"},
+}
+
+const (
+	walHeaderLen      = 32
+	walFrameHeaderLen = 24
+)
+
+// walChecksum continues checksum s0, s1 over b, b length must be a multiple of 8
+func walChecksum(bo binary.ByteOrder, s0, s1 uint32, b []byte) (uint32, uint32) {
+	for i := 0; i+8 <= len(b); i += 8 {
+		s0 += bo.Uint32(b[i:]) + s1
+		s1 += bo.Uint32(b[i+4:]) + s0
+	}
+	return s0, s1
+}
+
+func walDecode(d *decode.D, in interface{}) interface{} {
+	var bo binary.ByteOrder
+	var pageSize uint64
+	var salt1, salt2 uint64
+	var s0, s1 uint32
+
+	d.FieldStruct("header", func(d *decode.D) {
+		magic := d.FieldU32("magic", d.AssertU(walMagicLE, walMagicBE), walMagicNames, scalar.Hex)
+		bo = binary.LittleEndian
+		if magic == walMagicBE {
+			bo = binary.BigEndian
+		}
+		d.FieldU32("file_format_version")
+		pageSize = d.FieldU32("page_size", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+			// 65536 does not fit in 16 bits used by the database header so is stored as 1
+			if s.ActualU() == 1 {
+				s.Sym = uint64(65536)
+			}
+			return s, nil
+		}))
+		if pageSize == 1 {
+			pageSize = 65536
+		}
+		if pageSize < 512 || pageSize > 65536 || pageSize&(pageSize-1) != 0 {
+			d.Fatalf("invalid page size %d", pageSize)
+		}
+		d.FieldU32("checkpoint_sequence")
+		salt1 = d.FieldU32("salt1", scalar.Hex)
+		salt2 = d.FieldU32("salt2", scalar.Hex)
+		s0, s1 = walChecksum(bo, 0, 0, d.BytesRange(0, walHeaderLen-8))
+		d.FieldU32("checksum1", d.ValidateU(uint64(s0)), scalar.Hex)
+		d.FieldU32("checksum2", d.ValidateU(uint64(s1)), scalar.Hex)
+	})
+
+	frameLen := int64(walFrameHeaderLen+pageSize) * 8
+	d.FieldStructArrayLoop("frames", "frame", func() bool { return d.BitsLeft() >= frameLen }, func(d *decode.D) {
+		frameStart := d.Pos()
+		// checksum is cumulative over first 8 bytes of frame header and page data of all frames
+		s0, s1 = walChecksum(bo, s0, s1, d.BytesRange(frameStart, 8))
+		s0, s1 = walChecksum(bo, s0, s1, d.BytesRange(frameStart+walFrameHeaderLen*8, int(pageSize)))
+
+		d.FieldU32("page_number")
+		d.FieldU32("commit_size", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+			// database size in pages after commit for commit frames, zero for other frames
+			if s.ActualU() != 0 {
+				s.Description = "commit"
+			}
+			return s, nil
+		}))
+		// frames with salt not matching header are left over from a previous checkpoint
+		d.FieldU32("salt1", d.ValidateU(salt1), scalar.Hex)
+		d.FieldU32("salt2", d.ValidateU(salt2), scalar.Hex)
+		d.FieldU32("checksum1", d.ValidateU(uint64(s0)), scalar.Hex)
+		d.FieldU32("checksum2", d.ValidateU(uint64(s1)), scalar.Hex)
+		d.FieldRawLen("page", int64(pageSize)*8)
+	})
+	if d.NotEnd() {
+		d.FieldRawLen("unknown", d.BitsLeft())
+	}
+
+	return nil
+}
@@ -0,0 +1,191 @@
+package ssh
+
+// https://the.earth.li/~sgtatham/putty/0.76/htmldoc/AppendixC.html
+
+import (
+	"bytes"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.PPK,
+		Description: "PuTTY private key",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    ppkDecode,
+	})
+}
+
+const ppkMagic = "PuTTY-User-Key-File-"
+
+// optional key derivation lines, present in version 3 encrypted keys
+var ppkKDFLines = []struct {
+	key     string
+	name    string
+	numeric bool
+}{
+	{"Key-Derivation", "key_derivation", false},
+	{"Argon2-Memory", "argon2_memory", true},
+	{"Argon2-Passes", "argon2_passes", true},
+	{"Argon2-Parallelism", "argon2_parallelism", true},
+	{"Argon2-Salt", "argon2_salt", false},
+}
+
+// peekLine returns next line without line ending and its length including line ending
+func peekLine(d *decode.D) (string, int) {
+	bs := d.PeekBytes(int(d.BitsLeft() / 8))
+	n := bytes.IndexByte(bs, '\n')
+	if n == -1 {
+		n = len(bs)
+	} else {
+		n++
+	}
+	return strings.TrimRight(string(bs[0:n]), "\r\n"), n
+}
+
+// peekKeyValue returns key and value of next "Key: value" line and its length
+func peekKeyValue(d *decode.D) (string, string, int) {
+	line, n := peekLine(d)
+	i := strings.Index(line, ": ")
+	if i == -1 {
+		return "", "", n
+	}
+	return line[0:i], line[i+2:], n
+}
+
+// fieldKeyValue adds a "Key: value" line with value as string, range includes the whole line
+func fieldKeyValue(d *decode.D, name string, key string, sms ...scalar.Mapper) string {
+	return d.FieldStrFn(name, func(d *decode.D) string {
+		k, v, n := peekKeyValue(d)
+		if k != key {
+			d.Fatalf("expected %q line found %q", key, k)
+		}
+		d.SeekRel(int64(n) * 8)
+		return v
+	}, sms...)
+}
+
+// fieldKeyValueU is like fieldKeyValue but value is a decimal number
+func fieldKeyValueU(d *decode.D, name string, key string, sms ...scalar.Mapper) uint64 {
+	return d.FieldUFn(name, func(d *decode.D) uint64 {
+		k, v, n := peekKeyValue(d)
+		if k != key {
+			d.Fatalf("expected %q line found %q", key, k)
+		}
+		u, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			d.Fatalf("%s: %s", key, err)
+		}
+		d.SeekRel(int64(n) * 8)
+		return u
+	}, sms...)
+}
+
+// fieldBase64Lines adds nLines of base64 as a string without line endings and returns decoded bytes
+func fieldBase64Lines(d *decode.D, name string, nLines int) []byte {
+	b64 := d.FieldStrFn(name, func(d *decode.D) string {
+		var sb strings.Builder
+		for i := 0; i < nLines; i++ {
+			line, n := peekLine(d)
+			if n == 0 {
+				d.Fatalf("expected %d base64 lines found %d", nLines, i)
+			}
+			sb.WriteString(line)
+			d.SeekRel(int64(n) * 8)
+		}
+		return sb.String()
+	})
+	bs, err := base64.StdEncoding.DecodeString(b64)
+	if err != nil {
+		d.Fatalf("%s: base64: %s", name, err)
+	}
+	return bs
+}
+
+// decodePPKPrivateKey decodes private key blob, unlike openssh it only has the private parts
+func decodePPKPrivateKey(d *decode.D, keyType string) {
+	switch keyType {
+	case "ssh-rsa":
+		fieldBytes(d, "d")
+		fieldBytes(d, "p")
+		fieldBytes(d, "q")
+		fieldBytes(d, "iqmp")
+	case "ssh-dss":
+		fieldBytes(d, "x")
+	case "ssh-ed25519",
+		"ssh-ed448",
+		"ecdsa-sha2-nistp256",
+		"ecdsa-sha2-nistp384",
+		"ecdsa-sha2-nistp521":
+		fieldBytes(d, "private_key")
+	}
+	if d.NotEnd() {
+		d.FieldRawLen("unknown", d.BitsLeft())
+	}
+}
+
+func ppkDecode(d *decode.D, in interface{}) interface{} {
+	line, _ := peekLine(d)
+	if !strings.HasPrefix(line, ppkMagic) {
+		d.Fatalf("no %q line", ppkMagic)
+	}
+
+	d.FieldUFn("version", func(d *decode.D) uint64 {
+		k, _, _ := peekKeyValue(d)
+		v, err := strconv.ParseUint(strings.TrimPrefix(k, ppkMagic), 10, 64)
+		if err != nil {
+			d.Fatalf("version: %s", err)
+		}
+		d.SeekRel(int64(len(k)+len(": ")) * 8)
+		return v
+	})
+	algorithm := d.FieldStrFn("algorithm", func(d *decode.D) string {
+		v, n := peekLine(d)
+		d.SeekRel(int64(n) * 8)
+		return v
+	})
+	encryption := fieldKeyValue(d, "encryption", "Encryption")
+	fieldKeyValue(d, "comment", "Comment")
+
+	publicLines := fieldKeyValueU(d, "public_lines", "Public-Lines")
+	publicKey := fieldBase64Lines(d, "public_base64", int(publicLines))
+	d.FieldStructRootBitBufFn("public_key", bitio.NewBufferFromBytes(publicKey, -1), decodePublicKey)
+
+	for _, l := range ppkKDFLines {
+		if k, _, _ := peekKeyValue(d); k != l.key {
+			continue
+		}
+		if l.numeric {
+			fieldKeyValueU(d, l.name, l.key)
+		} else {
+			fieldKeyValue(d, l.name, l.key)
+		}
+	}
+
+	privateLines := fieldKeyValueU(d, "private_lines", "Private-Lines")
+	privateKey := fieldBase64Lines(d, "private_base64", int(privateLines))
+	d.FieldStructRootBitBufFn("private_key", bitio.NewBufferFromBytes(privateKey, -1), func(d *decode.D) {
+		if encryption != "none" {
+			d.FieldRawLen("encrypted", d.BitsLeft())
+			return
+		}
+		decodePPKPrivateKey(d, algorithm)
+	})
+
+	// version 1 used a hash instead of a mac
+	if k, _, _ := peekKeyValue(d); k == "Private-Hash" {
+		fieldKeyValue(d, "private_hash", "Private-Hash")
+	} else {
+		fieldKeyValue(d, "private_mac", "Private-MAC")
+	}
+
+	return nil
+}
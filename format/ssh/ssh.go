@@ -0,0 +1,114 @@
+package ssh
+
+// SSH wire format helpers shared by ssh key formats
+// https://datatracker.ietf.org/doc/html/rfc4251#section-5
+// https://github.com/openssh/openssh-portable/blob/master/PROTOCOL.key
+
+import (
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+// fieldString adds a uint32 length prefixed string, range includes the length
+func fieldString(d *decode.D, name string, sms ...scalar.Mapper) string {
+	return d.FieldStrFn(name, func(d *decode.D) string {
+		l := d.U32()
+		if int64(l) > d.BitsLeft()/8 {
+			d.Fatalf("string length %d larger than rest of input", l)
+		}
+		return d.UTF8(int(l))
+	}, sms...)
+}
+
+// fieldBytes adds uint32 length prefixed binary data, range includes the length
+func fieldBytes(d *decode.D, name string) *bitio.Buffer {
+	return d.FieldBitBufFn(name, func(d *decode.D) *bitio.Buffer {
+		return d.RawLen(int64(d.U32()) * 8)
+	}, scalar.RawHex)
+}
+
+// fieldStringFn adds a struct with uint32 length followed by fields decoded by fn
+func fieldStringFn(d *decode.D, name string, fn func(d *decode.D)) {
+	d.FieldStruct(name, func(d *decode.D) {
+		length := d.FieldU32("length")
+		d.LenFn(int64(length)*8, fn)
+	})
+}
+
+// decodePublicKey decodes a public key blob, key type and then type specific fields
+func decodePublicKey(d *decode.D) {
+	keyType := fieldString(d, "key_type")
+	switch keyType {
+	case "ssh-rsa":
+		fieldBytes(d, "e")
+		fieldBytes(d, "n")
+	case "ssh-dss":
+		fieldBytes(d, "p")
+		fieldBytes(d, "q")
+		fieldBytes(d, "g")
+		fieldBytes(d, "y")
+	case "ssh-ed25519":
+		fieldBytes(d, "public_key")
+	case "ecdsa-sha2-nistp256",
+		"ecdsa-sha2-nistp384",
+		"ecdsa-sha2-nistp521":
+		fieldString(d, "curve")
+		fieldBytes(d, "public_key")
+	case "sk-ssh-ed25519@openssh.com":
+		fieldBytes(d, "public_key")
+		fieldString(d, "application")
+	case "sk-ecdsa-sha2-nistp256@openssh.com":
+		fieldString(d, "curve")
+		fieldBytes(d, "public_key")
+		fieldString(d, "application")
+	}
+	if d.NotEnd() {
+		d.FieldRawLen("unknown", d.BitsLeft())
+	}
+}
+
+// decodePrivateKey decodes type specific fields of a private key, returns false if key type is unknown
+func decodePrivateKey(d *decode.D, keyType string) bool {
+	switch keyType {
+	case "ssh-rsa":
+		fieldBytes(d, "n")
+		fieldBytes(d, "e")
+		fieldBytes(d, "d")
+		fieldBytes(d, "iqmp")
+		fieldBytes(d, "p")
+		fieldBytes(d, "q")
+	case "ssh-dss":
+		fieldBytes(d, "p")
+		fieldBytes(d, "q")
+		fieldBytes(d, "g")
+		fieldBytes(d, "y")
+		fieldBytes(d, "x")
+	case "ssh-ed25519":
+		fieldBytes(d, "public_key")
+		// private key is followed by public key
+		fieldBytes(d, "private_key")
+	case "ecdsa-sha2-nistp256",
+		"ecdsa-sha2-nistp384",
+		"ecdsa-sha2-nistp521":
+		fieldString(d, "curve")
+		fieldBytes(d, "public_key")
+		fieldBytes(d, "private_key")
+	case "sk-ssh-ed25519@openssh.com":
+		fieldBytes(d, "public_key")
+		fieldString(d, "application")
+		d.FieldU8("flags", scalar.Hex)
+		fieldBytes(d, "key_handle")
+		fieldBytes(d, "reserved")
+	case "sk-ecdsa-sha2-nistp256@openssh.com":
+		fieldString(d, "curve")
+		fieldBytes(d, "public_key")
+		fieldString(d, "application")
+		d.FieldU8("flags", scalar.Hex)
+		fieldBytes(d, "key_handle")
+		fieldBytes(d, "reserved")
+	default:
+		return false
+	}
+	return true
+}
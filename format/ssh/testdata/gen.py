@@ -0,0 +1,87 @@
+# converts unencrypted openssh test keys to PuTTY ppk files
+# encrypted.ppk has random private bytes, it is only for testing the key derivation lines
+import base64
+import hashlib
+import hmac
+import struct
+
+
+def string(b):
+    return struct.pack(">I", len(b)) + b
+
+
+class Reader:
+    def __init__(self, b):
+        self.b = b
+
+    def u32(self):
+        v = struct.unpack(">I", self.b[0:4])[0]
+        self.b = self.b[4:]
+        return v
+
+    def string(self):
+        n = self.u32()
+        v = self.b[0:n]
+        self.b = self.b[n:]
+        return v
+
+
+def openssh_key(path):
+    lines = open(path).read().splitlines()
+    r = Reader(base64.b64decode("".join(lines[1:-1])))
+    r.b = r.b[len(b"openssh-key-v1\x00"):]
+    r.string(), r.string(), r.string(), r.u32()
+    public = r.string()
+    p = Reader(r.string())
+    p.u32(), p.u32()
+    key_type = p.string()
+    if key_type == b"ssh-ed25519":
+        p.string()
+        private = string(p.string()[0:32])
+    elif key_type == b"ssh-rsa":
+        n, e, d, iqmp, pp, q = [p.string() for _ in range(6)]
+        private = string(d) + string(pp) + string(q) + string(iqmp)
+    else:
+        raise Exception(key_type)
+    comment = p.string()
+    return key_type, public, private, comment
+
+
+def b64lines(b):
+    s = base64.b64encode(b).decode()
+    return [s[i:i + 64] for i in range(0, len(s), 64)]
+
+
+def ppk(version, key_type, encryption, comment, public, private, kdf=None):
+    mac_data = string(key_type) + string(encryption) + string(comment) + string(public) + string(private)
+    if version == 2:
+        mac = hmac.new(hashlib.sha1(b"putty-private-key-file-mac-key").digest(), mac_data, hashlib.sha1)
+    else:
+        mac = hmac.new(b"", mac_data, hashlib.sha256)
+    pub = b64lines(public)
+    priv = b64lines(private)
+    s = "PuTTY-User-Key-File-%d: %s\n" % (version, key_type.decode())
+    s += "Encryption: %s\n" % encryption.decode()
+    s += "Comment: %s\n" % comment.decode()
+    s += "Public-Lines: %d\n" % len(pub)
+    s += "".join(l + "\n" for l in pub)
+    for k, v in kdf or []:
+        s += "%s: %s\n" % (k, v)
+    s += "Private-Lines: %d\n" % len(priv)
+    s += "".join(l + "\n" for l in priv)
+    s += "Private-MAC: %s\n" % mac.hexdigest()
+    return s
+
+
+key_type, public, private, comment = openssh_key("id_ed25519")
+open("ed25519.ppk", "w").write(ppk(3, key_type, b"none", comment, public, private))
+key_type, public, private, comment = openssh_key("id_rsa")
+open("rsa.ppk", "w").write(ppk(2, key_type, b"none", comment, public, private))
+key_type, public, private, comment = openssh_key("id_ed25519")
+open("encrypted.ppk", "w").write(ppk(3, key_type, b"aes256-cbc", comment, public, bytes(range(48)), kdf=[
+    ("Key-Derivation", "Argon2id"),
+    ("Argon2-Memory", "8192"),
+    ("Argon2-Passes", "13"),
+    ("Argon2-Parallelism", "1"),
+    ("Argon2-Salt", "000102030405060708090a0b0c0d0e0f"),
+]))
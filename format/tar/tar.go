@@ -1,7 +1,7 @@
 package tar
 
 // https://www.gnu.org/software/tar/manual/html_node/Standard.html
-// TODO: extensions?
+// https://pubs.opengroup.org/onlinepubs/9699919799/utilities/pax.html#tag_20_92_13_03
 
 import (
 	"bytes"
@@ -28,6 +28,68 @@ func init() {
 	})
 }
 
+const (
+	typeFlagPaxExtendedHeader = "x"
+	typeFlagPaxGlobalHeader   = "g"
+	typeFlagGNULongName       = "L"
+	typeFlagGNULongLinkName   = "K"
+)
+
+var typeFlagMap = scalar.StrToScalar{
+	"":                        {Description: "Regular file"},
+	"0":                       {Description: "Regular file"},
+	"1":                       {Description: "Hard link"},
+	"2":                       {Description: "Symbolic link"},
+	"3":                       {Description: "Character device"},
+	"4":                       {Description: "Block device"},
+	"5":                       {Description: "Directory"},
+	"6":                       {Description: "FIFO"},
+	"7":                       {Description: "Contiguous file"},
+	typeFlagPaxExtendedHeader: {Description: "pax extended header"},
+	typeFlagPaxGlobalHeader:   {Description: "pax global extended header"},
+	"D":                       {Description: "GNU directory dump"},
+	typeFlagGNULongLinkName:   {Description: "GNU long link name"},
+	typeFlagGNULongName:       {Description: "GNU long name"},
+	"M":                       {Description: "GNU multi volume continuation"},
+	"S":                       {Description: "GNU sparse file"},
+	"V":                       {Description: "GNU volume header"},
+}
+
+// pax records are "<length> <key>=<value>\n" where length includes the whole record
+func fieldPaxRecords(d *decode.D, size int64) {
+	d.FieldArray("pax_records", func(d *decode.D) {
+		d.LenFn(size, func(d *decode.D) {
+			for d.BitsLeft() >= 8 {
+				if d.PeekBits(8) == 0 {
+					break
+				}
+				bs := d.PeekBytes(int(d.BitsLeft() / 8))
+				sp := bytes.IndexByte(bs, ' ')
+				if sp == -1 {
+					break
+				}
+				length, err := strconv.Atoi(string(bs[0:sp]))
+				if err != nil || length <= sp+1 || length > len(bs) {
+					break
+				}
+				record := bs[sp+1 : length]
+				eq := bytes.IndexByte(record, '=')
+				if eq == -1 {
+					break
+				}
+				d.FieldStruct("record", func(d *decode.D) {
+					d.FieldUTF8("length", sp+1, scalar.TrimSpace)
+					d.FieldUTF8("key", eq+1, scalar.Trim("="))
+					d.FieldUTF8("value", len(record)-eq-1, scalar.Trim("\n"))
+				})
+			}
+			if d.BitsLeft() > 0 {
+				d.FieldRawLen("padding", d.BitsLeft())
+			}
+		})
+	})
+}
+
 func tarDecode(d *decode.D, in interface{}) interface{} {
 	const blockBytes = 512
 	const blockBits = blockBytes * 8
@@ -66,23 +128,54 @@ func tarDecode(d *decode.D, in interface{}) interface{} {
 				size := int64(sizeS.SymU()) * 8
 				d.FieldUTF8NullFixedLen("mtime", 12, mapOctStrToSymU)
 				d.FieldUTF8NullFixedLen("chksum", 8, mapOctStrToSymU)
-				d.FieldUTF8("typeflag", 1, mapTrimSpaceNull)
+				typeFlag := d.FieldUTF8("typeflag", 1, mapTrimSpaceNull, typeFlagMap)
 				d.FieldUTF8("linkname", 100, mapTrimSpaceNull)
+				// POSIX is "ustar\x00" and version "00", GNU is "ustar " and version " \x00"
+				isGNU := bytes.Equal(d.PeekBytes(8), []byte("ustar  \x00"))
 				magic := d.FieldUTF8("magic", 6, mapTrimSpaceNull)
 				if magic != "ustar" {
 					d.Errorf("invalid magic %s", magic)
 				}
-				d.FieldUTF8NullFixedLen("version", 2, mapOctStrToSymU)
+				if isGNU {
+					d.FieldUTF8("version", 2)
+				} else {
+					d.FieldUTF8NullFixedLen("version", 2, mapOctStrToSymU)
+				}
 				d.FieldUTF8("uname", 32, mapTrimSpaceNull)
 				d.FieldUTF8("gname", 32, mapTrimSpaceNull)
 				d.FieldUTF8NullFixedLen("devmajor", 8, mapOctStrToSymU)
 				d.FieldUTF8NullFixedLen("devminor", 8, mapOctStrToSymU)
-				d.FieldUTF8("prefix", 155, mapTrimSpaceNull)
+				if isGNU {
+					d.FieldUTF8NullFixedLen("atime", 12, mapOctStrToSymU)
+					d.FieldUTF8NullFixedLen("ctime", 12, mapOctStrToSymU)
+					d.FieldUTF8NullFixedLen("offset", 12, mapOctStrToSymU)
+					d.FieldRawLen("longnames", 4*8)
+					d.FieldRawLen("unused", 1*8)
+					d.FieldArray("sparse", func(d *decode.D) {
+						for i := 0; i < 4; i++ {
+							d.FieldStruct("sparse", func(d *decode.D) {
+								d.FieldUTF8NullFixedLen("offset", 12, mapOctStrToSymU)
+								d.FieldUTF8NullFixedLen("numbytes", 12, mapOctStrToSymU)
+							})
+						}
+					})
+					d.FieldU8("isextended")
+					d.FieldUTF8NullFixedLen("realsize", 12, mapOctStrToSymU)
+				} else {
+					d.FieldUTF8("prefix", 155, mapTrimSpaceNull)
+				}
 				d.FieldRawLen("header_block_padding", blockPadding(d), d.BitBufIsZero())
 
-				dv, _, _ := d.TryFieldFormatLen("data", size, probeFormat, nil)
-				if dv == nil {
-					d.FieldRawLen("data", size)
+				switch typeFlag {
+				case typeFlagPaxExtendedHeader, typeFlagPaxGlobalHeader:
+					fieldPaxRecords(d, size)
+				case typeFlagGNULongName, typeFlagGNULongLinkName:
+					d.FieldUTF8NullFixedLen("long_name", int(size/8))
+				default:
+					dv, _, _ := d.TryFieldFormatLen("data", size, probeFormat, nil)
+					if dv == nil {
+						d.FieldRawLen("data", size)
+					}
 				}
 
 				d.FieldRawLen("data_block_padding", blockPadding(d), d.BitBufIsZero())
@@ -0,0 +1,20 @@
+import io
+import tarfile
+
+
+def add(tf, name, data):
+    ti = tarfile.TarInfo(name)
+    ti.size = len(data)
+    ti.mtime = 1600000000
+    ti.uname = "user"
+    ti.gname = "group"
+    tf.addfile(ti, io.BytesIO(data))
+
+
+long_name = "long/" + "a" * 100 + ".txt"
+
+with tarfile.open("gnu.tar", "w", format=tarfile.GNU_FORMAT) as tf:
+    add(tf, long_name, b"hello gnu\n")
+
+with tarfile.open("pax.tar", "w", format=tarfile.PAX_FORMAT, pax_headers={"comment": "global"}) as tf:
+    add(tf, long_name, b"hello pax\n")
@@ -0,0 +1,91 @@
+package theora
+
+// https://www.theora.org/doc/Theora.pdf
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var vorbisComment decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.THEORA_PACKET,
+		Description: "Theora packet",
+		DecodeFn:    theoraDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.VORBIS_COMMENT}, Group: &vorbisComment},
+		},
+	})
+}
+
+const (
+	packetTypeIdentification = 0x80
+	packetTypeComment        = 0x81
+	packetTypeSetup          = 0x82
+)
+
+var packetTypeNames = scalar.UToSymStr{
+	packetTypeIdentification: "identification",
+	packetTypeComment:        "comment",
+	packetTypeSetup:          "setup",
+}
+
+var colorSpaceNames = scalar.UToSymStr{
+	0: "undefined",
+	1: "rec_470m",
+	2: "rec_470bg",
+}
+
+var pixelFormatNames = scalar.UToSymStr{
+	0: "4:2:0",
+	1: "reserved",
+	2: "4:2:2",
+	3: "4:4:4",
+}
+
+func theoraDecode(d *decode.D, in interface{}) interface{} {
+	// data packets has the first bit set to zero
+	if d.PeekBits(1) == 0 {
+		d.FieldRawLen("data", d.BitsLeft())
+		return nil
+	}
+
+	packetType := d.FieldU8("packet_type", packetTypeNames, scalar.Hex)
+	d.FieldRawMagic("magic", []byte("theora"))
+
+	switch packetType {
+	case packetTypeIdentification:
+		d.FieldU8("version_major")
+		d.FieldU8("version_minor")
+		d.FieldU8("version_revision")
+		d.FieldU16("frame_width_mbs")
+		d.FieldU16("frame_height_mbs")
+		d.FieldU24("picture_width")
+		d.FieldU24("picture_height")
+		d.FieldU8("picture_x")
+		d.FieldU8("picture_y")
+		d.FieldU32("frame_rate_numerator")
+		d.FieldU32("frame_rate_denominator")
+		d.FieldU24("aspect_ratio_numerator")
+		d.FieldU24("aspect_ratio_denominator")
+		d.FieldU8("color_space", colorSpaceNames)
+		d.FieldU24("nominal_bitrate")
+		d.FieldU6("quality")
+		d.FieldU5("keyframe_granule_shift")
+		d.FieldU2("pixel_format", pixelFormatNames)
+		d.FieldU3("reserved", d.ValidateU(0))
+	case packetTypeComment:
+		d.FieldFormat("comment", vorbisComment, nil)
+	case packetTypeSetup:
+		// TODO: loop filter limits, quantization parameters and huffman tables
+		d.FieldRawLen("setup", d.BitsLeft())
+	default:
+		d.FieldRawLen("data", d.BitsLeft())
+	}
+
+	return nil
+}
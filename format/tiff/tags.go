@@ -795,3 +795,51 @@ var gpsInfoTagNames = scalar.UToSymStr{
 	GPSDifferential:      "GPSDifferential",
 	GPSHPositioningError: "GPSHPositioningError",
 }
+
+const (
+	InteroperabilityIndex   = 0x0001
+	InteroperabilityVersion = 0x0002
+	RelatedImageFileFormat  = 0x1000
+	RelatedImageWidth       = 0x1001
+	RelatedImageLength      = 0x1002
+)
+
+var interopTagNames = scalar.UToSymStr{
+	InteroperabilityIndex:   "InteroperabilityIndex",
+	InteroperabilityVersion: "InteroperabilityVersion",
+	RelatedImageFileFormat:  "RelatedImageFileFormat",
+	RelatedImageWidth:       "RelatedImageWidth",
+	RelatedImageLength:      "RelatedImageLength",
+}
+
+// EXIF LightSource values, also used by DNG CalibrationIlluminant1/2
+var lightSourceNames = scalar.UToSymStr{
+	0:   "Unknown",
+	1:   "Daylight",
+	2:   "Fluorescent",
+	3:   "Tungsten",
+	4:   "Flash",
+	9:   "Fine weather",
+	10:  "Cloudy",
+	11:  "Shade",
+	12:  "Daylight fluorescent",
+	13:  "Day white fluorescent",
+	14:  "Cool white fluorescent",
+	15:  "White fluorescent",
+	16:  "Warm white fluorescent",
+	17:  "Standard light A",
+	18:  "Standard light B",
+	19:  "Standard light C",
+	20:  "D55",
+	21:  "D65",
+	22:  "D75",
+	23:  "D50",
+	24:  "ISO studio tungsten",
+	255: "Other",
+}
+
+var tagValueMappers = map[uint64]scalar.Mapper{
+	LightSource:            lightSourceNames,
+	CalibrationIlluminant1: lightSourceNames,
+	CalibrationIlluminant2: lightSourceNames,
+}
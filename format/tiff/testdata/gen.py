@@ -0,0 +1,203 @@
+#!/usr/bin/env python3
+# generates little and big endian tiff files with ascii and rational tags,
+# exif and gps sub ifds and a second ifd, and a canon raw (CR2) file with
+# the extended header and an image, thumbnail, and RAW IFD chain, and a DNG
+# file with DNG specific tags and a raw image sub ifd
+import struct
+
+ASCII = 2
+SHORT = 3
+LONG = 4
+RATIONAL = 5
+BYTE = 1
+SRATIONAL = 10
+
+
+def tiff(endian):
+    e = "<" if endian == b"II" else ">"
+    out = bytearray(endian + struct.pack(e + "HI", 42, 8))
+
+    def ifd(entries, next_ifd_fn=None):
+        # entries: (tag, type, count, data bytes)
+        pos = len(out)
+        size = 2 + len(entries) * 12 + 4
+        data_pos = pos + size
+        body = bytearray(struct.pack(e + "H", len(entries)))
+        extra = bytearray()
+        for tag, typ, count, data in entries:
+            if len(data) <= 4:
+                body += struct.pack(e + "HHI", tag, typ, count)[:8] + data.ljust(4, b"\0")
+            else:
+                body += struct.pack(e + "HHII", tag, typ, count, data_pos + len(extra))
+                extra += data
+                if len(extra) % 2:
+                    extra += b"\0"
+        next_pos = len(body) + 4
+        out.extend(body + b"\0\0\0\0" + extra)
+        return pos, pos + next_pos - 4
+
+    def patch(pos, v):
+        out[pos:pos + 4] = struct.pack(e + "I", v)
+
+    def short(v):
+        return struct.pack(e + "H", v)
+
+    def long(v):
+        return struct.pack(e + "I", v)
+
+    def rational(n, d):
+        return struct.pack(e + "II", n, d)
+
+    # pixel data strip
+    strip = b"\xf0\xf0\xf0\xf0"
+
+    _, next0 = ifd([
+        (0x0100, SHORT, 1, short(2)),  # ImageWidth
+        (0x0101, SHORT, 1, short(2)),  # ImageLength
+        (0x0102, SHORT, 1, short(8)),  # BitsPerSample
+        (0x0106, SHORT, 1, short(1)),  # PhotometricInterpretation
+        (0x010f, ASCII, 5, b"Test\0"),  # Make
+        (0x0111, LONG, 1, long(0)),  # StripOffsets, patched below
+        (0x0117, LONG, 1, long(len(strip))),  # StripByteCounts
+        (0x011a, RATIONAL, 1, rational(72, 1)),  # XResolution
+        (0x011b, RATIONAL, 1, rational(300, 2)),  # YResolution
+        (0x0131, ASCII, 10, b"fq gen.py\0"),  # Software
+        (0x8769, LONG, 1, long(0)),  # ExifIFD, patched below
+        (0x8825, LONG, 1, long(0)),  # GPSInfo, patched below
+    ])
+    # entry offsets: 8 + 2 + i*12 + 8
+    def entry_value_pos(i):
+        return 8 + 2 + i * 12 + 8
+
+    patch(entry_value_pos(10), len(out))
+    ifd([
+        (0x829a, RATIONAL, 1, rational(1, 250)),  # ExposureTime
+        (0x9003, ASCII, 20, b"2021:01:02 03:04:05\0"),  # DateTimeOriginal
+    ])
+    patch(entry_value_pos(11), len(out))
+    ifd([
+        (0x0001, ASCII, 2, b"N\0"),  # GPSLatitudeRef
+        (0x0002, RATIONAL, 3, rational(59, 1) + rational(20, 1) + rational(1234, 100)),  # GPSLatitude
+    ])
+    patch(next0, len(out))
+    ifd([
+        (0x0100, SHORT, 1, short(1)),  # ImageWidth
+        (0x0101, SHORT, 1, short(1)),  # ImageLength
+    ])
+    patch(entry_value_pos(5), len(out))
+    out.extend(strip)
+
+    return bytes(out)
+
+
+def cr2():
+    e = "<"
+    # header with CR2 magic, version 2.0 and RAW IFD offset, patched below
+    out = bytearray(b"II" + struct.pack(e + "HI", 42, 16) + b"CR\x02\x00" + struct.pack(e + "I", 0))
+
+    def ifd(entries, data):
+        # entries: (tag, type, count, value) with data placed after the ifd
+        pos = len(out)
+        data_pos = pos + 2 + len(entries) * 12 + 4
+        out.extend(struct.pack(e + "H", len(entries)))
+        for tag, typ, count, value in entries:
+            if value is None:
+                value = data_pos
+            out.extend(struct.pack(e + "HHII", tag, typ, count, value))
+        next_pos = len(out)
+        out.extend(struct.pack(e + "I", 0) + data)
+        return pos, next_pos
+
+    def strip_ifd(data, extra=[]):
+        return ifd(extra + [
+            (0x0111, LONG, 1, None),  # StripOffsets
+            (0x0117, LONG, 1, len(data)),  # StripByteCounts
+        ], data)
+
+    # image, thumbnail, unused and RAW ifd
+    _, next0 = strip_ifd(b"\xff\xd8\xff\xd9", [
+        (0x010f, ASCII, 4, struct.unpack(e + "I", b"Can\0")[0]),  # Make
+    ])
+    pos1, next1 = ifd([
+        (0x0201, LONG, 1, 0),  # JPEGInterchangeFormat
+        (0x0202, LONG, 1, 0),  # JPEGInterchangeFormatLength
+    ], b"")
+    pos2, next2 = strip_ifd(b"\x00\x01")
+    pos3, _ = strip_ifd(b"\x01\x02\x03\x04")
+
+    out[next0:next0 + 4] = struct.pack(e + "I", pos1)
+    out[next1:next1 + 4] = struct.pack(e + "I", pos2)
+    out[next2:next2 + 4] = struct.pack(e + "I", pos3)
+    out[12:16] = struct.pack(e + "I", pos3)
+
+    return bytes(out)
+
+
+def dng():
+    e = "<"
+    out = bytearray(b"II" + struct.pack(e + "HI", 42, 8))
+
+    def ifd(entries):
+        # entries: (tag, type, count, data bytes), larger values placed after the ifd
+        pos = len(out)
+        data_pos = pos + 2 + len(entries) * 12 + 4
+        body = bytearray(struct.pack(e + "H", len(entries)))
+        extra = bytearray()
+        value_pos = {}
+        for tag, typ, count, data in entries:
+            value_pos[tag] = pos + len(body) + 8
+            if len(data) <= 4:
+                body += struct.pack(e + "HHI", tag, typ, count) + data.ljust(4, b"\0")
+            else:
+                body += struct.pack(e + "HHII", tag, typ, count, data_pos + len(extra))
+                extra += data
+        out.extend(body + b"\0\0\0\0" + extra)
+        return value_pos
+
+    def shorts(*vs):
+        return struct.pack(e + "%dH" % len(vs), *vs)
+
+    def long(v):
+        return struct.pack(e + "I", v)
+
+    def srationals(*vs):
+        return b"".join(struct.pack(e + "ii", n, d) for n, d in vs)
+
+    def rationals(*vs):
+        return b"".join(struct.pack(e + "II", n, d) for n, d in vs)
+
+    raw = b"\x10\x20\x30\x40\x50\x60\x70\x80"
+
+    ifd0 = ifd([
+        (0x00fe, LONG, 1, long(1)),  # NewSubfileType, reduced resolution preview
+        (0x010f, ASCII, 5, b"Test\0"),  # Make
+        (0x014a, LONG, 1, long(0)),  # SubIFDs, patched below
+        (0xc612, BYTE, 4, bytes([1, 4, 0, 0])),  # DNGVersion
+        (0xc613, BYTE, 4, bytes([1, 1, 0, 0])),  # DNGBackwardVersion
+        (0xc614, ASCII, 10, b"Test Cam1\0"),  # UniqueCameraModel
+        (0xc621, SRATIONAL, 9, srationals((1, 1), (0, 1), (0, 1), (0, 1), (1, 1), (0, 1), (0, 1), (0, 1), (-1, 2))),  # ColorMatrix1
+        (0xc628, RATIONAL, 3, rationals((1, 2), (1, 1), (2, 3))),  # AsShotNeutral
+        (0xc62a, SRATIONAL, 1, srationals((-1, 2))),  # BaselineExposure
+        (0xc65a, SHORT, 1, shorts(21)),  # CalibrationIlluminant1, D65
+        (0xc65b, SHORT, 1, shorts(17)),  # CalibrationIlluminant2, Standard light A
+    ])
+    out[ifd0[0x014a]:ifd0[0x014a] + 4] = long(len(out))
+    sub = ifd([
+        (0x00fe, LONG, 1, long(0)),  # NewSubfileType, full resolution
+        (0x0100, SHORT, 1, shorts(2)),  # ImageWidth
+        (0x0101, SHORT, 1, shorts(2)),  # ImageLength
+        (0x0111, LONG, 1, long(0)),  # StripOffsets, patched below
+        (0x0117, LONG, 1, long(len(raw))),  # StripByteCounts
+        (0x828d, SHORT, 2, shorts(2, 2)),  # CFARepeatPatternDim
+        (0x828e, BYTE, 4, bytes([0, 1, 1, 2])),  # CFAPattern
+    ])
+    out[sub[0x0111]:sub[0x0111] + 4] = long(len(out))
+    out.extend(raw)
+
+    return bytes(out)
+
+
+open("le.tiff", "wb").write(tiff(b"II"))
+open("be.tiff", "wb").write(tiff(b"MM"))
+open("test.cr2", "wb").write(cr2())
+open("test.dng", "wb").write(dng())
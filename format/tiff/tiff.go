@@ -1,6 +1,8 @@
 package tiff
 
 // https://www.adobe.io/content/dam/udp/en/open/standards/tiff/TIFF6.pdf
+// CR2 header: http://lclevy.free.fr/cr2/
+// DNG: https://helpx.adobe.com/camera-raw/digital-negative.html
 
 import (
 	"github.com/wader/fq/format"
@@ -40,6 +42,7 @@ const (
 	UNDEFINED = 7
 	SLONG     = 9
 	SRATIONAL = 10
+	IFD       = 13
 )
 
 var typeNames = scalar.UToSymStr{
@@ -51,6 +54,7 @@ var typeNames = scalar.UToSymStr{
 	UNDEFINED: "UNDEFINED",
 	SLONG:     "SLONG",
 	SRATIONAL: "SRATIONAL",
+	IFD:       "IFD",
 }
 
 // TODO: tiff 6.0 types
@@ -63,6 +67,7 @@ var typeByteSize = map[uint64]uint64{
 	UNDEFINED: 1,
 	SLONG:     4,
 	SRATIONAL: 4 + 4,
+	IFD:       4,
 }
 
 func fieldRational(d *decode.D, name string) float64 {
@@ -70,7 +75,7 @@ func fieldRational(d *decode.D, name string) float64 {
 	d.FieldStruct(name, func(d *decode.D) {
 		numerator := d.FieldU32("numerator")
 		denominator := d.FieldU32("denominator")
-		v := float64(numerator) / float64(denominator)
+		v = float64(numerator) / float64(denominator)
 		d.FieldValueFloat("float", v)
 	})
 	return v
@@ -81,7 +86,7 @@ func fieldSRational(d *decode.D, name string) float64 {
 	d.FieldStruct(name, func(d *decode.D) {
 		numerator := d.FieldS32("numerator")
 		denominator := d.FieldS32("denominator")
-		v := float64(numerator) / float64(denominator)
+		v = float64(numerator) / float64(denominator)
 		d.FieldValueFloat("float", v)
 	})
 	return v
@@ -92,9 +97,16 @@ type strips struct {
 	byteCounts []int64
 }
 
-func decodeIfd(d *decode.D, s *strips, tagNames scalar.UToSymStr) int64 {
+// ifdSeen is used to catch infinite loops
+func decodeIfd(d *decode.D, s *strips, ifdSeen map[int64]struct{}, tagNames scalar.UToSymStr) int64 {
 	var nextIfdOffset int64
 
+	ifdOffset := d.Pos() / 8
+	if _, ok := ifdSeen[ifdOffset]; ok {
+		d.Fatalf("ifd loop detected for %d", ifdOffset)
+	}
+	ifdSeen[ifdOffset] = struct{}{}
+
 	d.FieldStruct("ifd", func(d *decode.D) {
 		numberOfFields := d.FieldU16("number_of_field")
 		d.FieldArray("entries", func(d *decode.D) {
@@ -117,19 +129,38 @@ func decodeIfd(d *decode.D, s *strips, tagNames scalar.UToSymStr) int64 {
 					}
 
 					switch {
-					case typ == LONG && (tag == ExifIFD || tag == GPSInfo):
+					case typ == LONG && (tag == ExifIFD || tag == GPSInfo || tag == InteroperabilityIFD):
 						ifdPos := valueOrByteOffset
 						pos := d.Pos()
 						d.SeekAbs(int64(ifdPos * 8))
 
 						switch tag {
 						case ExifIFD:
-							// TODO: exif tag names?
-							decodeIfd(d, &strips{}, tiffTagNames)
+							decodeIfd(d, &strips{}, ifdSeen, tiffTagNames)
 						case GPSInfo:
-							decodeIfd(d, &strips{}, gpsInfoTagNames)
+							decodeIfd(d, &strips{}, ifdSeen, gpsInfoTagNames)
+						case InteroperabilityIFD:
+							decodeIfd(d, &strips{}, ifdSeen, interopTagNames)
 						}
 
+						d.SeekAbs(pos)
+					case (typ == LONG || typ == IFD) && tag == SubIFDs:
+						// DNG uses SubIFDs for the full-resolution raw image etc
+						var subIfdOffsets []int64
+						d.FieldArray("values", func(d *decode.D) {
+							d.RangeFn(int64(valueByteOffset*8), int64(valueByteSize*8), func(d *decode.D) {
+								for i := uint64(0); i < count; i++ {
+									subIfdOffsets = append(subIfdOffsets, int64(d.FieldU32("value")))
+								}
+							})
+						})
+						pos := d.Pos()
+						d.FieldArray("ifds", func(d *decode.D) {
+							for _, o := range subIfdOffsets {
+								d.SeekAbs(o * 8)
+								decodeIfd(d, s, ifdSeen, tiffTagNames)
+							}
+						})
 						d.SeekAbs(pos)
 					default:
 
@@ -148,6 +179,12 @@ func decodeIfd(d *decode.D, s *strips, tagNames scalar.UToSymStr) int64 {
 								d.RangeFn(int64(valueByteOffset*8), int64(valueByteSize*8), func(d *decode.D) {
 									d.FieldUTF8NullFixedLen("value", int(valueByteSize))
 								})
+							case typ == BYTE && (tag == DNGVersion || tag == DNGBackwardVersion):
+								d.RangeFn(int64(valueByteOffset*8), int64(valueByteSize*8), func(d *decode.D) {
+									for i := uint64(0); i < count; i++ {
+										d.FieldU8("value")
+									}
+								})
 							case typ == BYTE:
 								d.RangeFn(int64(valueByteOffset*8), int64(valueByteSize*8), func(d *decode.D) {
 									d.FieldRawLen("value", d.BitsLeft())
@@ -160,7 +197,12 @@ func decodeIfd(d *decode.D, s *strips, tagNames scalar.UToSymStr) int64 {
 										case BYTE:
 											d.FieldU8("value")
 										case SHORT:
-											v := d.FieldU16("value")
+											var v uint64
+											if m, ok := tagValueMappers[tag]; ok {
+												v = d.FieldU16("value", m)
+											} else {
+												v = d.FieldU16("value")
+											}
 											_ = v
 											switch tag {
 											case StripOffsets:
@@ -168,7 +210,7 @@ func decodeIfd(d *decode.D, s *strips, tagNames scalar.UToSymStr) int64 {
 											case StripByteCounts:
 												s.byteCounts = append(s.byteCounts, int64(v*8))
 											}
-										case LONG:
+										case LONG, IFD:
 											v := d.FieldU32("value")
 											_ = v
 											switch tag {
@@ -221,17 +263,26 @@ func tiffDecode(d *decode.D, in interface{}) interface{} {
 	ifdOffset := int64(d.FieldU32("first_ifd"))
 	s := &strips{}
 
-	// to catch infinite loops
+	// canon raw (CR2) has an extended header with an offset to the RAW IFD
+	var cr2RawIfdOffset int64
+	if d.BitsLeft() >= 8*8 && d.PeekBits(16) == 'C'<<8|'R' {
+		d.FieldUTF8("cr2_magic", 2)
+		d.FieldU8("cr2_major_version")
+		d.FieldU8("cr2_minor_version")
+		cr2RawIfdOffset = int64(d.FieldU32("cr2_raw_ifd_offset"))
+	}
+
 	ifdSeen := map[int64]struct{}{}
 
 	d.FieldArray("ifds", func(d *decode.D) {
 		for ifdOffset != 0 {
-			if _, ok := ifdSeen[ifdOffset]; ok {
-				d.Fatalf("ifd loop detected for %d", ifdOffset)
-			}
-			ifdSeen[ifdOffset] = struct{}{}
 			d.SeekAbs(ifdOffset * 8)
-			ifdOffset = decodeIfd(d, s, tiffTagNames)
+			ifdOffset = decodeIfd(d, s, ifdSeen, tiffTagNames)
+		}
+		// RAW IFD is usually last in the IFD chain but decode it if it's not
+		if _, ok := ifdSeen[cr2RawIfdOffset]; cr2RawIfdOffset != 0 && !ok {
+			d.SeekAbs(cr2RawIfdOffset * 8)
+			decodeIfd(d, s, ifdSeen, tiffTagNames)
 		}
 	})
 
@@ -0,0 +1,124 @@
+package tracev3
+
+// https://github.com/libyal/dtformats/blob/main/documentation/Apple%20Unified%20Logging%20and%20Activity%20Tracing%20formats.asciidoc
+// https://github.com/mandiant/macos-UnifiedLogs
+// TODO: catalog, chunkset (lz4 compressed firehose etc) and log entries
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.TRACEV3,
+		Description: "Apple unified logging tracev3",
+		DecodeFn:    tracev3Decode,
+	})
+}
+
+const (
+	tagHeader     = 0x1000
+	tagFirehose   = 0x6001
+	tagOversize   = 0x6002
+	tagStatedump  = 0x6003
+	tagSimpledump = 0x6004
+	tagCatalog    = 0x600b
+	tagChunkset   = 0x600d
+)
+
+var tagNames = scalar.UToSymStr{
+	tagHeader:     "header",
+	tagFirehose:   "firehose",
+	tagOversize:   "oversize",
+	tagStatedump:  "statedump",
+	tagSimpledump: "simpledump",
+	tagCatalog:    "catalog",
+	tagChunkset:   "chunkset",
+}
+
+const (
+	headerTagContinuousTime = 0x6100
+	headerTagSystemInfo     = 0x6101
+	headerTagGeneration     = 0x6102
+	headerTagTimezone       = 0x6103
+)
+
+var headerTagNames = scalar.UToSymStr{
+	headerTagContinuousTime: "continuous_time",
+	headerTagSystemInfo:     "system_info",
+	headerTagGeneration:     "generation",
+	headerTagTimezone:       "timezone",
+}
+
+func decodeHeader(d *decode.D) {
+	d.FieldU32("timebase_numerator")
+	d.FieldU32("timebase_denominator")
+	d.FieldU64("continuous_time")
+	d.FieldU64("time")
+	d.FieldU32("unknown0")
+	d.FieldU32("bias_minutes")
+	d.FieldU32("daylight_savings", scalar.UToSymStr{0: "no", 1: "yes"})
+	d.FieldU32("flags", scalar.Hex)
+
+	d.FieldStructArrayLoop("sub_chunks", "sub_chunk", d.NotEnd, func(d *decode.D) {
+		tag := d.FieldU32("tag", headerTagNames, scalar.Hex)
+		size := d.FieldU32("size")
+		d.LenFn(int64(size)*8, func(d *decode.D) {
+			switch tag {
+			case headerTagContinuousTime:
+				d.FieldU64("continuous_time")
+			case headerTagSystemInfo:
+				d.FieldU32("unknown0")
+				d.FieldU32("unknown1")
+				d.FieldUTF8NullFixedLen("build_version", 16)
+				d.FieldUTF8NullFixedLen("hardware_model", 32)
+			case headerTagGeneration:
+				d.FieldRawLen("boot_uuid", 16*8, scalar.RawUUID)
+				d.FieldU32("logd_pid")
+				d.FieldU32("logd_exit_status")
+			case headerTagTimezone:
+				d.FieldUTF8NullFixedLen("timezone_path", 48)
+			}
+			if d.NotEnd() {
+				d.FieldRawLen("data", d.BitsLeft())
+			}
+		})
+	})
+}
+
+func tracev3Decode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	first := true
+	d.FieldStructArrayLoop("chunks", "chunk", d.NotEnd, func(d *decode.D) {
+		tag := d.FieldU32("tag", tagNames, scalar.Hex)
+		if first && tag != tagHeader {
+			d.Fatalf("first chunk is not a header chunk")
+		}
+		first = false
+		d.FieldU32("subtag", scalar.Hex)
+		size := d.FieldU64("size")
+		if int64(size)*8 > d.BitsLeft() {
+			d.Fatalf("chunk size %d outside of file", size)
+		}
+
+		d.LenFn(int64(size)*8, func(d *decode.D) {
+			switch tag {
+			case tagHeader:
+				decodeHeader(d)
+			default:
+				d.FieldRawLen("data", d.BitsLeft())
+			}
+		})
+
+		// chunks are 8 byte aligned
+		if padLen := int64((8 - size%8) % 8); padLen != 0 && d.BitsLeft() >= padLen*8 {
+			d.FieldRawLen("padding", padLen*8, d.BitBufIsZero())
+		}
+	})
+
+	return nil
+}
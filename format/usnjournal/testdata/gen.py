@@ -0,0 +1,28 @@
+import struct
+
+# synthetic $UsnJrnl:$J with made up values, zero block like a sparse journal
+def record(version, usn, ref, parent, reason, attrs, name):
+    n = name.encode("utf-16-le")
+    if version == 2:
+        refs = struct.pack("<QQ", ref, parent)
+    else:
+        refs = struct.pack("<QQQQ", ref, 0, parent, 0)
+    off = 8 + len(refs) + 8 + 8 + 4 * 4 + 4
+    body = refs + struct.pack("<qQIIIIHH", usn, 132000000000000000 + usn, reason, 0, 0, attrs, len(n), off) + n
+    length = 8 + len(body)
+    length += (8 - length % 8) % 8
+    return (struct.pack("<IHH", length, version, 0) + body).ljust(length, b"\0")
+
+data = b"\0" * 32
+usn = 32
+for version, reason, attrs, name in [
+    (2, 0x00000100, 0x20, "test.txt"),
+    (2, 0x80000102, 0x20, "test.txt"),
+    (2, 0x00001000, 0x20, "test.txt"),
+    (3, 0x80002000, 0x20, "renamed.txt"),
+    (3, 0x80000200, 0x20, "renamed.txt"),
+]:
+    r = record(version, usn, (1 << 48) | 100, (5 << 48) | 5, reason, attrs, name)
+    data += r
+    usn += len(r)
+open("UsnJrnl_J", "wb").write(data)
@@ -0,0 +1,159 @@
+package usnjournal
+
+// https://docs.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-usn_record_v2
+// https://docs.microsoft.com/en-us/windows/win32/api/winioctl/ns-winioctl-usn_record_v3
+// https://github.com/libyal/libfsntfs/blob/main/documentation/New%20Technologies%20File%20System%20(NTFS).asciidoc
+
+import (
+	"fmt"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.USN_JOURNAL,
+		Description: "NTFS USN change journal",
+		DecodeFn:    usnJournalDecode,
+	})
+}
+
+var reasonNames = map[int]string{
+	0:  "data_overwrite",
+	1:  "data_extend",
+	2:  "data_truncation",
+	4:  "named_data_overwrite",
+	5:  "named_data_extend",
+	6:  "named_data_truncation",
+	8:  "file_create",
+	9:  "file_delete",
+	10: "ea_change",
+	11: "security_change",
+	12: "rename_old_name",
+	13: "rename_new_name",
+	14: "indexable_change",
+	15: "basic_info_change",
+	16: "hard_link_change",
+	17: "compression_change",
+	18: "encryption_change",
+	19: "object_id_change",
+	20: "reparse_point_change",
+	21: "stream_change",
+	22: "transacted_change",
+	23: "integrity_change",
+	31: "close",
+}
+
+var sourceInfoNames = map[int]string{
+	0: "data_management",
+	1: "auxiliary_data",
+	2: "replication_management",
+	3: "client_replication_management",
+}
+
+var fileAttributeNames = map[int]string{
+	0:  "readonly",
+	1:  "hidden",
+	2:  "system",
+	4:  "directory",
+	5:  "archive",
+	6:  "device",
+	7:  "normal",
+	8:  "temporary",
+	9:  "sparse_file",
+	10: "reparse_point",
+	11: "compressed",
+	12: "offline",
+	13: "not_content_indexed",
+	14: "encrypted",
+	15: "integrity_stream",
+	16: "virtual",
+	17: "no_scrub_data",
+	18: "recall_on_open",
+	19: "pinned",
+	20: "unpinned",
+	22: "recall_on_data_access",
+}
+
+// fieldFlags32 decodes a little endian 32 bit flags field as bools, names are by bit number
+func fieldFlags32(d *decode.D, name string, names map[int]string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		for byteI := 0; byteI < 4; byteI++ {
+			for bitI := 7; bitI >= 0; bitI-- {
+				bit := byteI*8 + bitI
+				if n, ok := names[bit]; ok {
+					d.FieldBool(n)
+				} else {
+					d.FieldBool(fmt.Sprintf("unknown%d", bit))
+				}
+			}
+		}
+	})
+}
+
+func fieldFileReference(d *decode.D, name string, majorVersion uint64) {
+	d.FieldStruct(name, func(d *decode.D) {
+		if majorVersion == 2 {
+			d.FieldU48("mft_entry")
+			d.FieldU16("sequence_number")
+			return
+		}
+		// 128 bit file id, ReFS uses all bits
+		d.FieldRawLen("file_id", 128, scalar.RawHex)
+	})
+}
+
+const headerSize = 8
+
+func usnJournalDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldArray("records", func(d *decode.D) {
+		for d.BitsLeft() >= headerSize*8 {
+			// journal file is sparse and records are 8 byte aligned, skip zero blocks
+			if d.PeekBits(64) == 0 {
+				d.SeekRel(64)
+				continue
+			}
+
+			d.FieldStruct("record", func(d *decode.D) {
+				recordStart := d.Pos()
+				recordLength := d.FieldU32("record_length")
+				if recordLength < headerSize || int64(recordLength)*8 > d.BitsLeft()+32 {
+					d.Fatalf("invalid record length %d", recordLength)
+				}
+				majorVersion := d.FieldU16("major_version")
+				d.FieldU16("minor_version")
+
+				switch majorVersion {
+				case 2, 3:
+					fieldFileReference(d, "file_reference", majorVersion)
+					fieldFileReference(d, "parent_file_reference", majorVersion)
+					d.FieldS64("usn")
+					d.FieldU64("timestamp", format.WindowsFileTime)
+					fieldFlags32(d, "reason", reasonNames)
+					fieldFlags32(d, "source_info", sourceInfoNames)
+					d.FieldU32("security_id")
+					fieldFlags32(d, "file_attributes", fileAttributeNames)
+					nameLength := d.FieldU16("name_length")
+					nameOffset := d.FieldU16("name_offset")
+					d.SeekAbs(recordStart + int64(nameOffset)*8)
+					d.FieldUTF16LE("name", int(nameLength))
+				}
+
+				if paddingLen := recordStart + int64(recordLength)*8 - d.Pos(); paddingLen > 0 {
+					if majorVersion == 2 || majorVersion == 3 {
+						d.FieldRawLen("padding", paddingLen)
+					} else {
+						d.FieldRawLen("data", paddingLen)
+					}
+				}
+			})
+		}
+	})
+
+	return nil
+}
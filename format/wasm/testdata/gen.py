@@ -0,0 +1,107 @@
+#!/usr/bin/env python3
+# hand assembled module, validate with:
+# node -e 'WebAssembly.compile(require("fs").readFileSync("test.wasm")).then(m => console.log(WebAssembly.Module.exports(m)))'
+
+
+def uleb(n):
+    b = bytearray()
+    while True:
+        c = n & 0x7f
+        n >>= 7
+        if n:
+            b.append(c | 0x80)
+        else:
+            b.append(c)
+            return bytes(b)
+
+
+def sleb(n):
+    b = bytearray()
+    while True:
+        c = n & 0x7f
+        n >>= 7
+        if (n == 0 and c & 0x40 == 0) or (n == -1 and c & 0x40):
+            b.append(c)
+            return bytes(b)
+        b.append(c | 0x80)
+
+
+def vec(items):
+    return uleb(len(items)) + b"".join(items)
+
+
+def name(s):
+    return uleb(len(s)) + s.encode()
+
+
+def section(id, payload):
+    return bytes([id]) + uleb(len(payload)) + payload
+
+
+I32 = b"\x7f"
+FUNCREF = b"\x70"
+END = b"\x0b"
+
+
+def i32_const(v):
+    return b"\x41" + sleb(v)
+
+
+types = vec([
+    b"\x60" + vec([I32, I32]) + vec([I32]),  # (i32, i32) -> i32
+    b"\x60" + vec([I32]) + vec([]),  # (i32) -> ()
+    b"\x60" + vec([]) + vec([]),  # () -> ()
+])
+imports = vec([
+    name("env") + name("log") + b"\x00" + uleb(1),
+])
+functions = vec([uleb(0), uleb(2)])
+tables = vec([FUNCREF + b"\x00" + uleb(2)])
+memories = vec([b"\x01" + uleb(1) + uleb(2)])
+globals_ = vec([I32 + b"\x01" + i32_const(-42) + END])
+exports = vec([
+    name("add") + b"\x00" + uleb(1),
+    name("memory") + b"\x02" + uleb(0),
+    name("counter") + b"\x03" + uleb(0),
+])
+start = uleb(2)
+elements = vec([b"\x00" + i32_const(0) + END + vec([uleb(1)])])
+
+
+def body(locals_, code):
+    b = vec(locals_) + code
+    return uleb(len(b)) + b
+
+
+codes = vec([
+    # local.get 0, local.get 1, i32.add
+    body([], b"\x20\x00\x20\x01\x6a" + END),
+    # one i32 local, i32.const 1, call 0 (log)
+    body([uleb(1) + I32], i32_const(1) + b"\x10\x00" + END),
+])
+data = vec([b"\x00" + i32_const(8) + END + name("hello")])
+
+module = b"\x00asm" + b"\x01\x00\x00\x00" + \
+    section(1, types) + \
+    section(2, imports) + \
+    section(3, functions) + \
+    section(4, tables) + \
+    section(5, memories) + \
+    section(6, globals_) + \
+    section(7, exports) + \
+    section(8, start) + \
+    section(9, elements) + \
+    section(12, uleb(1)) + \
+    section(10, codes) + \
+    section(11, data) + \
+    section(0, name("producers") + b"\x00")
+
+open("test.wasm", "wb").write(module)
+
+header = b"\x00asm" + b"\x01\x00\x00\x00"
+# custom section name length -1 as int64
+open("name_length_overflow.wasm", "wb").write(header + b"\x00\x0b" + b"\xff" * 9 + b"\x01\x00")
+# custom section name length larger than section
+open("long_name_length.wasm", "wb").write(header + b"\x00\x06" + b"\xff\xff\xff\xff\x3f\x00")
+# section size larger than rest of input
+open("long_section_size.wasm", "wb").write(header + section(1, types)[:-1])
@@ -0,0 +1,332 @@
+package wasm
+
+// https://webassembly.github.io/spec/core/binary/index.html
+// TODO: decode instructions in function bodies
+// TODO: name custom section
+
+import (
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.WASM,
+		Description: "WebAssembly binary module",
+		Groups:      []string{format.PROBE},
+		DecodeFn:    wasmDecode,
+	})
+}
+
+const (
+	sectionCustom    = 0
+	sectionType      = 1
+	sectionImport    = 2
+	sectionFunction  = 3
+	sectionTable     = 4
+	sectionMemory    = 5
+	sectionGlobal    = 6
+	sectionExport    = 7
+	sectionStart     = 8
+	sectionElement   = 9
+	sectionCode      = 10
+	sectionData      = 11
+	sectionDataCount = 12
+)
+
+var sectionIDNames = scalar.UToSymStr{
+	sectionCustom:    "custom",
+	sectionType:      "type",
+	sectionImport:    "import",
+	sectionFunction:  "function",
+	sectionTable:     "table",
+	sectionMemory:    "memory",
+	sectionGlobal:    "global",
+	sectionExport:    "export",
+	sectionStart:     "start",
+	sectionElement:   "element",
+	sectionCode:      "code",
+	sectionData:      "data",
+	sectionDataCount: "data_count",
+}
+
+var valTypeNames = scalar.UToSymStr{
+	0x7f: "i32",
+	0x7e: "i64",
+	0x7d: "f32",
+	0x7c: "f64",
+	0x7b: "v128",
+	0x70: "funcref",
+	0x6f: "externref",
+}
+
+const (
+	externFunc   = 0
+	externTable  = 1
+	externMemory = 2
+	externGlobal = 3
+)
+
+var externKindNames = scalar.UToSymStr{
+	externFunc:   "func",
+	externTable:  "table",
+	externMemory: "memory",
+	externGlobal: "global",
+}
+
+var mutNames = scalar.UToSymStr{
+	0: "const",
+	1: "var",
+}
+
+const (
+	opEnd       = 0x0b
+	opGlobalGet = 0x23
+	opI32Const  = 0x41
+	opI64Const  = 0x42
+	opF32Const  = 0x43
+	opF64Const  = 0x44
+	opRefNull   = 0xd0
+	opRefFunc   = 0xd2
+)
+
+// opcodes allowed in constant expressions
+var constOpcodeNames = scalar.UToSymStr{
+	opEnd:       "end",
+	opGlobalGet: "global.get",
+	opI32Const:  "i32.const",
+	opI64Const:  "i64.const",
+	opF32Const:  "f32.const",
+	opF64Const:  "f64.const",
+	0x6a:        "i32.add",
+	0x6b:        "i32.sub",
+	0x6c:        "i32.mul",
+	0x7c:        "i64.add",
+	0x7d:        "i64.sub",
+	0x7e:        "i64.mul",
+	opRefNull:   "ref.null",
+	opRefFunc:   "ref.func",
+}
+
+// fieldLength adds a byte length, fails if larger than rest of input
+func fieldLength(d *decode.D, name string) int64 {
+	l := d.FieldULEB128(name)
+	if l > uint64(d.BitsLeft()/8) {
+		d.Fatalf("%s %d larger than rest of input", name, l)
+	}
+	return int64(l)
+}
+
+// name is a utf8 byte vector, adds a name_length field and the string
+func fieldName(d *decode.D, name string) string {
+	l := fieldLength(d, name+"_length")
+	return d.FieldUTF8(name, int(l))
+}
+
+// fieldVec adds a name_count field and an array of count elements
+func fieldVec(d *decode.D, name string, fn func(d *decode.D)) {
+	count := d.FieldULEB128(name + "_count")
+	d.FieldArrayCount(name, count, fn)
+}
+
+func fieldLimits(d *decode.D, name string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		hasMax := d.FieldU8("flags")&1 == 1
+		d.FieldULEB128("min")
+		if hasMax {
+			d.FieldULEB128("max")
+		}
+	})
+}
+
+func fieldTableType(d *decode.D, name string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		d.FieldU8("elem_type", valTypeNames, scalar.Hex)
+		fieldLimits(d, "limits")
+	})
+}
+
+func fieldGlobalType(d *decode.D, name string) {
+	d.FieldStruct(name, func(d *decode.D) {
+		d.FieldU8("val_type", valTypeNames, scalar.Hex)
+		d.FieldU8("mut", mutNames)
+	})
+}
+
+// fieldConstExpr adds instructions of a constant expression up to and including end
+func fieldConstExpr(d *decode.D, name string) {
+	d.FieldArray(name, func(d *decode.D) {
+		for {
+			opcode := uint64(0)
+			d.FieldStruct("instr", func(d *decode.D) {
+				opcode = d.FieldU8("opcode", constOpcodeNames, scalar.Hex)
+				switch opcode {
+				case opEnd, 0x6a, 0x6b, 0x6c, 0x7c, 0x7d, 0x7e:
+				case opGlobalGet:
+					d.FieldULEB128("global_index")
+				case opI32Const, opI64Const:
+					d.FieldSLEB128("value")
+				case opF32Const:
+					d.FieldF32LE("value")
+				case opF64Const:
+					d.FieldF64LE("value")
+				case opRefNull:
+					d.FieldU8("ref_type", valTypeNames, scalar.Hex)
+				case opRefFunc:
+					d.FieldULEB128("func_index")
+				default:
+					d.Fatalf("unsupported constant expression opcode 0x%x", opcode)
+				}
+			})
+			if opcode == opEnd {
+				return
+			}
+		}
+	})
+}
+
+func fieldFuncIndices(d *decode.D) {
+	fieldVec(d, "func_indices", func(d *decode.D) { d.FieldULEB128("func_index") })
+}
+
+func decodeImport(d *decode.D) {
+	fieldName(d, "module")
+	fieldName(d, "name")
+	switch d.FieldU8("kind", externKindNames) {
+	case externFunc:
+		d.FieldULEB128("type_index")
+	case externTable:
+		fieldTableType(d, "table_type")
+	case externMemory:
+		fieldLimits(d, "limits")
+	case externGlobal:
+		fieldGlobalType(d, "global_type")
+	default:
+		d.Fatalf("unknown import kind")
+	}
+}
+
+func decodeElement(d *decode.D) {
+	flags := d.FieldULEB128("flags")
+	// bit 0 passive or declarative, bit 1 explicit table index or declarative, bit 2 expressions
+	if flags > 7 {
+		d.Fatalf("unknown element segment flags %d", flags)
+	}
+	passive := flags&1 != 0
+	explicit := flags&2 != 0
+	exprs := flags&4 != 0
+
+	if !passive && explicit {
+		d.FieldULEB128("table_index")
+	}
+	if !passive {
+		fieldConstExpr(d, "offset")
+	}
+	if passive || explicit {
+		if exprs {
+			d.FieldU8("ref_type", valTypeNames, scalar.Hex)
+		} else {
+			d.FieldU8("elem_kind")
+		}
+	}
+	if exprs {
+		fieldVec(d, "init", func(d *decode.D) { fieldConstExpr(d, "expr") })
+	} else {
+		fieldFuncIndices(d)
+	}
+}
+
+func decodeData(d *decode.D) {
+	switch d.FieldULEB128("flags") {
+	case 0:
+		fieldConstExpr(d, "offset")
+	case 1:
+	case 2:
+		d.FieldULEB128("memory_index")
+		fieldConstExpr(d, "offset")
+	default:
+		d.Fatalf("unknown data segment flags")
+	}
+	size := fieldLength(d, "size")
+	d.FieldRawLen("init", size*8)
+}
+
+func decodeFunc(d *decode.D) {
+	size := fieldLength(d, "size")
+	d.LenFn(size*8, func(d *decode.D) {
+		fieldVec(d, "locals", func(d *decode.D) {
+			d.FieldStruct("local", func(d *decode.D) {
+				d.FieldULEB128("count")
+				d.FieldU8("type", valTypeNames, scalar.Hex)
+			})
+		})
+		d.FieldRawLen("code", d.BitsLeft())
+	})
+}
+
+func decodeSection(d *decode.D) {
+	id := d.FieldU8("id", sectionIDNames)
+	size := fieldLength(d, "size")
+	d.LenFn(size*8, func(d *decode.D) {
+		switch id {
+		case sectionCustom:
+			fieldName(d, "name")
+			d.FieldRawLen("data", d.BitsLeft())
+		case sectionType:
+			fieldVec(d, "types", func(d *decode.D) {
+				d.FieldStruct("type", func(d *decode.D) {
+					d.FieldU8("form", d.AssertU(0x60), scalar.Hex)
+					fieldVec(d, "params", func(d *decode.D) { d.FieldU8("param", valTypeNames, scalar.Hex) })
+					fieldVec(d, "results", func(d *decode.D) { d.FieldU8("result", valTypeNames, scalar.Hex) })
+				})
+			})
+		case sectionImport:
+			fieldVec(d, "imports", func(d *decode.D) { d.FieldStruct("import", decodeImport) })
+		case sectionFunction:
+			fieldVec(d, "type_indices", func(d *decode.D) { d.FieldULEB128("type_index") })
+		case sectionTable:
+			fieldVec(d, "tables", func(d *decode.D) { fieldTableType(d, "table") })
+		case sectionMemory:
+			fieldVec(d, "memories", func(d *decode.D) { fieldLimits(d, "memory") })
+		case sectionGlobal:
+			fieldVec(d, "globals", func(d *decode.D) {
+				d.FieldStruct("global", func(d *decode.D) {
+					fieldGlobalType(d, "type")
+					fieldConstExpr(d, "init")
+				})
+			})
+		case sectionExport:
+			fieldVec(d, "exports", func(d *decode.D) {
+				d.FieldStruct("export", func(d *decode.D) {
+					fieldName(d, "name")
+					d.FieldU8("kind", externKindNames)
+					d.FieldULEB128("index")
+				})
+			})
+		case sectionStart:
+			d.FieldULEB128("func_index")
+		case sectionElement:
+			fieldVec(d, "elements", func(d *decode.D) { d.FieldStruct("element", decodeElement) })
+		case sectionCode:
+			fieldVec(d, "functions", func(d *decode.D) { d.FieldStruct("function", decodeFunc) })
+		case sectionData:
+			fieldVec(d, "segments", func(d *decode.D) { d.FieldStruct("segment", decodeData) })
+		case sectionDataCount:
+			d.FieldULEB128("count")
+		default:
+			d.FieldRawLen("data", d.BitsLeft())
+		}
+	})
+}
+
+func wasmDecode(d *decode.D, in interface{}) interface{} {
+	d.Endian = decode.LittleEndian
+
+	d.FieldRawMagic("magic", []byte("\x00asm"))
+	d.FieldU32("version")
+	d.FieldStructArrayLoop("sections", "section", d.NotEnd, decodeSection)
+
+	return nil
+}
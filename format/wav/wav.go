@@ -5,12 +5,8 @@ package wav
 // https://tech.ebu.ch/docs/tech/tech3285.pdf
 // http://www-mmsp.ece.mcgill.ca/Documents/AudioFormats/WAVE/WAVE.html
 // TODO: audio/wav
-// TODO: default little endian
 
 import (
-	"fmt"
-	"strings"
-
 	"github.com/wader/fq/format"
 	"github.com/wader/fq/format/registry"
 	"github.com/wader/fq/pkg/decode"
@@ -124,88 +120,55 @@ var subFormatNames = scalar.BytesToScalar{
 	{Bytes: subFormatIEEEFloat[:], Scalar: scalar.S{Sym: "IEEE_FLOAT"}},
 }
 
-func decodeChunk(d *decode.D, expectedChunkID string, stringData bool) int64 { //nolint:unparam
-	d.Endian = decode.LittleEndian
-
-	chunks := map[string]func(d *decode.D){
-		"RIFF": func(d *decode.D) {
-			d.FieldUTF8("format", 4)
-			decodeChunks(d, false)
-		},
-		"fmt": func(d *decode.D) {
-			audioFormat := d.FieldU16("audio_format", audioFormatName)
-			d.FieldU16("num_channels")
-			d.FieldU32("sample_rate")
-			d.FieldU32("byte_rate")
-			d.FieldU16("block_align")
-			d.FieldU16("bits_per_sample")
-
-			if audioFormat == formatExtensible && d.BitsLeft() > 0 {
-				d.FieldU16("extension_size")
-				d.FieldU16("valid_bits_per_sample")
-				d.FieldU32("channel_mask")
-				d.FieldRawLen("sub_format", 16*8, subFormatNames)
-			}
-		},
-		"data": func(d *decode.D) {
-			d.FieldRawLen("samples", d.BitsLeft())
-		},
-		"LIST": func(d *decode.D) {
-			d.FieldUTF8("list_type", 4)
-			decodeChunks(d, true)
-		},
-		"fact": func(d *decode.D) {
-			d.FieldU32("sample_length")
-		},
-	}
-
-	trimChunkID := d.FieldStrFn("id", func(d *decode.D) string {
-		return strings.TrimSpace(d.UTF8(4))
-	})
-	if expectedChunkID != "" && trimChunkID != expectedChunkID {
-		d.Errorf(fmt.Sprintf("expected chunk id %q found %q", expectedChunkID, trimChunkID))
-	}
-	const restOfFileLen = 0xffffffff
-	chunkLen := int64(d.FieldUScalarFn("size", func(d *decode.D) scalar.S {
-		l := d.U32()
-		if l == restOfFileLen {
-			return scalar.S{Actual: l, ActualDisplay: scalar.NumberHex, Sym: "rest of file"}
+var chunks = map[string]decode.ChunkFn{
+	"RIFF": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldUTF8("format", 4, d.AssertStr("WAVE"))
+		d.FieldChunks("chunks", opts)
+	},
+	"fmt": func(d *decode.D, opts decode.ChunkOpts) {
+		audioFormat := d.FieldU16("audio_format", audioFormatName)
+		d.FieldU16("num_channels")
+		d.FieldU32("sample_rate")
+		d.FieldU32("byte_rate")
+		d.FieldU16("block_align")
+		d.FieldU16("bits_per_sample")
+
+		if audioFormat == formatExtensible && d.BitsLeft() > 0 {
+			d.FieldU16("extension_size")
+			d.FieldU16("valid_bits_per_sample")
+			d.FieldU32("channel_mask")
+			d.FieldRawLen("sub_format", 16*8, subFormatNames)
 		}
-		return scalar.S{Actual: l, ActualDisplay: scalar.NumberDecimal}
-	}))
-
-	if chunkLen == restOfFileLen {
-		chunkLen = d.BitsLeft() / 8
-	}
-
-	if fn, ok := chunks[trimChunkID]; ok {
-		d.LenFn(chunkLen*8, fn)
-	} else {
-		if stringData {
-			d.FieldUTF8("data", int(chunkLen), scalar.Trim(" \x00"))
-		} else {
-			d.FieldRawLen("data", chunkLen*8)
+	},
+	"data": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldRawLen("samples", d.BitsLeft())
+	},
+	"LIST": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldUTF8("list_type", 4)
+		// unknown list chunks are usually strings
+		opts.UnknownFn = func(d *decode.D, opts decode.ChunkOpts) {
+			d.FieldUTF8("data", int(d.BitsLeft()/8), scalar.Trim(" \x00"))
 		}
-	}
-
-	if chunkLen%2 != 0 {
-		d.FieldRawLen("align", 8)
-	}
-
-	return chunkLen + 8
+		d.FieldChunks("chunks", opts)
+	},
+	"fact": func(d *decode.D, opts decode.ChunkOpts) {
+		d.FieldU32("sample_length")
+	},
 }
 
-func decodeChunks(d *decode.D, stringData bool) {
-	d.FieldStructArrayLoop("chunks", "chunk", d.NotEnd, func(d *decode.D) {
-		decodeChunk(d, "", stringData)
-	})
+var chunkOpts = decode.ChunkOpts{
+	Endian:   decode.LittleEndian,
+	TrimID:   true,
+	RestSize: 0xffffffff,
+	Align:    2,
+	Chunks:   chunks,
 }
 
 func wavDecode(d *decode.D, in interface{}) interface{} {
 	// there are wav files in the wild with id3v2 header id3v1 footer
 	_, _, _ = d.TryFieldFormat("header", headerFormat, nil)
 
-	decodeChunk(d, "RIFF", false)
+	d.FieldChunk(chunkOpts, "RIFF")
 
 	_, _, _ = d.TryFieldFormat("footer", footerFormat, nil)
 
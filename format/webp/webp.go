@@ -8,7 +8,6 @@ import (
 	"github.com/wader/fq/format"
 	"github.com/wader/fq/format/registry"
 	"github.com/wader/fq/pkg/decode"
-	"github.com/wader/fq/pkg/scalar"
 )
 
 var vp8Frame decode.Group
@@ -25,20 +24,18 @@ func init() {
 	})
 }
 
-func decodeChunk(d *decode.D, expectedChunkID string, fn func(d *decode.D)) bool { //nolint:unparam
-	trimChunkID := d.FieldUTF8("id", 4, scalar.TrimSpace)
-	if expectedChunkID != "" && trimChunkID != expectedChunkID {
-		return false
-	}
-	chunkLen := int64(d.FieldU32("size"))
-
-	if fn != nil {
-		d.LenFn(chunkLen*8, fn)
-	} else {
-		d.FieldRawLen("data", chunkLen*8)
-	}
-
-	return true
+var chunkOpts = decode.ChunkOpts{
+	Endian: decode.LittleEndian,
+	TrimID: true,
+	Align:  2,
+	Chunks: map[string]decode.ChunkFn{
+		"VP8": func(d *decode.D, opts decode.ChunkOpts) {
+			d.Format(vp8Frame, nil)
+		},
+		"VP8L": func(d *decode.D, opts decode.ChunkOpts) {
+			// TODO
+		},
+	},
 }
 
 func webpDecode(d *decode.D, in interface{}) interface{} {
@@ -55,17 +52,9 @@ func webpDecode(d *decode.D, in interface{}) interface{} {
 
 		switch {
 		case bytes.Equal(p, []byte("VP8 ")):
-			d.FieldStruct("image", func(d *decode.D) {
-				decodeChunk(d, "VP8", func(d *decode.D) {
-					d.Format(vp8Frame, nil)
-				})
-			})
+			d.FieldStruct("image", func(d *decode.D) { d.FieldChunk(chunkOpts, "VP8") })
 		case bytes.Equal(p, []byte("VP8L")):
-			d.FieldStruct("image", func(d *decode.D) {
-				decodeChunk(d, "VP8L", func(d *decode.D) {
-					// TODO
-				})
-			})
+			d.FieldStruct("image", func(d *decode.D) { d.FieldChunk(chunkOpts, "VP8L") })
 		default:
 			d.Fatalf("could not find VP8 or VP8L chunk")
 		}
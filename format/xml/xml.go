@@ -0,0 +1,117 @@
+package xml
+
+// Elements are objects with attributes as "-name" keys, text as "#text" and child
+// elements as keys with names including namespace prefix. Repeated child elements
+// becomes arrays and elements with only text becomes strings.
+// TODO: ranges for elements, mixed content order is not preserved
+
+import (
+	stdxml "encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.XML,
+		Description: "Extensible Markup Language",
+		DecodeFn:    decodeXML,
+	})
+}
+
+func xmlName(n stdxml.Name) string {
+	if n.Space != "" {
+		return n.Space + ":" + n.Local
+	}
+	return n.Local
+}
+
+// elements nested deeper than this are an error instead of exhausting the stack
+const maxElementDepth = 1000
+
+func decodeElement(xd *stdxml.Decoder, se stdxml.StartElement, depth int) (interface{}, error) {
+	if depth > maxElementDepth {
+		return nil, fmt.Errorf("element %s nested deeper than %d", xmlName(se.Name), maxElementDepth)
+	}
+
+	m := map[string]interface{}{}
+	for _, a := range se.Attr {
+		m["-"+xmlName(a.Name)] = a.Value
+	}
+
+	var text strings.Builder
+	for {
+		t, err := xd.RawToken()
+		if err != nil {
+			return nil, err
+		}
+		switch t := t.(type) {
+		case stdxml.StartElement:
+			name := xmlName(t.Name)
+			v, err := decodeElement(xd, t, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			switch e := m[name].(type) {
+			case nil:
+				m[name] = v
+			case []interface{}:
+				m[name] = append(e, v)
+			default:
+				m[name] = []interface{}{e, v}
+			}
+		case stdxml.CharData:
+			text.Write(t)
+		case stdxml.EndElement:
+			if t.Name != se.Name {
+				return nil, errors.New("element " + xmlName(se.Name) + " closed by " + xmlName(t.Name))
+			}
+			s := strings.TrimSpace(text.String())
+			if len(m) == 0 {
+				return s, nil
+			}
+			if s != "" {
+				m["#text"] = s
+			}
+			return m, nil
+		}
+	}
+}
+
+func decodeXML(d *decode.D, in interface{}) interface{} {
+	bb := d.RawLen(d.Len())
+	xd := stdxml.NewDecoder(bb)
+	xd.CharsetReader = func(charset string, input io.Reader) (io.Reader, error) { return input, nil }
+
+	var s scalar.S
+	for s.Actual == nil {
+		t, err := xd.RawToken()
+		if err != nil {
+			d.Fatalf("%s", err)
+		}
+		switch t := t.(type) {
+		case stdxml.StartElement:
+			v, err := decodeElement(xd, t, 1)
+			if err != nil {
+				d.Fatalf("%s", err)
+			}
+			s.Actual = map[string]interface{}{xmlName(t.Name): v}
+		case stdxml.CharData:
+			if strings.TrimSpace(string(t)) != "" {
+				d.Fatalf("text outside of root element")
+			}
+		}
+	}
+
+	d.Value.V = &s
+	d.Value.Range.Len = d.Len()
+
+	return nil
+}
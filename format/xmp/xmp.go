@@ -0,0 +1,68 @@
+package xmp
+
+// https://www.adobe.com/devnet/xmp.html
+// XMP Specification Part 1, 7.3 XMP packet wrapper
+
+import (
+	"bytes"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+)
+
+var xmlFormat decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.XMP,
+		Description: "Extensible Metadata Platform packet",
+		DecodeFn:    xmpDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.XML}, Group: &xmlFormat},
+		},
+	})
+}
+
+var packetBegin = []byte("<?xpacket begin=")
+var packetEnd = []byte("<?xpacket end=")
+var piEnd = []byte("?>")
+
+func xmpDecode(d *decode.D, in interface{}) interface{} {
+	b := d.BytesRange(0, int(d.Len()/8))
+
+	bodyStart := 0
+	bodyEnd := len(b)
+	endStart := -1
+	endEnd := -1
+
+	if bytes.HasPrefix(b, packetBegin) {
+		i := bytes.Index(b, piEnd)
+		if i == -1 {
+			d.Fatalf("packet begin not terminated")
+		}
+		bodyStart = i + len(piEnd)
+	}
+	if i := bytes.LastIndex(b, packetEnd); i != -1 {
+		j := bytes.Index(b[i:], piEnd)
+		if j == -1 {
+			d.Fatalf("packet end not terminated")
+		}
+		bodyEnd = i
+		endStart = i
+		endEnd = i + j + len(piEnd)
+	}
+	if bodyStart > bodyEnd {
+		d.Fatalf("packet end before begin")
+	}
+
+	if bodyStart > 0 {
+		d.FieldUTF8("packet_begin", bodyStart)
+	}
+	d.FieldFormatLen("rdf", int64(bodyEnd-bodyStart)*8, xmlFormat, nil)
+	if endStart != -1 {
+		d.FieldUTF8("packet_end", endEnd-endStart)
+	}
+
+	return nil
+}
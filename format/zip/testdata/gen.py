@@ -0,0 +1,31 @@
+import struct
+import zlib
+
+# zip64 archive with one stored file, sizes and offsets are in zip64 extra
+# fields and end of central directory record
+name = b"hello.txt"
+data = b"hello zip64\n"
+crc = zlib.crc32(data)
+
+local_extra = struct.pack("<HHQQ", 0x0001, 16, len(data), len(data))
+local = struct.pack(
+    "<4sHHHHHIIIHH", b"PK\x03\x04", 45, 0, 0, 0, 0, crc,
+    0xffffffff, 0xffffffff, len(name), len(local_extra),
+) + name + local_extra + data
+
+central_extra = struct.pack("<HHQQQ", 0x0001, 24, len(data), len(data), 0)
+central = struct.pack(
+    "<4sHHHHHHIIIHHHHHII", b"PK\x01\x02", 45, 45, 0, 0, 0, 0, crc,
+    0xffffffff, 0xffffffff, len(name), len(central_extra), 0, 0, 0, 0, 0xffffffff,
+) + name + central_extra
+
+eocd64_offset = len(local) + len(central)
+eocd64 = struct.pack(
+    "<4sQHHIIQQQQ", b"PK\x06\x06", 44, 45, 45, 0, 0, 1, 1, len(central), len(local),
+)
+locator = struct.pack("<4sIQI", b"PK\x06\x07", 0, eocd64_offset, 1)
+eocd = struct.pack(
+    "<4sHHHHIIH", b"PK\x05\x06", 0, 0, 0xffff, 0xffff, 0xffffffff, 0xffffffff, 0,
+)
+
+open("zip64.zip", "wb").write(local + central + eocd64 + locator + eocd)
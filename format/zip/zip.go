@@ -65,13 +65,24 @@ var compressionMethodMap = scalar.UToSymStr{
 }
 
 var (
-	centralDirectorySignature       = []byte("PK\x01\x02")
-	endOfCentralDirectorySignature  = []byte("PK\x05\x06")
-	endOfCentralDirectorySignatureN = 0x06054b50
-	localFileSignature              = []byte("PK\x03\x04")
-	dataIndicatorSignature          = []byte("PK\x07\x08")
+	centralDirectorySignature               = []byte("PK\x01\x02")
+	endOfCentralDirectorySignature          = []byte("PK\x05\x06")
+	endOfCentralDirectorySignatureN         = 0x06054b50
+	endOfCentralDirectory64Signature        = []byte("PK\x06\x06")
+	endOfCentralDirectory64LocatorSignature = []byte("PK\x06\x07")
+	endOfCentralDirectory64LocatorLen       = 20
+	localFileSignature                      = []byte("PK\x03\x04")
+	dataIndicatorSignature                  = []byte("PK\x07\x08")
 )
 
+// values in headers that instead are in the zip64 records
+const (
+	zip64Marker16 = 0xffff
+	zip64Marker32 = 0xffffffff
+)
+
+const headerIDZip64 = 0x0001
+
 var headerIDMap = scalar.UToScalar{
 	0x0001: {Description: "ZIP64 extended information extra field"},
 	0x0007: {Description: "AV Info"},
@@ -127,6 +138,28 @@ func fieldMSDOSDate(d *decode.D) {
 	d.FieldU5("day")
 }
 
+// fieldExtraFields decodes extra fields, zip64Fn if not nil is used to decode
+// zip64 extended information data
+func fieldExtraFields(d *decode.D, length uint64, zip64Fn func(d *decode.D)) {
+	d.FieldArray("extra_fields", func(d *decode.D) {
+		d.LenFn(int64(length)*8, func(d *decode.D) {
+			for !d.End() {
+				d.FieldStruct("extra_field", func(d *decode.D) {
+					headerID := d.FieldU16("header_id", headerIDMap, scalar.Hex)
+					dataSize := d.FieldU16("data_size")
+					if headerID == headerIDZip64 && zip64Fn != nil {
+						d.LenFn(int64(dataSize)*8, func(d *decode.D) {
+							d.FieldStruct("data", zip64Fn)
+						})
+					} else {
+						d.FieldRawLen("data", int64(dataSize)*8)
+					}
+				})
+			}
+		})
+	})
+}
+
 func zipDecode(d *decode.D, in interface{}) interface{} {
 	// TODO: just decode instead?
 	if !bytes.Equal(d.PeekBytes(4), []byte("PK\x03\x04")) {
@@ -144,7 +177,8 @@ func zipDecode(d *decode.D, in interface{}) interface{} {
 	if err != nil {
 		d.Fatalf("can't find end of central directory")
 	}
-	d.SeekAbs(d.Len() + p)
+	eocdPos := d.Len() + p
+	d.SeekAbs(eocdPos)
 
 	var offsetCD uint64
 	var sizeCD uint64
@@ -162,6 +196,41 @@ func zipDecode(d *decode.D, in interface{}) interface{} {
 		d.FieldUTF8("comment", int(commentLength))
 	})
 
+	// zip64 locator is just before end of central directory
+	locatorPos := eocdPos - int64(endOfCentralDirectory64LocatorLen)*8
+	if locatorPos >= 0 {
+		d.SeekAbs(locatorPos)
+		if bytes.Equal(d.PeekBytes(4), endOfCentralDirectory64LocatorSignature) {
+			var offsetEOCD64 uint64
+			d.FieldStruct("end_of_central_directory64_locator", func(d *decode.D) {
+				d.FieldRawLen("signature", 4*8, d.ValidateBitBuf(endOfCentralDirectory64LocatorSignature))
+				d.FieldU32("end_of_central_directory64_disk_nr")
+				offsetEOCD64 = d.FieldU64("offset_of_end_of_central_directory64")
+				d.FieldU32("total_disks")
+			})
+
+			d.SeekAbs(int64(offsetEOCD64) * 8)
+			d.FieldStruct("end_of_central_directory64", func(d *decode.D) {
+				d.FieldRawLen("signature", 4*8, d.ValidateBitBuf(endOfCentralDirectory64Signature))
+				// size of remaining record
+				recordSize := d.FieldU64("size_of_record")
+				d.LenFn(int64(recordSize)*8, func(d *decode.D) {
+					d.FieldU16("version_made_by")
+					d.FieldU16("version_needed")
+					diskNr = d.FieldU32("disk_nr")
+					d.FieldU32("central_directory_start_disk_nr")
+					d.FieldU64("nr_of_central_directory_records_on_disk")
+					d.FieldU64("nr_of_central_directory_records")
+					sizeCD = d.FieldU64("size_of_central_directory")
+					offsetCD = d.FieldU64("offset_of_start_of_central_directory")
+					if d.BitsLeft() > 0 {
+						d.FieldRawLen("extensible_data", d.BitsLeft())
+					}
+				})
+			})
+		}
+	}
+
 	var localFileOffsets []uint64
 
 	d.SeekAbs(int64(offsetCD) * 8)
@@ -193,8 +262,8 @@ func zipDecode(d *decode.D, in interface{}) interface{} {
 					d.FieldStruct("last_modification_date", fieldMSDOSTime)
 					d.FieldStruct("last_modification_time", fieldMSDOSDate)
 					d.FieldU32("crc32_uncompressed", scalar.Hex)
-					d.FieldU32("compressed_size")
-					d.FieldU32("uncompressed_size")
+					compressedSize := d.FieldU32("compressed_size")
+					uncompressedSize := d.FieldU32("uncompressed_size")
 					fileNameLength := d.FieldU16("file_name_length")
 					extraFieldLength := d.FieldU16("extra_field_length")
 					fileCommentLength := d.FieldU16("file_comment_length")
@@ -203,16 +272,20 @@ func zipDecode(d *decode.D, in interface{}) interface{} {
 					d.FieldU32("external_file_attributes")
 					localFileOffset := d.FieldU32("relative_offset_of_local_file_header")
 					d.FieldUTF8("file_name", int(fileNameLength))
-					d.FieldArray("extra_fields", func(d *decode.D) {
-						d.LenFn(int64(extraFieldLength)*8, func(d *decode.D) {
-							for !d.End() {
-								d.FieldStruct("extra_field", func(d *decode.D) {
-									d.FieldU16("header_id", headerIDMap, scalar.Hex)
-									dataSize := d.FieldU16("data_size")
-									d.FieldRawLen("data", int64(dataSize)*8)
-								})
-							}
-						})
+					// only values that did not fit in header are present and in this order
+					fieldExtraFields(d, extraFieldLength, func(d *decode.D) {
+						if uncompressedSize == zip64Marker32 && !d.End() {
+							d.FieldU64("uncompressed_size")
+						}
+						if compressedSize == zip64Marker32 && !d.End() {
+							d.FieldU64("compressed_size")
+						}
+						if localFileOffset == zip64Marker32 && !d.End() {
+							localFileOffset = d.FieldU64("relative_offset_of_local_file_header")
+						}
+						if diskNrStart == zip64Marker16 && !d.End() {
+							diskNrStart = d.FieldU32("disk_number_where_file_starts")
+						}
 					})
 					d.FieldUTF8("file_comment", int(fileCommentLength))
 
@@ -229,6 +302,7 @@ func zipDecode(d *decode.D, in interface{}) interface{} {
 			d.SeekAbs(int64(o) * 8)
 			d.FieldStruct("local_file", func(d *decode.D) {
 				var hasDataDescriptor bool
+				var isZip64 bool
 				d.FieldRawLen("signature", 4*8, d.ValidateBitBuf(localFileSignature))
 				d.FieldU16("version_needed")
 				d.FieldStruct("flags", func(d *decode.D) {
@@ -253,20 +327,20 @@ func zipDecode(d *decode.D, in interface{}) interface{} {
 				d.FieldStruct("last_modification_time", fieldMSDOSDate)
 				d.FieldU32("crc32_uncompressed", scalar.Hex)
 				compressedSizeBytes := d.FieldU32("compressed_size")
-				d.FieldU32("uncompressed_size")
+				uncompressedSizeBytes := d.FieldU32("uncompressed_size")
 				fileNameLength := d.FieldU16("file_name_length")
 				extraFieldLength := d.FieldU16("extra_field_length")
 				d.FieldUTF8("file_name", int(fileNameLength))
-				d.FieldArray("extra_fields", func(d *decode.D) {
-					d.LenFn(int64(extraFieldLength)*8, func(d *decode.D) {
-						for !d.End() {
-							d.FieldStruct("extra_field", func(d *decode.D) {
-								d.FieldU16("header_id", headerIDMap, scalar.Hex)
-								dataSize := d.FieldU16("data_size")
-								d.FieldRawLen("data", int64(dataSize)*8)
-							})
-						}
-					})
+				// local header zip64 extended information has both sizes
+				fieldExtraFields(d, extraFieldLength, func(d *decode.D) {
+					isZip64 = true
+					if (uncompressedSizeBytes == zip64Marker32 || compressedSizeBytes == zip64Marker32) && d.BitsLeft() >= 128 {
+						d.FieldU64("uncompressed_size")
+						compressedSizeBytes = d.FieldU64("compressed_size")
+					}
+					if d.BitsLeft() > 0 {
+						d.FieldRawLen("unknown", d.BitsLeft())
+					}
 				})
 				compressedSize := int64(compressedSizeBytes) * 8
 				compressedStart := d.Pos()
@@ -314,8 +388,13 @@ func zipDecode(d *decode.D, in interface{}) interface{} {
 							d.FieldRawLen("signature", 4*8, d.ValidateBitBuf(dataIndicatorSignature))
 						}
 						d.FieldU32("crc32_uncompressed", scalar.Hex)
-						d.FieldU32("compressed_size")
-						d.FieldU32("uncompressed_size")
+						if isZip64 {
+							d.FieldU64("compressed_size")
+							d.FieldU64("uncompressed_size")
+						} else {
+							d.FieldU32("compressed_size")
+							d.FieldU32("uncompressed_size")
+						}
 					})
 				}
 			})
@@ -0,0 +1,63 @@
+package zlib
+
+// https://tools.ietf.org/html/rfc1951
+
+import (
+	"compress/flate"
+	"io"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+)
+
+var deflateProbeGroup decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.DEFLATE,
+		Description: "Raw deflate compressed data",
+		DecodeFn:    deflateDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.PROBE}, Group: &deflateProbeGroup},
+		},
+	})
+}
+
+// fieldDeflate adds uncompressed and compressed fields for deflate data at current position
+// and returns uncompressed data, dict is a preset dictionary or nil.
+// compressed is a raw field unless the deflate blocks decode option is true, then it's the
+// decoded block structure, see inflate.go. Symbols are only decoded as fields if the symbols
+// option is true, implies blocks, ex: decode("deflate"; {deflate: {symbols: true}}).
+func fieldDeflate(d *decode.D, dict []byte, probeGroup decode.Group) *bitio.Buffer {
+	// *bitio.Buffer implements io.ByteReader so that deflate don't do own
+	// buffering and might read more than needed messing up knowing compressed size
+	rFn := func(r io.Reader) io.Reader { return flate.NewReaderDict(r, dict) }
+
+	readCompressedSize, uncompressedBB, dv, _, err := d.TryFieldReaderRangeFormat("uncompressed", d.Pos(), d.BitsLeft(), rFn, probeGroup, nil)
+	if uncompressedBB == nil {
+		d.Fatalf("deflate: %s", err)
+	}
+	if dv == nil {
+		d.FieldRootBitBuf("uncompressed", uncompressedBB)
+	}
+	deflateOpts, _ := d.Options.FormatOptions[format.DEFLATE].(map[string]interface{})
+	blocks, _ := deflateOpts["blocks"].(bool)
+	symbols, _ := deflateOpts["symbols"].(bool)
+	if !blocks && !symbols {
+		// walking blocks reads every huffman code so only do it if asked for
+		d.FieldRawLen("compressed", readCompressedSize)
+		return uncompressedBB
+	}
+	d.LenFn(readCompressedSize, func(d *decode.D) {
+		d.FieldStruct("compressed", func(d *decode.D) { decodeBlocks(d, symbols) })
+	})
+
+	return uncompressedBB
+}
+
+func deflateDecode(d *decode.D, in interface{}) interface{} {
+	uncompressedBB := fieldDeflate(d, nil, deflateProbeGroup)
+	return format.DeflateOut{Uncompressed: uncompressedBB}
+}
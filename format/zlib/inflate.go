@@ -0,0 +1,272 @@
+package zlib
+
+// decode deflate block structure
+// https://tools.ietf.org/html/rfc1951
+
+import (
+	"fmt"
+
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+const (
+	blockTypeStored   = 0
+	blockTypeFixed    = 1
+	blockTypeDynamic  = 2
+	blockTypeReserved = 3
+)
+
+var blockTypeNames = scalar.UToSymStr{
+	blockTypeStored:   "stored",
+	blockTypeFixed:    "fixed",
+	blockTypeDynamic:  "dynamic",
+	blockTypeReserved: "reserved",
+}
+
+const endOfBlock = 256
+
+var lengthBase = [...]uint64{3, 4, 5, 6, 7, 8, 9, 10, 11, 13, 15, 17, 19, 23, 27, 31, 35, 43, 51, 59, 67, 83, 99, 115, 131, 163, 195, 227, 258}
+var lengthExtra = [...]int{0, 0, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 2, 2, 2, 2, 3, 3, 3, 3, 4, 4, 4, 4, 5, 5, 5, 5, 0}
+var distanceBase = [...]uint64{1, 2, 3, 4, 5, 7, 9, 13, 17, 25, 33, 49, 65, 97, 129, 193, 257, 385, 513, 769, 1025, 1537, 2049, 3073, 4097, 6145, 8193, 12289, 16385, 24577}
+var distanceExtra = [...]int{0, 0, 0, 0, 1, 1, 2, 2, 3, 3, 4, 4, 5, 5, 6, 6, 7, 7, 8, 8, 9, 9, 10, 10, 11, 11, 12, 12, 13, 13}
+
+// order of code length code lengths
+var codeLengthOrder = [...]int{16, 17, 18, 0, 8, 7, 9, 6, 10, 5, 11, 4, 12, 3, 13, 2, 14, 1, 15}
+
+const maxCodeBits = 15
+
+// canonical huffman code
+type huffman struct {
+	counts  [maxCodeBits + 1]int
+	symbols []uint64
+}
+
+func newHuffman(lengths []int) huffman {
+	var h huffman
+	for _, l := range lengths {
+		h.counts[l]++
+	}
+	h.counts[0] = 0
+	var offsets [maxCodeBits + 2]int
+	for l := 1; l <= maxCodeBits; l++ {
+		offsets[l+1] = offsets[l] + h.counts[l]
+	}
+	h.symbols = make([]uint64, offsets[maxCodeBits+1])
+	for s, l := range lengths {
+		if l != 0 {
+			h.symbols[offsets[l]] = uint64(s)
+			offsets[l]++
+		}
+	}
+	return h
+}
+
+// decode reads one code bit at a time, first bit is most significant code bit
+func (h huffman) decode(d *decode.D) uint64 {
+	code, first, index := 0, 0, 0
+	for l := 1; l <= maxCodeBits; l++ {
+		code |= int(d.U1())
+		count := h.counts[l]
+		if code-count < first {
+			return h.symbols[index+(code-first)]
+		}
+		index += count
+		first += count
+		first <<= 1
+		code <<= 1
+	}
+	d.Fatalf("invalid huffman code")
+	return 0
+}
+
+var fixedLiteralLength, fixedDistance = func() (huffman, huffman) {
+	lengths := make([]int, 288)
+	for i := range lengths {
+		switch {
+		case i < 144:
+			lengths[i] = 8
+		case i < 256:
+			lengths[i] = 9
+		case i < 280:
+			lengths[i] = 7
+		default:
+			lengths[i] = 8
+		}
+	}
+	distLengths := make([]int, 30)
+	for i := range distLengths {
+		distLengths[i] = 5
+	}
+	return newHuffman(lengths), newHuffman(distLengths)
+}()
+
+var literalLengthMapper = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	n := s.ActualU()
+	switch {
+	case n < 256:
+		s.Description = fmt.Sprintf("literal %q", rune(n))
+	case n == endOfBlock:
+		s.Sym = "end_of_block"
+	default:
+		s.Description = "length"
+	}
+	return s, nil
+})
+
+// readSymbol reads one literal/length symbol and its distance without adding fields,
+// returns false for end of block
+func readSymbol(d *decode.D, literalLength huffman, distance huffman) bool {
+	code := literalLength.decode(d)
+	switch {
+	case code < 256:
+	case code == endOfBlock:
+		return false
+	case code <= 285:
+		i := code - 257
+		d.SeekRel(int64(lengthExtra[i]))
+		dc := distance.decode(d)
+		if dc >= uint64(len(distanceBase)) {
+			d.Fatalf("invalid distance code %d", dc)
+		}
+		d.SeekRel(int64(distanceExtra[dc]))
+	default:
+		d.Fatalf("invalid literal/length code %d", code)
+	}
+	return true
+}
+
+// decodeSymbols adds symbols as a raw field or if symbols is true as a struct per symbol
+func decodeSymbols(d *decode.D, literalLength huffman, distance huffman, symbols bool) {
+	if !symbols {
+		start := d.Pos()
+		for readSymbol(d, literalLength, distance) {
+		}
+		stop := d.Pos()
+		d.SeekAbs(start)
+		d.FieldRawLen("symbols", stop-start)
+		return
+	}
+
+	d.FieldArray("symbols", func(d *decode.D) {
+		for {
+			done := false
+			d.FieldStruct("symbol", func(d *decode.D) {
+				code := d.FieldUFn("literal_length", literalLength.decode, literalLengthMapper)
+				switch {
+				case code < 256:
+				case code == endOfBlock:
+					done = true
+				case code <= 285:
+					i := code - 257
+					length := lengthBase[i]
+					if lengthExtra[i] > 0 {
+						length += d.FieldU("length_extra", lengthExtra[i])
+					}
+					d.FieldValueU("length", length)
+					dc := d.FieldUFn("distance_code", distance.decode)
+					if dc >= uint64(len(distanceBase)) {
+						d.Fatalf("invalid distance code %d", dc)
+					}
+					dist := distanceBase[dc]
+					if distanceExtra[dc] > 0 {
+						dist += d.FieldU("distance_extra", distanceExtra[dc])
+					}
+					d.FieldValueU("distance", dist)
+				default:
+					d.Fatalf("invalid literal/length code %d", code)
+				}
+			})
+			if done {
+				break
+			}
+		}
+	})
+}
+
+func decodeDynamicHeader(d *decode.D) (huffman, huffman) {
+	hlit := int(d.FieldU5("hlit", scalar.UAdd(257)))
+	hdist := int(d.FieldU5("hdist", scalar.UAdd(1)))
+	hclen := int(d.FieldU4("hclen", scalar.UAdd(4)))
+
+	codeLengthLengths := make([]int, len(codeLengthOrder))
+	d.FieldArray("code_length_code_lengths", func(d *decode.D) {
+		for i := 0; i < hclen; i++ {
+			codeLengthLengths[codeLengthOrder[i]] = int(d.FieldU3("length"))
+		}
+	})
+	codeLength := newHuffman(codeLengthLengths)
+
+	lengths := make([]int, hlit+hdist)
+	d.FieldArray("code_lengths", func(d *decode.D) {
+		for i := 0; i < len(lengths); {
+			d.FieldStruct("code_length", func(d *decode.D) {
+				code := d.FieldUFn("code", codeLength.decode)
+				var v, repeat int
+				switch {
+				case code < 16:
+					v, repeat = int(code), 1
+				case code == 16:
+					if i == 0 {
+						d.Fatalf("repeat previous code length without previous")
+					}
+					v, repeat = lengths[i-1], int(d.FieldU2("repeat", scalar.UAdd(3)))
+				case code == 17:
+					repeat = int(d.FieldU3("repeat", scalar.UAdd(3)))
+				default:
+					repeat = int(d.FieldU7("repeat", scalar.UAdd(11)))
+				}
+				if i+repeat > len(lengths) {
+					d.Fatalf("code lengths overflow")
+				}
+				for j := 0; j < repeat; j++ {
+					lengths[i] = v
+					i++
+				}
+			})
+		}
+	})
+	if lengths[endOfBlock] == 0 {
+		d.Fatalf("missing end of block code")
+	}
+
+	return newHuffman(lengths[0:hlit]), newHuffman(lengths[hlit:])
+}
+
+// decodeBlocks decodes deflate blocks, bits are least significant bit first.
+// Symbols are a raw field per block unless symbols is true as a struct per symbol
+// uses lots of memory for larger streams.
+func decodeBlocks(d *decode.D, symbols bool) {
+	d.LSBBitOrder(func(d *decode.D) {
+		d.FieldArray("blocks", func(d *decode.D) {
+			for {
+				final := false
+				d.FieldStruct("block", func(d *decode.D) {
+					final = d.FieldBool("final")
+					switch d.FieldU2("type", blockTypeNames) {
+					case blockTypeStored:
+						if n := (8 - d.Pos()%8) % 8; n > 0 {
+							d.FieldU("padding", int(n))
+						}
+						length := d.FieldU16("len")
+						d.FieldU16("nlen", d.ValidateU(length^0xffff))
+						d.FieldRawLen("data", int64(length)*8)
+					case blockTypeFixed:
+						decodeSymbols(d, fixedLiteralLength, fixedDistance, symbols)
+					case blockTypeDynamic:
+						literalLength, distance := decodeDynamicHeader(d)
+						decodeSymbols(d, literalLength, distance, symbols)
+					default:
+						d.Fatalf("reserved block type")
+					}
+				})
+				if final {
+					break
+				}
+			}
+		})
+		if n := (8 - d.Pos()%8) % 8; n > 0 && d.BitsLeft() >= n {
+			d.FieldU("padding", int(n))
+		}
+	})
+}
@@ -0,0 +1,27 @@
+import random
+import zlib
+
+text = b"hello zlib " * 20 + b"\n"
+open("test.zlib", "wb").write(zlib.compress(text, 9))
+
+c = zlib.compressobj(-1, zlib.DEFLATED, -15)
+open("test.deflate", "wb").write(c.compress(text) + c.flush())
+
+c = zlib.compressobj(-1, zlib.DEFLATED, 15, zdict=b"hello zlib ")
+open("dict.zlib", "wb").write(c.compress(text) + c.flush())
+
+# fastest level and a header with broken check bits
+open("fast.zlib", "wb").write(zlib.compress(text, 1))
+b = bytearray(zlib.compress(text, 9))
+b[1] ^= 1
+open("bad_check.zlib", "wb").write(b)
+
+# dynamic huffman block
+random.seed(1)
+text = bytes(random.choice(b"aaaaaaaaabbbbc") for _ in range(90))
+c = zlib.compressobj(9, zlib.DEFLATED, -15)
+open("dynamic.deflate", "wb").write(c.compress(text) + c.flush())
+
+# stored block
+c = zlib.compressobj(0, zlib.DEFLATED, -15)
+open("stored.deflate", "wb").write(c.compress(b"hello stored") + c.flush())
@@ -0,0 +1,87 @@
+package zlib
+
+// https://tools.ietf.org/html/rfc1950
+
+import (
+	"fmt"
+	"hash/adler32"
+
+	"github.com/wader/fq/format"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var zlibProbeGroup decode.Group
+
+func init() {
+	registry.MustRegister(decode.Format{
+		Name:        format.ZLIB,
+		Description: "zlib compressed data",
+		DecodeFn:    zlibDecode,
+		Dependencies: []decode.Dependency{
+			{Names: []string{format.PROBE}, Group: &zlibProbeGroup},
+		},
+	})
+}
+
+const deflateMethod = 8
+
+var compressionMethodNames = scalar.UToSymStr{
+	deflateMethod: "deflate",
+}
+
+var compressionLevelNames = scalar.UToSymStr{
+	0: "fastest",
+	1: "fast",
+	2: "default",
+	3: "slowest",
+}
+
+func zlibDecode(d *decode.D, in interface{}) interface{} {
+	var dict []byte
+	if zi, ok := in.(format.ZlibIn); ok {
+		dict = zi.Dictionary
+	}
+
+	header := d.PeekBits(16)
+	compressionMethod := uint64(0)
+	hasDict := false
+	d.FieldStruct("cmf", func(d *decode.D) {
+		d.FieldU4("compression_info", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+			s.Description = fmt.Sprintf("window size %d", 1<<(s.ActualU()+8))
+			return s, nil
+		}))
+		compressionMethod = d.FieldU4("compression_method", compressionMethodNames)
+	})
+	d.FieldStruct("flg", func(d *decode.D) {
+		d.FieldU2("compression_level", compressionLevelNames)
+		hasDict = d.FieldBool("preset_dictionary")
+		d.FieldU5("check", scalar.Fn(func(s scalar.S) (scalar.S, error) {
+			if header%31 != 0 {
+				return s, decode.Annotation{Severity: decode.SeverityWarning, Message: "invalid header check"}
+			}
+			return s, nil
+		}))
+	})
+	if compressionMethod != deflateMethod {
+		d.Fatalf("unsupported compression method %d", compressionMethod)
+	}
+	if hasDict {
+		if dict == nil {
+			d.FieldU32("dictionary_id", scalar.Hex)
+			// can't decompress without the dictionary
+			d.FieldRawLen("compressed", d.BitsLeft()-32)
+			d.FieldU32("adler32", scalar.Hex)
+			return nil
+		}
+		d.FieldU32("dictionary_id", d.ValidateU(uint64(adler32.Checksum(dict))), scalar.Hex)
+	}
+
+	uncompressedBB := fieldDeflate(d, dict, zlibProbeGroup)
+	adler32W := adler32.New()
+	d.MustCopy(adler32W, uncompressedBB.Clone())
+	d.FieldU32("adler32", d.ValidateUBytes(adler32W.Sum(nil)), scalar.Hex)
+
+	return format.DeflateOut{Uncompressed: uncompressedBB}
+}
@@ -129,3 +129,26 @@ func TwosComplement(nBits int, n uint64) int64 {
 func ZigZag(n uint64) int64 {
 	return int64(n>>1 ^ -(n & 1))
 }
+
+// Float16 converts IEEE 754 half precision bits to a float64
+func Float16(n uint16) float64 {
+	sign := 1.0
+	if n&0x8000 != 0 {
+		sign = -1.0
+	}
+	exp := int(n>>10) & 0x1f
+	frac := float64(n & 0x3ff)
+
+	switch exp {
+	case 0:
+		// zero or subnormal
+		return sign * math.Ldexp(frac, -24)
+	case 0x1f:
+		if frac == 0 {
+			return math.Inf(int(sign))
+		}
+		return math.NaN()
+	default:
+		return sign * math.Ldexp(1024+frac, exp-25)
+	}
+}
@@ -0,0 +1,104 @@
+// Package rewindreadseeker provides a io.ReadSeeker for a forward only io.Reader
+// that can seek backwards a bounded number of bytes.
+package rewindreadseeker
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrOutsideBuffer = errors.New("outside of rewind buffer")
+
+// Reader buffers at most size bytes read from r. Read only returns buffered data and
+// io.EOF at end of it, use Fill to buffer more. Seek relative to io.SeekEnd is relative
+// to the end of the buffered data as the real end is unknown.
+type Reader struct {
+	r    io.Reader
+	size int
+
+	buf       []byte
+	bufOffset int64 // stream offset of buf[0]
+	offset    int64
+	err       error // sticky read error, usually io.EOF
+}
+
+func New(r io.Reader, size int) *Reader {
+	return &Reader{
+		r:    r,
+		size: size,
+	}
+}
+
+// Fill drops buffered data before current offset and reads until buffer is
+// full or r returns an error. Returns number of bytes available at current offset.
+func (r *Reader) Fill() (int, error) {
+	if r.offset > r.bufOffset {
+		drop := int(r.offset - r.bufOffset)
+		if drop > len(r.buf) {
+			drop = len(r.buf)
+		}
+		r.buf = r.buf[:copy(r.buf, r.buf[drop:])]
+		r.bufOffset += int64(drop)
+	}
+	// offset was seeked past buffered data, skip forward
+	for r.bufOffset < r.offset && r.err == nil {
+		n, err := io.CopyN(io.Discard, r.r, r.offset-r.bufOffset)
+		r.bufOffset += n
+		r.err = err
+	}
+
+	if cap(r.buf) < r.size {
+		nb := make([]byte, len(r.buf), r.size)
+		copy(nb, r.buf)
+		r.buf = nb
+	}
+	for len(r.buf) < r.size && r.err == nil {
+		n, err := r.r.Read(r.buf[len(r.buf):r.size])
+		r.buf = r.buf[:len(r.buf)+n]
+		r.err = err
+	}
+
+	n := int(r.bufOffset + int64(len(r.buf)) - r.offset)
+	if n < 0 {
+		n = 0
+	}
+	if r.err != nil && !errors.Is(r.err, io.EOF) {
+		return n, r.err
+	}
+	return n, nil
+}
+
+func (r *Reader) Read(p []byte) (int, error) {
+	if r.offset < r.bufOffset {
+		return 0, fmt.Errorf("%w: offset %d", ErrOutsideBuffer, r.offset)
+	}
+	if r.offset >= r.bufOffset+int64(len(r.buf)) {
+		return 0, io.EOF
+	}
+
+	n := copy(p, r.buf[r.offset-r.bufOffset:])
+	r.offset += int64(n)
+
+	return n, nil
+}
+
+func (r *Reader) Seek(offset int64, whence int) (int64, error) {
+	var absOff int64
+	switch whence {
+	case io.SeekStart:
+		absOff = offset
+	case io.SeekCurrent:
+		absOff = r.offset + offset
+	case io.SeekEnd:
+		absOff = r.bufOffset + int64(len(r.buf)) + offset
+	default:
+		return 0, fmt.Errorf("invalid whence %d", whence)
+	}
+	if absOff < r.bufOffset {
+		return 0, fmt.Errorf("%w: seek to %d", ErrOutsideBuffer, absOff)
+	}
+	r.offset = absOff
+
+	return absOff, nil
+}
@@ -0,0 +1,61 @@
+package rewindreadseeker_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/wader/fq/internal/rewindreadseeker"
+)
+
+func TestReader(t *testing.T) {
+	r := rewindreadseeker.New(strings.NewReader("abcdefgh"), 4)
+
+	n, err := r.Fill()
+	if err != nil || n != 4 {
+		t.Fatalf("expected 4 nil, got %d %v", n, err)
+	}
+	if end, _ := r.Seek(0, io.SeekEnd); end != 4 {
+		t.Errorf("expected end 4, got %d", end)
+	}
+
+	b := make([]byte, 3)
+	if _, err := r.Seek(1, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := r.Read(b); string(b[:n]) != "bcd" {
+		t.Errorf("expected bcd, got %q", b[:n])
+	}
+	if _, err := r.Read(b); !errors.Is(err, io.EOF) {
+		t.Errorf("expected EOF at end of buffer, got %v", err)
+	}
+
+	// drops "abc" and reads "efg"
+	if _, err := r.Seek(3, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Fill(); err != nil || n != 4 {
+		t.Fatalf("expected 4 nil, got %d %v", n, err)
+	}
+	if _, err := r.Seek(2, io.SeekStart); !errors.Is(err, rewindreadseeker.ErrOutsideBuffer) {
+		t.Errorf("expected outside buffer error, got %v", err)
+	}
+	if n, _ := r.Read(b); string(b[:n]) != "def" {
+		t.Errorf("expected def, got %q", b[:n])
+	}
+
+	// skip forward past buffered data
+	if _, err := r.Seek(7, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := r.Fill(); err != nil || n != 1 {
+		t.Fatalf("expected 1 nil, got %d %v", n, err)
+	}
+	if n, _ := r.Read(b); string(b[:n]) != "h" {
+		t.Errorf("expected h, got %q", b[:n])
+	}
+	if n, err := r.Fill(); err != nil || n != 0 {
+		t.Fatalf("expected 0 nil at end, got %d %v", n, err)
+	}
+}
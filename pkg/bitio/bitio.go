@@ -8,6 +8,23 @@ import (
 var ErrOffset = errors.New("invalid seek offset")
 var ErrNegativeNBits = errors.New("negative number of bits")
 
+// BitOrder is order of bits in a byte
+type BitOrder int
+
+const (
+	// MSBFirst most significant bit is first, default
+	MSBFirst BitOrder = iota
+	// LSBFirst least significant bit is first, used by DEFLATE, Vorbis etc
+	LSBFirst
+)
+
+func (o BitOrder) String() string {
+	if o == LSBFirst {
+		return "lsb"
+	}
+	return "msb"
+}
+
 type BitReaderAt interface {
 	ReadBitsAt(p []byte, nBits int, bitOff int64) (n int, err error)
 }
@@ -34,6 +51,20 @@ type BitWriter interface {
 	WriteBits(p []byte, nBits int) (n int, err error)
 }
 
+type BitWriterAt interface {
+	WriteBitsAt(p []byte, nBits int, bitOff int64) (n int, err error)
+}
+
+type BitWriteSeeker interface {
+	BitWriter
+	BitSeeker
+}
+
+type BitWriteAtSeeker interface {
+	BitWriterAt
+	BitSeeker
+}
+
 func CopyBuffer(dst BitWriter, src BitReader, buf []byte) (n int64, err error) {
 	// same default size as io.Copy
 	if buf == nil {
@@ -103,7 +134,7 @@ func readFull(p []byte, nBits int, bitOff int64, fn func(p []byte, nBits int, bi
 			readBitOffset += rBits
 
 			if err != nil {
-				return nBits - readBitOffset, err
+				return readBitOffset, err
 			}
 
 			continue
@@ -113,7 +144,7 @@ func readFull(p []byte, nBits int, bitOff int64, fn func(p []byte, nBits int, bi
 
 		readBitOffset += rBits
 		if err != nil {
-			return nBits - readBitOffset, err
+			return readBitOffset, err
 		}
 	}
 
@@ -2,8 +2,10 @@ package bitio_test
 
 import (
 	"bytes"
+	"errors"
 	"io"
 	"log"
+	"sync"
 	"testing"
 
 	"github.com/wader/fq/pkg/bitio"
@@ -65,6 +67,17 @@ func TestReadFull(t *testing.T) {
 
 }
 
+func TestReadAtFullShort(t *testing.T) {
+	br := bitio.NewBitReader([]byte{0xff}, -1)
+	ob := make([]byte, 2)
+	for _, firstBit := range []int64{0, 3, 8} {
+		n, err := bitio.ReadAtFull(shortBitReader{br}, ob, 16, firstBit)
+		if expected := int(8 - firstBit); n != expected || err == nil {
+			t.Errorf("firstBit %d: expected %d bits and error, got %d %v", firstBit, expected, n, err)
+		}
+	}
+}
+
 func TestMultiBitReader(t *testing.T) {
 
 	bb1, bb1Bits := bitio.BytesFromBitString("101")
@@ -161,3 +174,358 @@ func TestAlignBitWriter(t *testing.T) {
 	log.Printf("n: %#+v\n", n)
 	log.Printf("err: %#+v\n", err)
 }
+
+func TestBytesReader(t *testing.T) {
+	b := []byte{0b1010_0101, 0b1111_0000, 0b0011_1100}
+	bs := bitio.BitStringFromBytes(b, 24)
+	br := bitio.NewBitReader(b, -1)
+
+	for bitOff := 0; bitOff < 24; bitOff++ {
+		for nBits := 0; nBits <= 24; nBits++ {
+			expectedN := nBits
+			if bitOff+nBits > 24 {
+				expectedN = 24 - bitOff
+			}
+			expected := bs[bitOff : bitOff+expectedN]
+
+			ob := make([]byte, 3)
+			n, err := br.ReadBitsAt(ob, nBits, int64(bitOff))
+			if actual := bitio.BitStringFromBytes(ob, n); actual != expected {
+				t.Errorf("bitOff %d nBits %d: expected %s, got %s", bitOff, nBits, expected, actual)
+			}
+			if (err == io.EOF) != (expectedN < nBits) {
+				t.Errorf("bitOff %d nBits %d: unexpected err %v", bitOff, nBits, err)
+			}
+		}
+	}
+
+	br = bitio.NewBitReader(b, 12)
+	if _, err := br.SeekBits(4, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	ob := make([]byte, 2)
+	n, err := br.ReadBits(ob, 16)
+	if n != 8 || err != io.EOF || ob[0] != 0b0101_1111 {
+		t.Errorf("expected 8 EOF 0b01011111, got %d %v %08b", n, err, ob[0])
+	}
+	if end, _ := br.SeekBits(0, io.SeekEnd); end != 12 {
+		t.Errorf("expected end 12, got %d", end)
+	}
+}
+
+func TestReaderSeekBits(t *testing.T) {
+	br := bitio.NewReaderFromReadSeeker(bytes.NewReader([]byte{0b1010_0101, 0b1111_0000}))
+
+	testCases := []struct {
+		bitOff   int64
+		whence   int
+		expected int64
+		err      error
+	}{
+		{11, io.SeekStart, 11, nil},
+		{-3, io.SeekCurrent, 8, nil},
+		{-5, io.SeekCurrent, 3, nil},
+		{-4, io.SeekCurrent, 0, bitio.ErrOffset},
+		{-3, io.SeekCurrent, 0, nil},
+		{-9, io.SeekEnd, 7, nil},
+		{-17, io.SeekEnd, 0, bitio.ErrOffset},
+		{-1, io.SeekStart, 0, bitio.ErrOffset},
+	}
+	for _, tC := range testCases {
+		p, err := br.SeekBits(tC.bitOff, tC.whence)
+		if err != tC.err || (err == nil && p != tC.expected) {
+			t.Fatalf("SeekBits(%d, %d): expected %d %v, got %d %v", tC.bitOff, tC.whence, tC.expected, tC.err, p, err)
+		}
+	}
+
+	// position 7 from last successful seek
+	ob := make([]byte, 1)
+	if n, err := br.ReadBits(ob, 4); n != 4 || err != nil || ob[0] != 0b1111_0000 {
+		t.Errorf("expected 4 nil 0b11110000, got %d %v %08b", n, err, ob[0])
+	}
+}
+
+func TestReaderFromReaderAtConcurrent(t *testing.T) {
+	buf := make([]byte, 4096)
+	for i := range buf {
+		buf[i] = byte(i * 31)
+	}
+	expectedBR := bitio.NewBitReader(buf, -1)
+	br := bitio.NewReaderFromReaderAt(bytes.NewReader(buf))
+
+	if end, err := bitio.EndPos(br); err != nil || end != int64(len(buf))*8 {
+		t.Fatalf("expected end %d nil, got %d %v", len(buf)*8, end, err)
+	}
+
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			p := make([]byte, 16)
+			ep := make([]byte, 16)
+			for bitOff := int64(g); bitOff < int64(len(buf))*8; bitOff += 67 {
+				nBits := 1 + int(bitOff%128)
+				n, err := br.ReadBitsAt(p, nBits, bitOff)
+				en, eerr := expectedBR.ReadBitsAt(ep, nBits, bitOff)
+				if n != en || !errors.Is(err, eerr) || !bytes.Equal(p[0:bitio.BitsByteCount(int64(n))], ep[0:bitio.BitsByteCount(int64(en))]) {
+					t.Errorf("ReadBitsAt(%d, %d): expected %d %v %x, got %d %v %x", nBits, bitOff, en, eerr, ep, n, err, p)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+type countingReadSeeker struct {
+	io.ReadSeeker
+	reads int
+}
+
+func (c *countingReadSeeker) Read(p []byte) (int, error) {
+	c.reads++
+	return c.ReadSeeker.Read(p)
+}
+
+func TestReaderCache(t *testing.T) {
+	buf := make([]byte, 1000)
+	for i := range buf {
+		buf[i] = byte(i * 31)
+	}
+	expectedBR := bitio.NewBitReader(buf, -1)
+
+	for _, cacheSize := range []int{0, 1, 7, 64, 2000} {
+		crs := &countingReadSeeker{ReadSeeker: bytes.NewReader(buf)}
+		br := bitio.NewReaderFromReadSeekerCacheSize(crs, cacheSize)
+		p := make([]byte, 16)
+		ep := make([]byte, 16)
+		nReads := 0
+		for bitOff := int64(0); bitOff < int64(len(buf))*8+16; bitOff += 13 {
+			nBits := 1 + int(bitOff%100)
+			n, err := br.ReadBitsAt(p, nBits, bitOff)
+			en, eerr := expectedBR.ReadBitsAt(ep, nBits, bitOff)
+			if n != en || !errors.Is(err, eerr) || !bytes.Equal(p[0:bitio.BitsByteCount(int64(n))], ep[0:bitio.BitsByteCount(int64(en))]) {
+				t.Fatalf("cacheSize %d ReadBitsAt(%d, %d): expected %d %v %x, got %d %v %x", cacheSize, nBits, bitOff, en, eerr, ep, n, err, p)
+			}
+			nReads++
+		}
+		if cacheSize == 2000 && crs.reads > 3 {
+			t.Errorf("cacheSize %d: expected at most 3 reads, got %d", cacheSize, crs.reads)
+		}
+		if cacheSize == 0 && crs.reads < nReads {
+			t.Errorf("cacheSize %d: expected at least %d reads, got %d", cacheSize, nReads, crs.reads)
+		}
+	}
+}
+
+func BenchmarkBytesReader(b *testing.B) {
+	buf := make([]byte, 4096)
+	p := make([]byte, 8)
+
+	benchReader := func(b *testing.B, br bitio.BitReaderAt) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for bitOff := int64(0); bitOff < int64(len(buf)-8)*8; bitOff += 61 {
+				if _, err := br.ReadBitsAt(p, 61, bitOff); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	}
+
+	b.Run("ReadSeeker", func(b *testing.B) {
+		benchReader(b, bitio.NewReaderFromReadSeeker(bytes.NewReader(buf)))
+	})
+	b.Run("ReadSeekerNoCache", func(b *testing.B) {
+		benchReader(b, bitio.NewReaderFromReadSeekerCacheSize(bytes.NewReader(buf), 0))
+	})
+	b.Run("ReaderAt", func(b *testing.B) {
+		benchReader(b, bitio.NewReaderFromReaderAt(bytes.NewReader(buf)))
+	})
+	b.Run("Bytes", func(b *testing.B) {
+		benchReader(b, bitio.NewBitReader(buf, -1))
+	})
+}
+
+func TestBytesWriter(t *testing.T) {
+	w := bitio.NewBytesWriter()
+	for _, s := range []string{"101", "0001", "1", "111100001111000011110000111100001111000011110000111100001111000011"} {
+		b, nBits := bitio.BytesFromBitString(s)
+		if n, err := w.WriteBits(b, nBits); n != nBits || err != nil {
+			t.Fatalf("expected %d nil, got %d %v", nBits, n, err)
+		}
+	}
+	expected := "101" + "0001" + "1" + "111100001111000011110000111100001111000011110000111100001111000011"
+	if actual := bitio.BitStringFromBytes(w.Bytes(), int(w.Len())); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+// writeSeeker is a in memory io.WriteSeeker, optionally also a io.ReaderAt
+type writeSeeker struct {
+	buf []byte
+	pos int64
+}
+
+func (ws *writeSeeker) Write(p []byte) (int, error) {
+	if end := ws.pos + int64(len(p)); end > int64(len(ws.buf)) {
+		ws.buf = append(ws.buf, make([]byte, end-int64(len(ws.buf)))...)
+	}
+	copy(ws.buf[ws.pos:], p)
+	ws.pos += int64(len(p))
+	return len(p), nil
+}
+
+func (ws *writeSeeker) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += ws.pos
+	case io.SeekEnd:
+		offset += int64(len(ws.buf))
+	}
+	ws.pos = offset
+	return offset, nil
+}
+
+type readAtWriteSeeker struct {
+	*writeSeeker
+}
+
+func (ws readAtWriteSeeker) ReadAt(p []byte, off int64) (int, error) {
+	return bytes.NewReader(ws.buf).ReadAt(p, off)
+}
+
+func TestWriter(t *testing.T) {
+	parts := []string{"101", "0001", "1", "111100001111000011110000111100001111000011110000111100001111000011", "01"}
+	expected := "101" + "0001" + "1" + "111100001111000011110000111100001111000011110000111100001111000011" + "01"
+	// padded to byte boundary on flush
+	expected += "000000"[0 : (8-len(expected)%8)%8]
+
+	for _, tc := range []struct {
+		name string
+		ws   func() (io.WriteSeeker, func() []byte)
+	}{
+		{"WriteSeeker", func() (io.WriteSeeker, func() []byte) {
+			ws := &writeSeeker{}
+			return ws, func() []byte { return ws.buf }
+		}},
+		{"ReaderAt", func() (io.WriteSeeker, func() []byte) {
+			ws := readAtWriteSeeker{&writeSeeker{}}
+			return ws, func() []byte { return ws.buf }
+		}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ws, bufFn := tc.ws()
+			w := bitio.NewWriterFromWriteSeeker(ws)
+			for _, s := range parts {
+				b, nBits := bitio.BytesFromBitString(s)
+				if n, err := w.WriteBits(b, nBits); n != nBits || err != nil {
+					t.Fatalf("expected %d nil, got %d %v", nBits, n, err)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			buf := bufFn()
+			if actual := bitio.BitStringFromBytes(buf, len(buf)*8); actual != expected {
+				t.Errorf("expected %s, got %s", expected, actual)
+			}
+		})
+	}
+}
+
+func TestWriterWriteBitsAt(t *testing.T) {
+	ws := readAtWriteSeeker{&writeSeeker{buf: []byte{0b1111_1111, 0b0000_0000, 0b1010_1010}}}
+	w := bitio.NewWriterFromWriteSeeker(ws)
+
+	b, nBits := bitio.BytesFromBitString("0110011001")
+	if n, err := w.WriteBitsAt(b, nBits, 4); n != nBits || err != nil {
+		t.Fatalf("expected %d nil, got %d %v", nBits, n, err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "1111" + "0110011001" + "00" + "10101010"
+	if actual := bitio.BitStringFromBytes(ws.buf, len(ws.buf)*8); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestSectionBitWriter(t *testing.T) {
+	ws := readAtWriteSeeker{&writeSeeker{buf: make([]byte, 2)}}
+	w := bitio.NewWriterFromWriteSeeker(ws)
+	sw := bitio.NewSectionBitWriter(w, 3, 6)
+
+	b, nBits := bitio.BytesFromBitString("1111")
+	if n, err := sw.WriteBits(b, nBits); n != 4 || err != nil {
+		t.Fatalf("expected 4 nil, got %d %v", n, err)
+	}
+	if n, err := sw.WriteBits(b, nBits); n != 2 || !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("expected 2 io.ErrShortWrite, got %d %v", n, err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "000" + "111111" + "0000000"
+	if actual := bitio.BitStringFromBytes(ws.buf, len(ws.buf)*8); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestMultiBitWriter(t *testing.T) {
+	ws := readAtWriteSeeker{&writeSeeker{buf: make([]byte, 2)}}
+	w := bitio.NewWriterFromWriteSeeker(ws)
+	sw1 := bitio.NewSectionBitWriter(w, 0, 3)
+	sw2 := bitio.NewSectionBitWriter(w, 8, 5)
+
+	mw, err := bitio.NewMultiBitWriter([]bitio.BitWriteAtSeeker{sw1, sw2})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, nBits := bitio.BytesFromBitString("10110111")
+	if n, err := mw.WriteBits(b, nBits); n != nBits || err != nil {
+		t.Fatalf("expected %d nil, got %d %v", nBits, n, err)
+	}
+	if n, err := mw.WriteBits(b, 1); n != 0 || !errors.Is(err, io.ErrShortWrite) {
+		t.Fatalf("expected 0 io.ErrShortWrite, got %d %v", n, err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	expected := "101" + "00000" + "10111" + "000"
+	if actual := bitio.BitStringFromBytes(ws.buf, len(ws.buf)*8); actual != expected {
+		t.Errorf("expected %s, got %s", expected, actual)
+	}
+}
+
+func TestReaderLSBFirst(t *testing.T) {
+	buf := []byte{0b1010_1101, 0b0000_1111, 0x42}
+	br := bitio.NewReaderFromReadSeeker(bytes.NewReader(buf))
+	br.SetBitOrder(bitio.LSBFirst)
+
+	p := make([]byte, 2)
+	for _, tc := range []struct {
+		nBits    int
+		expected uint64
+	}{
+		{3, 0b101},
+		{9, 0b1_1111_0101},
+		{4, 0b0000},
+	} {
+		n, err := br.ReadBits(p, tc.nBits)
+		if n != tc.nBits || err != nil {
+			t.Fatalf("expected %d nil, got %d %v", tc.nBits, n, err)
+		}
+		if actual := bitio.Uint64ReverseBits(n, bitio.Read64(p, 0, n)); actual != tc.expected {
+			t.Errorf("expected %b, got %b", tc.expected, actual)
+		}
+	}
+
+	// byte aligned Read returns underlying bytes
+	if n, err := br.Read(p[0:1]); n != 1 || err != nil || p[0] != 0x42 {
+		t.Errorf("expected 1 nil 0x42, got %d %v %x", n, err, p[0])
+	}
+}
@@ -1,7 +1,6 @@
 package bitio
 
 import (
-	"bytes"
 	"errors"
 	"fmt"
 	"io"
@@ -71,7 +70,7 @@ func NewBufferFromBytes(buf []byte, nBits int64) *Buffer {
 		nBits = int64(len(buf)) * 8
 	}
 	return &Buffer{
-		br:     NewSectionBitReader(NewReaderFromReadSeeker(bytes.NewReader(buf)), 0, nBits),
+		br:     NewBitReader(buf, nBits),
 		bitLen: nBits,
 	}
 }
@@ -137,16 +136,13 @@ func (b *Buffer) BitBufLen(nBits int64) (*Buffer, error) {
 }
 
 // PeekBytes peek nBytes bytes from buffer
+// Reads at current position without updating it.
 func (b *Buffer) PeekBytes(nBytes int) ([]byte, error) {
-	start, err := b.br.SeekBits(0, io.SeekCurrent)
+	pos, err := b.br.SeekBits(0, io.SeekCurrent)
 	if err != nil {
 		return nil, err
 	}
-	bs, err := b.BytesLen(nBytes)
-	if _, err := b.br.SeekBits(start, io.SeekStart); err != nil {
-		return nil, err
-	}
-	return bs, err
+	return b.BytesRange(pos, nBytes)
 }
 
 // required by some decompressors (like deflate) to not do own buffering
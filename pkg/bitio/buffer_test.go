@@ -3,6 +3,7 @@ package bitio_test
 // TODO: unbreak, check err
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"strings"
@@ -94,3 +95,19 @@ func TestInvalidBitString(t *testing.T) {
 	}()
 	bitio.NewBufferFromBitString("01invalid")
 }
+
+func TestBufferPeekBytes(t *testing.T) {
+	bb := bitio.NewBufferFromBytes([]byte{1, 2, 3}, -1)
+	if _, err := bb.SeekRel(8); err != nil {
+		t.Fatal(err)
+	}
+	if bs, err := bb.PeekBytes(2); err != nil || !bytes.Equal(bs, []byte{2, 3}) {
+		t.Errorf("expected [2 3] nil, got %v %v", bs, err)
+	}
+	if pos, _ := bb.Pos(); pos != 8 {
+		t.Errorf("expected pos 8, got %d", pos)
+	}
+	if _, err := bb.PeekBytes(3); err == nil {
+		t.Error("expected error peeking past end")
+	}
+}
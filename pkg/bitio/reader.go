@@ -3,20 +3,141 @@ package bitio
 import (
 	"errors"
 	"io"
+	"sync"
 )
 
-// Reader is a BitReadSeeker and BitReaderAt reading from a io.ReadSeeker
+// DefaultReaderCacheSize is the read cache size in bytes used by NewReaderFromReadSeeker
+const DefaultReaderCacheSize = 16 * 1024
+
+// Reader is a BitReadSeeker and BitReaderAt reading from a io.ReadSeeker or io.ReaderAt
 type Reader struct {
-	bitPos int64
-	rs     io.ReadSeeker
-	buf    []byte
+	bitPos   int64
+	rs       io.ReadSeeker
+	ra       io.ReaderAt
+	raSize   int64
+	buf      []byte
+	bitOrder BitOrder
+
+	// sliding window read cache for rs
+	cache        []byte
+	cacheSize    int
+	cacheBytePos int64
+	cacheEOF     bool // cache window reaches end of rs
 }
 
 func NewReaderFromReadSeeker(rs io.ReadSeeker) *Reader {
+	return NewReaderFromReadSeekerCacheSize(rs, DefaultReaderCacheSize)
+}
+
+// NewReaderFromReadSeekerCacheSize returns a Reader reading from a io.ReadSeeker
+// caching up to cacheSize bytes around last read position. Reads that fits in the
+// cache are served from memory instead of a Seek and Read on rs.
+// A cacheSize of 0 disables the cache. Assumes rs content does not change.
+func NewReaderFromReadSeekerCacheSize(rs io.ReadSeeker, cacheSize int) *Reader {
 	return &Reader{
-		bitPos: 0,
-		rs:     rs,
+		bitPos:    0,
+		rs:        rs,
+		cacheSize: cacheSize,
+	}
+}
+
+// NewReaderFromReaderAt returns a Reader reading from a io.ReaderAt.
+// ReadBitsAt has no shared state and is safe to call concurrently if ra.ReadAt is.
+// ReadBits, SeekBits, Read and Seek share position and are not.
+// Seeking relative to end requires ra to have a Size() int64 method, like
+// *bytes.Reader, *io.SectionReader and *strings.Reader.
+func NewReaderFromReaderAt(ra io.ReaderAt) *Reader {
+	r := &Reader{
+		ra:     ra,
+		raSize: -1,
 	}
+	if s, ok := ra.(interface{ Size() int64 }); ok {
+		r.raSize = s.Size()
+	}
+	return r
+}
+
+var readerAtBufPool = sync.Pool{
+	New: func() interface{} { return new([]byte) },
+}
+
+// SetBitOrder sets order of bits in each byte. With LSBFirst bit offsets count
+// from least significant bit of each byte and bits are returned in stream order,
+// use Read64LSB or Uint64ReverseBits to get LSB first integers.
+// Read returns bytes with least significant bit first bits in LSBFirst mode so
+// byte aligned reads return the underlying bytes.
+func (r *Reader) SetBitOrder(o BitOrder) { r.bitOrder = o }
+
+// BitOrder returns order of bits in each byte
+func (r *Reader) BitOrder() BitOrder { return r.bitOrder }
+
+// readBytesAt reads into buf at byte position, returns io.ErrUnexpectedEOF if short
+func (r *Reader) readBytesAt(buf []byte, bytePos int64) (int, error) {
+	if r.ra != nil {
+		n, err := r.ra.ReadAt(buf, bytePos)
+		if n == len(buf) {
+			return n, nil
+		}
+		if errors.Is(err, io.EOF) {
+			if n == 0 {
+				return 0, io.EOF
+			}
+			return n, io.ErrUnexpectedEOF
+		}
+		return n, err
+	}
+
+	if len(buf) > r.cacheSize {
+		_, err := r.rs.Seek(bytePos, io.SeekStart)
+		if err != nil {
+			return 0, err
+		}
+		return io.ReadFull(r.rs, buf)
+	}
+
+	cacheEnd := r.cacheBytePos + int64(len(r.cache))
+	if bytePos < r.cacheBytePos || (bytePos+int64(len(buf)) > cacheEnd && !r.cacheEOF) {
+		if err := r.fillCache(bytePos); err != nil {
+			return 0, err
+		}
+	}
+
+	var n int
+	if cacheOff := bytePos - r.cacheBytePos; cacheOff < int64(len(r.cache)) {
+		n = copy(buf, r.cache[cacheOff:])
+	}
+	if n == len(buf) {
+		return n, nil
+	}
+	if n == 0 {
+		return 0, io.EOF
+	}
+	return n, io.ErrUnexpectedEOF
+}
+
+// fillCache reads cache window starting at bytePos, might be short at end
+func (r *Reader) fillCache(bytePos int64) error {
+	if cap(r.cache) < r.cacheSize {
+		r.cache = make([]byte, r.cacheSize)
+	}
+	r.cache = r.cache[0:r.cacheSize]
+	r.cacheBytePos = bytePos
+	r.cacheEOF = false
+
+	_, err := r.rs.Seek(bytePos, io.SeekStart)
+	if err != nil {
+		r.cache = r.cache[0:0]
+		return err
+	}
+	n, err := io.ReadFull(r.rs, r.cache)
+	r.cache = r.cache[0:n]
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		r.cacheEOF = true
+	} else if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (r *Reader) ReadBitsAt(p []byte, nBits int, bitOffset int64) (int, error) {
@@ -29,27 +150,36 @@ func (r *Reader) ReadBitsAt(p []byte, nBits int, bitOffset int64) (int, error) {
 	wantReadBits := readSkipBits + nBits
 	wantReadBytes := int(BitsByteCount(int64(wantReadBits)))
 
-	if wantReadBytes > len(r.buf) {
+	buf := r.buf
+	if r.ra != nil {
+		// use pooled scratch buffer to not share state between concurrent calls
+		bufp := readerAtBufPool.Get().(*[]byte)
+		defer readerAtBufPool.Put(bufp)
+		if wantReadBytes > len(*bufp) {
+			*bufp = make([]byte, wantReadBytes)
+		}
+		buf = *bufp
+	} else if wantReadBytes > len(r.buf) {
 		// TODO: use append somehow?
 		r.buf = make([]byte, wantReadBytes)
-	}
-
-	_, err := r.rs.Seek(readBytePos, io.SeekStart)
-	if err != nil {
-		return 0, err
+		buf = r.buf
 	}
 
 	// TODO: nBits should be available
-	readBytes, err := io.ReadFull(r.rs, r.buf[0:wantReadBytes])
+	readBytes, err := r.readBytesAt(buf[0:wantReadBytes], readBytePos)
 	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
 		return 0, err
 	} else if errors.Is(err, io.ErrUnexpectedEOF) {
-		nBits = readBytes * 8
+		nBits = readBytes*8 - readSkipBits
 		err = io.EOF
 	}
+	if r.bitOrder == LSBFirst {
+		// buf is a copy, reverse so stream order bits are most significant first
+		ReverseBitsInBytes(buf[0:readBytes])
+	}
 
 	if readSkipBits == 0 && nBits%8 == 0 {
-		copy(p[0:readBytes], r.buf[0:readBytes])
+		copy(p[0:readBytes], buf[0:readBytes])
 		return nBits, err
 	}
 
@@ -58,10 +188,10 @@ func (r *Reader) ReadBitsAt(p []byte, nBits int, bitOffset int64) (int, error) {
 
 	// TODO: copy smartness if many bytes
 	for i := 0; i < nBytes; i++ {
-		p[i] = byte(Read64(r.buf, readSkipBits+i*8, 8))
+		p[i] = byte(Read64(buf, readSkipBits+i*8, 8))
 	}
 	if restBits != 0 {
-		p[nBytes] = byte(Read64(r.buf, readSkipBits+nBytes*8, restBits)) << (8 - restBits)
+		p[nBytes] = byte(Read64(buf, readSkipBits+nBytes*8, restBits)) << (8 - restBits)
 	}
 
 	return nBits, err
@@ -74,19 +204,40 @@ func (r *Reader) ReadBits(p []byte, nBits int) (n int, err error) {
 }
 
 func (r *Reader) SeekBits(bitOff int64, whence int) (int64, error) {
-	seekBytesPos, err := r.rs.Seek(bitOff/8, whence)
-	if err != nil {
-		return 0, err
+	// resolve bit position here as underlying reader only knows about bytes and
+	// bitOff%8 can be negative
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		bitOff += r.bitPos
+	case io.SeekEnd:
+		endBytePos, err := r.endBytePos()
+		if err != nil {
+			return 0, err
+		}
+		bitOff += endBytePos * 8
+	default:
+		panic("unknown whence")
+	}
+	if bitOff < 0 {
+		return 0, ErrOffset
 	}
-	seekBitPos := seekBytesPos*8 + bitOff%8
-	r.bitPos = seekBitPos
+	if r.rs != nil {
+		if _, err := r.rs.Seek(bitOff/8, io.SeekStart); err != nil {
+			return 0, err
+		}
+	}
+	r.bitPos = bitOff
 
-	return seekBitPos, nil
+	return bitOff, nil
 }
 
 func (r *Reader) Read(p []byte) (n int, err error) {
 	n, err = r.ReadBitsAt(p, len(p)*8, r.bitPos)
 	r.bitPos += int64(n)
+	if r.bitOrder == LSBFirst {
+		ReverseBitsInBytes(p[0:BitsByteCount(int64(n))])
+	}
 	if err != nil {
 		return int(BitsByteCount(int64(n))), err
 	}
@@ -94,13 +245,116 @@ func (r *Reader) Read(p []byte) (n int, err error) {
 	return int(BitsByteCount(int64(n))), nil
 }
 
+func (r *Reader) endBytePos() (int64, error) {
+	if r.ra != nil {
+		if r.raSize < 0 {
+			return 0, errors.New("io.ReaderAt has unknown size")
+		}
+		return r.raSize, nil
+	}
+	return r.rs.Seek(0, io.SeekEnd)
+}
+
 func (r *Reader) Seek(offset int64, whence int) (int64, error) {
-	seekBytesPos, err := r.rs.Seek(offset, whence)
-	if err != nil {
-		return 0, err
+	// rs position is not kept in sync with bit position when reading from cache
+	// so always resolve using bit position
+	seekBitsPos, err := r.SeekBits(offset*8, whence)
+	return seekBitsPos / 8, err
+}
+
+// BytesReader is a BitReadSeeker and BitReaderAt reading directly from a byte slice
+type BytesReader struct {
+	bitPos int64
+	buf    []byte
+	bitLen int64
+}
+
+// NewBitReader new BytesReader reading from buf, buf is not copied.
+// if nBits is < 0 nBits is all bits in buf
+func NewBitReader(buf []byte, nBits int64) *BytesReader {
+	if nBits < 0 || nBits > int64(len(buf))*8 {
+		nBits = int64(len(buf)) * 8
+	}
+	return &BytesReader{
+		buf:    buf,
+		bitLen: nBits,
+	}
+}
+
+func (r *BytesReader) ReadBitsAt(p []byte, nBits int, bitOffset int64) (int, error) {
+	if nBits < 0 {
+		return 0, ErrNegativeNBits
+	}
+	if bitOffset < 0 || bitOffset >= r.bitLen {
+		return 0, io.EOF
+	}
+
+	var err error
+	if maxBits := r.bitLen - bitOffset; int64(nBits) > maxBits {
+		nBits = int(maxBits)
+		err = io.EOF
+	}
+
+	readBytePos := int(bitOffset / 8)
+	readSkipBits := int(bitOffset % 8)
+	nBytes := nBits / 8
+	restBits := nBits % 8
+
+	// byte at i shifted left by readSkipBits with bits from next byte
+	shiftedByte := func(i int) byte {
+		b := r.buf[i] << readSkipBits
+		if readSkipBits != 0 && i+1 < len(r.buf) {
+			b |= r.buf[i+1] >> (8 - readSkipBits)
+		}
+		return b
+	}
+
+	if readSkipBits == 0 {
+		copy(p[0:nBytes], r.buf[readBytePos:readBytePos+nBytes])
+	} else {
+		for i := 0; i < nBytes; i++ {
+			p[i] = shiftedByte(readBytePos + i)
+		}
+	}
+	if restBits != 0 {
+		p[nBytes] = shiftedByte(readBytePos+nBytes) &^ (0xff >> restBits)
+	}
+
+	return nBits, err
+}
+
+func (r *BytesReader) ReadBits(p []byte, nBits int) (n int, err error) {
+	rBits, err := r.ReadBitsAt(p, nBits, r.bitPos)
+	r.bitPos += int64(rBits)
+	return rBits, err
+}
+
+func (r *BytesReader) SeekBits(bitOff int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		bitOff += r.bitPos
+	case io.SeekEnd:
+		bitOff += r.bitLen
+	default:
+		panic("unknown whence")
 	}
-	r.bitPos = seekBytesPos * 8
-	return seekBytesPos, nil
+	if bitOff < 0 {
+		return 0, ErrOffset
+	}
+	r.bitPos = bitOff
+	return bitOff, nil
+}
+
+func (r *BytesReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadBitsAt(p, len(p)*8, r.bitPos)
+	r.bitPos += int64(n)
+	return int(BitsByteCount(int64(n))), err
+}
+
+func (r *BytesReader) Seek(offset int64, whence int) (int64, error) {
+	seekBitsPos, err := r.SeekBits(offset*8, whence)
+	return seekBitsPos / 8, err
 }
 
 // SectionBitReader is a BitReadSeeker reading from a BitReaderAt
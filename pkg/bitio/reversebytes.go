@@ -1,5 +1,7 @@
 package bitio
 
+import "math/bits"
+
 func ReverseBytes(bs []byte) []byte {
 	l := len(bs)
 	for i := 0; i < l/2; i++ {
@@ -7,3 +9,11 @@ func ReverseBytes(bs []byte) []byte {
 	}
 	return bs
 }
+
+// ReverseBitsInBytes reverse bit order of each byte in bs
+func ReverseBitsInBytes(bs []byte) []byte {
+	for i, b := range bs {
+		bs[i] = bits.Reverse8(b)
+	}
+	return bs
+}
@@ -3,6 +3,7 @@ package bitio
 import (
 	"encoding/binary"
 	"fmt"
+	"math/bits"
 )
 
 // Read64 read nBits bits large unsigned integer from buf starting from firstBit.
@@ -170,6 +171,68 @@ func Write64(v uint64, nBits int, buf []byte, firstBit int) {
 	}
 }
 
+// Read64LSB read nBits bits large unsigned integer from buf starting from firstBit.
+// Bits are numbered least significant bit first in each byte and integer is read
+// least significant bit first, as in DEFLATE.
+func Read64LSB(buf []byte, firstBit int, nBits int) uint64 {
+	if nBits < 0 || nBits > 64 {
+		panic(fmt.Sprintf("nBits must be 0-64 (%d)", nBits))
+	}
+
+	var n uint64
+	bitPos := firstBit
+	shift := 0
+
+	for shift < nBits {
+		bytePos, byteBitPos := bitPos>>3, bitPos&0x7 // / % 8
+		byteBitsLeft := 8 - byteBitPos
+		if left := nBits - shift; byteBitsLeft > left {
+			byteBitsLeft = left
+		}
+		b := uint64(buf[bytePos]>>byteBitPos) & ((1 << byteBitsLeft) - 1)
+		n |= b << shift
+		bitPos += byteBitsLeft
+		shift += byteBitsLeft
+	}
+
+	return n
+}
+
+// Write64LSB write nBits bits large unsigned integer to buf starting from firstBit.
+// Bits are numbered least significant bit first in each byte and integer is written
+// least significant bit first, as in DEFLATE.
+func Write64LSB(v uint64, nBits int, buf []byte, firstBit int) {
+	if nBits < 0 || nBits > 64 {
+		panic(fmt.Sprintf("nBits must be 0-64 (%d)", nBits))
+	}
+
+	bitPos := firstBit
+	shift := 0
+
+	for shift < nBits {
+		bytePos, byteBitPos := bitPos>>3, bitPos&0x7 // / % 8
+		byteBitsLeft := 8 - byteBitPos
+		if left := nBits - shift; byteBitsLeft > left {
+			byteBitsLeft = left
+		}
+		bMask := byte(((1 << byteBitsLeft) - 1) << byteBitPos)
+		buf[bytePos] = buf[bytePos]&^bMask | byte(v>>shift)<<byteBitPos&bMask
+		bitPos += byteBitsLeft
+		shift += byteBitsLeft
+	}
+}
+
+// Uint64ReverseBits reverse order of the nBits least significant bits
+func Uint64ReverseBits(nBits int, n uint64) uint64 {
+	if nBits < 0 || nBits > 64 {
+		panic(fmt.Sprintf("nBits must be 0-64 (%d)", nBits))
+	}
+	if nBits == 0 {
+		return 0
+	}
+	return bits.Reverse64(n) >> (64 - nBits)
+}
+
 func Uint64ReverseBytes(nBits int, n uint64) uint64 {
 	switch {
 	case nBits <= 8:
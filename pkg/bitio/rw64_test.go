@@ -186,3 +186,65 @@ func TestUint64ReverseBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestRead64LSB(t *testing.T) {
+	testCases := []struct {
+		buf      []byte
+		firstBit int
+		nBits    int
+		expected uint64
+	}{
+		{[]byte{0b1010_1101}, 0, 3, 0b101},
+		{[]byte{0b1010_1101}, 3, 5, 0b10101},
+		{[]byte{0b1010_1101}, 0, 8, 0b1010_1101},
+		{[]byte{0xf0, 0x0f}, 4, 8, 0xff},
+		{[]byte{0x34, 0x12}, 0, 16, 0x1234},
+		{[]byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}, 0, 64, 0xefcdab8967452301},
+		{[]byte{0x80, 0x01}, 7, 2, 0b11},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(fmt.Sprintf("%s %d %d", hex.EncodeToString(tC.buf), tC.firstBit, tC.nBits), func(t *testing.T) {
+			actual := bitio.Read64LSB(tC.buf, tC.firstBit, tC.nBits)
+			if tC.expected != actual {
+				t.Errorf("expected %x, got %x", tC.expected, actual)
+			}
+
+			buf := make([]byte, len(tC.buf))
+			bitio.Write64LSB(tC.expected, tC.nBits, buf, tC.firstBit)
+			if actual := bitio.Read64LSB(buf, tC.firstBit, tC.nBits); tC.expected != actual {
+				t.Errorf("write expected %x, got %x", tC.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWrite64LSB(t *testing.T) {
+	buf := []byte{0b1111_1111, 0b1111_1111}
+	bitio.Write64LSB(0b0000, 4, buf, 6)
+	if expected := []byte{0b0011_1111, 0b1111_1100}; !bytes.Equal(expected, buf) {
+		t.Errorf("expected %08b, got %08b", expected, buf)
+	}
+}
+
+func TestUint64ReverseBits(t *testing.T) {
+	testCases := []struct {
+		nBits    int
+		n        uint64
+		expected uint64
+	}{
+		{nBits: 0, n: 0, expected: 0},
+		{nBits: 1, n: 1, expected: 1},
+		{nBits: 3, n: 0b110, expected: 0b011},
+		{nBits: 8, n: 0b1000_0010, expected: 0b0100_0001},
+		{nBits: 64, n: 1, expected: 1 << 63},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%d %b %b", tC.nBits, tC.n, tC.expected), func(t *testing.T) {
+			actual := bitio.Uint64ReverseBits(tC.nBits, tC.n)
+			if tC.expected != actual {
+				t.Errorf("expected %b, got %b", tC.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,402 @@
+package bitio
+
+import (
+	"errors"
+	"io"
+)
+
+// BytesWriter is a BitWriter appending bits to a byte slice
+type BytesWriter struct {
+	buf    []byte
+	bitLen int64
+}
+
+func NewBytesWriter() *BytesWriter {
+	return &BytesWriter{}
+}
+
+func (w *BytesWriter) WriteBits(p []byte, nBits int) (int, error) {
+	if nBits < 0 {
+		return 0, ErrNegativeNBits
+	}
+
+	if n := BitsByteCount(w.bitLen + int64(nBits)); n > int64(len(w.buf)) {
+		w.buf = append(w.buf, make([]byte, n-int64(len(w.buf)))...)
+	}
+	for i := 0; i < nBits; i += 64 {
+		n := nBits - i
+		if n > 64 {
+			n = 64
+		}
+		Write64(Read64(p, i, n), n, w.buf, int(w.bitLen))
+		w.bitLen += int64(n)
+	}
+
+	return nBits, nil
+}
+
+// Bytes returns written bytes, last byte is zero padded if not byte aligned
+func (w *BytesWriter) Bytes() []byte { return w.buf }
+
+// Len returns number of bits written
+func (w *BytesWriter) Len() int64 { return w.bitLen }
+
+// Writer is a BitWriteSeeker and BitWriterAt writing to a io.WriteSeeker
+//
+// Bytes partially covered by a write are read back from the underlying writer if
+// it is a io.ReaderAt, otherwise bits not written are zero. A trailing partial
+// byte is kept until more bits are written to it, the writer seeks or writes
+// elsewhere, or Flush is called. Flush writes it zero padded.
+type Writer struct {
+	bitPos  int64
+	ws      io.WriteSeeker
+	buf     []byte
+	tail    byte
+	tailPos int64 // byte position of tail, -1 if none
+	dirty   bool  // tail not written yet
+}
+
+func NewWriterFromWriteSeeker(ws io.WriteSeeker) *Writer {
+	return &Writer{
+		ws:      ws,
+		tailPos: -1,
+	}
+}
+
+// existing byte at byte position
+func (w *Writer) byteAt(bytePos int64) (byte, error) {
+	if bytePos == w.tailPos {
+		return w.tail, nil
+	}
+	if ra, ok := w.ws.(io.ReaderAt); ok {
+		var b [1]byte
+		_, err := ra.ReadAt(b[:], bytePos)
+		if err != nil && !errors.Is(err, io.EOF) {
+			return 0, err
+		}
+		return b[0], nil
+	}
+	return 0, nil
+}
+
+func (w *Writer) writeBytesAt(buf []byte, bytePos int64) error {
+	if len(buf) == 0 {
+		return nil
+	}
+	if _, err := w.ws.Seek(bytePos, io.SeekStart); err != nil {
+		return err
+	}
+	_, err := w.ws.Write(buf)
+	return err
+}
+
+// Flush writes pending trailing partial byte, not written bits are zero
+func (w *Writer) Flush() error {
+	if !w.dirty {
+		return nil
+	}
+	if err := w.writeBytesAt([]byte{w.tail}, w.tailPos); err != nil {
+		return err
+	}
+	w.dirty = false
+	return nil
+}
+
+func (w *Writer) WriteBitsAt(p []byte, nBits int, bitOffset int64) (int, error) {
+	if nBits < 0 {
+		return 0, ErrNegativeNBits
+	}
+	if bitOffset < 0 {
+		return 0, ErrOffset
+	}
+	if nBits == 0 {
+		return 0, nil
+	}
+
+	startBytePos := bitOffset / 8
+	skipBits := int(bitOffset % 8)
+	endBitPos := bitOffset + int64(nBits)
+	nBytes := int(BitsByteCount(endBitPos) - startBytePos)
+	lastBytePos := startBytePos + int64(nBytes) - 1
+	restBits := int(endBitPos % 8)
+
+	if w.tailPos != -1 && (w.tailPos < startBytePos || w.tailPos > lastBytePos) {
+		if err := w.Flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if nBytes > len(w.buf) {
+		w.buf = make([]byte, nBytes)
+	}
+	buf := w.buf[0:nBytes]
+	for i := range buf {
+		buf[i] = 0
+	}
+	var err error
+	if skipBits != 0 {
+		if buf[0], err = w.byteAt(startBytePos); err != nil {
+			return 0, err
+		}
+	}
+	if restBits != 0 {
+		if buf[nBytes-1], err = w.byteAt(lastBytePos); err != nil {
+			return 0, err
+		}
+	}
+
+	for i := 0; i < nBits; i += 64 {
+		n := nBits - i
+		if n > 64 {
+			n = 64
+		}
+		Write64(Read64(p, i, n), n, buf, skipBits+i)
+	}
+
+	if restBits != 0 {
+		w.tail = buf[nBytes-1]
+		w.tailPos = lastBytePos
+		w.dirty = true
+		buf = buf[0 : nBytes-1]
+	} else {
+		w.tailPos = -1
+		w.dirty = false
+	}
+	if err := w.writeBytesAt(buf, startBytePos); err != nil {
+		return 0, err
+	}
+
+	return nBits, nil
+}
+
+func (w *Writer) WriteBits(p []byte, nBits int) (n int, err error) {
+	wBits, err := w.WriteBitsAt(p, nBits, w.bitPos)
+	w.bitPos += int64(wBits)
+	return wBits, err
+}
+
+func (w *Writer) SeekBits(bitOff int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		bitOff += w.bitPos
+	case io.SeekEnd:
+		if err := w.Flush(); err != nil {
+			return 0, err
+		}
+		endBytePos, err := w.ws.Seek(0, io.SeekEnd)
+		if err != nil {
+			return 0, err
+		}
+		bitOff += endBytePos * 8
+	default:
+		panic("unknown whence")
+	}
+	if bitOff < 0 {
+		return 0, ErrOffset
+	}
+	w.bitPos = bitOff
+
+	return bitOff, nil
+}
+
+func (w *Writer) Write(p []byte) (n int, err error) {
+	n, err = w.WriteBitsAt(p, len(p)*8, w.bitPos)
+	w.bitPos += int64(n)
+	return n / 8, err
+}
+
+func (w *Writer) Seek(offset int64, whence int) (int64, error) {
+	seekBitsPos, err := w.SeekBits(offset*8, whence)
+	return seekBitsPos / 8, err
+}
+
+// SectionBitWriter is a BitWriteSeeker writing to a BitWriterAt
+// modelled after SectionBitReader. Writes past the end are truncated and
+// return io.ErrShortWrite.
+type SectionBitWriter struct {
+	w        BitWriterAt
+	bitBase  int64
+	bitOff   int64
+	bitLimit int64
+}
+
+func NewSectionBitWriter(w BitWriterAt, bitOff int64, nBits int64) *SectionBitWriter {
+	return &SectionBitWriter{
+		w:        w,
+		bitBase:  bitOff,
+		bitOff:   bitOff,
+		bitLimit: bitOff + nBits,
+	}
+}
+
+func (w *SectionBitWriter) WriteBitsAt(p []byte, nBits int, bitOff int64) (int, error) {
+	if bitOff < 0 || bitOff >= w.bitLimit-w.bitBase {
+		return 0, io.ErrShortWrite
+	}
+	bitOff += w.bitBase
+	if maxBits := int(w.bitLimit - bitOff); nBits > maxBits {
+		wBits, err := w.w.WriteBitsAt(p, maxBits, bitOff)
+		if err == nil {
+			err = io.ErrShortWrite
+		}
+		return wBits, err
+	}
+	return w.w.WriteBitsAt(p, nBits, bitOff)
+}
+
+func (w *SectionBitWriter) WriteBits(p []byte, nBits int) (n int, err error) {
+	wBits, err := w.WriteBitsAt(p, nBits, w.bitOff-w.bitBase)
+	w.bitOff += int64(wBits)
+	return wBits, err
+}
+
+func (w *SectionBitWriter) SeekBits(bitOff int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+		bitOff += w.bitBase
+	case io.SeekCurrent:
+		bitOff += w.bitOff
+	case io.SeekEnd:
+		bitOff += w.bitLimit
+	default:
+		panic("unknown whence")
+	}
+	if bitOff < w.bitBase {
+		return 0, ErrOffset
+	}
+	w.bitOff = bitOff
+	return bitOff - w.bitBase, nil
+}
+
+func (w *SectionBitWriter) Write(p []byte) (n int, err error) {
+	n, err = w.WriteBitsAt(p, len(p)*8, w.bitOff-w.bitBase)
+	w.bitOff += int64(n)
+	return n / 8, err
+}
+
+func (w *SectionBitWriter) Seek(offset int64, whence int) (int64, error) {
+	seekBitsPos, err := w.SeekBits(offset*8, whence)
+	return seekBitsPos / 8, err
+}
+
+// MultiBitWriter is a BitWriteSeeker writing to multiple BitWriteAtSeeker:s
+// after each other, size of each writer is its end position when created.
+// Writes spanning writers are split.
+type MultiBitWriter struct {
+	pos        int64
+	writers    []BitWriteAtSeeker
+	writerEnds []int64
+}
+
+func NewMultiBitWriter(ws []BitWriteAtSeeker) (*MultiBitWriter, error) {
+	writerEnds := make([]int64, len(ws))
+	var esSum int64
+	for i, w := range ws {
+		e, err := EndPos(w)
+		if err != nil {
+			return nil, err
+		}
+		esSum += e
+		writerEnds[i] = esSum
+	}
+	return &MultiBitWriter{writers: ws, writerEnds: writerEnds}, nil
+}
+
+func (m *MultiBitWriter) end() int64 {
+	if len(m.writers) == 0 {
+		return 0
+	}
+	return m.writerEnds[len(m.writers)-1]
+}
+
+func (m *MultiBitWriter) WriteBitsAt(p []byte, nBits int, bitOff int64) (n int, err error) {
+	if nBits < 0 {
+		return 0, ErrNegativeNBits
+	}
+
+	var wBits int
+	prevAtEnd := int64(0)
+	for i, end := range m.writerEnds {
+		if wBits == nBits {
+			break
+		}
+		pos := bitOff + int64(wBits)
+		if pos >= end {
+			prevAtEnd = end
+			continue
+		}
+
+		n := nBits - wBits
+		if maxBits := int(end - pos); n > maxBits {
+			n = maxBits
+		}
+		// source bits might not be byte aligned for the following writers
+		var wp []byte
+		if wBits%8 == 0 {
+			wp = p[wBits/8:]
+		} else {
+			wp = make([]byte, BitsByteCount(int64(n)))
+			for j := 0; j < n; j += 64 {
+				c := n - j
+				if c > 64 {
+					c = 64
+				}
+				Write64(Read64(p, wBits+j, c), c, wp, j)
+			}
+		}
+
+		cBits, err := m.writers[i].WriteBitsAt(wp, n, pos-prevAtEnd)
+		wBits += cBits
+		if err != nil {
+			return wBits, err
+		}
+		prevAtEnd = end
+	}
+
+	if wBits != nBits {
+		return wBits, io.ErrShortWrite
+	}
+
+	return wBits, nil
+}
+
+func (m *MultiBitWriter) WriteBits(p []byte, nBits int) (n int, err error) {
+	n, err = m.WriteBitsAt(p, nBits, m.pos)
+	m.pos += int64(n)
+	return n, err
+}
+
+func (m *MultiBitWriter) SeekBits(bitOff int64, whence int) (int64, error) {
+	var p int64
+	end := m.end()
+
+	switch whence {
+	case io.SeekStart:
+		p = bitOff
+	case io.SeekCurrent:
+		p = m.pos + bitOff
+	case io.SeekEnd:
+		p = end + bitOff
+	default:
+		panic("unknown whence")
+	}
+	if p < 0 || p > end {
+		return 0, ErrOffset
+	}
+
+	m.pos = p
+
+	return p, nil
+}
+
+func (m *MultiBitWriter) Write(p []byte) (n int, err error) {
+	n, err = m.WriteBitsAt(p, len(p)*8, m.pos)
+	m.pos += int64(n)
+	return n / 8, err
+}
+
+func (m *MultiBitWriter) Seek(offset int64, whence int) (int64, error) {
+	seekBitsPos, err := m.SeekBits(offset*8, whence)
+	return seekBitsPos / 8, err
+}
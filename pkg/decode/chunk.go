@@ -0,0 +1,127 @@
+package decode
+
+import (
+	"strings"
+
+	"github.com/wader/fq/pkg/scalar"
+)
+
+// ChunkFn decodes chunk data, opts can be used to decode sub-chunks with FieldChunks
+type ChunkFn func(d *D, opts ChunkOpts)
+
+// ChunkOpts describes an id, size and data chunk layout as used by RIFF, IFF and similar formats
+type ChunkOpts struct {
+	Endian             Endian
+	IDLen              int    // id length in bytes, 4 if zero
+	TrimID             bool   // trim spaces from id, "fmt " is "fmt"
+	ReverseID          bool   // id is stored byte reversed, ex little endian RIFX stores "RIFX" as "XFIR"
+	SizeBits           int    // size field length in bits, 32 if zero
+	SizeIncludesHeader bool   // size includes id and size fields
+	RestSize           uint64 // size value meaning chunk extends to end of parent, zero if not used
+	Align              int64  // data is padded to a multiple of Align bytes, zero for no padding
+
+	Chunks    map[string]ChunkFn // known chunks by id
+	UnknownFn ChunkFn            // decode unknown chunks, data is added as raw bits if nil
+}
+
+func (o ChunkOpts) idLen() int {
+	if o.IDLen == 0 {
+		return 4
+	}
+	return o.IDLen
+}
+
+func (o ChunkOpts) sizeBits() int {
+	if o.SizeBits == 0 {
+		return 32
+	}
+	return o.SizeBits
+}
+
+func reverse(s string) string {
+	b := []byte(s)
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+	return string(b)
+}
+
+var reverseStr = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	s.Actual = reverse(s.ActualStr())
+	return s, nil
+})
+
+// FieldChunk adds id, size and data fields for one chunk to current struct and returns the id.
+// Sets current endian to opts.Endian. Decoding fails if expectedID is not empty and does not match.
+func (d *D) FieldChunk(opts ChunkOpts, expectedID string) string {
+	d.Endian = opts.Endian
+
+	var idSms []scalar.Mapper
+	if opts.ReverseID {
+		idSms = append(idSms, reverseStr)
+	}
+	if opts.TrimID {
+		idSms = append(idSms, scalar.TrimSpace)
+	}
+	id := d.FieldUTF8("id", opts.idLen(), idSms...)
+	if opts.TrimID {
+		id = strings.TrimSpace(id)
+	}
+	if expectedID != "" && id != expectedID {
+		d.Errorf("expected chunk id %q found %q", expectedID, id)
+	}
+
+	var size uint64
+	if opts.RestSize != 0 {
+		size = d.FieldUScalarFn("size", func(d *D) scalar.S {
+			s := d.U(opts.sizeBits())
+			if s == opts.RestSize {
+				return scalar.S{Actual: s, ActualDisplay: scalar.NumberHex, Sym: "rest of file"}
+			}
+			return scalar.S{Actual: s, ActualDisplay: scalar.NumberDecimal}
+		})
+	} else {
+		size = d.FieldU("size", opts.sizeBits())
+	}
+
+	dataLen := int64(size) * 8
+	switch {
+	case opts.RestSize != 0 && size == opts.RestSize:
+		dataLen = d.BitsLeft()
+	case opts.SizeIncludesHeader:
+		headerLen := int64(opts.idLen())*8 + int64(opts.sizeBits())
+		if dataLen < headerLen {
+			d.Errorf("chunk %q size %d smaller than header", id, size)
+		}
+		dataLen -= headerLen
+	}
+	if dataLen > d.BitsLeft() {
+		d.Errorf("chunk %q size %d outside of parent", id, size)
+	}
+
+	fn := opts.UnknownFn
+	if cfn, ok := opts.Chunks[id]; ok {
+		fn = cfn
+	}
+	if fn != nil {
+		d.LenFn(dataLen, func(d *D) { fn(d, opts) })
+	} else {
+		d.FieldRawLen("data", dataLen)
+	}
+
+	// last chunk in parent is sometimes not padded
+	if opts.Align > 1 {
+		if padLen := (opts.Align - (dataLen/8)%opts.Align) % opts.Align; padLen != 0 && d.BitsLeft() >= padLen*8 {
+			d.FieldRawLen("align", padLen*8)
+		}
+	}
+
+	return id
+}
+
+// FieldChunks adds an array of chunk structs until end of current buffer
+func (d *D) FieldChunks(name string, opts ChunkOpts) *D {
+	return d.FieldStructArrayLoop(name, "chunk", d.NotEnd, func(d *D) {
+		d.FieldChunk(opts, "")
+	})
+}
@@ -3,6 +3,7 @@ package decode
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -34,8 +35,15 @@ type Options struct {
 	FormatOptions map[string]interface{}
 	FormatInArg   interface{}
 	ReadBuf       *[]byte
+	// MaxDepth is max number of nested format decodes, 0 is no limit
+	MaxDepth int
+
+	depth int // number of format decodes above this one
 }
 
+// ErrMaxDepth is returned when a nested format decode would exceed Options.MaxDepth
+var ErrMaxDepth = errors.New("max depth exceeded")
+
 // Decode try decode group and return first success and all other decoder errors
 func Decode(ctx context.Context, bb *bitio.Buffer, group Group, opts Options) (*Value, interface{}, error) {
 	return decode(ctx, bb, group, opts)
@@ -50,10 +58,17 @@ func decode(ctx context.Context, bb *bitio.Buffer, group Group, opts Options) (*
 	if group == nil {
 		panic("group is nil, failed to register format?")
 	}
+	if opts.MaxDepth > 0 && opts.depth >= opts.MaxDepth {
+		return nil, nil, fmt.Errorf("%w (%d)", ErrMaxDepth, opts.MaxDepth)
+	}
 
 	formatsErr := FormatsError{}
 
 	for _, g := range group {
+		if ctx != nil && ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+
 		cbb, err := bb.BitBufRange(decodeRange.Start, decodeRange.Len)
 		if err != nil {
 			return nil, nil, IOError{Err: err, Op: "BitBufRange", ReadSize: decodeRange.Len, Pos: decodeRange.Start}
@@ -73,10 +88,16 @@ func decode(ctx context.Context, bb *bitio.Buffer, group Group, opts Options) (*
 		if !rOk {
 			if re, ok := r.RecoverV.(RecoverableErrorer); ok && re.IsRecoverableError() {
 				panicErr, _ := re.(error)
+				errPos, ok := errorPos(panicErr)
+				if !ok {
+					errPos = d.Pos()
+				}
 				formatErr := FormatError{
 					Err:        panicErr,
 					Format:     g,
 					Stacktrace: r,
+					Pos:        decodeRange.Start + errPos,
+					Root:       d.Value,
 				}
 				formatsErr.Errs = append(formatsErr.Errs, formatErr)
 
@@ -127,14 +148,25 @@ func decode(ctx context.Context, bb *bitio.Buffer, group Group, opts Options) (*
 }
 
 type D struct {
-	Ctx     context.Context
-	Endian  Endian
-	Value   *Value
-	Options Options
+	Ctx context.Context
+	// Endian is the byte order used by field and read functions without explicit endian,
+	// child decoders inherit it and can change it without affecting the parent
+	Endian Endian
+	// BitOrder is the order of bits in a byte used by integer and float read functions,
+	// with bitio.LSBFirst bit positions count from least significant bit of each byte and
+	// integers are read least significant bit first, endian is then ignored.
+	// Child decoders inherit it, see FieldBitStruct and LSBBitOrder.
+	BitOrder bitio.BitOrder
+	Value    *Value
+	Options  Options
 
 	bitBuf *bitio.Buffer
 
 	readBuf *[]byte
+
+	// encoding of last read and number of reads since reset, used to know how a scalar field was read
+	readEncoding  Encoding
+	readEncodings int
 }
 
 // TODO: new struct decoder?
@@ -170,8 +202,9 @@ func newDecoder(ctx context.Context, format Format, bb *bitio.Buffer, opts Optio
 
 func (d *D) FieldDecoder(name string, bitBuf *bitio.Buffer, v interface{}) *D {
 	return &D{
-		Ctx:    d.Ctx,
-		Endian: d.Endian,
+		Ctx:      d.Ctx,
+		Endian:   d.Endian,
+		BitOrder: d.BitOrder,
 		Value: &Value{
 			Name:       name,
 			V:          v,
@@ -218,8 +251,12 @@ func (d *D) SharedReadBuf(n int) []byte {
 func (d *D) FillGaps(r ranges.Range, namePrefix string) {
 	makeWalkFn := func(fn func(iv *Value)) func(iv *Value, rootV *Value, depth int, rootDepth int) error {
 		return func(iv *Value, rootV *Value, depth int, rootDepth int) error {
-			switch iv.V.(type) {
+			switch ivv := iv.V.(type) {
 			case *Compound:
+				// not yet decoded lazy structs covers their whole range
+				if ivv.lazyFn != nil {
+					fn(iv)
+				}
 			default:
 				fn(iv)
 			}
@@ -253,6 +290,7 @@ func (d *D) FillGaps(r ranges.Range, namePrefix string) {
 			},
 			RootBitBuf: d.bitBuf,
 			Range:      gap,
+			gap:        true,
 		}
 
 		d.AddChild(v)
@@ -280,6 +318,17 @@ func (d *D) bits(nBits int) (uint64, error) {
 	if nBits < 0 || nBits > 64 {
 		return 0, fmt.Errorf("nBits must be 0-64 (%d)", nBits)
 	}
+	if d.BitOrder == bitio.LSBFirst {
+		pos := d.Pos()
+		n, err := d.lsbBitsAt(nBits, pos)
+		if err != nil {
+			return 0, err
+		}
+		if _, err := d.bitBuf.SeekAbs(pos + int64(nBits)); err != nil {
+			return 0, err
+		}
+		return n, nil
+	}
 	// 64 bits max, 9 byte worse case if not byte aligned
 	buf := d.SharedReadBuf(9)
 	_, err := bitio.ReadFull(d.bitBuf, buf, nBits)
@@ -290,6 +339,28 @@ func (d *D) bits(nBits int) (uint64, error) {
 	return bitio.Read64(buf[:], 0, nBits), nil
 }
 
+// lsbBitsAt reads nBits least significant bit first at LSB first bit position
+func (d *D) lsbBitsAt(nBits int, bitOff int64) (uint64, error) {
+	if bitOff+int64(nBits) > d.bitBuf.Len() {
+		return 0, io.ErrUnexpectedEOF
+	}
+	firstByteBit := bitOff / 8 * 8
+	readBits := bitio.BitsByteCount(bitOff+int64(nBits))*8 - firstByteBit
+	if maxBits := d.bitBuf.Len() - firstByteBit; readBits > maxBits {
+		readBits = maxBits
+	}
+	// 64 bits max, 9 byte worse case if not byte aligned
+	buf := d.SharedReadBuf(9)
+	for i := range buf {
+		buf[i] = 0
+	}
+	if _, err := bitio.ReadAtFull(d.bitBuf, buf, int(readBits), firstByteBit); err != nil {
+		return 0, err
+	}
+
+	return bitio.Read64LSB(buf, int(bitOff%8), nBits), nil
+}
+
 // Bits reads nBits bits from buffer
 func (d *D) Bits(nBits int) (uint64, error) {
 	n, err := d.bits(nBits)
@@ -347,18 +418,24 @@ func (d *D) PeekFindByte(findV uint8, maxLen int64) int64 {
 	return peekBits / 8
 }
 
-// PeekBits peek nBits bits from buffer
-// TODO: share code?
+// TryPeekBits reads nBits bits at current position without changing it
 func (d *D) TryPeekBits(nBits int) (uint64, error) {
-	start, err := d.bitBuf.SeekBits(0, io.SeekCurrent)
+	if nBits < 0 || nBits > 64 {
+		return 0, fmt.Errorf("nBits must be 0-64 (%d)", nBits)
+	}
+	pos, err := d.bitBuf.Pos()
 	if err != nil {
 		return 0, err
 	}
-	n, err := d.bits(nBits)
-	if _, err := d.bitBuf.SeekBits(start, io.SeekStart); err != nil {
+	if d.BitOrder == bitio.LSBFirst {
+		return d.lsbBitsAt(nBits, pos)
+	}
+	// 64 bits max, 9 byte worse case if not byte aligned
+	buf := d.SharedReadBuf(9)
+	if _, err := bitio.ReadAtFull(d.bitBuf, buf, nBits, pos); err != nil {
 		return 0, err
 	}
-	return n, err
+	return bitio.Read64(buf, 0, nBits), nil
 }
 
 func (d *D) TryPeekFind(nBits int, seekBits int64, maxLen int64, fn func(v uint64) bool) (int64, uint64, error) {
@@ -456,6 +533,15 @@ func (d *D) End() bool {
 
 func (d *D) NotEnd() bool { return !d.End() }
 
+// FieldUnparsedTail adds bits left as a raw unparsed_tail field if there are any.
+// Use after a loop that stops when the rest can't be a complete element to
+// keep trailing bits instead of failing or dropping them.
+func (d *D) FieldUnparsedTail() {
+	if d.BitsLeft() > 0 {
+		d.FieldRawLen("unparsed_tail", d.BitsLeft())
+	}
+}
+
 func (d *D) BitsLeft() int64 {
 	bBitsLeft, err := d.bitBuf.BitsLeft()
 	if err != nil {
@@ -504,7 +590,19 @@ func (d *D) SeekAbs(pos int64) int64 {
 	return pos
 }
 
+// CheckContext panics with the context error if the decode has been canceled.
+// Called for each added field but long running loops not adding fields should call it.
+func (d *D) CheckContext() {
+	if d.Ctx == nil {
+		return
+	}
+	if err := d.Ctx.Err(); err != nil {
+		panic(err)
+	}
+}
+
 func (d *D) AddChild(v *Value) {
+	d.CheckContext()
 	v.Parent = d.Value
 
 	switch fv := d.Value.V.(type) {
@@ -559,10 +657,112 @@ func (d *D) FieldStruct(name string, fn func(d *D)) *D {
 	return cd
 }
 
+// FieldBitStruct same as FieldStruct but decodes using bit order
+func (d *D) FieldBitStruct(name string, bitOrder bitio.BitOrder, fn func(d *D)) *D {
+	return d.FieldStruct(name, func(d *D) {
+		d.BitOrder = bitOrder
+		fn(d)
+	})
+}
+
+// LSBBitOrder calls fn with bit order set to bitio.LSBFirst and restores bit order after
+func (d *D) LSBBitOrder(fn func(d *D)) {
+	prev := d.BitOrder
+	d.BitOrder = bitio.LSBFirst
+	defer func() { d.BitOrder = prev }()
+	fn(d)
+}
+
 func (d *D) FieldStructValue(name string) *D {
 	return d.FieldStruct(name, func(d *D) {})
 }
 
+// FieldStructLazy adds a struct for firstBit to firstBit+nBits that is decoded by fn on
+// first access (see Value.Materialize) instead of directly. fn can only read inside the range
+// and errors end up in the struct instead of failing the current decode.
+func (d *D) FieldStructLazy(name string, firstBit int64, nBits int64, fn func(d *D)) *Value {
+	bb := d.BitBufRange(0, firstBit+nBits)
+	c := &Compound{}
+	v := &Value{
+		Name:       name,
+		V:          c,
+		Range:      ranges.Range{Start: firstBit, Len: nBits},
+		RootBitBuf: d.bitBuf,
+	}
+	ctx := d.Ctx
+	endian := d.Endian
+	bitOrder := d.BitOrder
+	opts := d.Options
+	c.lazyFn = func() {
+		if ctx != nil && ctx.Err() != nil {
+			c.Err = ctx.Err()
+			return
+		}
+
+		// ranges are relative to the buffer at this point, value might have been moved since
+		delta := v.Range.Start - firstBit
+		rootBitBuf := v.RootBitBuf
+
+		cd := &D{
+			Ctx:      ctx,
+			Endian:   endian,
+			BitOrder: bitOrder,
+			Value:    v,
+			Options:  opts,
+			bitBuf:   bb,
+		}
+		if _, err := bb.SeekAbs(firstBit); err != nil {
+			c.Err = IOError{Err: err, Op: "FieldStructLazy: SeekAbs", Pos: firstBit}
+			return
+		}
+
+		r, rOk := recoverfn.Run(func() { fn(cd) })
+
+		if ctx != nil && ctx.Err() != nil {
+			// partially decoded fields are not usable
+			c.Children = nil
+			c.Err = ctx.Err()
+			return
+		}
+
+		if !rOk {
+			if re, ok := r.RecoverV.(RecoverableErrorer); ok && re.IsRecoverableError() {
+				c.Err, _ = re.(error)
+			} else {
+				r.RePanic()
+			}
+		}
+
+		if opts.FillGaps {
+			cd.FillGaps(ranges.Range{Start: firstBit, Len: nBits}, "unknown")
+		}
+
+		_ = v.WalkRootPreOrder(func(cv *Value, rootV *Value, depth int, rootDepth int) error {
+			if cv == v {
+				return nil
+			}
+			cv.Range.Start += delta
+			cv.RootBitBuf = rootBitBuf
+			return nil
+		})
+	}
+	d.AddChild(v)
+
+	return v
+}
+
+// FieldStructArrayLazy adds an array of count structs that are decoded on first access, see FieldStructLazy.
+// rangeFn returns first bit and length in bits for element i and fn decodes it.
+func (d *D) FieldStructArrayLazy(name string, structName string, count int, rangeFn func(i int) (int64, int64), fn func(d *D, i int)) *D {
+	return d.FieldArray(name, func(d *D) {
+		for i := 0; i < count; i++ {
+			i := i
+			firstBit, nBits := rangeFn(i)
+			d.FieldStructLazy(structName, firstBit, nBits, func(d *D) { fn(d, i) })
+		}
+	})
+}
+
 func (d *D) FieldStructArrayLoop(name string, structName string, condFn func() bool, fn func(d *D)) *D {
 	return d.FieldArray(name, func(d *D) {
 		for condFn() {
@@ -579,6 +779,59 @@ func (d *D) FieldArrayLoop(name string, condFn func() bool, fn func(d *D)) *D {
 	})
 }
 
+// FieldArrayCount adds an array and calls fn n times to decode its elements.
+// Fails if there are no bits left before all n elements have been decoded.
+func (d *D) FieldArrayCount(name string, n uint64, fn func(d *D)) *D {
+	return d.FieldArray(name, func(d *D) {
+		for i := uint64(0); i < n; i++ {
+			if !d.NotEnd() {
+				d.Errorf("array %s ended after %d of %d elements", name, i, n)
+				return
+			}
+			fn(d)
+		}
+	})
+}
+
+// FieldStructArray adds an array of count structs decoded by fn that all have the same size.
+// The size is known after the first element so the number of elements that fit can be
+// calculated before decoding the rest. Fails if an element has a different size or, after
+// decoding the elements that fit, if not enough bits are left for all of them.
+func (d *D) FieldStructArray(name string, elemName string, count uint64, fn func(d *D)) *D {
+	return d.FieldArray(name, func(d *D) {
+		if count == 0 {
+			return
+		}
+		start := d.Pos()
+		d.FieldStruct(elemName, fn)
+		elemBits := d.Pos() - start
+		if elemBits == 0 {
+			d.Fatalf("array %s element has zero size", name)
+		}
+		// decode as many elements as fit so that truncated input still has partial output
+		left := d.BitsLeft()
+		fit := count
+		if n := uint64(left / elemBits); n < count-1 {
+			fit = n + 1
+		}
+
+		c := d.Value.V.(*Compound)
+		children := make([]*Value, len(c.Children), fit)
+		copy(children, c.Children)
+		c.Children = children
+
+		for i := uint64(1); i < fit; i++ {
+			d.FieldStruct(elemName, fn)
+			if d.Pos()-start != int64(i+1)*elemBits {
+				d.Fatalf("array %s element %d is not %d bits", name, i, elemBits)
+			}
+		}
+		if fit < count {
+			d.Fatalf("array %s of %d elements of %d bits needs %d bits, %d left", name, count, elemBits, elemBits*int64(count-1), left)
+		}
+	})
+}
+
 func (d *D) FieldRangeFn(name string, firstBit int64, nBits int64, fn func() *Value) *Value {
 	v := fn()
 	v.Name = name
@@ -638,6 +891,54 @@ func (d *D) FieldValueRaw(name string, a []byte, sms ...scalar.Mapper) {
 	}, sms...)
 }
 
+// FieldUEnum adds a field, reads nBits unsigned integer in current endian and maps it to a symbolic name
+// Values not found in m keep the number as value and are flagged as unknown.
+func (d *D) FieldUEnum(name string, nBits int, m scalar.UToSymStr, sms ...scalar.Mapper) uint64 {
+	return d.FieldU(name, nBits, append([]scalar.Mapper{scalar.UEnum(m)}, sms...)...)
+}
+
+// FieldRawMagic adds a len(expected) bytes raw field and fails decoding if it does not match expected
+func (d *D) FieldRawMagic(name string, expected []byte) *bitio.Buffer {
+	return d.FieldRawLen(name, int64(len(expected))*8, d.assertMagicBytes(expected))
+}
+
+// FieldBytesLen adds a nBytes bytes raw field and returns the bytes, current position does not have to be byte aligned
+func (d *D) FieldBytesLen(name string, nBytes int, sms ...scalar.Mapper) []byte {
+	bs, err := d.FieldRawLen(name, int64(nBytes)*8, sms...).Bytes()
+	if err != nil {
+		d.IOPanic(err, "FieldBytesLen: Bytes")
+	}
+	return bs
+}
+
+var paddingZero = scalar.Fn(func(s scalar.S) (scalar.S, error) {
+	bb, ok := s.Actual.(*bitio.Buffer)
+	if !ok {
+		return s, nil
+	}
+	bs, err := bb.Bytes()
+	if err != nil {
+		return s, err
+	}
+	for _, b := range bs {
+		if b != 0 {
+			return s, Annotation{Severity: SeverityWarning, Message: "non-zero padding"}
+		}
+	}
+	return s, nil
+})
+
+// FieldPadding adds a raw field from current position to next alignBits aligned position, zero length if already aligned.
+// Padding with non-zero bits gets a warning annotation.
+func (d *D) FieldPadding(name string, alignBits int) *bitio.Buffer {
+	return d.FieldRawLen(name, int64(d.AlignBits(alignBits)), paddingZero)
+}
+
+// FieldUMagic adds a nBits unsigned integer field in current endian and fails decoding if it is not expected
+func (d *D) FieldUMagic(name string, nBits int, expected uint64, sms ...scalar.Mapper) uint64 {
+	return d.FieldU(name, nBits, append([]scalar.Mapper{d.assertMagicU(nBits, expected)}, sms...)...)
+}
+
 func (d *D) LenFn(nBits int64, fn func(d *D)) {
 	d.RangeFn(d.Pos(), nBits, fn)
 	d.SeekRel(nBits)
@@ -683,12 +984,15 @@ func (d *D) RangeFn(firstBit int64, nBits int64, fn func(d *D)) {
 
 func (d *D) Format(group Group, inArg interface{}) interface{} {
 	dv, v, err := decode(d.Ctx, d.bitBuf, group, Options{
-		Force:       d.Options.Force,
-		FillGaps:    false,
-		IsRoot:      false,
-		Range:       ranges.Range{Start: d.Pos(), Len: d.BitsLeft()},
-		FormatInArg: inArg,
-		ReadBuf:     d.readBuf,
+		Force:         d.Options.Force,
+		FillGaps:      false,
+		IsRoot:        false,
+		Range:         ranges.Range{Start: d.Pos(), Len: d.BitsLeft()},
+		FormatInArg:   inArg,
+		ReadBuf:       d.readBuf,
+		MaxDepth:      d.Options.MaxDepth,
+		FormatOptions: d.Options.FormatOptions,
+		depth:         d.Options.depth + 1,
 	})
 	if dv == nil || dv.Errors() != nil {
 		d.IOPanic(err, "Format: decode")
@@ -712,13 +1016,16 @@ func (d *D) Format(group Group, inArg interface{}) interface{} {
 
 func (d *D) TryFieldFormat(name string, group Group, inArg interface{}) (*Value, interface{}, error) {
 	dv, v, err := decode(d.Ctx, d.bitBuf, group, Options{
-		Name:        name,
-		Force:       d.Options.Force,
-		FillGaps:    false,
-		IsRoot:      false,
-		Range:       ranges.Range{Start: d.Pos(), Len: d.BitsLeft()},
-		FormatInArg: inArg,
-		ReadBuf:     d.readBuf,
+		Name:          name,
+		Force:         d.Options.Force,
+		FillGaps:      false,
+		IsRoot:        false,
+		Range:         ranges.Range{Start: d.Pos(), Len: d.BitsLeft()},
+		FormatInArg:   inArg,
+		ReadBuf:       d.readBuf,
+		MaxDepth:      d.Options.MaxDepth,
+		FormatOptions: d.Options.FormatOptions,
+		depth:         d.Options.depth + 1,
 	})
 	if dv == nil || dv.Errors() != nil {
 		return nil, nil, err
@@ -740,19 +1047,52 @@ func (d *D) FieldFormat(name string, group Group, inArg interface{}) (*Value, in
 	return dv, v
 }
 
+// consumedStop returns stop of the last value decoded by a format, gap fields added by FillGaps are
+// not counted. Returns start if nothing was decoded.
+func consumedStop(dv *Value, start int64) int64 {
+	stop := start
+	_ = dv.WalkRootPreOrder(func(v *Value, rootV *Value, depth int, rootDepth int) error {
+		if v.gap {
+			return nil
+		}
+		if c, ok := v.V.(*Compound); ok && c.lazyFn == nil {
+			// not yet decoded lazy structs covers their whole range
+			return nil
+		}
+		if s := v.Range.Stop(); s > stop {
+			stop = s
+		}
+		return nil
+	})
+	return stop
+}
+
+// TryFieldFormatLen decodes nBits bits at current position using group and adds it as a field.
+// The format only sees nBits bits so reading outside is an error, bits not consumed are added
+// as unknown gap fields and a warning annotation. Position is moved nBits on success.
 func (d *D) TryFieldFormatLen(name string, nBits int64, group Group, inArg interface{}) (*Value, interface{}, error) {
+	start := d.Pos()
 	dv, v, err := decode(d.Ctx, d.bitBuf, group, Options{
-		Name:        name,
-		Force:       d.Options.Force,
-		FillGaps:    true,
-		IsRoot:      false,
-		Range:       ranges.Range{Start: d.Pos(), Len: nBits},
-		FormatInArg: inArg,
-		ReadBuf:     d.readBuf,
+		Name:          name,
+		Force:         d.Options.Force,
+		FillGaps:      true,
+		IsRoot:        false,
+		Range:         ranges.Range{Start: d.Pos(), Len: nBits},
+		FormatInArg:   inArg,
+		ReadBuf:       d.readBuf,
+		MaxDepth:      d.Options.MaxDepth,
+		FormatOptions: d.Options.FormatOptions,
+		depth:         d.Options.depth + 1,
 	})
 	if dv == nil || dv.Errors() != nil {
 		return nil, nil, err
 	}
+	if n := start + nBits - consumedStop(dv, start); n > 0 {
+		dv.Annotations = append(dv.Annotations, Annotation{
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("%d bits not consumed", n),
+		})
+	}
 
 	d.AddChild(dv)
 	if _, err := d.bitBuf.SeekRel(nBits); err != nil {
@@ -762,6 +1102,7 @@ func (d *D) TryFieldFormatLen(name string, nBits int64, group Group, inArg inter
 	return dv, v, err
 }
 
+// FieldFormatLen same as TryFieldFormatLen but fails decode on error
 func (d *D) FieldFormatLen(name string, nBits int64, group Group, inArg interface{}) (*Value, interface{}) {
 	dv, v, err := d.TryFieldFormatLen(name, nBits, group, inArg)
 	if dv == nil || dv.Errors() != nil {
@@ -773,13 +1114,16 @@ func (d *D) FieldFormatLen(name string, nBits int64, group Group, inArg interfac
 // TODO: return decooder?
 func (d *D) TryFieldFormatRange(name string, firstBit int64, nBits int64, group Group, inArg interface{}) (*Value, interface{}, error) {
 	dv, v, err := decode(d.Ctx, d.bitBuf, group, Options{
-		Name:        name,
-		Force:       d.Options.Force,
-		FillGaps:    true,
-		IsRoot:      false,
-		Range:       ranges.Range{Start: firstBit, Len: nBits},
-		FormatInArg: inArg,
-		ReadBuf:     d.readBuf,
+		Name:          name,
+		Force:         d.Options.Force,
+		FillGaps:      true,
+		IsRoot:        false,
+		Range:         ranges.Range{Start: firstBit, Len: nBits},
+		FormatInArg:   inArg,
+		ReadBuf:       d.readBuf,
+		MaxDepth:      d.Options.MaxDepth,
+		FormatOptions: d.Options.FormatOptions,
+		depth:         d.Options.depth + 1,
 	})
 	if dv == nil || dv.Errors() != nil {
 		return nil, nil, err
@@ -801,12 +1145,15 @@ func (d *D) FieldFormatRange(name string, firstBit int64, nBits int64, group Gro
 
 func (d *D) TryFieldFormatBitBuf(name string, bb *bitio.Buffer, group Group, inArg interface{}) (*Value, interface{}, error) {
 	dv, v, err := decode(d.Ctx, bb, group, Options{
-		Name:        name,
-		Force:       d.Options.Force,
-		FillGaps:    true,
-		IsRoot:      true,
-		FormatInArg: inArg,
-		ReadBuf:     d.readBuf,
+		Name:          name,
+		Force:         d.Options.Force,
+		FillGaps:      true,
+		IsRoot:        true,
+		FormatInArg:   inArg,
+		ReadBuf:       d.readBuf,
+		MaxDepth:      d.Options.MaxDepth,
+		FormatOptions: d.Options.FormatOptions,
+		depth:         d.Options.depth + 1,
 	})
 	if dv == nil || dv.Errors() != nil {
 		return nil, nil, err
@@ -852,6 +1199,17 @@ func (d *D) FieldStructRootBitBufFn(name string, bb *bitio.Buffer, fn func(d *D)
 	return cd.Value
 }
 
+func (d *D) FieldArrayRootBitBufFn(name string, bb *bitio.Buffer, fn func(d *D)) *Value {
+	cd := d.FieldDecoder(name, bb, &Compound{IsArray: true})
+	cd.Value.IsRoot = true
+	d.AddChild(cd.Value)
+	fn(cd)
+
+	cd.Value.postProcess()
+
+	return cd.Value
+}
+
 // TODO: range?
 func (d *D) FieldFormatReaderLen(name string, nBits int64, fn func(r io.Reader) (io.ReadCloser, error), group Group) (*Value, interface{}) {
 	bb, err := d.bitBuf.BitBufLen(nBits)
@@ -931,17 +1289,18 @@ func (d *D) FieldValue(name string, fn func() *Value) *Value {
 // looks a bit weird to force at least one ScalarFn arg
 func (d *D) TryFieldScalarFn(name string, sfn scalar.Fn, sms ...scalar.Mapper) (*scalar.S, error) {
 	v, err := d.TryFieldValue(name, func() (*Value, error) {
+		d.readEncoding, d.readEncodings = Encoding{}, 0
 		s, err := sfn(scalar.S{})
 		if err != nil {
 			return &Value{V: &s}, err
 		}
-		for _, sm := range sms {
-			s, err = sm.MapScalar(s)
-			if err != nil {
-				return &Value{V: &s}, err
-			}
+		var e Encoding
+		// only know how to encode if value was read using one read
+		if d.readEncodings == 1 {
+			e = d.readEncoding
 		}
-		return &Value{V: &s}, nil
+		s, as, err := mapScalar(s, sms)
+		return &Value{V: &s, Annotations: as, Encoding: e}, err
 	})
 	if err != nil {
 		return &scalar.S{}, err
@@ -957,19 +1316,41 @@ func (d *D) FieldScalarFn(name string, sfn scalar.Fn, sms ...scalar.Mapper) *sca
 	return v
 }
 
-func (v *Value) TryScalarFn(sms ...scalar.Mapper) error {
-	var err error
-	sr, ok := v.V.(*scalar.S)
-	if !ok {
-		panic("not a scalar value")
-	}
-	s := *sr
+// mapScalar applies mappers in order, annotation errors are collected and do not stop mapping
+func mapScalar(s scalar.S, sms []scalar.Mapper) (scalar.S, []Annotation, error) {
+	var as []Annotation
 	for _, sm := range sms {
+		var err error
 		s, err = sm.MapScalar(s)
+		var a Annotation
+		if errors.As(err, &a) {
+			as = append(as, a)
+			continue
+		}
 		if err != nil {
-			break
+			return s, as, err
 		}
 	}
+	return s, as, nil
+}
+
+// SetActual changes the actual value of a scalar and marks it as modified, see Encode
+func (v *Value) SetActual(a interface{}) {
+	s, ok := v.V.(*scalar.S)
+	if !ok {
+		panic("not a scalar value")
+	}
+	s.Actual = a
+	v.modified = true
+}
+
+func (v *Value) TryScalarFn(sms ...scalar.Mapper) error {
+	sr, ok := v.V.(*scalar.S)
+	if !ok {
+		panic("not a scalar value")
+	}
+	s, as, err := mapScalar(*sr, sms)
 	v.V = &s
+	v.Annotations = append(v.Annotations, as...)
 	return err
 }
@@ -310,7 +310,7 @@ func (d *D) FieldScalarUFn(name string, fn func(d *D) uint64, sms ...scalar.Mapp
 
 // Require/Assert/Validate Bool
 
-func requireBool(name string, s scalar.S, desc bool, fail bool, vs ...bool) (scalar.S, error) {
+func requireBool(name string, s scalar.S, desc bool, fail bool, severity Severity, vs ...bool) (scalar.S, error) {
 	a := s.ActualBool()
 	for _, b := range vs {
 		if a == b {
@@ -326,27 +326,35 @@ func requireBool(name string, s scalar.S, desc bool, fail bool, vs ...bool) (sca
 	if fail {
 		return s, fmt.Errorf("failed to %s Bool", name)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s Bool, %v not one of %v", name, a, vs)}
 }
 
 // RequireBool that actual value is one of given bool values
 func (d *D) RequireBool(vs ...bool) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireBool("require", s, false, true, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireBool("require", s, false, true, SeverityError, vs...)
+	})
 }
 
 // AssertBool validate and asserts that actual value is one of given bool values
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertBool(vs ...bool) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireBool("assert", s, true, !d.Options.Force, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireBool("assert", s, true, !d.Options.Force, SeverityError, vs...)
+	})
 }
 
 // ValidateBool validates that actual value is one of given bool values
+// A failed validation is added as a warning annotation
 func (d *D) ValidateBool(vs ...bool) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireBool("validate", s, true, false, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireBool("validate", s, true, false, SeverityWarning, vs...)
+	})
 }
 
 // Require/Assert/Validate F
 
-func requireF(name string, s scalar.S, desc bool, fail bool, vs ...float64) (scalar.S, error) {
+func requireF(name string, s scalar.S, desc bool, fail bool, severity Severity, vs ...float64) (scalar.S, error) {
 	a := s.ActualF()
 	for _, b := range vs {
 		if a == b {
@@ -362,27 +370,33 @@ func requireF(name string, s scalar.S, desc bool, fail bool, vs ...float64) (sca
 	if fail {
 		return s, fmt.Errorf("failed to %s F", name)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s F, %v not one of %v", name, a, vs)}
 }
 
 // RequireF that actual value is one of given float64 values
 func (d *D) RequireF(vs ...float64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireF("require", s, false, true, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireF("require", s, false, true, SeverityError, vs...) })
 }
 
 // AssertF validate and asserts that actual value is one of given float64 values
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertF(vs ...float64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireF("assert", s, true, !d.Options.Force, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireF("assert", s, true, !d.Options.Force, SeverityError, vs...)
+	})
 }
 
 // ValidateF validates that actual value is one of given float64 values
+// A failed validation is added as a warning annotation
 func (d *D) ValidateF(vs ...float64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireF("validate", s, true, false, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireF("validate", s, true, false, SeverityWarning, vs...)
+	})
 }
 
 // Require/Assert/ValidatRange F
 
-func requireRangeF(name string, s scalar.S, desc bool, fail bool, start, end float64) (scalar.S, error) {
+func requireRangeF(name string, s scalar.S, desc bool, fail bool, severity Severity, start, end float64) (scalar.S, error) {
 	a := s.ActualF()
 	if a >= start && a <= end {
 		if desc {
@@ -396,29 +410,35 @@ func requireRangeF(name string, s scalar.S, desc bool, fail bool, start, end flo
 	if fail {
 		return s, fmt.Errorf("failed to %s F range %v-%v", name, start, end)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s F range %v-%v, %v outside range", name, start, end, a)}
 }
 
 // RequireFRange require that actual value is in range
 func (d *D) RequireFRange(start, end float64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeF("require", s, false, true, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeF("require", s, false, true, SeverityError, start, end)
+	})
 }
 
 // AssertFRange asserts that actual value is in range
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertFRange(start, end float64) scalar.Mapper {
 	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
-		return requireRangeF("assert", s, true, !d.Options.Force, start, end)
+		return requireRangeF("assert", s, true, !d.Options.Force, SeverityError, start, end)
 	})
 }
 
 // ValidateFRange validates that actual value is in range
+// A failed validation is added as a warning annotation
 func (d *D) ValidateFRange(start, end float64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeF("validate", s, true, false, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeF("validate", s, true, false, SeverityWarning, start, end)
+	})
 }
 
 // Require/Assert/Validate S
 
-func requireS(name string, s scalar.S, desc bool, fail bool, vs ...int64) (scalar.S, error) {
+func requireS(name string, s scalar.S, desc bool, fail bool, severity Severity, vs ...int64) (scalar.S, error) {
 	a := s.ActualS()
 	for _, b := range vs {
 		if a == b {
@@ -434,27 +454,33 @@ func requireS(name string, s scalar.S, desc bool, fail bool, vs ...int64) (scala
 	if fail {
 		return s, fmt.Errorf("failed to %s S", name)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s S, %v not one of %v", name, a, vs)}
 }
 
 // RequireS that actual value is one of given int64 values
 func (d *D) RequireS(vs ...int64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireS("require", s, false, true, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireS("require", s, false, true, SeverityError, vs...) })
 }
 
 // AssertS validate and asserts that actual value is one of given int64 values
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertS(vs ...int64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireS("assert", s, true, !d.Options.Force, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireS("assert", s, true, !d.Options.Force, SeverityError, vs...)
+	})
 }
 
 // ValidateS validates that actual value is one of given int64 values
+// A failed validation is added as a warning annotation
 func (d *D) ValidateS(vs ...int64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireS("validate", s, true, false, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireS("validate", s, true, false, SeverityWarning, vs...)
+	})
 }
 
 // Require/Assert/ValidatRange S
 
-func requireRangeS(name string, s scalar.S, desc bool, fail bool, start, end int64) (scalar.S, error) {
+func requireRangeS(name string, s scalar.S, desc bool, fail bool, severity Severity, start, end int64) (scalar.S, error) {
 	a := s.ActualS()
 	if a >= start && a <= end {
 		if desc {
@@ -468,29 +494,35 @@ func requireRangeS(name string, s scalar.S, desc bool, fail bool, start, end int
 	if fail {
 		return s, fmt.Errorf("failed to %s S range %v-%v", name, start, end)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s S range %v-%v, %v outside range", name, start, end, a)}
 }
 
 // RequireSRange require that actual value is in range
 func (d *D) RequireSRange(start, end int64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeS("require", s, false, true, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeS("require", s, false, true, SeverityError, start, end)
+	})
 }
 
 // AssertSRange asserts that actual value is in range
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertSRange(start, end int64) scalar.Mapper {
 	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
-		return requireRangeS("assert", s, true, !d.Options.Force, start, end)
+		return requireRangeS("assert", s, true, !d.Options.Force, SeverityError, start, end)
 	})
 }
 
 // ValidateSRange validates that actual value is in range
+// A failed validation is added as a warning annotation
 func (d *D) ValidateSRange(start, end int64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeS("validate", s, true, false, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeS("validate", s, true, false, SeverityWarning, start, end)
+	})
 }
 
 // Require/Assert/Validate Str
 
-func requireStr(name string, s scalar.S, desc bool, fail bool, vs ...string) (scalar.S, error) {
+func requireStr(name string, s scalar.S, desc bool, fail bool, severity Severity, vs ...string) (scalar.S, error) {
 	a := s.ActualStr()
 	for _, b := range vs {
 		if a == b {
@@ -506,27 +538,33 @@ func requireStr(name string, s scalar.S, desc bool, fail bool, vs ...string) (sc
 	if fail {
 		return s, fmt.Errorf("failed to %s Str", name)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s Str, %v not one of %v", name, a, vs)}
 }
 
 // RequireStr that actual value is one of given string values
 func (d *D) RequireStr(vs ...string) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireStr("require", s, false, true, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireStr("require", s, false, true, SeverityError, vs...) })
 }
 
 // AssertStr validate and asserts that actual value is one of given string values
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertStr(vs ...string) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireStr("assert", s, true, !d.Options.Force, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireStr("assert", s, true, !d.Options.Force, SeverityError, vs...)
+	})
 }
 
 // ValidateStr validates that actual value is one of given string values
+// A failed validation is added as a warning annotation
 func (d *D) ValidateStr(vs ...string) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireStr("validate", s, true, false, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireStr("validate", s, true, false, SeverityWarning, vs...)
+	})
 }
 
 // Require/Assert/ValidatRange Str
 
-func requireRangeStr(name string, s scalar.S, desc bool, fail bool, start, end string) (scalar.S, error) {
+func requireRangeStr(name string, s scalar.S, desc bool, fail bool, severity Severity, start, end string) (scalar.S, error) {
 	a := s.ActualStr()
 	if a >= start && a <= end {
 		if desc {
@@ -540,29 +578,35 @@ func requireRangeStr(name string, s scalar.S, desc bool, fail bool, start, end s
 	if fail {
 		return s, fmt.Errorf("failed to %s Str range %v-%v", name, start, end)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s Str range %v-%v, %v outside range", name, start, end, a)}
 }
 
 // RequireStrRange require that actual value is in range
 func (d *D) RequireStrRange(start, end string) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeStr("require", s, false, true, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeStr("require", s, false, true, SeverityError, start, end)
+	})
 }
 
 // AssertStrRange asserts that actual value is in range
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertStrRange(start, end string) scalar.Mapper {
 	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
-		return requireRangeStr("assert", s, true, !d.Options.Force, start, end)
+		return requireRangeStr("assert", s, true, !d.Options.Force, SeverityError, start, end)
 	})
 }
 
 // ValidateStrRange validates that actual value is in range
+// A failed validation is added as a warning annotation
 func (d *D) ValidateStrRange(start, end string) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeStr("validate", s, true, false, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeStr("validate", s, true, false, SeverityWarning, start, end)
+	})
 }
 
 // Require/Assert/Validate U
 
-func requireU(name string, s scalar.S, desc bool, fail bool, vs ...uint64) (scalar.S, error) {
+func requireU(name string, s scalar.S, desc bool, fail bool, severity Severity, vs ...uint64) (scalar.S, error) {
 	a := s.ActualU()
 	for _, b := range vs {
 		if a == b {
@@ -578,27 +622,33 @@ func requireU(name string, s scalar.S, desc bool, fail bool, vs ...uint64) (scal
 	if fail {
 		return s, fmt.Errorf("failed to %s U", name)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s U, %v not one of %v", name, a, vs)}
 }
 
 // RequireU that actual value is one of given uint64 values
 func (d *D) RequireU(vs ...uint64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireU("require", s, false, true, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireU("require", s, false, true, SeverityError, vs...) })
 }
 
 // AssertU validate and asserts that actual value is one of given uint64 values
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertU(vs ...uint64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireU("assert", s, true, !d.Options.Force, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireU("assert", s, true, !d.Options.Force, SeverityError, vs...)
+	})
 }
 
 // ValidateU validates that actual value is one of given uint64 values
+// A failed validation is added as a warning annotation
 func (d *D) ValidateU(vs ...uint64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireU("validate", s, true, false, vs...) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireU("validate", s, true, false, SeverityWarning, vs...)
+	})
 }
 
 // Require/Assert/ValidatRange U
 
-func requireRangeU(name string, s scalar.S, desc bool, fail bool, start, end uint64) (scalar.S, error) {
+func requireRangeU(name string, s scalar.S, desc bool, fail bool, severity Severity, start, end uint64) (scalar.S, error) {
 	a := s.ActualU()
 	if a >= start && a <= end {
 		if desc {
@@ -612,24 +662,30 @@ func requireRangeU(name string, s scalar.S, desc bool, fail bool, start, end uin
 	if fail {
 		return s, fmt.Errorf("failed to %s U range %v-%v", name, start, end)
 	}
-	return s, nil
+	return s, Annotation{Severity: severity, Message: fmt.Sprintf("failed to %s U range %v-%v, %v outside range", name, start, end, a)}
 }
 
 // RequireURange require that actual value is in range
 func (d *D) RequireURange(start, end uint64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeU("require", s, false, true, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeU("require", s, false, true, SeverityError, start, end)
+	})
 }
 
 // AssertURange asserts that actual value is in range
+// If forced a failed assert is added as an error annotation instead
 func (d *D) AssertURange(start, end uint64) scalar.Mapper {
 	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
-		return requireRangeU("assert", s, true, !d.Options.Force, start, end)
+		return requireRangeU("assert", s, true, !d.Options.Force, SeverityError, start, end)
 	})
 }
 
 // ValidateURange validates that actual value is in range
+// A failed validation is added as a warning annotation
 func (d *D) ValidateURange(start, end uint64) scalar.Mapper {
-	return scalar.Fn(func(s scalar.S) (scalar.S, error) { return requireRangeU("validate", s, true, false, start, end) })
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		return requireRangeU("validate", s, true, false, SeverityWarning, start, end)
+	})
 }
 
 // Reader RawLen
@@ -671,6 +727,9 @@ func (d *D) FieldScalarRawLen(name string, nBits int64, sms ...scalar.Mapper) *s
 // TryFieldRawLen tries to add a field and read nBits raw bits
 func (d *D) TryFieldRawLen(name string, nBits int64, sms ...scalar.Mapper) (*bitio.Buffer, error) {
 	s, err := d.TryFieldScalarRawLen(name, nBits, sms...)
+	if err != nil {
+		return nil, err
+	}
 	return s.ActualBitBuf(), err
 }
 
@@ -718,6 +777,9 @@ func (d *D) FieldScalarBool(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldBool tries to add a field and read 1 bit boolean
 func (d *D) TryFieldBool(name string, sms ...scalar.Mapper) (bool, error) {
 	s, err := d.TryFieldScalarBool(name, sms...)
+	if err != nil {
+		return false, err
+	}
 	return s.ActualBool(), err
 }
 
@@ -765,6 +827,9 @@ func (d *D) FieldScalarU(name string, nBits int, sms ...scalar.Mapper) *scalar.S
 // TryFieldU tries to add a field and read nBits bits unsigned integer in current endian
 func (d *D) TryFieldU(name string, nBits int, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU(name, nBits, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -812,6 +877,9 @@ func (d *D) FieldScalarUE(name string, nBits int, endian Endian, sms ...scalar.M
 // TryFieldUE tries to add a field and read nBits unsigned integer in specified endian
 func (d *D) TryFieldUE(name string, nBits int, endian Endian, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarUE(name, nBits, endian, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -859,6 +927,9 @@ func (d *D) FieldScalarU1(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU1 tries to add a field and read 1 bit unsigned integer in current endian
 func (d *D) TryFieldU1(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU1(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -906,6 +977,9 @@ func (d *D) FieldScalarU2(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU2 tries to add a field and read 2 bit unsigned integer in current endian
 func (d *D) TryFieldU2(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU2(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -953,6 +1027,9 @@ func (d *D) FieldScalarU3(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU3 tries to add a field and read 3 bit unsigned integer in current endian
 func (d *D) TryFieldU3(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU3(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1000,6 +1077,9 @@ func (d *D) FieldScalarU4(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU4 tries to add a field and read 4 bit unsigned integer in current endian
 func (d *D) TryFieldU4(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU4(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1047,6 +1127,9 @@ func (d *D) FieldScalarU5(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU5 tries to add a field and read 5 bit unsigned integer in current endian
 func (d *D) TryFieldU5(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU5(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1094,6 +1177,9 @@ func (d *D) FieldScalarU6(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU6 tries to add a field and read 6 bit unsigned integer in current endian
 func (d *D) TryFieldU6(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU6(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1141,6 +1227,9 @@ func (d *D) FieldScalarU7(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU7 tries to add a field and read 7 bit unsigned integer in current endian
 func (d *D) TryFieldU7(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU7(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1188,6 +1277,9 @@ func (d *D) FieldScalarU8(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU8 tries to add a field and read 8 bit unsigned integer in current endian
 func (d *D) TryFieldU8(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU8(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1235,6 +1327,9 @@ func (d *D) FieldScalarU9(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU9 tries to add a field and read 9 bit unsigned integer in current endian
 func (d *D) TryFieldU9(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU9(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1282,6 +1377,9 @@ func (d *D) FieldScalarU10(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU10 tries to add a field and read 10 bit unsigned integer in current endian
 func (d *D) TryFieldU10(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU10(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1329,6 +1427,9 @@ func (d *D) FieldScalarU11(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU11 tries to add a field and read 11 bit unsigned integer in current endian
 func (d *D) TryFieldU11(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU11(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1376,6 +1477,9 @@ func (d *D) FieldScalarU12(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU12 tries to add a field and read 12 bit unsigned integer in current endian
 func (d *D) TryFieldU12(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU12(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1423,6 +1527,9 @@ func (d *D) FieldScalarU13(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU13 tries to add a field and read 13 bit unsigned integer in current endian
 func (d *D) TryFieldU13(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU13(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1470,6 +1577,9 @@ func (d *D) FieldScalarU14(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU14 tries to add a field and read 14 bit unsigned integer in current endian
 func (d *D) TryFieldU14(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU14(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1517,6 +1627,9 @@ func (d *D) FieldScalarU15(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU15 tries to add a field and read 15 bit unsigned integer in current endian
 func (d *D) TryFieldU15(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU15(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1564,6 +1677,9 @@ func (d *D) FieldScalarU16(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU16 tries to add a field and read 16 bit unsigned integer in current endian
 func (d *D) TryFieldU16(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU16(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1611,6 +1727,9 @@ func (d *D) FieldScalarU17(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU17 tries to add a field and read 17 bit unsigned integer in current endian
 func (d *D) TryFieldU17(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU17(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1658,6 +1777,9 @@ func (d *D) FieldScalarU18(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU18 tries to add a field and read 18 bit unsigned integer in current endian
 func (d *D) TryFieldU18(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU18(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1705,6 +1827,9 @@ func (d *D) FieldScalarU19(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU19 tries to add a field and read 19 bit unsigned integer in current endian
 func (d *D) TryFieldU19(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU19(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1752,6 +1877,9 @@ func (d *D) FieldScalarU20(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU20 tries to add a field and read 20 bit unsigned integer in current endian
 func (d *D) TryFieldU20(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU20(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1799,6 +1927,9 @@ func (d *D) FieldScalarU21(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU21 tries to add a field and read 21 bit unsigned integer in current endian
 func (d *D) TryFieldU21(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU21(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1846,6 +1977,9 @@ func (d *D) FieldScalarU22(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU22 tries to add a field and read 22 bit unsigned integer in current endian
 func (d *D) TryFieldU22(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU22(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1893,6 +2027,9 @@ func (d *D) FieldScalarU23(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU23 tries to add a field and read 23 bit unsigned integer in current endian
 func (d *D) TryFieldU23(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU23(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1940,6 +2077,9 @@ func (d *D) FieldScalarU24(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU24 tries to add a field and read 24 bit unsigned integer in current endian
 func (d *D) TryFieldU24(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU24(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -1987,6 +2127,9 @@ func (d *D) FieldScalarU25(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU25 tries to add a field and read 25 bit unsigned integer in current endian
 func (d *D) TryFieldU25(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU25(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2034,6 +2177,9 @@ func (d *D) FieldScalarU26(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU26 tries to add a field and read 26 bit unsigned integer in current endian
 func (d *D) TryFieldU26(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU26(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2081,6 +2227,9 @@ func (d *D) FieldScalarU27(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU27 tries to add a field and read 27 bit unsigned integer in current endian
 func (d *D) TryFieldU27(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU27(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2128,6 +2277,9 @@ func (d *D) FieldScalarU28(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU28 tries to add a field and read 28 bit unsigned integer in current endian
 func (d *D) TryFieldU28(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU28(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2175,6 +2327,9 @@ func (d *D) FieldScalarU29(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU29 tries to add a field and read 29 bit unsigned integer in current endian
 func (d *D) TryFieldU29(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU29(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2222,6 +2377,9 @@ func (d *D) FieldScalarU30(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU30 tries to add a field and read 30 bit unsigned integer in current endian
 func (d *D) TryFieldU30(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU30(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2269,6 +2427,9 @@ func (d *D) FieldScalarU31(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU31 tries to add a field and read 31 bit unsigned integer in current endian
 func (d *D) TryFieldU31(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU31(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2316,6 +2477,9 @@ func (d *D) FieldScalarU32(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU32 tries to add a field and read 32 bit unsigned integer in current endian
 func (d *D) TryFieldU32(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU32(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2363,6 +2527,9 @@ func (d *D) FieldScalarU33(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU33 tries to add a field and read 33 bit unsigned integer in current endian
 func (d *D) TryFieldU33(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU33(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2410,6 +2577,9 @@ func (d *D) FieldScalarU34(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU34 tries to add a field and read 34 bit unsigned integer in current endian
 func (d *D) TryFieldU34(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU34(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2457,6 +2627,9 @@ func (d *D) FieldScalarU35(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU35 tries to add a field and read 35 bit unsigned integer in current endian
 func (d *D) TryFieldU35(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU35(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2504,6 +2677,9 @@ func (d *D) FieldScalarU36(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU36 tries to add a field and read 36 bit unsigned integer in current endian
 func (d *D) TryFieldU36(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU36(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2551,6 +2727,9 @@ func (d *D) FieldScalarU37(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU37 tries to add a field and read 37 bit unsigned integer in current endian
 func (d *D) TryFieldU37(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU37(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2598,6 +2777,9 @@ func (d *D) FieldScalarU38(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU38 tries to add a field and read 38 bit unsigned integer in current endian
 func (d *D) TryFieldU38(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU38(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2645,6 +2827,9 @@ func (d *D) FieldScalarU39(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU39 tries to add a field and read 39 bit unsigned integer in current endian
 func (d *D) TryFieldU39(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU39(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2692,6 +2877,9 @@ func (d *D) FieldScalarU40(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU40 tries to add a field and read 40 bit unsigned integer in current endian
 func (d *D) TryFieldU40(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU40(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2739,6 +2927,9 @@ func (d *D) FieldScalarU41(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU41 tries to add a field and read 41 bit unsigned integer in current endian
 func (d *D) TryFieldU41(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU41(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2786,6 +2977,9 @@ func (d *D) FieldScalarU42(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU42 tries to add a field and read 42 bit unsigned integer in current endian
 func (d *D) TryFieldU42(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU42(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2833,6 +3027,9 @@ func (d *D) FieldScalarU43(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU43 tries to add a field and read 43 bit unsigned integer in current endian
 func (d *D) TryFieldU43(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU43(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2880,6 +3077,9 @@ func (d *D) FieldScalarU44(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU44 tries to add a field and read 44 bit unsigned integer in current endian
 func (d *D) TryFieldU44(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU44(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2927,6 +3127,9 @@ func (d *D) FieldScalarU45(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU45 tries to add a field and read 45 bit unsigned integer in current endian
 func (d *D) TryFieldU45(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU45(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -2974,6 +3177,9 @@ func (d *D) FieldScalarU46(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU46 tries to add a field and read 46 bit unsigned integer in current endian
 func (d *D) TryFieldU46(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU46(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3021,6 +3227,9 @@ func (d *D) FieldScalarU47(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU47 tries to add a field and read 47 bit unsigned integer in current endian
 func (d *D) TryFieldU47(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU47(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3068,6 +3277,9 @@ func (d *D) FieldScalarU48(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU48 tries to add a field and read 48 bit unsigned integer in current endian
 func (d *D) TryFieldU48(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU48(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3115,6 +3327,9 @@ func (d *D) FieldScalarU49(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU49 tries to add a field and read 49 bit unsigned integer in current endian
 func (d *D) TryFieldU49(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU49(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3162,6 +3377,9 @@ func (d *D) FieldScalarU50(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU50 tries to add a field and read 50 bit unsigned integer in current endian
 func (d *D) TryFieldU50(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU50(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3209,6 +3427,9 @@ func (d *D) FieldScalarU51(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU51 tries to add a field and read 51 bit unsigned integer in current endian
 func (d *D) TryFieldU51(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU51(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3256,6 +3477,9 @@ func (d *D) FieldScalarU52(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU52 tries to add a field and read 52 bit unsigned integer in current endian
 func (d *D) TryFieldU52(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU52(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3303,6 +3527,9 @@ func (d *D) FieldScalarU53(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU53 tries to add a field and read 53 bit unsigned integer in current endian
 func (d *D) TryFieldU53(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU53(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3350,6 +3577,9 @@ func (d *D) FieldScalarU54(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU54 tries to add a field and read 54 bit unsigned integer in current endian
 func (d *D) TryFieldU54(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU54(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3397,6 +3627,9 @@ func (d *D) FieldScalarU55(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU55 tries to add a field and read 55 bit unsigned integer in current endian
 func (d *D) TryFieldU55(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU55(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3444,6 +3677,9 @@ func (d *D) FieldScalarU56(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU56 tries to add a field and read 56 bit unsigned integer in current endian
 func (d *D) TryFieldU56(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU56(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3491,6 +3727,9 @@ func (d *D) FieldScalarU57(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU57 tries to add a field and read 57 bit unsigned integer in current endian
 func (d *D) TryFieldU57(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU57(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3538,6 +3777,9 @@ func (d *D) FieldScalarU58(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU58 tries to add a field and read 58 bit unsigned integer in current endian
 func (d *D) TryFieldU58(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU58(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3585,6 +3827,9 @@ func (d *D) FieldScalarU59(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU59 tries to add a field and read 59 bit unsigned integer in current endian
 func (d *D) TryFieldU59(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU59(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3632,6 +3877,9 @@ func (d *D) FieldScalarU60(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU60 tries to add a field and read 60 bit unsigned integer in current endian
 func (d *D) TryFieldU60(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU60(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3679,6 +3927,9 @@ func (d *D) FieldScalarU61(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU61 tries to add a field and read 61 bit unsigned integer in current endian
 func (d *D) TryFieldU61(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU61(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3726,6 +3977,9 @@ func (d *D) FieldScalarU62(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU62 tries to add a field and read 62 bit unsigned integer in current endian
 func (d *D) TryFieldU62(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU62(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3773,6 +4027,9 @@ func (d *D) FieldScalarU63(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU63 tries to add a field and read 63 bit unsigned integer in current endian
 func (d *D) TryFieldU63(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU63(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3820,6 +4077,9 @@ func (d *D) FieldScalarU64(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU64 tries to add a field and read 64 bit unsigned integer in current endian
 func (d *D) TryFieldU64(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU64(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3867,6 +4127,9 @@ func (d *D) FieldScalarU8LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU8LE tries to add a field and read 8 bit unsigned integer in little-endian
 func (d *D) TryFieldU8LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU8LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3914,6 +4177,9 @@ func (d *D) FieldScalarU9LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU9LE tries to add a field and read 9 bit unsigned integer in little-endian
 func (d *D) TryFieldU9LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU9LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -3961,6 +4227,9 @@ func (d *D) FieldScalarU10LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU10LE tries to add a field and read 10 bit unsigned integer in little-endian
 func (d *D) TryFieldU10LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU10LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4008,6 +4277,9 @@ func (d *D) FieldScalarU11LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU11LE tries to add a field and read 11 bit unsigned integer in little-endian
 func (d *D) TryFieldU11LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU11LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4055,8 +4327,11 @@ func (d *D) FieldScalarU12LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU12LE tries to add a field and read 12 bit unsigned integer in little-endian
 func (d *D) TryFieldU12LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU12LE(name, sms...)
-	return s.ActualU(), err
-}
+	if err != nil {
+		return 0, err
+	}
+	return s.ActualU(), err
+}
 
 // FieldU12LE adds a field and reads 12 bit unsigned integer in little-endian
 func (d *D) FieldU12LE(name string, sms ...scalar.Mapper) uint64 {
@@ -4102,6 +4377,9 @@ func (d *D) FieldScalarU13LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU13LE tries to add a field and read 13 bit unsigned integer in little-endian
 func (d *D) TryFieldU13LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU13LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4149,6 +4427,9 @@ func (d *D) FieldScalarU14LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU14LE tries to add a field and read 14 bit unsigned integer in little-endian
 func (d *D) TryFieldU14LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU14LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4196,6 +4477,9 @@ func (d *D) FieldScalarU15LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU15LE tries to add a field and read 15 bit unsigned integer in little-endian
 func (d *D) TryFieldU15LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU15LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4243,6 +4527,9 @@ func (d *D) FieldScalarU16LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU16LE tries to add a field and read 16 bit unsigned integer in little-endian
 func (d *D) TryFieldU16LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU16LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4290,6 +4577,9 @@ func (d *D) FieldScalarU17LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU17LE tries to add a field and read 17 bit unsigned integer in little-endian
 func (d *D) TryFieldU17LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU17LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4337,6 +4627,9 @@ func (d *D) FieldScalarU18LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU18LE tries to add a field and read 18 bit unsigned integer in little-endian
 func (d *D) TryFieldU18LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU18LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4384,6 +4677,9 @@ func (d *D) FieldScalarU19LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU19LE tries to add a field and read 19 bit unsigned integer in little-endian
 func (d *D) TryFieldU19LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU19LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4431,6 +4727,9 @@ func (d *D) FieldScalarU20LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU20LE tries to add a field and read 20 bit unsigned integer in little-endian
 func (d *D) TryFieldU20LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU20LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4478,6 +4777,9 @@ func (d *D) FieldScalarU21LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU21LE tries to add a field and read 21 bit unsigned integer in little-endian
 func (d *D) TryFieldU21LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU21LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4525,6 +4827,9 @@ func (d *D) FieldScalarU22LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU22LE tries to add a field and read 22 bit unsigned integer in little-endian
 func (d *D) TryFieldU22LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU22LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4572,6 +4877,9 @@ func (d *D) FieldScalarU23LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU23LE tries to add a field and read 23 bit unsigned integer in little-endian
 func (d *D) TryFieldU23LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU23LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4619,6 +4927,9 @@ func (d *D) FieldScalarU24LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU24LE tries to add a field and read 24 bit unsigned integer in little-endian
 func (d *D) TryFieldU24LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU24LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4666,6 +4977,9 @@ func (d *D) FieldScalarU25LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU25LE tries to add a field and read 25 bit unsigned integer in little-endian
 func (d *D) TryFieldU25LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU25LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4713,6 +5027,9 @@ func (d *D) FieldScalarU26LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU26LE tries to add a field and read 26 bit unsigned integer in little-endian
 func (d *D) TryFieldU26LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU26LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4760,6 +5077,9 @@ func (d *D) FieldScalarU27LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU27LE tries to add a field and read 27 bit unsigned integer in little-endian
 func (d *D) TryFieldU27LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU27LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4807,6 +5127,9 @@ func (d *D) FieldScalarU28LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU28LE tries to add a field and read 28 bit unsigned integer in little-endian
 func (d *D) TryFieldU28LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU28LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4854,6 +5177,9 @@ func (d *D) FieldScalarU29LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU29LE tries to add a field and read 29 bit unsigned integer in little-endian
 func (d *D) TryFieldU29LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU29LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4901,6 +5227,9 @@ func (d *D) FieldScalarU30LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU30LE tries to add a field and read 30 bit unsigned integer in little-endian
 func (d *D) TryFieldU30LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU30LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4948,6 +5277,9 @@ func (d *D) FieldScalarU31LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU31LE tries to add a field and read 31 bit unsigned integer in little-endian
 func (d *D) TryFieldU31LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU31LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -4995,6 +5327,9 @@ func (d *D) FieldScalarU32LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU32LE tries to add a field and read 32 bit unsigned integer in little-endian
 func (d *D) TryFieldU32LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU32LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5042,6 +5377,9 @@ func (d *D) FieldScalarU33LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU33LE tries to add a field and read 33 bit unsigned integer in little-endian
 func (d *D) TryFieldU33LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU33LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5089,6 +5427,9 @@ func (d *D) FieldScalarU34LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU34LE tries to add a field and read 34 bit unsigned integer in little-endian
 func (d *D) TryFieldU34LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU34LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5136,6 +5477,9 @@ func (d *D) FieldScalarU35LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU35LE tries to add a field and read 35 bit unsigned integer in little-endian
 func (d *D) TryFieldU35LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU35LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5183,6 +5527,9 @@ func (d *D) FieldScalarU36LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU36LE tries to add a field and read 36 bit unsigned integer in little-endian
 func (d *D) TryFieldU36LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU36LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5230,6 +5577,9 @@ func (d *D) FieldScalarU37LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU37LE tries to add a field and read 37 bit unsigned integer in little-endian
 func (d *D) TryFieldU37LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU37LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5277,6 +5627,9 @@ func (d *D) FieldScalarU38LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU38LE tries to add a field and read 38 bit unsigned integer in little-endian
 func (d *D) TryFieldU38LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU38LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5324,6 +5677,9 @@ func (d *D) FieldScalarU39LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU39LE tries to add a field and read 39 bit unsigned integer in little-endian
 func (d *D) TryFieldU39LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU39LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5371,6 +5727,9 @@ func (d *D) FieldScalarU40LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU40LE tries to add a field and read 40 bit unsigned integer in little-endian
 func (d *D) TryFieldU40LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU40LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5418,6 +5777,9 @@ func (d *D) FieldScalarU41LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU41LE tries to add a field and read 41 bit unsigned integer in little-endian
 func (d *D) TryFieldU41LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU41LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5465,6 +5827,9 @@ func (d *D) FieldScalarU42LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU42LE tries to add a field and read 42 bit unsigned integer in little-endian
 func (d *D) TryFieldU42LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU42LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5512,6 +5877,9 @@ func (d *D) FieldScalarU43LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU43LE tries to add a field and read 43 bit unsigned integer in little-endian
 func (d *D) TryFieldU43LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU43LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5559,6 +5927,9 @@ func (d *D) FieldScalarU44LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU44LE tries to add a field and read 44 bit unsigned integer in little-endian
 func (d *D) TryFieldU44LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU44LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5606,6 +5977,9 @@ func (d *D) FieldScalarU45LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU45LE tries to add a field and read 45 bit unsigned integer in little-endian
 func (d *D) TryFieldU45LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU45LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5653,6 +6027,9 @@ func (d *D) FieldScalarU46LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU46LE tries to add a field and read 46 bit unsigned integer in little-endian
 func (d *D) TryFieldU46LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU46LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5700,6 +6077,9 @@ func (d *D) FieldScalarU47LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU47LE tries to add a field and read 47 bit unsigned integer in little-endian
 func (d *D) TryFieldU47LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU47LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5747,6 +6127,9 @@ func (d *D) FieldScalarU48LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU48LE tries to add a field and read 48 bit unsigned integer in little-endian
 func (d *D) TryFieldU48LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU48LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5794,6 +6177,9 @@ func (d *D) FieldScalarU49LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU49LE tries to add a field and read 49 bit unsigned integer in little-endian
 func (d *D) TryFieldU49LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU49LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5841,6 +6227,9 @@ func (d *D) FieldScalarU50LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU50LE tries to add a field and read 50 bit unsigned integer in little-endian
 func (d *D) TryFieldU50LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU50LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5888,6 +6277,9 @@ func (d *D) FieldScalarU51LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU51LE tries to add a field and read 51 bit unsigned integer in little-endian
 func (d *D) TryFieldU51LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU51LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5935,6 +6327,9 @@ func (d *D) FieldScalarU52LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU52LE tries to add a field and read 52 bit unsigned integer in little-endian
 func (d *D) TryFieldU52LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU52LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -5982,6 +6377,9 @@ func (d *D) FieldScalarU53LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU53LE tries to add a field and read 53 bit unsigned integer in little-endian
 func (d *D) TryFieldU53LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU53LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6029,6 +6427,9 @@ func (d *D) FieldScalarU54LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU54LE tries to add a field and read 54 bit unsigned integer in little-endian
 func (d *D) TryFieldU54LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU54LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6076,6 +6477,9 @@ func (d *D) FieldScalarU55LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU55LE tries to add a field and read 55 bit unsigned integer in little-endian
 func (d *D) TryFieldU55LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU55LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6123,6 +6527,9 @@ func (d *D) FieldScalarU56LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU56LE tries to add a field and read 56 bit unsigned integer in little-endian
 func (d *D) TryFieldU56LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU56LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6170,6 +6577,9 @@ func (d *D) FieldScalarU57LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU57LE tries to add a field and read 57 bit unsigned integer in little-endian
 func (d *D) TryFieldU57LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU57LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6217,6 +6627,9 @@ func (d *D) FieldScalarU58LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU58LE tries to add a field and read 58 bit unsigned integer in little-endian
 func (d *D) TryFieldU58LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU58LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6264,6 +6677,9 @@ func (d *D) FieldScalarU59LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU59LE tries to add a field and read 59 bit unsigned integer in little-endian
 func (d *D) TryFieldU59LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU59LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6311,6 +6727,9 @@ func (d *D) FieldScalarU60LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU60LE tries to add a field and read 60 bit unsigned integer in little-endian
 func (d *D) TryFieldU60LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU60LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6358,6 +6777,9 @@ func (d *D) FieldScalarU61LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU61LE tries to add a field and read 61 bit unsigned integer in little-endian
 func (d *D) TryFieldU61LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU61LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6405,6 +6827,9 @@ func (d *D) FieldScalarU62LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU62LE tries to add a field and read 62 bit unsigned integer in little-endian
 func (d *D) TryFieldU62LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU62LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6452,6 +6877,9 @@ func (d *D) FieldScalarU63LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU63LE tries to add a field and read 63 bit unsigned integer in little-endian
 func (d *D) TryFieldU63LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU63LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6499,6 +6927,9 @@ func (d *D) FieldScalarU64LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU64LE tries to add a field and read 64 bit unsigned integer in little-endian
 func (d *D) TryFieldU64LE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU64LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6546,6 +6977,9 @@ func (d *D) FieldScalarU8BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU8BE tries to add a field and read 8 bit unsigned integer in big-endian
 func (d *D) TryFieldU8BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU8BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6593,6 +7027,9 @@ func (d *D) FieldScalarU9BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU9BE tries to add a field and read 9 bit unsigned integer in big-endian
 func (d *D) TryFieldU9BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU9BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6640,6 +7077,9 @@ func (d *D) FieldScalarU10BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU10BE tries to add a field and read 10 bit unsigned integer in big-endian
 func (d *D) TryFieldU10BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU10BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6687,6 +7127,9 @@ func (d *D) FieldScalarU11BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU11BE tries to add a field and read 11 bit unsigned integer in big-endian
 func (d *D) TryFieldU11BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU11BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6734,6 +7177,9 @@ func (d *D) FieldScalarU12BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU12BE tries to add a field and read 12 bit unsigned integer in big-endian
 func (d *D) TryFieldU12BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU12BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6781,6 +7227,9 @@ func (d *D) FieldScalarU13BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU13BE tries to add a field and read 13 bit unsigned integer in big-endian
 func (d *D) TryFieldU13BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU13BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6828,6 +7277,9 @@ func (d *D) FieldScalarU14BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU14BE tries to add a field and read 14 bit unsigned integer in big-endian
 func (d *D) TryFieldU14BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU14BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6875,6 +7327,9 @@ func (d *D) FieldScalarU15BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU15BE tries to add a field and read 15 bit unsigned integer in big-endian
 func (d *D) TryFieldU15BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU15BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6922,6 +7377,9 @@ func (d *D) FieldScalarU16BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU16BE tries to add a field and read 16 bit unsigned integer in big-endian
 func (d *D) TryFieldU16BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU16BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -6969,6 +7427,9 @@ func (d *D) FieldScalarU17BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU17BE tries to add a field and read 17 bit unsigned integer in big-endian
 func (d *D) TryFieldU17BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU17BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7016,6 +7477,9 @@ func (d *D) FieldScalarU18BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU18BE tries to add a field and read 18 bit unsigned integer in big-endian
 func (d *D) TryFieldU18BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU18BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7063,6 +7527,9 @@ func (d *D) FieldScalarU19BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU19BE tries to add a field and read 19 bit unsigned integer in big-endian
 func (d *D) TryFieldU19BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU19BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7110,6 +7577,9 @@ func (d *D) FieldScalarU20BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU20BE tries to add a field and read 20 bit unsigned integer in big-endian
 func (d *D) TryFieldU20BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU20BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7157,6 +7627,9 @@ func (d *D) FieldScalarU21BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU21BE tries to add a field and read 21 bit unsigned integer in big-endian
 func (d *D) TryFieldU21BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU21BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7204,6 +7677,9 @@ func (d *D) FieldScalarU22BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU22BE tries to add a field and read 22 bit unsigned integer in big-endian
 func (d *D) TryFieldU22BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU22BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7251,6 +7727,9 @@ func (d *D) FieldScalarU23BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU23BE tries to add a field and read 23 bit unsigned integer in big-endian
 func (d *D) TryFieldU23BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU23BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7298,6 +7777,9 @@ func (d *D) FieldScalarU24BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU24BE tries to add a field and read 24 bit unsigned integer in big-endian
 func (d *D) TryFieldU24BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU24BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7345,6 +7827,9 @@ func (d *D) FieldScalarU25BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU25BE tries to add a field and read 25 bit unsigned integer in big-endian
 func (d *D) TryFieldU25BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU25BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7392,6 +7877,9 @@ func (d *D) FieldScalarU26BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU26BE tries to add a field and read 26 bit unsigned integer in big-endian
 func (d *D) TryFieldU26BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU26BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7439,6 +7927,9 @@ func (d *D) FieldScalarU27BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU27BE tries to add a field and read 27 bit unsigned integer in big-endian
 func (d *D) TryFieldU27BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU27BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7486,6 +7977,9 @@ func (d *D) FieldScalarU28BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU28BE tries to add a field and read 28 bit unsigned integer in big-endian
 func (d *D) TryFieldU28BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU28BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7533,6 +8027,9 @@ func (d *D) FieldScalarU29BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU29BE tries to add a field and read 29 bit unsigned integer in big-endian
 func (d *D) TryFieldU29BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU29BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7580,6 +8077,9 @@ func (d *D) FieldScalarU30BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU30BE tries to add a field and read 30 bit unsigned integer in big-endian
 func (d *D) TryFieldU30BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU30BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7627,6 +8127,9 @@ func (d *D) FieldScalarU31BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU31BE tries to add a field and read 31 bit unsigned integer in big-endian
 func (d *D) TryFieldU31BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU31BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7674,6 +8177,9 @@ func (d *D) FieldScalarU32BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU32BE tries to add a field and read 32 bit unsigned integer in big-endian
 func (d *D) TryFieldU32BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU32BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7721,6 +8227,9 @@ func (d *D) FieldScalarU33BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU33BE tries to add a field and read 33 bit unsigned integer in big-endian
 func (d *D) TryFieldU33BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU33BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7768,6 +8277,9 @@ func (d *D) FieldScalarU34BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU34BE tries to add a field and read 34 bit unsigned integer in big-endian
 func (d *D) TryFieldU34BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU34BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7815,6 +8327,9 @@ func (d *D) FieldScalarU35BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU35BE tries to add a field and read 35 bit unsigned integer in big-endian
 func (d *D) TryFieldU35BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU35BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7862,6 +8377,9 @@ func (d *D) FieldScalarU36BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU36BE tries to add a field and read 36 bit unsigned integer in big-endian
 func (d *D) TryFieldU36BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU36BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7909,6 +8427,9 @@ func (d *D) FieldScalarU37BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU37BE tries to add a field and read 37 bit unsigned integer in big-endian
 func (d *D) TryFieldU37BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU37BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -7956,6 +8477,9 @@ func (d *D) FieldScalarU38BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU38BE tries to add a field and read 38 bit unsigned integer in big-endian
 func (d *D) TryFieldU38BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU38BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8003,8 +8527,11 @@ func (d *D) FieldScalarU39BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU39BE tries to add a field and read 39 bit unsigned integer in big-endian
 func (d *D) TryFieldU39BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU39BE(name, sms...)
-	return s.ActualU(), err
-}
+	if err != nil {
+		return 0, err
+	}
+	return s.ActualU(), err
+}
 
 // FieldU39BE adds a field and reads 39 bit unsigned integer in big-endian
 func (d *D) FieldU39BE(name string, sms ...scalar.Mapper) uint64 {
@@ -8050,6 +8577,9 @@ func (d *D) FieldScalarU40BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU40BE tries to add a field and read 40 bit unsigned integer in big-endian
 func (d *D) TryFieldU40BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU40BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8097,6 +8627,9 @@ func (d *D) FieldScalarU41BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU41BE tries to add a field and read 41 bit unsigned integer in big-endian
 func (d *D) TryFieldU41BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU41BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8144,6 +8677,9 @@ func (d *D) FieldScalarU42BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU42BE tries to add a field and read 42 bit unsigned integer in big-endian
 func (d *D) TryFieldU42BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU42BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8191,6 +8727,9 @@ func (d *D) FieldScalarU43BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU43BE tries to add a field and read 43 bit unsigned integer in big-endian
 func (d *D) TryFieldU43BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU43BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8238,6 +8777,9 @@ func (d *D) FieldScalarU44BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU44BE tries to add a field and read 44 bit unsigned integer in big-endian
 func (d *D) TryFieldU44BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU44BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8285,6 +8827,9 @@ func (d *D) FieldScalarU45BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU45BE tries to add a field and read 45 bit unsigned integer in big-endian
 func (d *D) TryFieldU45BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU45BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8332,6 +8877,9 @@ func (d *D) FieldScalarU46BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU46BE tries to add a field and read 46 bit unsigned integer in big-endian
 func (d *D) TryFieldU46BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU46BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8379,6 +8927,9 @@ func (d *D) FieldScalarU47BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU47BE tries to add a field and read 47 bit unsigned integer in big-endian
 func (d *D) TryFieldU47BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU47BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8426,6 +8977,9 @@ func (d *D) FieldScalarU48BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU48BE tries to add a field and read 48 bit unsigned integer in big-endian
 func (d *D) TryFieldU48BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU48BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8473,6 +9027,9 @@ func (d *D) FieldScalarU49BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU49BE tries to add a field and read 49 bit unsigned integer in big-endian
 func (d *D) TryFieldU49BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU49BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8520,6 +9077,9 @@ func (d *D) FieldScalarU50BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU50BE tries to add a field and read 50 bit unsigned integer in big-endian
 func (d *D) TryFieldU50BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU50BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8567,6 +9127,9 @@ func (d *D) FieldScalarU51BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU51BE tries to add a field and read 51 bit unsigned integer in big-endian
 func (d *D) TryFieldU51BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU51BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8614,6 +9177,9 @@ func (d *D) FieldScalarU52BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU52BE tries to add a field and read 52 bit unsigned integer in big-endian
 func (d *D) TryFieldU52BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU52BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8661,6 +9227,9 @@ func (d *D) FieldScalarU53BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU53BE tries to add a field and read 53 bit unsigned integer in big-endian
 func (d *D) TryFieldU53BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU53BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8708,6 +9277,9 @@ func (d *D) FieldScalarU54BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU54BE tries to add a field and read 54 bit unsigned integer in big-endian
 func (d *D) TryFieldU54BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU54BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8755,6 +9327,9 @@ func (d *D) FieldScalarU55BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU55BE tries to add a field and read 55 bit unsigned integer in big-endian
 func (d *D) TryFieldU55BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU55BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8802,6 +9377,9 @@ func (d *D) FieldScalarU56BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU56BE tries to add a field and read 56 bit unsigned integer in big-endian
 func (d *D) TryFieldU56BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU56BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8849,6 +9427,9 @@ func (d *D) FieldScalarU57BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU57BE tries to add a field and read 57 bit unsigned integer in big-endian
 func (d *D) TryFieldU57BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU57BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8896,6 +9477,9 @@ func (d *D) FieldScalarU58BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU58BE tries to add a field and read 58 bit unsigned integer in big-endian
 func (d *D) TryFieldU58BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU58BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8943,6 +9527,9 @@ func (d *D) FieldScalarU59BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU59BE tries to add a field and read 59 bit unsigned integer in big-endian
 func (d *D) TryFieldU59BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU59BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -8990,6 +9577,9 @@ func (d *D) FieldScalarU60BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU60BE tries to add a field and read 60 bit unsigned integer in big-endian
 func (d *D) TryFieldU60BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU60BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -9037,6 +9627,9 @@ func (d *D) FieldScalarU61BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU61BE tries to add a field and read 61 bit unsigned integer in big-endian
 func (d *D) TryFieldU61BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU61BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -9084,6 +9677,9 @@ func (d *D) FieldScalarU62BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU62BE tries to add a field and read 62 bit unsigned integer in big-endian
 func (d *D) TryFieldU62BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU62BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -9131,6 +9727,9 @@ func (d *D) FieldScalarU63BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU63BE tries to add a field and read 63 bit unsigned integer in big-endian
 func (d *D) TryFieldU63BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU63BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -9178,6 +9777,9 @@ func (d *D) FieldScalarU64BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldU64BE tries to add a field and read 64 bit unsigned integer in big-endian
 func (d *D) TryFieldU64BE(name string, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarU64BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -9225,6 +9827,9 @@ func (d *D) FieldScalarS(name string, nBits int, sms ...scalar.Mapper) *scalar.S
 // TryFieldS tries to add a field and read nBits bits signed integer in current endian
 func (d *D) TryFieldS(name string, nBits int, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS(name, nBits, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9272,6 +9877,9 @@ func (d *D) FieldScalarSE(name string, nBits int, endian Endian, sms ...scalar.M
 // TryFieldSE tries to add a field and read nBits signed integer in specified endian
 func (d *D) TryFieldSE(name string, nBits int, endian Endian, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarSE(name, nBits, endian, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9319,6 +9927,9 @@ func (d *D) FieldScalarS1(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS1 tries to add a field and read 1 bit signed integer in current endian
 func (d *D) TryFieldS1(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS1(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9366,6 +9977,9 @@ func (d *D) FieldScalarS2(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS2 tries to add a field and read 2 bit signed integer in current endian
 func (d *D) TryFieldS2(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS2(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9413,6 +10027,9 @@ func (d *D) FieldScalarS3(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS3 tries to add a field and read 3 bit signed integer in current endian
 func (d *D) TryFieldS3(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS3(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9460,6 +10077,9 @@ func (d *D) FieldScalarS4(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS4 tries to add a field and read 4 bit signed integer in current endian
 func (d *D) TryFieldS4(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS4(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9507,6 +10127,9 @@ func (d *D) FieldScalarS5(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS5 tries to add a field and read 5 bit signed integer in current endian
 func (d *D) TryFieldS5(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS5(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9554,6 +10177,9 @@ func (d *D) FieldScalarS6(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS6 tries to add a field and read 6 bit signed integer in current endian
 func (d *D) TryFieldS6(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS6(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9601,6 +10227,9 @@ func (d *D) FieldScalarS7(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS7 tries to add a field and read 7 bit signed integer in current endian
 func (d *D) TryFieldS7(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS7(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9648,6 +10277,9 @@ func (d *D) FieldScalarS8(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS8 tries to add a field and read 8 bit signed integer in current endian
 func (d *D) TryFieldS8(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS8(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9695,6 +10327,9 @@ func (d *D) FieldScalarS9(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS9 tries to add a field and read 9 bit signed integer in current endian
 func (d *D) TryFieldS9(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS9(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9742,6 +10377,9 @@ func (d *D) FieldScalarS10(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS10 tries to add a field and read 10 bit signed integer in current endian
 func (d *D) TryFieldS10(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS10(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9789,6 +10427,9 @@ func (d *D) FieldScalarS11(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS11 tries to add a field and read 11 bit signed integer in current endian
 func (d *D) TryFieldS11(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS11(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9836,6 +10477,9 @@ func (d *D) FieldScalarS12(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS12 tries to add a field and read 12 bit signed integer in current endian
 func (d *D) TryFieldS12(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS12(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9883,6 +10527,9 @@ func (d *D) FieldScalarS13(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS13 tries to add a field and read 13 bit signed integer in current endian
 func (d *D) TryFieldS13(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS13(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9930,6 +10577,9 @@ func (d *D) FieldScalarS14(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS14 tries to add a field and read 14 bit signed integer in current endian
 func (d *D) TryFieldS14(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS14(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -9977,6 +10627,9 @@ func (d *D) FieldScalarS15(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS15 tries to add a field and read 15 bit signed integer in current endian
 func (d *D) TryFieldS15(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS15(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10024,6 +10677,9 @@ func (d *D) FieldScalarS16(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS16 tries to add a field and read 16 bit signed integer in current endian
 func (d *D) TryFieldS16(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS16(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10071,6 +10727,9 @@ func (d *D) FieldScalarS17(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS17 tries to add a field and read 17 bit signed integer in current endian
 func (d *D) TryFieldS17(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS17(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10118,6 +10777,9 @@ func (d *D) FieldScalarS18(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS18 tries to add a field and read 18 bit signed integer in current endian
 func (d *D) TryFieldS18(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS18(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10165,6 +10827,9 @@ func (d *D) FieldScalarS19(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS19 tries to add a field and read 19 bit signed integer in current endian
 func (d *D) TryFieldS19(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS19(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10212,6 +10877,9 @@ func (d *D) FieldScalarS20(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS20 tries to add a field and read 20 bit signed integer in current endian
 func (d *D) TryFieldS20(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS20(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10259,6 +10927,9 @@ func (d *D) FieldScalarS21(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS21 tries to add a field and read 21 bit signed integer in current endian
 func (d *D) TryFieldS21(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS21(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10306,6 +10977,9 @@ func (d *D) FieldScalarS22(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS22 tries to add a field and read 22 bit signed integer in current endian
 func (d *D) TryFieldS22(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS22(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10353,6 +11027,9 @@ func (d *D) FieldScalarS23(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS23 tries to add a field and read 23 bit signed integer in current endian
 func (d *D) TryFieldS23(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS23(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10400,6 +11077,9 @@ func (d *D) FieldScalarS24(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS24 tries to add a field and read 24 bit signed integer in current endian
 func (d *D) TryFieldS24(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS24(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10447,6 +11127,9 @@ func (d *D) FieldScalarS25(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS25 tries to add a field and read 25 bit signed integer in current endian
 func (d *D) TryFieldS25(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS25(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10494,6 +11177,9 @@ func (d *D) FieldScalarS26(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS26 tries to add a field and read 26 bit signed integer in current endian
 func (d *D) TryFieldS26(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS26(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10541,6 +11227,9 @@ func (d *D) FieldScalarS27(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS27 tries to add a field and read 27 bit signed integer in current endian
 func (d *D) TryFieldS27(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS27(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10588,6 +11277,9 @@ func (d *D) FieldScalarS28(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS28 tries to add a field and read 28 bit signed integer in current endian
 func (d *D) TryFieldS28(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS28(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10635,6 +11327,9 @@ func (d *D) FieldScalarS29(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS29 tries to add a field and read 29 bit signed integer in current endian
 func (d *D) TryFieldS29(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS29(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10682,6 +11377,9 @@ func (d *D) FieldScalarS30(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS30 tries to add a field and read 30 bit signed integer in current endian
 func (d *D) TryFieldS30(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS30(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10729,6 +11427,9 @@ func (d *D) FieldScalarS31(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS31 tries to add a field and read 31 bit signed integer in current endian
 func (d *D) TryFieldS31(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS31(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10776,6 +11477,9 @@ func (d *D) FieldScalarS32(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS32 tries to add a field and read 32 bit signed integer in current endian
 func (d *D) TryFieldS32(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS32(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10823,6 +11527,9 @@ func (d *D) FieldScalarS33(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS33 tries to add a field and read 33 bit signed integer in current endian
 func (d *D) TryFieldS33(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS33(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10870,6 +11577,9 @@ func (d *D) FieldScalarS34(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS34 tries to add a field and read 34 bit signed integer in current endian
 func (d *D) TryFieldS34(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS34(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10917,6 +11627,9 @@ func (d *D) FieldScalarS35(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS35 tries to add a field and read 35 bit signed integer in current endian
 func (d *D) TryFieldS35(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS35(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -10964,6 +11677,9 @@ func (d *D) FieldScalarS36(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS36 tries to add a field and read 36 bit signed integer in current endian
 func (d *D) TryFieldS36(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS36(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11011,6 +11727,9 @@ func (d *D) FieldScalarS37(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS37 tries to add a field and read 37 bit signed integer in current endian
 func (d *D) TryFieldS37(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS37(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11058,6 +11777,9 @@ func (d *D) FieldScalarS38(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS38 tries to add a field and read 38 bit signed integer in current endian
 func (d *D) TryFieldS38(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS38(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11105,6 +11827,9 @@ func (d *D) FieldScalarS39(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS39 tries to add a field and read 39 bit signed integer in current endian
 func (d *D) TryFieldS39(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS39(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11152,6 +11877,9 @@ func (d *D) FieldScalarS40(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS40 tries to add a field and read 40 bit signed integer in current endian
 func (d *D) TryFieldS40(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS40(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11199,6 +11927,9 @@ func (d *D) FieldScalarS41(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS41 tries to add a field and read 41 bit signed integer in current endian
 func (d *D) TryFieldS41(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS41(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11246,6 +11977,9 @@ func (d *D) FieldScalarS42(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS42 tries to add a field and read 42 bit signed integer in current endian
 func (d *D) TryFieldS42(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS42(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11293,6 +12027,9 @@ func (d *D) FieldScalarS43(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS43 tries to add a field and read 43 bit signed integer in current endian
 func (d *D) TryFieldS43(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS43(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11340,6 +12077,9 @@ func (d *D) FieldScalarS44(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS44 tries to add a field and read 44 bit signed integer in current endian
 func (d *D) TryFieldS44(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS44(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11387,6 +12127,9 @@ func (d *D) FieldScalarS45(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS45 tries to add a field and read 45 bit signed integer in current endian
 func (d *D) TryFieldS45(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS45(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11434,6 +12177,9 @@ func (d *D) FieldScalarS46(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS46 tries to add a field and read 46 bit signed integer in current endian
 func (d *D) TryFieldS46(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS46(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11481,6 +12227,9 @@ func (d *D) FieldScalarS47(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS47 tries to add a field and read 47 bit signed integer in current endian
 func (d *D) TryFieldS47(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS47(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11528,6 +12277,9 @@ func (d *D) FieldScalarS48(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS48 tries to add a field and read 48 bit signed integer in current endian
 func (d *D) TryFieldS48(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS48(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11575,6 +12327,9 @@ func (d *D) FieldScalarS49(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS49 tries to add a field and read 49 bit signed integer in current endian
 func (d *D) TryFieldS49(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS49(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11622,6 +12377,9 @@ func (d *D) FieldScalarS50(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS50 tries to add a field and read 50 bit signed integer in current endian
 func (d *D) TryFieldS50(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS50(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11669,6 +12427,9 @@ func (d *D) FieldScalarS51(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS51 tries to add a field and read 51 bit signed integer in current endian
 func (d *D) TryFieldS51(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS51(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11716,6 +12477,9 @@ func (d *D) FieldScalarS52(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS52 tries to add a field and read 52 bit signed integer in current endian
 func (d *D) TryFieldS52(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS52(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11763,6 +12527,9 @@ func (d *D) FieldScalarS53(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS53 tries to add a field and read 53 bit signed integer in current endian
 func (d *D) TryFieldS53(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS53(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11810,6 +12577,9 @@ func (d *D) FieldScalarS54(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS54 tries to add a field and read 54 bit signed integer in current endian
 func (d *D) TryFieldS54(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS54(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11857,6 +12627,9 @@ func (d *D) FieldScalarS55(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS55 tries to add a field and read 55 bit signed integer in current endian
 func (d *D) TryFieldS55(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS55(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11904,6 +12677,9 @@ func (d *D) FieldScalarS56(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS56 tries to add a field and read 56 bit signed integer in current endian
 func (d *D) TryFieldS56(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS56(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -11951,8 +12727,11 @@ func (d *D) FieldScalarS57(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS57 tries to add a field and read 57 bit signed integer in current endian
 func (d *D) TryFieldS57(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS57(name, sms...)
-	return s.ActualS(), err
-}
+	if err != nil {
+		return 0, err
+	}
+	return s.ActualS(), err
+}
 
 // FieldS57 adds a field and reads 57 bit signed integer in current endian
 func (d *D) FieldS57(name string, sms ...scalar.Mapper) int64 {
@@ -11998,6 +12777,9 @@ func (d *D) FieldScalarS58(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS58 tries to add a field and read 58 bit signed integer in current endian
 func (d *D) TryFieldS58(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS58(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12045,6 +12827,9 @@ func (d *D) FieldScalarS59(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS59 tries to add a field and read 59 bit signed integer in current endian
 func (d *D) TryFieldS59(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS59(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12092,6 +12877,9 @@ func (d *D) FieldScalarS60(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS60 tries to add a field and read 60 bit signed integer in current endian
 func (d *D) TryFieldS60(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS60(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12139,6 +12927,9 @@ func (d *D) FieldScalarS61(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS61 tries to add a field and read 61 bit signed integer in current endian
 func (d *D) TryFieldS61(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS61(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12186,6 +12977,9 @@ func (d *D) FieldScalarS62(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS62 tries to add a field and read 62 bit signed integer in current endian
 func (d *D) TryFieldS62(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS62(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12233,6 +13027,9 @@ func (d *D) FieldScalarS63(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS63 tries to add a field and read 63 bit signed integer in current endian
 func (d *D) TryFieldS63(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS63(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12280,6 +13077,9 @@ func (d *D) FieldScalarS64(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS64 tries to add a field and read 64 bit signed integer in current endian
 func (d *D) TryFieldS64(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS64(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12327,6 +13127,9 @@ func (d *D) FieldScalarS8LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS8LE tries to add a field and read 8 bit signed integer in little-endian
 func (d *D) TryFieldS8LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS8LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12374,6 +13177,9 @@ func (d *D) FieldScalarS9LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS9LE tries to add a field and read 9 bit signed integer in little-endian
 func (d *D) TryFieldS9LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS9LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12421,6 +13227,9 @@ func (d *D) FieldScalarS10LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS10LE tries to add a field and read 10 bit signed integer in little-endian
 func (d *D) TryFieldS10LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS10LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12468,6 +13277,9 @@ func (d *D) FieldScalarS11LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS11LE tries to add a field and read 11 bit signed integer in little-endian
 func (d *D) TryFieldS11LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS11LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12515,6 +13327,9 @@ func (d *D) FieldScalarS12LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS12LE tries to add a field and read 12 bit signed integer in little-endian
 func (d *D) TryFieldS12LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS12LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12562,6 +13377,9 @@ func (d *D) FieldScalarS13LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS13LE tries to add a field and read 13 bit signed integer in little-endian
 func (d *D) TryFieldS13LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS13LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12609,6 +13427,9 @@ func (d *D) FieldScalarS14LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS14LE tries to add a field and read 14 bit signed integer in little-endian
 func (d *D) TryFieldS14LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS14LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12656,6 +13477,9 @@ func (d *D) FieldScalarS15LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS15LE tries to add a field and read 15 bit signed integer in little-endian
 func (d *D) TryFieldS15LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS15LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12703,6 +13527,9 @@ func (d *D) FieldScalarS16LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS16LE tries to add a field and read 16 bit signed integer in little-endian
 func (d *D) TryFieldS16LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS16LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12750,6 +13577,9 @@ func (d *D) FieldScalarS17LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS17LE tries to add a field and read 17 bit signed integer in little-endian
 func (d *D) TryFieldS17LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS17LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12797,6 +13627,9 @@ func (d *D) FieldScalarS18LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS18LE tries to add a field and read 18 bit signed integer in little-endian
 func (d *D) TryFieldS18LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS18LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12844,6 +13677,9 @@ func (d *D) FieldScalarS19LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS19LE tries to add a field and read 19 bit signed integer in little-endian
 func (d *D) TryFieldS19LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS19LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12891,6 +13727,9 @@ func (d *D) FieldScalarS20LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS20LE tries to add a field and read 20 bit signed integer in little-endian
 func (d *D) TryFieldS20LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS20LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12938,6 +13777,9 @@ func (d *D) FieldScalarS21LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS21LE tries to add a field and read 21 bit signed integer in little-endian
 func (d *D) TryFieldS21LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS21LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -12985,6 +13827,9 @@ func (d *D) FieldScalarS22LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS22LE tries to add a field and read 22 bit signed integer in little-endian
 func (d *D) TryFieldS22LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS22LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13032,6 +13877,9 @@ func (d *D) FieldScalarS23LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS23LE tries to add a field and read 23 bit signed integer in little-endian
 func (d *D) TryFieldS23LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS23LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13079,6 +13927,9 @@ func (d *D) FieldScalarS24LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS24LE tries to add a field and read 24 bit signed integer in little-endian
 func (d *D) TryFieldS24LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS24LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13126,6 +13977,9 @@ func (d *D) FieldScalarS25LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS25LE tries to add a field and read 25 bit signed integer in little-endian
 func (d *D) TryFieldS25LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS25LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13173,6 +14027,9 @@ func (d *D) FieldScalarS26LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS26LE tries to add a field and read 26 bit signed integer in little-endian
 func (d *D) TryFieldS26LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS26LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13220,6 +14077,9 @@ func (d *D) FieldScalarS27LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS27LE tries to add a field and read 27 bit signed integer in little-endian
 func (d *D) TryFieldS27LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS27LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13267,6 +14127,9 @@ func (d *D) FieldScalarS28LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS28LE tries to add a field and read 28 bit signed integer in little-endian
 func (d *D) TryFieldS28LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS28LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13314,6 +14177,9 @@ func (d *D) FieldScalarS29LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS29LE tries to add a field and read 29 bit signed integer in little-endian
 func (d *D) TryFieldS29LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS29LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13361,6 +14227,9 @@ func (d *D) FieldScalarS30LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS30LE tries to add a field and read 30 bit signed integer in little-endian
 func (d *D) TryFieldS30LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS30LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13408,6 +14277,9 @@ func (d *D) FieldScalarS31LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS31LE tries to add a field and read 31 bit signed integer in little-endian
 func (d *D) TryFieldS31LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS31LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13455,6 +14327,9 @@ func (d *D) FieldScalarS32LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS32LE tries to add a field and read 32 bit signed integer in little-endian
 func (d *D) TryFieldS32LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS32LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13502,6 +14377,9 @@ func (d *D) FieldScalarS33LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS33LE tries to add a field and read 33 bit signed integer in little-endian
 func (d *D) TryFieldS33LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS33LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13549,6 +14427,9 @@ func (d *D) FieldScalarS34LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS34LE tries to add a field and read 34 bit signed integer in little-endian
 func (d *D) TryFieldS34LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS34LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13596,6 +14477,9 @@ func (d *D) FieldScalarS35LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS35LE tries to add a field and read 35 bit signed integer in little-endian
 func (d *D) TryFieldS35LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS35LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13643,6 +14527,9 @@ func (d *D) FieldScalarS36LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS36LE tries to add a field and read 36 bit signed integer in little-endian
 func (d *D) TryFieldS36LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS36LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13690,6 +14577,9 @@ func (d *D) FieldScalarS37LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS37LE tries to add a field and read 37 bit signed integer in little-endian
 func (d *D) TryFieldS37LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS37LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13737,6 +14627,9 @@ func (d *D) FieldScalarS38LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS38LE tries to add a field and read 38 bit signed integer in little-endian
 func (d *D) TryFieldS38LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS38LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13784,6 +14677,9 @@ func (d *D) FieldScalarS39LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS39LE tries to add a field and read 39 bit signed integer in little-endian
 func (d *D) TryFieldS39LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS39LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13831,6 +14727,9 @@ func (d *D) FieldScalarS40LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS40LE tries to add a field and read 40 bit signed integer in little-endian
 func (d *D) TryFieldS40LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS40LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13878,6 +14777,9 @@ func (d *D) FieldScalarS41LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS41LE tries to add a field and read 41 bit signed integer in little-endian
 func (d *D) TryFieldS41LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS41LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13925,6 +14827,9 @@ func (d *D) FieldScalarS42LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS42LE tries to add a field and read 42 bit signed integer in little-endian
 func (d *D) TryFieldS42LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS42LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -13972,6 +14877,9 @@ func (d *D) FieldScalarS43LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS43LE tries to add a field and read 43 bit signed integer in little-endian
 func (d *D) TryFieldS43LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS43LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14019,6 +14927,9 @@ func (d *D) FieldScalarS44LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS44LE tries to add a field and read 44 bit signed integer in little-endian
 func (d *D) TryFieldS44LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS44LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14066,6 +14977,9 @@ func (d *D) FieldScalarS45LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS45LE tries to add a field and read 45 bit signed integer in little-endian
 func (d *D) TryFieldS45LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS45LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14113,6 +15027,9 @@ func (d *D) FieldScalarS46LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS46LE tries to add a field and read 46 bit signed integer in little-endian
 func (d *D) TryFieldS46LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS46LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14160,6 +15077,9 @@ func (d *D) FieldScalarS47LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS47LE tries to add a field and read 47 bit signed integer in little-endian
 func (d *D) TryFieldS47LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS47LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14207,6 +15127,9 @@ func (d *D) FieldScalarS48LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS48LE tries to add a field and read 48 bit signed integer in little-endian
 func (d *D) TryFieldS48LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS48LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14254,6 +15177,9 @@ func (d *D) FieldScalarS49LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS49LE tries to add a field and read 49 bit signed integer in little-endian
 func (d *D) TryFieldS49LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS49LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14301,6 +15227,9 @@ func (d *D) FieldScalarS50LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS50LE tries to add a field and read 50 bit signed integer in little-endian
 func (d *D) TryFieldS50LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS50LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14348,6 +15277,9 @@ func (d *D) FieldScalarS51LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS51LE tries to add a field and read 51 bit signed integer in little-endian
 func (d *D) TryFieldS51LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS51LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14395,6 +15327,9 @@ func (d *D) FieldScalarS52LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS52LE tries to add a field and read 52 bit signed integer in little-endian
 func (d *D) TryFieldS52LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS52LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14442,6 +15377,9 @@ func (d *D) FieldScalarS53LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS53LE tries to add a field and read 53 bit signed integer in little-endian
 func (d *D) TryFieldS53LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS53LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14489,6 +15427,9 @@ func (d *D) FieldScalarS54LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS54LE tries to add a field and read 54 bit signed integer in little-endian
 func (d *D) TryFieldS54LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS54LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14536,6 +15477,9 @@ func (d *D) FieldScalarS55LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS55LE tries to add a field and read 55 bit signed integer in little-endian
 func (d *D) TryFieldS55LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS55LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14583,6 +15527,9 @@ func (d *D) FieldScalarS56LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS56LE tries to add a field and read 56 bit signed integer in little-endian
 func (d *D) TryFieldS56LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS56LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14630,6 +15577,9 @@ func (d *D) FieldScalarS57LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS57LE tries to add a field and read 57 bit signed integer in little-endian
 func (d *D) TryFieldS57LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS57LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14677,6 +15627,9 @@ func (d *D) FieldScalarS58LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS58LE tries to add a field and read 58 bit signed integer in little-endian
 func (d *D) TryFieldS58LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS58LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14724,6 +15677,9 @@ func (d *D) FieldScalarS59LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS59LE tries to add a field and read 59 bit signed integer in little-endian
 func (d *D) TryFieldS59LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS59LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14771,6 +15727,9 @@ func (d *D) FieldScalarS60LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS60LE tries to add a field and read 60 bit signed integer in little-endian
 func (d *D) TryFieldS60LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS60LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14818,6 +15777,9 @@ func (d *D) FieldScalarS61LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS61LE tries to add a field and read 61 bit signed integer in little-endian
 func (d *D) TryFieldS61LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS61LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14865,6 +15827,9 @@ func (d *D) FieldScalarS62LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS62LE tries to add a field and read 62 bit signed integer in little-endian
 func (d *D) TryFieldS62LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS62LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14912,6 +15877,9 @@ func (d *D) FieldScalarS63LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS63LE tries to add a field and read 63 bit signed integer in little-endian
 func (d *D) TryFieldS63LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS63LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -14959,6 +15927,9 @@ func (d *D) FieldScalarS64LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS64LE tries to add a field and read 64 bit signed integer in little-endian
 func (d *D) TryFieldS64LE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS64LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15006,6 +15977,9 @@ func (d *D) FieldScalarS8BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS8BE tries to add a field and read 8 bit signed integer in big-endian
 func (d *D) TryFieldS8BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS8BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15053,6 +16027,9 @@ func (d *D) FieldScalarS9BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS9BE tries to add a field and read 9 bit signed integer in big-endian
 func (d *D) TryFieldS9BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS9BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15100,6 +16077,9 @@ func (d *D) FieldScalarS10BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS10BE tries to add a field and read 10 bit signed integer in big-endian
 func (d *D) TryFieldS10BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS10BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15147,6 +16127,9 @@ func (d *D) FieldScalarS11BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS11BE tries to add a field and read 11 bit signed integer in big-endian
 func (d *D) TryFieldS11BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS11BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15194,6 +16177,9 @@ func (d *D) FieldScalarS12BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS12BE tries to add a field and read 12 bit signed integer in big-endian
 func (d *D) TryFieldS12BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS12BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15241,6 +16227,9 @@ func (d *D) FieldScalarS13BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS13BE tries to add a field and read 13 bit signed integer in big-endian
 func (d *D) TryFieldS13BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS13BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15288,6 +16277,9 @@ func (d *D) FieldScalarS14BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS14BE tries to add a field and read 14 bit signed integer in big-endian
 func (d *D) TryFieldS14BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS14BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15335,6 +16327,9 @@ func (d *D) FieldScalarS15BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS15BE tries to add a field and read 15 bit signed integer in big-endian
 func (d *D) TryFieldS15BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS15BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15382,6 +16377,9 @@ func (d *D) FieldScalarS16BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS16BE tries to add a field and read 16 bit signed integer in big-endian
 func (d *D) TryFieldS16BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS16BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15429,6 +16427,9 @@ func (d *D) FieldScalarS17BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS17BE tries to add a field and read 17 bit signed integer in big-endian
 func (d *D) TryFieldS17BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS17BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15476,6 +16477,9 @@ func (d *D) FieldScalarS18BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS18BE tries to add a field and read 18 bit signed integer in big-endian
 func (d *D) TryFieldS18BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS18BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15523,6 +16527,9 @@ func (d *D) FieldScalarS19BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS19BE tries to add a field and read 19 bit signed integer in big-endian
 func (d *D) TryFieldS19BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS19BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15570,6 +16577,9 @@ func (d *D) FieldScalarS20BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS20BE tries to add a field and read 20 bit signed integer in big-endian
 func (d *D) TryFieldS20BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS20BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15617,6 +16627,9 @@ func (d *D) FieldScalarS21BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS21BE tries to add a field and read 21 bit signed integer in big-endian
 func (d *D) TryFieldS21BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS21BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15664,6 +16677,9 @@ func (d *D) FieldScalarS22BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS22BE tries to add a field and read 22 bit signed integer in big-endian
 func (d *D) TryFieldS22BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS22BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15711,6 +16727,9 @@ func (d *D) FieldScalarS23BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS23BE tries to add a field and read 23 bit signed integer in big-endian
 func (d *D) TryFieldS23BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS23BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15758,6 +16777,9 @@ func (d *D) FieldScalarS24BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS24BE tries to add a field and read 24 bit signed integer in big-endian
 func (d *D) TryFieldS24BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS24BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15805,6 +16827,9 @@ func (d *D) FieldScalarS25BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS25BE tries to add a field and read 25 bit signed integer in big-endian
 func (d *D) TryFieldS25BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS25BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15852,6 +16877,9 @@ func (d *D) FieldScalarS26BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS26BE tries to add a field and read 26 bit signed integer in big-endian
 func (d *D) TryFieldS26BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS26BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15899,8 +16927,11 @@ func (d *D) FieldScalarS27BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS27BE tries to add a field and read 27 bit signed integer in big-endian
 func (d *D) TryFieldS27BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS27BE(name, sms...)
-	return s.ActualS(), err
-}
+	if err != nil {
+		return 0, err
+	}
+	return s.ActualS(), err
+}
 
 // FieldS27BE adds a field and reads 27 bit signed integer in big-endian
 func (d *D) FieldS27BE(name string, sms ...scalar.Mapper) int64 {
@@ -15946,6 +16977,9 @@ func (d *D) FieldScalarS28BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS28BE tries to add a field and read 28 bit signed integer in big-endian
 func (d *D) TryFieldS28BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS28BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -15993,6 +17027,9 @@ func (d *D) FieldScalarS29BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS29BE tries to add a field and read 29 bit signed integer in big-endian
 func (d *D) TryFieldS29BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS29BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16040,6 +17077,9 @@ func (d *D) FieldScalarS30BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS30BE tries to add a field and read 30 bit signed integer in big-endian
 func (d *D) TryFieldS30BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS30BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16087,6 +17127,9 @@ func (d *D) FieldScalarS31BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS31BE tries to add a field and read 31 bit signed integer in big-endian
 func (d *D) TryFieldS31BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS31BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16134,6 +17177,9 @@ func (d *D) FieldScalarS32BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS32BE tries to add a field and read 32 bit signed integer in big-endian
 func (d *D) TryFieldS32BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS32BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16181,6 +17227,9 @@ func (d *D) FieldScalarS33BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS33BE tries to add a field and read 33 bit signed integer in big-endian
 func (d *D) TryFieldS33BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS33BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16228,6 +17277,9 @@ func (d *D) FieldScalarS34BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS34BE tries to add a field and read 34 bit signed integer in big-endian
 func (d *D) TryFieldS34BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS34BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16275,6 +17327,9 @@ func (d *D) FieldScalarS35BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS35BE tries to add a field and read 35 bit signed integer in big-endian
 func (d *D) TryFieldS35BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS35BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16322,6 +17377,9 @@ func (d *D) FieldScalarS36BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS36BE tries to add a field and read 36 bit signed integer in big-endian
 func (d *D) TryFieldS36BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS36BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16369,6 +17427,9 @@ func (d *D) FieldScalarS37BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS37BE tries to add a field and read 37 bit signed integer in big-endian
 func (d *D) TryFieldS37BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS37BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16416,6 +17477,9 @@ func (d *D) FieldScalarS38BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS38BE tries to add a field and read 38 bit signed integer in big-endian
 func (d *D) TryFieldS38BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS38BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16463,6 +17527,9 @@ func (d *D) FieldScalarS39BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS39BE tries to add a field and read 39 bit signed integer in big-endian
 func (d *D) TryFieldS39BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS39BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16510,6 +17577,9 @@ func (d *D) FieldScalarS40BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS40BE tries to add a field and read 40 bit signed integer in big-endian
 func (d *D) TryFieldS40BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS40BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16557,6 +17627,9 @@ func (d *D) FieldScalarS41BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS41BE tries to add a field and read 41 bit signed integer in big-endian
 func (d *D) TryFieldS41BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS41BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16604,6 +17677,9 @@ func (d *D) FieldScalarS42BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS42BE tries to add a field and read 42 bit signed integer in big-endian
 func (d *D) TryFieldS42BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS42BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16651,6 +17727,9 @@ func (d *D) FieldScalarS43BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS43BE tries to add a field and read 43 bit signed integer in big-endian
 func (d *D) TryFieldS43BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS43BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16698,6 +17777,9 @@ func (d *D) FieldScalarS44BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS44BE tries to add a field and read 44 bit signed integer in big-endian
 func (d *D) TryFieldS44BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS44BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16745,6 +17827,9 @@ func (d *D) FieldScalarS45BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS45BE tries to add a field and read 45 bit signed integer in big-endian
 func (d *D) TryFieldS45BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS45BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16792,6 +17877,9 @@ func (d *D) FieldScalarS46BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS46BE tries to add a field and read 46 bit signed integer in big-endian
 func (d *D) TryFieldS46BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS46BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16839,6 +17927,9 @@ func (d *D) FieldScalarS47BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS47BE tries to add a field and read 47 bit signed integer in big-endian
 func (d *D) TryFieldS47BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS47BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16886,6 +17977,9 @@ func (d *D) FieldScalarS48BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS48BE tries to add a field and read 48 bit signed integer in big-endian
 func (d *D) TryFieldS48BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS48BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16933,6 +18027,9 @@ func (d *D) FieldScalarS49BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS49BE tries to add a field and read 49 bit signed integer in big-endian
 func (d *D) TryFieldS49BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS49BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -16980,6 +18077,9 @@ func (d *D) FieldScalarS50BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS50BE tries to add a field and read 50 bit signed integer in big-endian
 func (d *D) TryFieldS50BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS50BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17027,6 +18127,9 @@ func (d *D) FieldScalarS51BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS51BE tries to add a field and read 51 bit signed integer in big-endian
 func (d *D) TryFieldS51BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS51BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17074,6 +18177,9 @@ func (d *D) FieldScalarS52BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS52BE tries to add a field and read 52 bit signed integer in big-endian
 func (d *D) TryFieldS52BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS52BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17121,6 +18227,9 @@ func (d *D) FieldScalarS53BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS53BE tries to add a field and read 53 bit signed integer in big-endian
 func (d *D) TryFieldS53BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS53BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17168,6 +18277,9 @@ func (d *D) FieldScalarS54BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS54BE tries to add a field and read 54 bit signed integer in big-endian
 func (d *D) TryFieldS54BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS54BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17215,6 +18327,9 @@ func (d *D) FieldScalarS55BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS55BE tries to add a field and read 55 bit signed integer in big-endian
 func (d *D) TryFieldS55BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS55BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17262,6 +18377,9 @@ func (d *D) FieldScalarS56BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS56BE tries to add a field and read 56 bit signed integer in big-endian
 func (d *D) TryFieldS56BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS56BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17309,6 +18427,9 @@ func (d *D) FieldScalarS57BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS57BE tries to add a field and read 57 bit signed integer in big-endian
 func (d *D) TryFieldS57BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS57BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17356,6 +18477,9 @@ func (d *D) FieldScalarS58BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS58BE tries to add a field and read 58 bit signed integer in big-endian
 func (d *D) TryFieldS58BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS58BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17403,6 +18527,9 @@ func (d *D) FieldScalarS59BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS59BE tries to add a field and read 59 bit signed integer in big-endian
 func (d *D) TryFieldS59BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS59BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17450,6 +18577,9 @@ func (d *D) FieldScalarS60BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS60BE tries to add a field and read 60 bit signed integer in big-endian
 func (d *D) TryFieldS60BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS60BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17497,6 +18627,9 @@ func (d *D) FieldScalarS61BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS61BE tries to add a field and read 61 bit signed integer in big-endian
 func (d *D) TryFieldS61BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS61BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17544,6 +18677,9 @@ func (d *D) FieldScalarS62BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS62BE tries to add a field and read 62 bit signed integer in big-endian
 func (d *D) TryFieldS62BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS62BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17591,6 +18727,9 @@ func (d *D) FieldScalarS63BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS63BE tries to add a field and read 63 bit signed integer in big-endian
 func (d *D) TryFieldS63BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS63BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17638,6 +18777,9 @@ func (d *D) FieldScalarS64BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldS64BE tries to add a field and read 64 bit signed integer in big-endian
 func (d *D) TryFieldS64BE(name string, sms ...scalar.Mapper) (int64, error) {
 	s, err := d.TryFieldScalarS64BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualS(), err
 }
 
@@ -17685,6 +18827,9 @@ func (d *D) FieldScalarF(name string, nBits int, sms ...scalar.Mapper) *scalar.S
 // TryFieldF tries to add a field and read nBit IEEE 754 float in current endian
 func (d *D) TryFieldF(name string, nBits int, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF(name, nBits, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -17732,6 +18877,9 @@ func (d *D) FieldScalarFE(name string, nBits int, endian Endian, sms ...scalar.M
 // TryFieldFE tries to add a field and read nBit IEEE 754 float in specified endian
 func (d *D) TryFieldFE(name string, nBits int, endian Endian, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFE(name, nBits, endian, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -17779,6 +18927,9 @@ func (d *D) FieldScalarF16(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF16 tries to add a field and read 16 bit IEEE 754 float in current endian
 func (d *D) TryFieldF16(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF16(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -17826,6 +18977,9 @@ func (d *D) FieldScalarF32(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF32 tries to add a field and read 32 bit IEEE 754 float in current endian
 func (d *D) TryFieldF32(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF32(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -17873,6 +19027,9 @@ func (d *D) FieldScalarF64(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF64 tries to add a field and read 64 bit IEEE 754 float in current endian
 func (d *D) TryFieldF64(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF64(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -17920,6 +19077,9 @@ func (d *D) FieldScalarF16LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF16LE tries to add a field and read 16 bit IEEE 754 float in little-endian
 func (d *D) TryFieldF16LE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF16LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -17967,6 +19127,9 @@ func (d *D) FieldScalarF32LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF32LE tries to add a field and read 32 bit IEEE 754 float in little-endian
 func (d *D) TryFieldF32LE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF32LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -18014,6 +19177,9 @@ func (d *D) FieldScalarF64LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF64LE tries to add a field and read 64 bit IEEE 754 float in little-endian
 func (d *D) TryFieldF64LE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF64LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -18061,6 +19227,9 @@ func (d *D) FieldScalarF16BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF16BE tries to add a field and read 16 bit IEEE 754 float in big-endian
 func (d *D) TryFieldF16BE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF16BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -18108,6 +19277,9 @@ func (d *D) FieldScalarF32BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF32BE tries to add a field and read 32 bit IEEE 754 float in big-endian
 func (d *D) TryFieldF32BE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF32BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -18155,6 +19327,9 @@ func (d *D) FieldScalarF64BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldF64BE tries to add a field and read 64 bit IEEE 754 float in big-endian
 func (d *D) TryFieldF64BE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarF64BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualF(), err
 }
 
@@ -18166,22 +19341,29 @@ func (d *D) FieldF64BE(name string, sms ...scalar.Mapper) float64 {
 // Reader FP
 
 // TryFP tries to read nBits fixed-point number in current endian
-func (d *D) TryFP(nBits int, fBits int) (float64, error) { return d.tryFPE(nBits, fBits, d.Endian) }
+func (d *D) TryFP(nBits int, fBits int) (float64, error) {
+	s, err := d.tryFPScalarE(nBits, fBits, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP reads nBits fixed-point number in current endian
 func (d *D) FP(nBits int, fBits int) float64 {
-	v, err := d.tryFPE(nBits, fBits, d.Endian)
+	v, err := d.tryFPScalarE(nBits, fBits, d.Endian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP tries to add a field and read nBits fixed-point number in current endian
 func (d *D) TryFieldScalarFP(name string, nBits int, fBits int, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(nBits, fBits, d.Endian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(nBits, fBits, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18202,35 +19384,43 @@ func (d *D) FieldScalarFP(name string, nBits int, fBits int, sms ...scalar.Mappe
 // TryFieldFP tries to add a field and read nBits fixed-point number in current endian
 func (d *D) TryFieldFP(name string, nBits int, fBits int, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP(name, nBits, fBits, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP adds a field and reads nBits fixed-point number in current endian
 func (d *D) FieldFP(name string, nBits int, fBits int, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP(name, nBits, fBits, sms...).ActualF()
+	return d.FieldScalarFP(name, nBits, fBits, sms...).SymF()
 }
 
 // Reader FPE
 
 // TryFPE tries to read nBits fixed-point number in specified endian
 func (d *D) TryFPE(nBits int, fBits int, endian Endian) (float64, error) {
-	return d.tryFPE(nBits, fBits, endian)
+	s, err := d.tryFPScalarE(nBits, fBits, endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
 }
 
 // FPE reads nBits fixed-point number in specified endian
 func (d *D) FPE(nBits int, fBits int, endian Endian) float64 {
-	v, err := d.tryFPE(nBits, fBits, endian)
+	v, err := d.tryFPScalarE(nBits, fBits, endian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FPE", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFPE tries to add a field and read nBits fixed-point number in specified endian
 func (d *D) TryFieldScalarFPE(name string, nBits int, fBits int, endian Endian, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(nBits, fBits, endian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(nBits, fBits, endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18251,33 +19441,43 @@ func (d *D) FieldScalarFPE(name string, nBits int, fBits int, endian Endian, sms
 // TryFieldFPE tries to add a field and read nBits fixed-point number in specified endian
 func (d *D) TryFieldFPE(name string, nBits int, fBits int, endian Endian, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFPE(name, nBits, fBits, endian, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFPE adds a field and reads nBits fixed-point number in specified endian
 func (d *D) FieldFPE(name string, nBits int, fBits int, endian Endian, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFPE(name, nBits, fBits, endian, sms...).ActualF()
+	return d.FieldScalarFPE(name, nBits, fBits, endian, sms...).SymF()
 }
 
 // Reader FP16
 
 // TryFP16 tries to read 16 bit fixed-point number in current endian
-func (d *D) TryFP16() (float64, error) { return d.tryFPE(16, 8, d.Endian) }
+func (d *D) TryFP16() (float64, error) {
+	s, err := d.tryFPScalarE(16, 8, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP16 reads 16 bit fixed-point number in current endian
 func (d *D) FP16() float64 {
-	v, err := d.tryFPE(16, 8, d.Endian)
+	v, err := d.tryFPScalarE(16, 8, d.Endian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP16", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP16 tries to add a field and read 16 bit fixed-point number in current endian
 func (d *D) TryFieldScalarFP16(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(16, 8, d.Endian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(16, 8, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18298,33 +19498,43 @@ func (d *D) FieldScalarFP16(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP16 tries to add a field and read 16 bit fixed-point number in current endian
 func (d *D) TryFieldFP16(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP16(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP16 adds a field and reads 16 bit fixed-point number in current endian
 func (d *D) FieldFP16(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP16(name, sms...).ActualF()
+	return d.FieldScalarFP16(name, sms...).SymF()
 }
 
 // Reader FP32
 
 // TryFP32 tries to read 32 bit fixed-point number in current endian
-func (d *D) TryFP32() (float64, error) { return d.tryFPE(32, 16, d.Endian) }
+func (d *D) TryFP32() (float64, error) {
+	s, err := d.tryFPScalarE(32, 16, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP32 reads 32 bit fixed-point number in current endian
 func (d *D) FP32() float64 {
-	v, err := d.tryFPE(32, 16, d.Endian)
+	v, err := d.tryFPScalarE(32, 16, d.Endian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP32", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP32 tries to add a field and read 32 bit fixed-point number in current endian
 func (d *D) TryFieldScalarFP32(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(32, 16, d.Endian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(32, 16, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18345,33 +19555,43 @@ func (d *D) FieldScalarFP32(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP32 tries to add a field and read 32 bit fixed-point number in current endian
 func (d *D) TryFieldFP32(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP32(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP32 adds a field and reads 32 bit fixed-point number in current endian
 func (d *D) FieldFP32(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP32(name, sms...).ActualF()
+	return d.FieldScalarFP32(name, sms...).SymF()
 }
 
 // Reader FP64
 
 // TryFP64 tries to read 64 bit fixed-point number in current endian
-func (d *D) TryFP64() (float64, error) { return d.tryFPE(64, 32, d.Endian) }
+func (d *D) TryFP64() (float64, error) {
+	s, err := d.tryFPScalarE(64, 32, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP64 reads 64 bit fixed-point number in current endian
 func (d *D) FP64() float64 {
-	v, err := d.tryFPE(64, 32, d.Endian)
+	v, err := d.tryFPScalarE(64, 32, d.Endian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP64", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP64 tries to add a field and read 64 bit fixed-point number in current endian
 func (d *D) TryFieldScalarFP64(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(64, 32, d.Endian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(64, 32, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18392,33 +19612,43 @@ func (d *D) FieldScalarFP64(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP64 tries to add a field and read 64 bit fixed-point number in current endian
 func (d *D) TryFieldFP64(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP64(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP64 adds a field and reads 64 bit fixed-point number in current endian
 func (d *D) FieldFP64(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP64(name, sms...).ActualF()
+	return d.FieldScalarFP64(name, sms...).SymF()
 }
 
 // Reader FP16LE
 
 // TryFP16LE tries to read 16 bit fixed-point number in little-endian
-func (d *D) TryFP16LE() (float64, error) { return d.tryFPE(16, 8, LittleEndian) }
+func (d *D) TryFP16LE() (float64, error) {
+	s, err := d.tryFPScalarE(16, 8, LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP16LE reads 16 bit fixed-point number in little-endian
 func (d *D) FP16LE() float64 {
-	v, err := d.tryFPE(16, 8, LittleEndian)
+	v, err := d.tryFPScalarE(16, 8, LittleEndian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP16LE", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP16LE tries to add a field and read 16 bit fixed-point number in little-endian
 func (d *D) TryFieldScalarFP16LE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(16, 8, LittleEndian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(16, 8, LittleEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18439,33 +19669,43 @@ func (d *D) FieldScalarFP16LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP16LE tries to add a field and read 16 bit fixed-point number in little-endian
 func (d *D) TryFieldFP16LE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP16LE(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP16LE adds a field and reads 16 bit fixed-point number in little-endian
 func (d *D) FieldFP16LE(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP16LE(name, sms...).ActualF()
+	return d.FieldScalarFP16LE(name, sms...).SymF()
 }
 
 // Reader FP32LE
 
 // TryFP32LE tries to read 32 bit fixed-point number in little-endian
-func (d *D) TryFP32LE() (float64, error) { return d.tryFPE(32, 16, LittleEndian) }
+func (d *D) TryFP32LE() (float64, error) {
+	s, err := d.tryFPScalarE(32, 16, LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP32LE reads 32 bit fixed-point number in little-endian
 func (d *D) FP32LE() float64 {
-	v, err := d.tryFPE(32, 16, LittleEndian)
+	v, err := d.tryFPScalarE(32, 16, LittleEndian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP32LE", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP32LE tries to add a field and read 32 bit fixed-point number in little-endian
 func (d *D) TryFieldScalarFP32LE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(32, 16, LittleEndian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(32, 16, LittleEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18486,33 +19726,43 @@ func (d *D) FieldScalarFP32LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP32LE tries to add a field and read 32 bit fixed-point number in little-endian
 func (d *D) TryFieldFP32LE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP32LE(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP32LE adds a field and reads 32 bit fixed-point number in little-endian
 func (d *D) FieldFP32LE(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP32LE(name, sms...).ActualF()
+	return d.FieldScalarFP32LE(name, sms...).SymF()
 }
 
 // Reader FP64LE
 
 // TryFP64LE tries to read 64 bit fixed-point number in little-endian
-func (d *D) TryFP64LE() (float64, error) { return d.tryFPE(64, 32, LittleEndian) }
+func (d *D) TryFP64LE() (float64, error) {
+	s, err := d.tryFPScalarE(64, 32, LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP64LE reads 64 bit fixed-point number in little-endian
 func (d *D) FP64LE() float64 {
-	v, err := d.tryFPE(64, 32, LittleEndian)
+	v, err := d.tryFPScalarE(64, 32, LittleEndian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP64LE", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP64LE tries to add a field and read 64 bit fixed-point number in little-endian
 func (d *D) TryFieldScalarFP64LE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(64, 32, LittleEndian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(64, 32, LittleEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18533,33 +19783,43 @@ func (d *D) FieldScalarFP64LE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP64LE tries to add a field and read 64 bit fixed-point number in little-endian
 func (d *D) TryFieldFP64LE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP64LE(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP64LE adds a field and reads 64 bit fixed-point number in little-endian
 func (d *D) FieldFP64LE(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP64LE(name, sms...).ActualF()
+	return d.FieldScalarFP64LE(name, sms...).SymF()
 }
 
 // Reader FP16BE
 
 // TryFP16BE tries to read 16 bit fixed-point number in big-endian
-func (d *D) TryFP16BE() (float64, error) { return d.tryFPE(16, 8, BigEndian) }
+func (d *D) TryFP16BE() (float64, error) {
+	s, err := d.tryFPScalarE(16, 8, BigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP16BE reads 16 bit fixed-point number in big-endian
 func (d *D) FP16BE() float64 {
-	v, err := d.tryFPE(16, 8, BigEndian)
+	v, err := d.tryFPScalarE(16, 8, BigEndian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP16BE", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP16BE tries to add a field and read 16 bit fixed-point number in big-endian
 func (d *D) TryFieldScalarFP16BE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(16, 8, BigEndian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(16, 8, BigEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18580,33 +19840,43 @@ func (d *D) FieldScalarFP16BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP16BE tries to add a field and read 16 bit fixed-point number in big-endian
 func (d *D) TryFieldFP16BE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP16BE(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP16BE adds a field and reads 16 bit fixed-point number in big-endian
 func (d *D) FieldFP16BE(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP16BE(name, sms...).ActualF()
+	return d.FieldScalarFP16BE(name, sms...).SymF()
 }
 
 // Reader FP32BE
 
 // TryFP32BE tries to read 32 bit fixed-point number in big-endian
-func (d *D) TryFP32BE() (float64, error) { return d.tryFPE(32, 16, BigEndian) }
+func (d *D) TryFP32BE() (float64, error) {
+	s, err := d.tryFPScalarE(32, 16, BigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP32BE reads 32 bit fixed-point number in big-endian
 func (d *D) FP32BE() float64 {
-	v, err := d.tryFPE(32, 16, BigEndian)
+	v, err := d.tryFPScalarE(32, 16, BigEndian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP32BE", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP32BE tries to add a field and read 32 bit fixed-point number in big-endian
 func (d *D) TryFieldScalarFP32BE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(32, 16, BigEndian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(32, 16, BigEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18627,33 +19897,43 @@ func (d *D) FieldScalarFP32BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP32BE tries to add a field and read 32 bit fixed-point number in big-endian
 func (d *D) TryFieldFP32BE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP32BE(name, sms...)
-	return s.ActualF(), err
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
 }
 
 // FieldFP32BE adds a field and reads 32 bit fixed-point number in big-endian
 func (d *D) FieldFP32BE(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP32BE(name, sms...).ActualF()
+	return d.FieldScalarFP32BE(name, sms...).SymF()
 }
 
 // Reader FP64BE
 
 // TryFP64BE tries to read 64 bit fixed-point number in big-endian
-func (d *D) TryFP64BE() (float64, error) { return d.tryFPE(64, 32, BigEndian) }
+func (d *D) TryFP64BE() (float64, error) {
+	s, err := d.tryFPScalarE(64, 32, BigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
 
 // FP64BE reads 64 bit fixed-point number in big-endian
 func (d *D) FP64BE() float64 {
-	v, err := d.tryFPE(64, 32, BigEndian)
+	v, err := d.tryFPScalarE(64, 32, BigEndian)
 	if err != nil {
 		panic(IOError{Err: err, Op: "FP64BE", Pos: d.Pos()})
 	}
-	return v
+	return v.SymF()
 }
 
 // TryFieldScalarFP64BE tries to add a field and read 64 bit fixed-point number in big-endian
 func (d *D) TryFieldScalarFP64BE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
 	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
-		v, err := d.tryFPE(64, 32, BigEndian)
-		s.Actual = v
+		v, err := d.tryFPScalarE(64, 32, BigEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
 		return s, err
 	}, sms...)
 	if err != nil {
@@ -18674,12 +19954,642 @@ func (d *D) FieldScalarFP64BE(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldFP64BE tries to add a field and read 64 bit fixed-point number in big-endian
 func (d *D) TryFieldFP64BE(name string, sms ...scalar.Mapper) (float64, error) {
 	s, err := d.TryFieldScalarFP64BE(name, sms...)
-	return s.ActualF(), err
-}
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
 
 // FieldFP64BE adds a field and reads 64 bit fixed-point number in big-endian
 func (d *D) FieldFP64BE(name string, sms ...scalar.Mapper) float64 {
-	return d.FieldScalarFP64BE(name, sms...).ActualF()
+	return d.FieldScalarFP64BE(name, sms...).SymF()
+}
+
+// Reader SFP
+
+// TrySFP tries to read nBits signed fixed-point number in current endian
+func (d *D) TrySFP(nBits int, fBits int) (float64, error) {
+	s, err := d.trySFPScalarE(nBits, fBits, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP reads nBits signed fixed-point number in current endian
+func (d *D) SFP(nBits int, fBits int) float64 {
+	v, err := d.trySFPScalarE(nBits, fBits, d.Endian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP tries to add a field and read nBits signed fixed-point number in current endian
+func (d *D) TryFieldScalarSFP(name string, nBits int, fBits int, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(nBits, fBits, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP adds a field and reads nBits signed fixed-point number in current endian
+func (d *D) FieldScalarSFP(name string, nBits int, fBits int, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP(name, nBits, fBits, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP tries to add a field and read nBits signed fixed-point number in current endian
+func (d *D) TryFieldSFP(name string, nBits int, fBits int, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP(name, nBits, fBits, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP adds a field and reads nBits signed fixed-point number in current endian
+func (d *D) FieldSFP(name string, nBits int, fBits int, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP(name, nBits, fBits, sms...).SymF()
+}
+
+// Reader SFPE
+
+// TrySFPE tries to read nBits signed fixed-point number in specified endian
+func (d *D) TrySFPE(nBits int, fBits int, endian Endian) (float64, error) {
+	s, err := d.trySFPScalarE(nBits, fBits, endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFPE reads nBits signed fixed-point number in specified endian
+func (d *D) SFPE(nBits int, fBits int, endian Endian) float64 {
+	v, err := d.trySFPScalarE(nBits, fBits, endian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFPE", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFPE tries to add a field and read nBits signed fixed-point number in specified endian
+func (d *D) TryFieldScalarSFPE(name string, nBits int, fBits int, endian Endian, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(nBits, fBits, endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFPE adds a field and reads nBits signed fixed-point number in specified endian
+func (d *D) FieldScalarSFPE(name string, nBits int, fBits int, endian Endian, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFPE(name, nBits, fBits, endian, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFPE", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFPE tries to add a field and read nBits signed fixed-point number in specified endian
+func (d *D) TryFieldSFPE(name string, nBits int, fBits int, endian Endian, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFPE(name, nBits, fBits, endian, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFPE adds a field and reads nBits signed fixed-point number in specified endian
+func (d *D) FieldSFPE(name string, nBits int, fBits int, endian Endian, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFPE(name, nBits, fBits, endian, sms...).SymF()
+}
+
+// Reader SFP16
+
+// TrySFP16 tries to read 16 bit signed fixed-point number in current endian
+func (d *D) TrySFP16() (float64, error) {
+	s, err := d.trySFPScalarE(16, 8, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP16 reads 16 bit signed fixed-point number in current endian
+func (d *D) SFP16() float64 {
+	v, err := d.trySFPScalarE(16, 8, d.Endian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP16", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP16 tries to add a field and read 16 bit signed fixed-point number in current endian
+func (d *D) TryFieldScalarSFP16(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(16, 8, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP16 adds a field and reads 16 bit signed fixed-point number in current endian
+func (d *D) FieldScalarSFP16(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP16(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP16", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP16 tries to add a field and read 16 bit signed fixed-point number in current endian
+func (d *D) TryFieldSFP16(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP16(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP16 adds a field and reads 16 bit signed fixed-point number in current endian
+func (d *D) FieldSFP16(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP16(name, sms...).SymF()
+}
+
+// Reader SFP32
+
+// TrySFP32 tries to read 32 bit signed fixed-point number in current endian
+func (d *D) TrySFP32() (float64, error) {
+	s, err := d.trySFPScalarE(32, 16, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP32 reads 32 bit signed fixed-point number in current endian
+func (d *D) SFP32() float64 {
+	v, err := d.trySFPScalarE(32, 16, d.Endian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP32", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP32 tries to add a field and read 32 bit signed fixed-point number in current endian
+func (d *D) TryFieldScalarSFP32(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(32, 16, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP32 adds a field and reads 32 bit signed fixed-point number in current endian
+func (d *D) FieldScalarSFP32(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP32(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP32", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP32 tries to add a field and read 32 bit signed fixed-point number in current endian
+func (d *D) TryFieldSFP32(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP32(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP32 adds a field and reads 32 bit signed fixed-point number in current endian
+func (d *D) FieldSFP32(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP32(name, sms...).SymF()
+}
+
+// Reader SFP64
+
+// TrySFP64 tries to read 64 bit signed fixed-point number in current endian
+func (d *D) TrySFP64() (float64, error) {
+	s, err := d.trySFPScalarE(64, 32, d.Endian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP64 reads 64 bit signed fixed-point number in current endian
+func (d *D) SFP64() float64 {
+	v, err := d.trySFPScalarE(64, 32, d.Endian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP64", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP64 tries to add a field and read 64 bit signed fixed-point number in current endian
+func (d *D) TryFieldScalarSFP64(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(64, 32, d.Endian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP64 adds a field and reads 64 bit signed fixed-point number in current endian
+func (d *D) FieldScalarSFP64(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP64(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP64", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP64 tries to add a field and read 64 bit signed fixed-point number in current endian
+func (d *D) TryFieldSFP64(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP64(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP64 adds a field and reads 64 bit signed fixed-point number in current endian
+func (d *D) FieldSFP64(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP64(name, sms...).SymF()
+}
+
+// Reader SFP16LE
+
+// TrySFP16LE tries to read 16 bit signed fixed-point number in little-endian
+func (d *D) TrySFP16LE() (float64, error) {
+	s, err := d.trySFPScalarE(16, 8, LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP16LE reads 16 bit signed fixed-point number in little-endian
+func (d *D) SFP16LE() float64 {
+	v, err := d.trySFPScalarE(16, 8, LittleEndian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP16LE", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP16LE tries to add a field and read 16 bit signed fixed-point number in little-endian
+func (d *D) TryFieldScalarSFP16LE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(16, 8, LittleEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP16LE adds a field and reads 16 bit signed fixed-point number in little-endian
+func (d *D) FieldScalarSFP16LE(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP16LE(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP16LE", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP16LE tries to add a field and read 16 bit signed fixed-point number in little-endian
+func (d *D) TryFieldSFP16LE(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP16LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP16LE adds a field and reads 16 bit signed fixed-point number in little-endian
+func (d *D) FieldSFP16LE(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP16LE(name, sms...).SymF()
+}
+
+// Reader SFP32LE
+
+// TrySFP32LE tries to read 32 bit signed fixed-point number in little-endian
+func (d *D) TrySFP32LE() (float64, error) {
+	s, err := d.trySFPScalarE(32, 16, LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP32LE reads 32 bit signed fixed-point number in little-endian
+func (d *D) SFP32LE() float64 {
+	v, err := d.trySFPScalarE(32, 16, LittleEndian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP32LE", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP32LE tries to add a field and read 32 bit signed fixed-point number in little-endian
+func (d *D) TryFieldScalarSFP32LE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(32, 16, LittleEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP32LE adds a field and reads 32 bit signed fixed-point number in little-endian
+func (d *D) FieldScalarSFP32LE(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP32LE(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP32LE", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP32LE tries to add a field and read 32 bit signed fixed-point number in little-endian
+func (d *D) TryFieldSFP32LE(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP32LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP32LE adds a field and reads 32 bit signed fixed-point number in little-endian
+func (d *D) FieldSFP32LE(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP32LE(name, sms...).SymF()
+}
+
+// Reader SFP64LE
+
+// TrySFP64LE tries to read 64 bit signed fixed-point number in little-endian
+func (d *D) TrySFP64LE() (float64, error) {
+	s, err := d.trySFPScalarE(64, 32, LittleEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP64LE reads 64 bit signed fixed-point number in little-endian
+func (d *D) SFP64LE() float64 {
+	v, err := d.trySFPScalarE(64, 32, LittleEndian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP64LE", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP64LE tries to add a field and read 64 bit signed fixed-point number in little-endian
+func (d *D) TryFieldScalarSFP64LE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(64, 32, LittleEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP64LE adds a field and reads 64 bit signed fixed-point number in little-endian
+func (d *D) FieldScalarSFP64LE(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP64LE(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP64LE", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP64LE tries to add a field and read 64 bit signed fixed-point number in little-endian
+func (d *D) TryFieldSFP64LE(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP64LE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP64LE adds a field and reads 64 bit signed fixed-point number in little-endian
+func (d *D) FieldSFP64LE(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP64LE(name, sms...).SymF()
+}
+
+// Reader SFP16BE
+
+// TrySFP16BE tries to read 16 bit signed fixed-point number in big-endian
+func (d *D) TrySFP16BE() (float64, error) {
+	s, err := d.trySFPScalarE(16, 8, BigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP16BE reads 16 bit signed fixed-point number in big-endian
+func (d *D) SFP16BE() float64 {
+	v, err := d.trySFPScalarE(16, 8, BigEndian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP16BE", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP16BE tries to add a field and read 16 bit signed fixed-point number in big-endian
+func (d *D) TryFieldScalarSFP16BE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(16, 8, BigEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP16BE adds a field and reads 16 bit signed fixed-point number in big-endian
+func (d *D) FieldScalarSFP16BE(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP16BE(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP16BE", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP16BE tries to add a field and read 16 bit signed fixed-point number in big-endian
+func (d *D) TryFieldSFP16BE(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP16BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP16BE adds a field and reads 16 bit signed fixed-point number in big-endian
+func (d *D) FieldSFP16BE(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP16BE(name, sms...).SymF()
+}
+
+// Reader SFP32BE
+
+// TrySFP32BE tries to read 32 bit signed fixed-point number in big-endian
+func (d *D) TrySFP32BE() (float64, error) {
+	s, err := d.trySFPScalarE(32, 16, BigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP32BE reads 32 bit signed fixed-point number in big-endian
+func (d *D) SFP32BE() float64 {
+	v, err := d.trySFPScalarE(32, 16, BigEndian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP32BE", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP32BE tries to add a field and read 32 bit signed fixed-point number in big-endian
+func (d *D) TryFieldScalarSFP32BE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(32, 16, BigEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP32BE adds a field and reads 32 bit signed fixed-point number in big-endian
+func (d *D) FieldScalarSFP32BE(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP32BE(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP32BE", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP32BE tries to add a field and read 32 bit signed fixed-point number in big-endian
+func (d *D) TryFieldSFP32BE(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP32BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP32BE adds a field and reads 32 bit signed fixed-point number in big-endian
+func (d *D) FieldSFP32BE(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP32BE(name, sms...).SymF()
+}
+
+// Reader SFP64BE
+
+// TrySFP64BE tries to read 64 bit signed fixed-point number in big-endian
+func (d *D) TrySFP64BE() (float64, error) {
+	s, err := d.trySFPScalarE(64, 32, BigEndian)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), nil
+}
+
+// SFP64BE reads 64 bit signed fixed-point number in big-endian
+func (d *D) SFP64BE() float64 {
+	v, err := d.trySFPScalarE(64, 32, BigEndian)
+	if err != nil {
+		panic(IOError{Err: err, Op: "SFP64BE", Pos: d.Pos()})
+	}
+	return v.SymF()
+}
+
+// TryFieldScalarSFP64BE tries to add a field and read 64 bit signed fixed-point number in big-endian
+func (d *D) TryFieldScalarSFP64BE(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySFPScalarE(64, 32, BigEndian)
+		s.Actual = v.Actual
+		s.Sym = v.Sym
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSFP64BE adds a field and reads 64 bit signed fixed-point number in big-endian
+func (d *D) FieldScalarSFP64BE(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSFP64BE(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SFP64BE", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSFP64BE tries to add a field and read 64 bit signed fixed-point number in big-endian
+func (d *D) TryFieldSFP64BE(name string, sms ...scalar.Mapper) (float64, error) {
+	s, err := d.TryFieldScalarSFP64BE(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.SymF(), err
+}
+
+// FieldSFP64BE adds a field and reads 64 bit signed fixed-point number in big-endian
+func (d *D) FieldSFP64BE(name string, sms ...scalar.Mapper) float64 {
+	return d.FieldScalarSFP64BE(name, sms...).SymF()
 }
 
 // Reader Unary
@@ -18721,6 +20631,9 @@ func (d *D) FieldScalarUnary(name string, ov uint64, sms ...scalar.Mapper) *scal
 // TryFieldUnary tries to add a field and read unary integer using ov as "one" value
 func (d *D) TryFieldUnary(name string, ov uint64, sms ...scalar.Mapper) (uint64, error) {
 	s, err := d.TryFieldScalarUnary(name, ov, sms...)
+	if err != nil {
+		return 0, err
+	}
 	return s.ActualU(), err
 }
 
@@ -18729,6 +20642,106 @@ func (d *D) FieldUnary(name string, ov uint64, sms ...scalar.Mapper) uint64 {
 	return d.FieldScalarUnary(name, ov, sms...).ActualU()
 }
 
+// Reader ULEB128
+
+// TryULEB128 tries to read unsigned LEB128 variable length integer
+func (d *D) TryULEB128() (uint64, error) { return d.tryULEB128() }
+
+// ULEB128 reads unsigned LEB128 variable length integer
+func (d *D) ULEB128() uint64 {
+	v, err := d.tryULEB128()
+	if err != nil {
+		panic(IOError{Err: err, Op: "ULEB128", Pos: d.Pos()})
+	}
+	return v
+}
+
+// TryFieldScalarULEB128 tries to add a field and read unsigned LEB128 variable length integer
+func (d *D) TryFieldScalarULEB128(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.tryULEB128()
+		s.Actual = v
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarULEB128 adds a field and reads unsigned LEB128 variable length integer
+func (d *D) FieldScalarULEB128(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarULEB128(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "ULEB128", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldULEB128 tries to add a field and read unsigned LEB128 variable length integer
+func (d *D) TryFieldULEB128(name string, sms ...scalar.Mapper) (uint64, error) {
+	s, err := d.TryFieldScalarULEB128(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.ActualU(), err
+}
+
+// FieldULEB128 adds a field and reads unsigned LEB128 variable length integer
+func (d *D) FieldULEB128(name string, sms ...scalar.Mapper) uint64 {
+	return d.FieldScalarULEB128(name, sms...).ActualU()
+}
+
+// Reader SLEB128
+
+// TrySLEB128 tries to read signed LEB128 variable length integer
+func (d *D) TrySLEB128() (int64, error) { return d.trySLEB128() }
+
+// SLEB128 reads signed LEB128 variable length integer
+func (d *D) SLEB128() int64 {
+	v, err := d.trySLEB128()
+	if err != nil {
+		panic(IOError{Err: err, Op: "SLEB128", Pos: d.Pos()})
+	}
+	return v
+}
+
+// TryFieldScalarSLEB128 tries to add a field and read signed LEB128 variable length integer
+func (d *D) TryFieldScalarSLEB128(name string, sms ...scalar.Mapper) (*scalar.S, error) {
+	s, err := d.TryFieldScalarFn(name, func(s scalar.S) (scalar.S, error) {
+		v, err := d.trySLEB128()
+		s.Actual = v
+		return s, err
+	}, sms...)
+	if err != nil {
+		return nil, err
+	}
+	return s, err
+}
+
+// FieldScalarSLEB128 adds a field and reads signed LEB128 variable length integer
+func (d *D) FieldScalarSLEB128(name string, sms ...scalar.Mapper) *scalar.S {
+	s, err := d.TryFieldScalarSLEB128(name, sms...)
+	if err != nil {
+		panic(IOError{Err: err, Name: name, Op: "SLEB128", Pos: d.Pos()})
+	}
+	return s
+}
+
+// TryFieldSLEB128 tries to add a field and read signed LEB128 variable length integer
+func (d *D) TryFieldSLEB128(name string, sms ...scalar.Mapper) (int64, error) {
+	s, err := d.TryFieldScalarSLEB128(name, sms...)
+	if err != nil {
+		return 0, err
+	}
+	return s.ActualS(), err
+}
+
+// FieldSLEB128 adds a field and reads signed LEB128 variable length integer
+func (d *D) FieldSLEB128(name string, sms ...scalar.Mapper) int64 {
+	return d.FieldScalarSLEB128(name, sms...).ActualS()
+}
+
 // Reader UTF8
 
 // TryUTF8 tries to read nBytes bytes UTF8 string
@@ -18768,6 +20781,9 @@ func (d *D) FieldScalarUTF8(name string, nBytes int, sms ...scalar.Mapper) *scal
 // TryFieldUTF8 tries to add a field and read nBytes bytes UTF8 string
 func (d *D) TryFieldUTF8(name string, nBytes int, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF8(name, nBytes, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
@@ -18815,6 +20831,9 @@ func (d *D) FieldScalarUTF16(name string, nBytes int, sms ...scalar.Mapper) *sca
 // TryFieldUTF16 tries to add a field and read nBytes bytes UTF16 string, default big-endian and accepts BOM
 func (d *D) TryFieldUTF16(name string, nBytes int, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF16(name, nBytes, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
@@ -18862,6 +20881,9 @@ func (d *D) FieldScalarUTF16LE(name string, nBytes int, sms ...scalar.Mapper) *s
 // TryFieldUTF16LE tries to add a field and read nBytes bytes UTF16 little-endian string
 func (d *D) TryFieldUTF16LE(name string, nBytes int, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF16LE(name, nBytes, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
@@ -18909,6 +20931,9 @@ func (d *D) FieldScalarUTF16BE(name string, nBytes int, sms ...scalar.Mapper) *s
 // TryFieldUTF16BE tries to add a field and read nBytes bytes UTF16 big-endian string
 func (d *D) TryFieldUTF16BE(name string, nBytes int, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF16BE(name, nBytes, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
@@ -18956,6 +20981,9 @@ func (d *D) FieldScalarUTF8ShortString(name string, sms ...scalar.Mapper) *scala
 // TryFieldUTF8ShortString tries to add a field and read one byte length fixed UTF8 string
 func (d *D) TryFieldUTF8ShortString(name string, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF8ShortString(name, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
@@ -19005,6 +21033,9 @@ func (d *D) FieldScalarUTF8ShortStringFixedLen(name string, fixedBytes int, sms
 // TryFieldUTF8ShortStringFixedLen tries to add a field and read fixedBytes bytes long one byte length prefixed UTF8 string
 func (d *D) TryFieldUTF8ShortStringFixedLen(name string, fixedBytes int, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF8ShortStringFixedLen(name, fixedBytes, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
@@ -19052,6 +21083,9 @@ func (d *D) FieldScalarUTF8Null(name string, sms ...scalar.Mapper) *scalar.S {
 // TryFieldUTF8Null tries to add a field and read null terminated UTF8 string
 func (d *D) TryFieldUTF8Null(name string, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF8Null(name, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
@@ -19101,6 +21135,9 @@ func (d *D) FieldScalarUTF8NullFixedLen(name string, fixedBytes int, sms ...scal
 // TryFieldUTF8NullFixedLen tries to add a field and read fixedBytes bytes long null terminated UTF8 string
 func (d *D) TryFieldUTF8NullFixedLen(name string, fixedBytes int, sms ...scalar.Mapper) (string, error) {
 	s, err := d.TryFieldScalarUTF8NullFixedLen(name, fixedBytes, sms...)
+	if err != nil {
+		return "", err
+	}
 	return s.ActualStr(), err
 }
 
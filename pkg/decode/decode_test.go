@@ -0,0 +1,1208 @@
+package decode_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/ranges"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func decodeBytes(t *testing.T, b []byte, fn func(d *decode.D)) *decode.Value {
+	t.Helper()
+	dv, _, err := decode.Decode(
+		context.Background(),
+		bitio.NewBufferFromBytes(b, -1),
+		decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+			fn(d)
+			return nil
+		}),
+		decode.Options{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dv
+}
+
+func fieldScalar(t *testing.T, dv *decode.Value, name string) *scalar.S {
+	t.Helper()
+	c, ok := dv.V.(*decode.Compound)
+	if !ok {
+		t.Fatalf("%s is not a compound", dv.Name)
+	}
+	for _, v := range c.Children {
+		if v.Name == name {
+			s, ok := v.V.(*scalar.S)
+			if !ok {
+				t.Fatalf("%s is not a scalar", name)
+			}
+			return s
+		}
+	}
+	t.Fatalf("%s not found", name)
+	return nil
+}
+
+func TestFieldUEnum(t *testing.T) {
+	names := scalar.UToSymStr{
+		1: "one",
+		2: "two",
+	}
+	dv := decodeBytes(t, []byte{1, 3}, func(d *decode.D) {
+		d.FieldUEnum("a", 8, names)
+		d.FieldUEnum("b", 8, names)
+	})
+
+	a := fieldScalar(t, dv, "a")
+	if a.Actual != uint64(1) || a.Sym != "one" || a.Description != "" || a.Unknown {
+		t.Errorf("expected known a, got %#+v", a)
+	}
+	b := fieldScalar(t, dv, "b")
+	if b.Actual != uint64(3) || b.Sym != nil || b.Description != "unknown" || !b.Unknown {
+		t.Errorf("expected unknown b, got %#+v", b)
+	}
+
+	if n, ok := names.Lookup("two"); !ok || n != 2 {
+		t.Errorf("expected reverse lookup two to be 2, got %v %v", n, ok)
+	}
+}
+
+func TestFieldULEB128(t *testing.T) {
+	testCases := []struct {
+		b        []byte
+		expected uint64
+	}{
+		{[]byte{0x00}, 0},
+		{[]byte{0x7f}, 127},
+		{[]byte{0x80, 0x01}, 128},
+		{[]byte{0xe5, 0x8e, 0x26}, 624485},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, 0xffff_ffff_ffff_ffff},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%x", tC.b), func(t *testing.T) {
+			// trailing byte should not be consumed
+			dv := decodeBytes(t, append(tC.b, 0xaa), func(d *decode.D) {
+				d.FieldULEB128("a")
+			})
+			c := dv.V.(*decode.Compound)
+			a := fieldScalar(t, dv, "a")
+			if a.Actual != tC.expected {
+				t.Errorf("expected %d, got %v", tC.expected, a.Actual)
+			}
+			if c.Children[0].Range.Len != int64(len(tC.b))*8 {
+				t.Errorf("expected %d bits range, got %d", len(tC.b)*8, c.Children[0].Range.Len)
+			}
+		})
+	}
+}
+
+func TestFieldSLEB128(t *testing.T) {
+	testCases := []struct {
+		b        []byte
+		expected int64
+	}{
+		{[]byte{0x00}, 0},
+		{[]byte{0x02}, 2},
+		{[]byte{0x7e}, -2},
+		{[]byte{0xff, 0x00}, 127},
+		{[]byte{0x81, 0x7f}, -127},
+		{[]byte{0xc0, 0xbb, 0x78}, -123456},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x00}, math.MaxInt64},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x7f}, math.MinInt64},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%x", tC.b), func(t *testing.T) {
+			dv := decodeBytes(t, append(tC.b, 0xaa), func(d *decode.D) {
+				d.FieldSLEB128("a")
+			})
+			c := dv.V.(*decode.Compound)
+			a := fieldScalar(t, dv, "a")
+			if a.Actual != tC.expected {
+				t.Errorf("expected %d, got %v", tC.expected, a.Actual)
+			}
+			if c.Children[0].Range.Len != int64(len(tC.b))*8 {
+				t.Errorf("expected %d bits range, got %d", len(tC.b)*8, c.Children[0].Range.Len)
+			}
+		})
+	}
+}
+
+func TestLEB128Overflow(t *testing.T) {
+	testCases := []struct {
+		b      []byte
+		signed bool
+	}{
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x7f}, false},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x01}, true},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}, false},
+		{[]byte{0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x80, 0x00}, true},
+	}
+	for _, tC := range testCases {
+		t.Run(fmt.Sprintf("%x_%v", tC.b, tC.signed), func(t *testing.T) {
+			decodeBytes(t, tC.b, func(d *decode.D) {
+				var err error
+				if tC.signed {
+					_, err = d.TryFieldSLEB128("a")
+				} else {
+					_, err = d.TryFieldULEB128("a")
+				}
+				if err == nil {
+					t.Error("expected overflow error")
+				}
+				if d.Pos() != 0 {
+					t.Errorf("expected position to be restored, got %d", d.Pos())
+				}
+				d.FieldRawLen("rest", d.BitsLeft())
+			})
+		})
+	}
+}
+
+func TestFieldRawMagic(t *testing.T) {
+	dv := decodeBytes(t, []byte("GRIB"), func(d *decode.D) {
+		d.FieldRawMagic("magic", []byte("GRIB"))
+	})
+	if s := fieldScalar(t, dv, "magic"); s.Description != "valid" {
+		t.Errorf("expected valid, got %q", s.Description)
+	}
+
+	_, _, err := decode.Decode(
+		context.Background(),
+		bitio.NewBufferFromBytes([]byte("GRIP"), -1),
+		decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+			d.FieldRawMagic("magic", []byte("GRIB"))
+			return nil
+		}),
+		decode.Options{},
+	)
+	if err == nil || !strings.Contains(err.Error(), "expected 47524942 found 47524950") {
+		t.Errorf("expected mismatch error, got %v", err)
+	}
+}
+
+func TestFieldUMagic(t *testing.T) {
+	dv := decodeBytes(t, []byte{0x77, 0x77}, func(d *decode.D) {
+		d.FieldUMagic("magic", 16, 0x7777)
+	})
+	if s := fieldScalar(t, dv, "magic"); s.Description != "valid" {
+		t.Errorf("expected valid, got %q", s.Description)
+	}
+
+	_, _, err := decode.Decode(
+		context.Background(),
+		bitio.NewBufferFromBytes([]byte{0x77, 0x76}, -1),
+		decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+			d.FieldUMagic("magic", 16, 0x7777)
+			return nil
+		}),
+		decode.Options{},
+	)
+	if err == nil || !strings.Contains(err.Error(), "expected 0x7777 found 0x7776") {
+		t.Errorf("expected mismatch error, got %v", err)
+	}
+}
+
+func TestEndian(t *testing.T) {
+	b := []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08}
+	testCases := []struct {
+		name        string
+		endian      decode.Endian
+		expectedU16 uint64
+		expectedU32 uint64
+		expectedU64 uint64
+	}{
+		{"big", decode.BigEndian, 0x0102, 0x01020304, 0x0102030405060708},
+		{"little", decode.LittleEndian, 0x0201, 0x04030201, 0x0807060504030201},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.name, func(t *testing.T) {
+			dv := decodeBytes(t, b, func(d *decode.D) {
+				d.Endian = tC.endian
+				d.RangeFn(0, 16, func(d *decode.D) { d.FieldU16("u16") })
+				d.RangeFn(0, 32, func(d *decode.D) { d.FieldU32("u32") })
+				d.RangeFn(0, 64, func(d *decode.D) { d.FieldU64("u64") })
+				// explicit endian ignores current endian
+				d.RangeFn(0, 16, func(d *decode.D) { d.FieldU16BE("u16be") })
+				d.RangeFn(0, 16, func(d *decode.D) { d.FieldU16LE("u16le") })
+			})
+			for name, expected := range map[string]uint64{
+				"u16":   tC.expectedU16,
+				"u32":   tC.expectedU32,
+				"u64":   tC.expectedU64,
+				"u16be": 0x0102,
+				"u16le": 0x0201,
+			} {
+				if actual := fieldScalar(t, dv, name).ActualU(); actual != expected {
+					t.Errorf("%s: expected %x, got %x", name, expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestEndianScope(t *testing.T) {
+	var inherited, overridden, after uint64
+	decodeBytes(t, []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06}, func(d *decode.D) {
+		d.Endian = decode.LittleEndian
+		d.FieldStruct("inherited", func(d *decode.D) {
+			inherited = d.FieldU16("v")
+		})
+		d.FieldStruct("overridden", func(d *decode.D) {
+			d.Endian = decode.BigEndian
+			overridden = d.FieldU16("v")
+		})
+		after = d.FieldU16("after")
+	})
+	if inherited != 0x0201 {
+		t.Errorf("inherited: expected 0x0201, got %x", inherited)
+	}
+	if overridden != 0x0304 {
+		t.Errorf("overridden: expected 0x0304, got %x", overridden)
+	}
+	if after != 0x0605 {
+		t.Errorf("after: expected 0x0605, got %x", after)
+	}
+}
+
+func TestBitOrder(t *testing.T) {
+	var before, a, b, c, le, peek, after uint64
+	dv := decodeBytes(t, []byte{0b1010_1101, 0b0000_1111, 0x34, 0x12, 0xff, 0b1000_0000}, func(d *decode.D) {
+		before = d.FieldU1("before")
+		d.SeekAbs(0)
+		d.FieldBitStruct("lsb", bitio.LSBFirst, func(d *decode.D) {
+			a = d.FieldU3("a")
+			b = d.FieldU9("b")
+			c = d.FieldU4("c")
+			// endian is ignored, LSB first integers are little endian
+			le = d.FieldU16LE("le")
+		})
+		d.LSBBitOrder(func(d *decode.D) {
+			peek = d.PeekBits(4)
+			d.FieldU8("v")
+		})
+		after = d.FieldU1("after")
+	})
+	for name, tc := range map[string]struct{ expected, actual uint64 }{
+		"before": {1, before},
+		"a":      {0b101, a},
+		"b":      {0b1_1111_0101, b},
+		"c":      {0b0000, c},
+		"le":     {0x1234, le},
+		"peek":   {0xf, peek},
+		"after":  {1, after},
+	} {
+		if tc.actual != tc.expected {
+			t.Errorf("%s: expected %b, got %b", name, tc.expected, tc.actual)
+		}
+	}
+	if e := fieldValue(t, dv, "lsb", "a").Encoding; e.BitOrder != bitio.LSBFirst {
+		t.Errorf("expected lsb encoding, got %v", e)
+	}
+}
+
+func TestBitOrderEOF(t *testing.T) {
+	_, _, err := decode.Decode(
+		context.Background(),
+		bitio.NewBufferFromBytes([]byte{0xff}, -1),
+		decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+			d.LSBBitOrder(func(d *decode.D) {
+				d.U4()
+				d.U5()
+			})
+			return nil
+		}),
+		decode.Options{},
+	)
+	if err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestFieldFixedPoint(t *testing.T) {
+	testCases := []struct {
+		name        string
+		b           []byte
+		fn          func(d *decode.D) float64
+		expected    float64
+		expectedRaw interface{}
+	}{
+		{"FP16 8.8", []byte{0x01, 0x80}, func(d *decode.D) float64 { return d.FieldFP16("v") }, 1.5, uint64(0x0180)},
+		{"FP32 16.16", []byte{0x00, 0x01, 0x00, 0x00}, func(d *decode.D) float64 { return d.FieldFP32("v") }, 1, uint64(0x10000)},
+		{"FP32 16.16 unsigned", []byte{0xff, 0xff, 0x00, 0x00}, func(d *decode.D) float64 { return d.FieldFP32("v") }, 65535, uint64(0xffff0000)},
+		{"FP 2.30", []byte{0x40, 0x00, 0x00, 0x00}, func(d *decode.D) float64 { return d.FieldFP("v", 32, 30) }, 1, uint64(0x40000000)},
+		{"FP32LE", []byte{0x00, 0x80, 0x02, 0x00}, func(d *decode.D) float64 { return d.FieldFP32LE("v") }, 2.5, uint64(0x28000)},
+		{"SFP16 8.8", []byte{0xff, 0x00}, func(d *decode.D) float64 { return d.FieldSFP16("v") }, -1, int64(-256)},
+		{"SFP16 8.8 positive", []byte{0x01, 0x00}, func(d *decode.D) float64 { return d.FieldSFP16("v") }, 1, int64(256)},
+		{"SFP32 16.16", []byte{0xff, 0xff, 0x00, 0x00}, func(d *decode.D) float64 { return d.FieldSFP32("v") }, -1, int64(-0x10000)},
+		{"SFP32 16.16 fraction", []byte{0xff, 0xfe, 0x80, 0x00}, func(d *decode.D) float64 { return d.FieldSFP32("v") }, -1.5, int64(-0x18000)},
+		{"SFP 2.30", []byte{0xc0, 0x00, 0x00, 0x00}, func(d *decode.D) float64 { return d.FieldSFP("v", 32, 30) }, -1, int64(-0x40000000)},
+		{"SFP32LE", []byte{0x00, 0x00, 0xff, 0xff}, func(d *decode.D) float64 { return d.FieldSFP32LE("v") }, -1, int64(-0x10000)},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.name, func(t *testing.T) {
+			var actual float64
+			dv := decodeBytes(t, tC.b, func(d *decode.D) { actual = tC.fn(d) })
+			if actual != tC.expected {
+				t.Errorf("expected %v, got %v", tC.expected, actual)
+			}
+			s := fieldScalar(t, dv, "v")
+			if s.Actual != tC.expectedRaw {
+				t.Errorf("expected field raw %v, got %v", tC.expectedRaw, s.Actual)
+			}
+			if s.SymF() != tC.expected {
+				t.Errorf("expected field %v, got %v", tC.expected, s.SymF())
+			}
+		})
+	}
+}
+
+func TestFieldStructLazy(t *testing.T) {
+	decoded := 0
+	dv := decodeBytes(t, []byte{0xff, 0x01, 0x02, 0x03}, func(d *decode.D) {
+		d.FieldU8("a")
+		d.FieldStructArrayLazy("elements", "element", 3,
+			func(i int) (int64, int64) { return int64(8 + i*8), 8 },
+			func(d *decode.D, i int) {
+				decoded++
+				if i == 2 {
+					d.Fatalf("broken")
+				}
+				d.FieldU8("v")
+			})
+	})
+	if decoded != 0 {
+		t.Fatalf("expected no decoded elements, got %d", decoded)
+	}
+
+	elements := dv.V.(*decode.Compound).Children[1]
+	ev := elements.V.(*decode.Compound).Children
+	if len(ev) != 3 {
+		t.Fatalf("expected 3 elements, got %d", len(ev))
+	}
+
+	ev[1].Materialize()
+	ev[1].Materialize()
+	if decoded != 1 {
+		t.Errorf("expected one decoded element, got %d", decoded)
+	}
+	if s := fieldScalar(t, ev[1], "v"); s.ActualU() != 0x02 {
+		t.Errorf("expected 0x02, got %x", s.ActualU())
+	}
+	if ev[1].Range.Start != 16 || ev[1].Range.Len != 8 {
+		t.Errorf("expected range 16-24, got %v", ev[1].Range)
+	}
+
+	ev[2].Materialize()
+	if err := ev[2].V.(*decode.Compound).Err; err == nil || !strings.Contains(err.Error(), "broken") {
+		t.Errorf("expected broken error, got %v", err)
+	}
+}
+
+func TestFieldStructLazyCtxFillGaps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var lazyCtx context.Context
+	dv, _, err := decode.Decode(
+		ctx,
+		bitio.NewBufferFromBytes([]byte{0xff, 0x01, 0x02}, -1),
+		decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+			d.FieldU8("a")
+			d.FieldStructLazy("lazy", 8, 16, func(d *decode.D) {
+				lazyCtx = d.Ctx
+				d.FieldU8("v")
+			})
+			return nil
+		}),
+		decode.Options{FillGaps: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lv := dv.V.(*decode.Compound).Children[1]
+	lv.Materialize()
+	if lazyCtx != ctx {
+		t.Errorf("expected lazy decode to use parent context")
+	}
+	s := fieldScalar(t, lv, "unknown0")
+	if !s.Unknown {
+		t.Errorf("expected unknown gap field")
+	}
+	if c := lv.V.(*decode.Compound).Children; len(c) != 2 || c[1].Range.Start != 16 || c[1].Range.Len != 8 {
+		t.Errorf("expected gap field at 16-24, got %v", c)
+	}
+}
+
+func TestFieldStructLazyCanceled(t *testing.T) {
+	decodeCanceled := func(t *testing.T, cancelInFn bool) *decode.Value {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		dv, _, err := decode.Decode(
+			ctx,
+			bitio.NewBufferFromBytes([]byte{0xff, 0x01, 0x02}, -1),
+			decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+				d.FieldU8("a")
+				d.FieldStructLazy("lazy", 8, 16, func(d *decode.D) {
+					d.FieldU8("v")
+					if cancelInFn {
+						cancel()
+					}
+					d.FieldU8("w")
+				})
+				return nil
+			}),
+			decode.Options{},
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !cancelInFn {
+			cancel()
+		}
+		return dv
+	}
+
+	for _, cancelInFn := range []bool{false, true} {
+		cancelInFn := cancelInFn
+		t.Run(fmt.Sprintf("materialize cancel in fn %v", cancelInFn), func(t *testing.T) {
+			dv := decodeCanceled(t, cancelInFn)
+			lv := dv.V.(*decode.Compound).Children[1]
+			lv.Materialize()
+			c := lv.V.(*decode.Compound)
+			if !errors.Is(c.Err, context.Canceled) {
+				t.Errorf("expected canceled error, got %v", c.Err)
+			}
+			if len(c.Children) != 0 {
+				t.Errorf("expected no children, got %v", c.Children)
+			}
+		})
+		t.Run(fmt.Sprintf("unmarshal cancel in fn %v", cancelInFn), func(t *testing.T) {
+			dv := decodeCanceled(t, cancelInFn)
+			var v struct {
+				Lazy struct {
+					V uint8 `fq:"v"`
+				} `fq:"lazy"`
+			}
+			if err := decode.Unmarshal(dv, &v); !errors.Is(err, context.Canceled) {
+				t.Errorf("expected canceled error, got %v", err)
+			}
+		})
+	}
+}
+
+func BenchmarkFieldStructLazy(b *testing.B) {
+	const n = 10000
+	buf := make([]byte, n*16)
+
+	for _, lazy := range []bool{false, true} {
+		name := "eager"
+		if lazy {
+			name = "lazy"
+		}
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				elementFn := func(d *decode.D) {
+					d.FieldU32("a")
+					d.FieldU32("b")
+					d.FieldU32("c")
+					d.FieldU32("d")
+				}
+				dv, _, err := decode.Decode(
+					context.Background(),
+					bitio.NewBufferFromBytes(buf, -1),
+					decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+						if lazy {
+							d.FieldStructArrayLazy("elements", "element", n,
+								func(i int) (int64, int64) { return int64(i) * 16 * 8, 16 * 8 },
+								func(d *decode.D, i int) { elementFn(d) })
+						} else {
+							d.FieldArray("elements", func(d *decode.D) {
+								for i := 0; i < n; i++ {
+									d.FieldStruct("element", elementFn)
+								}
+							})
+						}
+						return nil
+					}),
+					decode.Options{IsRoot: true},
+				)
+				if err != nil {
+					b.Fatal(err)
+				}
+				// only access first element
+				dv.V.(*decode.Compound).Children[0].V.(*decode.Compound).Children[0].Materialize()
+			}
+		})
+	}
+}
+
+func TestFieldChunks(t *testing.T) {
+	var ids []string
+	opts := decode.ChunkOpts{
+		Endian: decode.LittleEndian,
+		TrimID: true,
+		Align:  2,
+		Chunks: map[string]decode.ChunkFn{
+			"LIST": func(d *decode.D, opts decode.ChunkOpts) {
+				d.FieldChunks("chunks", opts)
+			},
+			"num": func(d *decode.D, opts decode.ChunkOpts) {
+				d.FieldU16("v")
+			},
+		},
+		UnknownFn: func(d *decode.D, opts decode.ChunkOpts) {
+			ids = append(ids, d.FieldUTF8("text", int(d.BitsLeft()/8)))
+		},
+	}
+
+	b := []byte("" +
+		"LIST\x1d\x00\x00\x00" +
+		/**/ "num \x02\x00\x00\x00\x01\x02" +
+		/**/ "txt \x01\x00\x00\x00a\x00" +
+		/**/ "txt \x01\x00\x00\x00b" + // last chunk not padded
+		"\x00" + // padding for LIST
+		"end \x00\x00\x00\x00")
+	dv := decodeBytes(t, b, func(d *decode.D) {
+		d.FieldChunks("chunks", opts)
+	})
+	if strings.Join(ids, ",") != "a,b," {
+		t.Errorf("expected a,b,, got %v", ids)
+	}
+
+	chunks := dv.V.(*decode.Compound).Children[0].V.(*decode.Compound).Children
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if s := fieldScalar(t, chunks[0], "align"); s == nil {
+		t.Error("expected align field")
+	}
+	if s := fieldScalar(t, chunks[1], "id"); s.ActualStr() != "end" {
+		t.Errorf("expected trimmed id, got %v", s)
+	}
+
+	// big endian with size including header and rest of parent size
+	dv = decodeBytes(t, []byte("ab\x00\x05xcd\xff\xffyz"), func(d *decode.D) {
+		opts := decode.ChunkOpts{IDLen: 2, SizeBits: 16, SizeIncludesHeader: true, RestSize: 0xffff}
+		d.FieldChunks("chunks", opts)
+	})
+	chunks = dv.V.(*decode.Compound).Children[0].V.(*decode.Compound).Children
+	if len(chunks) != 2 {
+		t.Fatalf("expected 2 chunks, got %d", len(chunks))
+	}
+	if s := fieldScalar(t, chunks[1], "data"); s.ActualBitBuf().Len() != 16 {
+		t.Errorf("expected rest of parent data, got %v", s)
+	}
+
+	// little endian RIFX style reversed id
+	dv = decodeBytes(t, []byte("pami\x02\x00\x00\x00ab"), func(d *decode.D) {
+		opts := decode.ChunkOpts{Endian: decode.LittleEndian, ReverseID: true}
+		d.FieldChunks("chunks", opts)
+	})
+	chunks = dv.V.(*decode.Compound).Children[0].V.(*decode.Compound).Children
+	if s := fieldScalar(t, chunks[0], "id"); s.ActualStr() != "imap" {
+		t.Errorf("expected reversed id imap, got %v", s)
+	}
+	if s := fieldScalar(t, chunks[0], "size"); s.ActualU() != 2 {
+		t.Errorf("expected size 2, got %v", s)
+	}
+}
+
+func TestFieldUnparsedTail(t *testing.T) {
+	// two 4 byte elements and a 3 byte remainder
+	b := []byte{0, 0, 0, 1, 0, 0, 0, 2, 0xaa, 0xbb, 0xcc}
+	dv := decodeBytes(t, b, func(d *decode.D) {
+		d.FieldArray("elements", func(d *decode.D) {
+			for d.BitsLeft() >= 32 {
+				d.FieldU32("element")
+			}
+			d.FieldUnparsedTail()
+		})
+	})
+	children := dv.V.(*decode.Compound).Children[0].V.(*decode.Compound).Children
+	if len(children) != 3 {
+		t.Fatalf("expected 3 fields, got %d", len(children))
+	}
+	tail := children[2]
+	if tail.Name != "unparsed_tail" || tail.Range.Start != 64 || tail.Range.Len != 24 {
+		t.Errorf("expected unparsed_tail at 64 with length 24, got %s %v", tail.Name, tail.Range)
+	}
+
+	// nothing added if all bits were used
+	dv = decodeBytes(t, b[0:8], func(d *decode.D) {
+		d.FieldArray("elements", func(d *decode.D) {
+			for d.BitsLeft() >= 32 {
+				d.FieldU32("element")
+			}
+			d.FieldUnparsedTail()
+		})
+	})
+	if n := len(dv.V.(*decode.Compound).Children[0].V.(*decode.Compound).Children); n != 2 {
+		t.Errorf("expected 2 fields, got %d", n)
+	}
+}
+
+func TestFieldFormatLen(t *testing.T) {
+	childFormat := func(n int) decode.Group {
+		return decode.Group{{
+			Name: "child",
+			DecodeFn: func(d *decode.D, in interface{}) interface{} {
+				d.FieldU("a", n)
+				return nil
+			},
+		}}
+	}
+
+	t.Run("under consume", func(t *testing.T) {
+		dv := decodeBytes(t, []byte{0x01, 0x02, 0x03}, func(d *decode.D) {
+			d.FieldFormatLen("child", 16, childFormat(8), nil)
+			d.FieldU8("after")
+		})
+		child := dv.V.(*decode.Compound).Children[0]
+		if s := fieldScalar(t, child, "unknown0"); s.ActualBitBuf().Len() != 8 {
+			t.Errorf("expected 8 bits unknown, got %d", s.ActualBitBuf().Len())
+		}
+		if s := fieldScalar(t, dv, "after"); s.ActualU() != 0x03 {
+			t.Errorf("expected after 0x03, got %x", s.ActualU())
+		}
+		if ws := child.Warnings(); len(ws) != 1 || ws[0].Message != "8 bits not consumed" {
+			t.Errorf("expected not consumed warning, got %v", ws)
+		}
+	})
+
+	t.Run("all consumed", func(t *testing.T) {
+		dv := decodeBytes(t, []byte{0x01, 0x02}, func(d *decode.D) {
+			d.FieldFormatLen("child", 16, childFormat(16), nil)
+		})
+		child := dv.V.(*decode.Compound).Children[0]
+		if ws := child.Warnings(); len(ws) != 0 {
+			t.Errorf("expected no warnings, got %v", ws)
+		}
+	})
+
+	t.Run("unmapped enum last", func(t *testing.T) {
+		dv := decodeBytes(t, []byte{0x00, 0x09}, func(d *decode.D) {
+			d.FieldFormatLen("child", 16, decode.Group{{
+				Name: "child",
+				DecodeFn: func(d *decode.D, in interface{}) interface{} {
+					d.FieldU8("a")
+					d.FieldUEnum("kind", 8, scalar.UToSymStr{1: "one"})
+					return nil
+				},
+			}}, nil)
+		})
+		child := dv.V.(*decode.Compound).Children[0]
+		if ws := child.Warnings(); len(ws) != 0 {
+			t.Errorf("expected no warnings, got %v", ws)
+		}
+	})
+
+	t.Run("over consume", func(t *testing.T) {
+		var err error
+		dv := decodeBytes(t, []byte{0x01, 0x02, 0x03}, func(d *decode.D) {
+			_, _, err = d.TryFieldFormatLen("child", 8, childFormat(16), nil)
+			d.FieldU8("after")
+		})
+		if err == nil {
+			t.Error("expected error when reading outside of length")
+		}
+		if s := fieldScalar(t, dv, "after"); s.ActualU() != 0x01 {
+			t.Errorf("expected position to not change on error, got %x", s.ActualU())
+		}
+	})
+}
+
+func TestFormatOptionsNested(t *testing.T) {
+	var seen interface{}
+	child := decode.Group{{
+		Name: "child",
+		DecodeFn: func(d *decode.D, in interface{}) interface{} {
+			seen = d.Options.FormatOptions["key"]
+			d.FieldU8("a")
+			return nil
+		},
+	}}
+
+	testCases := []struct {
+		name string
+		fn   func(d *decode.D)
+	}{
+		{"Format", func(d *decode.D) { d.Format(child, nil) }},
+		{"FieldFormat", func(d *decode.D) { d.FieldFormat("child", child, nil) }},
+		{"FieldFormatLen", func(d *decode.D) { d.FieldFormatLen("child", 8, child, nil) }},
+		{"FieldFormatRange", func(d *decode.D) { d.FieldFormatRange("child", 0, 8, child, nil) }},
+		{"FieldFormatBitBuf", func(d *decode.D) { d.FieldFormatBitBuf("child", d.BitBufRange(0, 8), child, nil) }},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.name, func(t *testing.T) {
+			seen = nil
+			_, _, err := decode.Decode(
+				context.Background(),
+				bitio.NewBufferFromBytes([]byte{0x01}, -1),
+				decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+					tC.fn(d)
+					return nil
+				}),
+				decode.Options{FormatOptions: map[string]interface{}{"key": "value"}},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if seen != "value" {
+				t.Errorf("expected nested format to see option, got %v", seen)
+			}
+		})
+	}
+}
+
+func TestAnnotations(t *testing.T) {
+	testCases := []struct {
+		name                string
+		force               bool
+		mapper              func(d *decode.D) scalar.Mapper
+		expectedErr         bool
+		expectedAnnotations []decode.Annotation
+		expectedWarning     int
+	}{
+		{
+			name:   "validate valid",
+			mapper: func(d *decode.D) scalar.Mapper { return d.ValidateURange(1, 192000) },
+		},
+		{
+			name:   "validate invalid",
+			mapper: func(d *decode.D) scalar.Mapper { return d.ValidateURange(1, 10) },
+			expectedAnnotations: []decode.Annotation{
+				{Severity: decode.SeverityWarning, Message: "failed to validate U range 1-10, 44100 outside range"},
+			},
+			expectedWarning: 1,
+		},
+		{
+			name:        "assert invalid",
+			mapper:      func(d *decode.D) scalar.Mapper { return d.AssertURange(1, 10) },
+			expectedErr: true,
+		},
+		{
+			name:   "assert invalid forced",
+			force:  true,
+			mapper: func(d *decode.D) scalar.Mapper { return d.AssertU(48000) },
+			expectedAnnotations: []decode.Annotation{
+				{Severity: decode.SeverityError, Message: "failed to assert U, 44100 not one of [48000]"},
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			dv, _, err := decode.Decode(
+				context.Background(),
+				bitio.NewBufferFromBytes([]byte{0, 0, 0xac, 0x44}, -1),
+				decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+					d.FieldU32("sample_rate", tC.mapper(d))
+					return nil
+				}),
+				decode.Options{Force: tC.force},
+			)
+			if tC.expectedErr {
+				if err == nil {
+					t.Fatal("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			v := dv.V.(*decode.Compound).Children[0]
+			if !reflect.DeepEqual(tC.expectedAnnotations, v.Annotations) {
+				t.Errorf("expected annotations %v, got %v", tC.expectedAnnotations, v.Annotations)
+			}
+			if len(v.Warnings()) != tC.expectedWarning {
+				t.Errorf("expected %d warnings, got %d", tC.expectedWarning, len(v.Warnings()))
+			}
+			if s := v.V.(*scalar.S); s.ActualU() != 44100 {
+				t.Errorf("expected 44100, got %d", s.ActualU())
+			}
+		})
+	}
+}
+
+func TestFieldArrayCount(t *testing.T) {
+	t.Run("normal", func(t *testing.T) {
+		dv := decodeBytes(t, []byte{1, 2, 3}, func(d *decode.D) {
+			d.FieldArrayCount("a", 2, func(d *decode.D) { d.FieldU8("v") })
+			d.FieldU8("after")
+		})
+		a := dv.V.(*decode.Compound).Children[0]
+		if c := a.V.(*decode.Compound); !c.IsArray || len(c.Children) != 2 {
+			t.Errorf("expected array with 2 elements, got %d", len(c.Children))
+		}
+		if s := fieldScalar(t, dv, "after"); s.ActualU() != 3 {
+			t.Errorf("expected after 3, got %d", s.ActualU())
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		dv := decodeBytes(t, []byte{1}, func(d *decode.D) {
+			d.FieldArrayCount("a", 0, func(d *decode.D) { d.FieldU8("v") })
+			d.FieldU8("after")
+		})
+		a := dv.V.(*decode.Compound).Children[0]
+		if c, ok := a.V.(*decode.Compound); !ok || !c.IsArray || len(c.Children) != 0 {
+			t.Errorf("expected empty array, got %#v", a.V)
+		}
+	})
+
+	t.Run("short", func(t *testing.T) {
+		_, _, err := decode.Decode(
+			context.Background(),
+			bitio.NewBufferFromBytes([]byte{1, 2}, -1),
+			decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+				d.FieldArrayCount("a", 3, func(d *decode.D) { d.FieldU8("v") })
+				return nil
+			}),
+			decode.Options{},
+		)
+		if err == nil || !strings.Contains(err.Error(), "ended after 2 of 3 elements") {
+			t.Errorf("expected short array error, got %v", err)
+		}
+	})
+}
+
+func TestPeek(t *testing.T) {
+	decodeBytes(t, []byte{0b1010_0101, 1, 2, 3}, func(d *decode.D) {
+		d.U3()
+		startPos := d.Pos()
+		if p, r := d.PeekBits(13), d.U13(); p != r {
+			t.Errorf("PeekBits: expected %d, got %d", r, p)
+		}
+		if d.Pos() != startPos+13 {
+			t.Errorf("expected pos %d, got %d", startPos+13, d.Pos())
+		}
+		if p, r := d.PeekBytes(2), d.BytesLen(2); !bytes.Equal(p, r) {
+			t.Errorf("PeekBytes: expected %v, got %v", r, p)
+		}
+		if _, err := d.TryPeekBits(1); err == nil {
+			t.Error("expected error peeking past end")
+		}
+		if d.Pos() != d.Len() {
+			t.Errorf("expected pos %d, got %d", d.Len(), d.Pos())
+		}
+	})
+}
+
+func TestMaxDepth(t *testing.T) {
+	// format that decodes the rest of the buffer as itself
+	var selfGroup decode.Group
+	selfGroup = decode.Group{{
+		Name: "self",
+		DecodeFn: func(d *decode.D, in interface{}) interface{} {
+			d.FieldU8("n")
+			if d.NotEnd() {
+				d.TryFieldFormatLen("self", d.BitsLeft(), selfGroup, nil)
+			}
+			return nil
+		},
+	}}
+	depthFn := func(dv *decode.Value) int {
+		depth := 0
+		for dv != nil {
+			depth++
+			var next *decode.Value
+			for _, v := range dv.V.(*decode.Compound).Children {
+				if v.Name == "self" {
+					next = v
+				}
+			}
+			dv = next
+		}
+		return depth
+	}
+
+	testCases := []struct {
+		maxDepth int
+		expected int
+	}{
+		{0, 10},
+		{1, 1},
+		{3, 3},
+		{10, 10},
+		{20, 10},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(fmt.Sprintf("%d", tC.maxDepth), func(t *testing.T) {
+			dv, _, err := decode.Decode(
+				context.Background(),
+				bitio.NewBufferFromBytes(make([]byte, 10), -1),
+				selfGroup,
+				decode.Options{IsRoot: true, MaxDepth: tC.maxDepth},
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if actual := depthFn(dv); actual != tC.expected {
+				t.Errorf("expected depth %d, got %d", tC.expected, actual)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		_, _, err := decode.Decode(
+			context.Background(),
+			bitio.NewBufferFromBytes(make([]byte, 10), -1),
+			decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+				d.FieldFormatLen("self", d.BitsLeft(), selfGroup, nil)
+				return nil
+			}),
+			decode.Options{MaxDepth: 1},
+		)
+		if err == nil || !strings.Contains(err.Error(), "max depth exceeded") {
+			t.Errorf("expected max depth error, got %v", err)
+		}
+	})
+}
+
+func TestFieldBytesLen(t *testing.T) {
+	// 3 bits then 40 bits 0x0102030405 then 5 bits
+	b := []byte{0b000_00000, 0b001_00000, 0b010_00000, 0b011_00000, 0b100_00000, 0b101_00000}
+	dv := decodeBytes(t, b, func(d *decode.D) {
+		d.FieldU3("a")
+		if bs := d.FieldBytesLen("b", 5); !bytes.Equal(bs, []byte{1, 2, 3, 4, 5}) {
+			t.Errorf("expected [1 2 3 4 5], got %v", bs)
+		}
+		d.FieldU5("c")
+	})
+	v := dv.V.(*decode.Compound).Children[1]
+	if v.Range.Start != 3 || v.Range.Len != 40 {
+		t.Errorf("expected range 3:40, got %s", v.Range)
+	}
+}
+
+func TestFieldPadding(t *testing.T) {
+	testCases := []struct {
+		name            string
+		b               []byte
+		skipBits        int
+		alignBits       int
+		expectedLen     int64
+		expectedWarning int
+	}{
+		{name: "aligned", b: []byte{0xff, 0xff}, skipBits: 8, alignBits: 8, expectedLen: 0},
+		{name: "3 bits", b: []byte{0b1111_1000, 0xff}, skipBits: 5, alignBits: 8, expectedLen: 3},
+		{name: "non-zero", b: []byte{0b1111_1010, 0xff}, skipBits: 5, alignBits: 8, expectedLen: 3, expectedWarning: 1},
+		{name: "16 bits", b: []byte{0xff, 0x00, 0x00, 0xff}, skipBits: 8, alignBits: 24, expectedLen: 16},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			dv := decodeBytes(t, tC.b, func(d *decode.D) {
+				d.FieldU("a", tC.skipBits)
+				d.FieldPadding("padding", tC.alignBits)
+				d.FieldRawLen("rest", d.BitsLeft())
+			})
+			v := dv.V.(*decode.Compound).Children[1]
+			if v.Name != "padding" || v.Range.Start != int64(tC.skipBits) || v.Range.Len != tC.expectedLen {
+				t.Errorf("expected padding %d:%d, got %s %s", tC.skipBits, tC.expectedLen, v.Name, v.Range)
+			}
+			if len(v.Warnings()) != tC.expectedWarning {
+				t.Errorf("expected %d warnings, got %v", tC.expectedWarning, v.Annotations)
+			}
+		})
+	}
+}
+
+func TestCancel(t *testing.T) {
+	const cancelAt = 1000
+	// large enough that a full decode would be noticeable
+	bb := bitio.NewBufferFromBytes(make([]byte, 16*1024*1024), -1)
+
+	childGroup := decode.Group{{
+		Name: "child",
+		DecodeFn: func(d *decode.D, in interface{}) interface{} {
+			d.FieldU8("a")
+			return nil
+		},
+	}}
+
+	testCases := []struct {
+		name string
+		fn   func(d *decode.D)
+	}{
+		{"array", func(d *decode.D) { d.FieldU8("a") }},
+		{"struct", func(d *decode.D) { d.FieldStruct("s", func(d *decode.D) { d.FieldU8("a") }) }},
+		{"format", func(d *decode.D) { d.FieldFormatLen("child", 8, childGroup, nil) }},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			n := 0
+			_, _, err := decode.Decode(
+				ctx,
+				bb,
+				decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+					d.FieldArray("entries", func(d *decode.D) {
+						for d.NotEnd() {
+							if n == cancelAt {
+								cancel()
+							}
+							n++
+							tC.fn(d)
+						}
+					})
+					return nil
+				}),
+				decode.Options{},
+			)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("expected context canceled error, got %v", err)
+			}
+			if n != cancelAt+1 {
+				t.Errorf("expected decode to stop at %d entries, got %d", cancelAt+1, n)
+			}
+		})
+	}
+}
+
+func TestFieldStructArray(t *testing.T) {
+	const n = 1000
+	b := make([]byte, n*6)
+	for i := 0; i < n; i++ {
+		binary.BigEndian.PutUint32(b[i*6:], uint32(i*3))
+		binary.BigEndian.PutUint16(b[i*6+4:], uint16(i))
+	}
+
+	t.Run("normal", func(t *testing.T) {
+		dv := decodeBytes(t, b, func(d *decode.D) {
+			d.FieldStructArray("entries", "entry", n, func(d *decode.D) {
+				d.FieldU32("a")
+				d.FieldU16("b")
+			})
+		})
+		c := dv.V.(*decode.Compound).Children[0].V.(*decode.Compound)
+		if len(c.Children) != n {
+			t.Fatalf("expected %d elements, got %d", n, len(c.Children))
+		}
+		for _, i := range []int{0, 1, 499, 731, n - 1} {
+			e := c.Children[i]
+			if e.Name != "entry" {
+				t.Errorf("%d: expected name entry, got %s", i, e.Name)
+			}
+			if e.Range.Start != int64(i*6*8) {
+				t.Errorf("%d: expected start %d, got %d", i, i*6*8, e.Range.Start)
+			}
+			if s := fieldScalar(t, e, "a"); s.ActualU() != uint64(i*3) {
+				t.Errorf("%d: expected a %d, got %d", i, i*3, s.ActualU())
+			}
+			if s := fieldScalar(t, e, "b"); s.ActualU() != uint64(i) {
+				t.Errorf("%d: expected b %d, got %d", i, i, s.ActualU())
+			}
+		}
+	})
+
+	testCases := []struct {
+		name             string
+		count            uint64
+		fn               func(d *decode.D)
+		expected         string
+		expectedElements int
+	}{
+		// elements that fit are kept
+		{"too short", n + 1, func(d *decode.D) { d.FieldU32("a"); d.FieldU16("b") }, "needs 48000 bits, 47952 left", n},
+		{"zero size", n, func(d *decode.D) {}, "element has zero size", 1},
+		// first non-zero byte is at index 9
+		{"not fixed size", n / 2, func(d *decode.D) {
+			if d.FieldU8("a") != 0 {
+				d.FieldU8("b")
+			}
+		}, "element 9 is not 8 bits", 10},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.name, func(t *testing.T) {
+			dv, _, err := decode.Decode(
+				context.Background(),
+				bitio.NewBufferFromBytes(b, -1),
+				decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+					d.FieldStructArray("entries", "entry", tC.count, tC.fn)
+					return nil
+				}),
+				decode.Options{},
+			)
+			if err == nil || !strings.Contains(err.Error(), tC.expected) {
+				t.Errorf("expected error %q, got %v", tC.expected, err)
+			}
+			if dv == nil {
+				t.Fatal("expected partial value")
+			}
+			c := dv.V.(*decode.Compound).Children[0].V.(*decode.Compound)
+			if len(c.Children) != tC.expectedElements {
+				t.Errorf("expected %d elements, got %d", tC.expectedElements, len(c.Children))
+			}
+		})
+	}
+}
+
+func TestFormatErrorPartial(t *testing.T) {
+	testCases := []struct {
+		name     string
+		r        ranges.Range
+		expected int64
+	}{
+		{"whole buffer", ranges.Range{}, 12},
+		// positions are relative to the buffer, not the decode range
+		{"range", ranges.Range{Start: 8, Len: 24}, 20},
+	}
+	for _, tC := range testCases {
+		tC := tC
+		t.Run(tC.name, func(t *testing.T) {
+			dv, _, err := decode.Decode(
+				context.Background(),
+				bitio.NewBufferFromBytes([]byte{1, 2, 3, 4}, -1),
+				decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+					d.FieldU8("a")
+					d.FieldStruct("s", func(d *decode.D) {
+						d.FieldU4("b")
+						d.Errorf("broken")
+						d.FieldU4("c")
+					})
+					return nil
+				}),
+				decode.Options{Range: tC.r},
+			)
+
+			var fes decode.FormatsError
+			if !errors.As(err, &fes) || len(fes.Errs) != 1 {
+				t.Fatalf("expected one format error, got %v", err)
+			}
+			fe := fes.Errs[0]
+			if fe.Pos != tC.expected {
+				t.Errorf("expected error pos %d, got %d", tC.expected, fe.Pos)
+			}
+			if fe.Root == nil || fe.Root != dv {
+				t.Fatalf("expected partial root value")
+			}
+			a := fe.Root.V.(*decode.Compound).Children[0]
+			if a.Name != "a" || a.Range.Start != tC.r.Start {
+				t.Errorf("expected a at %d, got %s at %d", tC.r.Start, a.Name, a.Range.Start)
+			}
+			s := fe.Root.V.(*decode.Compound).Children[1]
+			if c := s.V.(*decode.Compound); len(c.Children) != 1 || c.Children[0].Name != "b" {
+				t.Errorf("expected s to only have b")
+			}
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package decode
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+// Encode writes the bits of v to w. Unmodified ranges are copied from the decoded buffer and
+// scalars changed using SetActual are encoded using the encoding they were read with.
+func Encode(v *Value, w bitio.BitWriter) error {
+	bufferRootV := v.BufferRoot()
+
+	var modified []*Value
+	if err := v.Walk(WalkOpts{
+		PreOrder:    true,
+		Materialize: true,
+		Fn: func(wv *Value, rootV *Value, depth int, rootDepth int) error {
+			if !wv.modified {
+				return nil
+			}
+			if wv.BufferRoot() != bufferRootV {
+				return fmt.Errorf("%s: can't encode modified value in other buffer", wv.Name)
+			}
+			modified = append(modified, wv)
+			return nil
+		},
+	}); err != nil {
+		return err
+	}
+	sort.Slice(modified, func(i, j int) bool { return modified[i].Range.Start < modified[j].Range.Start })
+
+	r := v.InnerRange()
+	pos := r.Start
+	copyTo := func(stop int64) error {
+		if stop <= pos {
+			return nil
+		}
+		bb, err := v.RootBitBuf.BitBufRange(pos, stop-pos)
+		if err != nil {
+			return err
+		}
+		if _, err := bitio.Copy(w, bb); err != nil {
+			return err
+		}
+		pos = stop
+		return nil
+	}
+
+	for _, mv := range modified {
+		if mv.Range.Start < pos {
+			return fmt.Errorf("%s: overlaps previous modified value", mv.Name)
+		}
+		if err := copyTo(mv.Range.Start); err != nil {
+			return err
+		}
+		buf, err := encodeScalar(mv)
+		if err != nil {
+			return fmt.Errorf("%s: %w", mv.Name, err)
+		}
+		if _, err := w.WriteBits(buf, int(mv.Range.Len)); err != nil {
+			return err
+		}
+		pos = mv.Range.Stop()
+	}
+
+	return copyTo(r.Stop())
+}
+
+// encodeScalar returns bits for a modified scalar, same length as its range
+func encodeScalar(v *Value) ([]byte, error) {
+	s, ok := v.V.(*scalar.S)
+	if !ok {
+		return nil, fmt.Errorf("not a scalar")
+	}
+	if v.Encoding.BitOrder != bitio.MSBFirst {
+		return nil, fmt.Errorf("%s bit order not supported", v.Encoding.BitOrder)
+	}
+	nBits := int(v.Range.Len)
+	buf := make([]byte, bitio.BitsByteCount(int64(nBits)))
+
+	encodeU := func(n uint64) ([]byte, error) {
+		if nBits > 64 {
+			return nil, fmt.Errorf("unsupported size %d", nBits)
+		}
+		if v.Encoding.Endian == LittleEndian {
+			n = bitio.Uint64ReverseBytes(nBits, n)
+		}
+		bitio.Write64(n, nBits, buf, 0)
+		return buf, nil
+	}
+
+	switch v.Encoding.Type {
+	case EncodingU:
+		n, ok := s.Actual.(uint64)
+		if !ok {
+			return nil, fmt.Errorf("expected uint64 got %T", s.Actual)
+		}
+		if nBits < 64 && n >= 1<<nBits {
+			return nil, fmt.Errorf("%d does not fit in %d bits", n, nBits)
+		}
+		return encodeU(n)
+	case EncodingS:
+		n, ok := s.Actual.(int64)
+		if !ok {
+			return nil, fmt.Errorf("expected int64 got %T", s.Actual)
+		}
+		if nBits < 64 && (n < -(1<<(nBits-1)) || n >= 1<<(nBits-1)) {
+			return nil, fmt.Errorf("%d does not fit in %d bits", n, nBits)
+		}
+		return encodeU(uint64(n) & (math.MaxUint64 >> (64 - nBits)))
+	case EncodingF:
+		f, ok := s.Actual.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected float64 got %T", s.Actual)
+		}
+		switch nBits {
+		case 32:
+			return encodeU(uint64(math.Float32bits(float32(f))))
+		case 64:
+			return encodeU(math.Float64bits(f))
+		default:
+			return nil, fmt.Errorf("unsupported float size %d", nBits)
+		}
+	case EncodingBool:
+		b, ok := s.Actual.(bool)
+		if !ok {
+			return nil, fmt.Errorf("expected bool got %T", s.Actual)
+		}
+		if b {
+			return encodeU(1)
+		}
+		return encodeU(0)
+	case EncodingUTF8, EncodingUTF8BOM:
+		str, ok := s.Actual.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected string got %T", s.Actual)
+		}
+		if v.Encoding.Type == EncodingUTF8BOM {
+			str = string(utf8BOMBytes) + str
+		}
+		if len(str) > len(buf) || nBits%8 != 0 {
+			return nil, fmt.Errorf("string does not fit in %d bytes", len(buf))
+		}
+		copy(buf, str)
+		return buf, nil
+	case EncodingRaw:
+		bb, ok := s.Actual.(*bitio.Buffer)
+		if !ok {
+			return nil, fmt.Errorf("expected buffer got %T", s.Actual)
+		}
+		if bb.Len() != int64(nBits) {
+			return nil, fmt.Errorf("expected %d bits got %d", nBits, bb.Len())
+		}
+		bw := bitio.NewBytesWriter()
+		if _, err := bitio.Copy(bw, bb.Clone()); err != nil {
+			return nil, err
+		}
+		return bw.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unknown encoding")
+	}
+}
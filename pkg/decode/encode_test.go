@@ -0,0 +1,136 @@
+package decode_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+)
+
+func fieldValue(t *testing.T, dv *decode.Value, names ...string) *decode.Value {
+	t.Helper()
+	for _, name := range names {
+		c, ok := dv.V.(*decode.Compound)
+		if !ok {
+			t.Fatalf("%s is not a compound", dv.Name)
+		}
+		var found *decode.Value
+		for _, v := range c.Children {
+			if v.Name == name {
+				found = v
+				break
+			}
+		}
+		if found == nil {
+			t.Fatalf("%s not found", name)
+		}
+		dv = found
+	}
+	return dv
+}
+
+func encodeBytes(t *testing.T, dv *decode.Value) []byte {
+	t.Helper()
+	w := bitio.NewBytesWriter()
+	if err := decode.Encode(dv, w); err != nil {
+		t.Fatal(err)
+	}
+	return w.Bytes()
+}
+
+var encodeTestBytes = []byte{
+	0b101_00011, 0x34, 0x12, 0xff, 0xfe, 'a', 'b', 'c', 0, 1, 2, 3,
+}
+
+func encodeTestDecode(d *decode.D) {
+	d.FieldU3("a")
+	d.FieldS5("b")
+	d.FieldU16LE("c")
+	d.FieldStruct("s", func(d *decode.D) {
+		d.FieldS16("d")
+		d.FieldUTF8("e", 4)
+	})
+	d.FieldRawLen("f", 3*8)
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	dv := decodeBytes(t, encodeTestBytes, encodeTestDecode)
+	if actual := encodeBytes(t, dv); !bytes.Equal(encodeTestBytes, actual) {
+		t.Errorf("expected %x, got %x", encodeTestBytes, actual)
+	}
+}
+
+func TestEncodeModified(t *testing.T) {
+	testCases := []struct {
+		names    []string
+		actual   interface{}
+		expected []byte
+	}{
+		{[]string{"a"}, uint64(2), []byte{0b010_00011, 0x34, 0x12, 0xff, 0xfe, 'a', 'b', 'c', 0, 1, 2, 3}},
+		{[]string{"b"}, int64(-1), []byte{0b101_11111, 0x34, 0x12, 0xff, 0xfe, 'a', 'b', 'c', 0, 1, 2, 3}},
+		{[]string{"c"}, uint64(0xabcd), []byte{0b101_00011, 0xcd, 0xab, 0xff, 0xfe, 'a', 'b', 'c', 0, 1, 2, 3}},
+		{[]string{"s", "d"}, int64(1), []byte{0b101_00011, 0x34, 0x12, 0x00, 0x01, 'a', 'b', 'c', 0, 1, 2, 3}},
+		{[]string{"s", "e"}, "de", []byte{0b101_00011, 0x34, 0x12, 0xff, 0xfe, 'd', 'e', 0, 0, 1, 2, 3}},
+		{[]string{"f"}, bitio.NewBufferFromBytes([]byte{4, 5, 6}, -1), []byte{0b101_00011, 0x34, 0x12, 0xff, 0xfe, 'a', 'b', 'c', 0, 4, 5, 6}},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.names[len(tc.names)-1], func(t *testing.T) {
+			dv := decodeBytes(t, encodeTestBytes, encodeTestDecode)
+			fieldValue(t, dv, tc.names...).SetActual(tc.actual)
+			if actual := encodeBytes(t, dv); !bytes.Equal(tc.expected, actual) {
+				t.Errorf("expected %x, got %x", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	testCases := []struct {
+		names  []string
+		actual interface{}
+	}{
+		{[]string{"a"}, uint64(8)},
+		{[]string{"b"}, int64(16)},
+		{[]string{"c"}, "abc"},
+		{[]string{"s", "e"}, "abcde"},
+		{[]string{"f"}, bitio.NewBufferFromBytes([]byte{4}, -1)},
+	}
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.names[len(tc.names)-1], func(t *testing.T) {
+			dv := decodeBytes(t, encodeTestBytes, encodeTestDecode)
+			fieldValue(t, dv, tc.names...).SetActual(tc.actual)
+			if err := decode.Encode(dv, bitio.NewBytesWriter()); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestEncodeUnknownEncoding(t *testing.T) {
+	dv := decodeBytes(t, []byte{1, 2}, func(d *decode.D) {
+		d.FieldUFn("a", func(d *decode.D) uint64 { return d.U8() + d.U8() })
+	})
+	if e := fieldValue(t, dv, "a").Encoding; e.Type != decode.EncodingNone {
+		t.Errorf("expected no encoding, got %v", e)
+	}
+}
+
+func TestEncodeUTF8BOM(t *testing.T) {
+	b := []byte{0xef, 0xbb, 0xbf, 'a', 'b', 0, 1}
+	dv := decodeBytes(t, b, func(d *decode.D) {
+		d.FieldUTF8("a", 6)
+		d.FieldU8("b")
+	})
+	av := fieldValue(t, dv, "a")
+	if av.Encoding.Type != decode.EncodingUTF8BOM {
+		t.Errorf("expected UTF8 BOM encoding, got %v", av.Encoding)
+	}
+	av.SetActual("c")
+	expected := []byte{0xef, 0xbb, 0xbf, 'c', 0, 0, 1}
+	if actual := encodeBytes(t, dv); !bytes.Equal(expected, actual) {
+		t.Errorf("expected %x, got %x", expected, actual)
+	}
+}
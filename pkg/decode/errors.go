@@ -1,6 +1,7 @@
 package decode
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -16,6 +17,10 @@ type FormatError struct {
 	Err        error
 	Format     Format
 	Stacktrace recoverfn.Raw
+	// Pos is the bit position of the error, same base as the ranges in Root
+	Pos int64
+	// Root is the partial value tree decoded before the error
+	Root *Value
 }
 
 type FormatsError struct {
@@ -48,6 +53,7 @@ func (fe FormatError) Value() interface{} {
 	return map[string]interface{}{
 		"format":     fe.Format.Name,
 		"error":      fe.Err.Error(),
+		"pos":        int(fe.Pos),
 		"stacktrace": st,
 	}
 }
@@ -88,3 +94,17 @@ func (e DecoderError) Error() string {
 }
 
 func (DecoderError) IsRecoverableError() bool { return true }
+
+// errorPos returns the bit position of err if known
+func errorPos(err error) (int64, bool) {
+	var de DecoderError
+	var ie IOError
+	switch {
+	case errors.As(err, &de):
+		return de.Pos, true
+	case errors.As(err, &ie):
+		return ie.Pos, true
+	default:
+		return 0, false
+	}
+}
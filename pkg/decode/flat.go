@@ -0,0 +1,95 @@
+package decode
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var flatIdentRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z_0-9]*$`)
+var flatKeyEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// same quoting as path_to_expr, ex: .a[1]."b.c"
+func flatPathKey(name string) string {
+	if flatIdentRe.MatchString(name) {
+		return name
+	}
+	return `"` + flatKeyEscaper.Replace(name) + `"`
+}
+
+// WalkFlat calls fn in pre-order for each scalar value in v with a jq style path relative to v
+func WalkFlat(v *Value, fn func(path string, v *Value)) {
+	walkFlat(v, "", fn)
+}
+
+func walkFlat(v *Value, path string, fn func(path string, v *Value)) {
+	v.Materialize()
+
+	switch vv := v.V.(type) {
+	case *Compound:
+		for i, cv := range vv.Children {
+			if vv.IsArray {
+				walkFlat(cv, path+"["+strconv.Itoa(i)+"]", fn)
+			} else {
+				walkFlat(cv, path+"."+flatPathKey(cv.Name), fn)
+			}
+		}
+	default:
+		if path == "" {
+			path = "."
+		}
+		fn(path, v)
+	}
+}
+
+func flatTypeValue(v *Value) (string, string, error) {
+	s, ok := v.V.(*scalar.S)
+	if !ok {
+		return "", "", fmt.Errorf("%s: unknown value %T", v.Name, v.V)
+	}
+	switch a := s.Actual.(type) {
+	case nil:
+		return "null", "null", nil
+	case uint64:
+		return "uint", strconv.FormatUint(a, 10), nil
+	case int64:
+		return "int", strconv.FormatInt(a, 10), nil
+	case float64:
+		return "float", strconv.FormatFloat(a, 'g', -1, 64), nil
+	case bool:
+		return "bool", strconv.FormatBool(a), nil
+	case string:
+		return "string", strconv.Quote(a), nil
+	case *bitio.Buffer:
+		bs, err := a.Bytes()
+		if err != nil {
+			return "", "", err
+		}
+		return "raw", hex.EncodeToString(bs), nil
+	default:
+		return fmt.Sprintf("%T", a), fmt.Sprintf("%v", a), nil
+	}
+}
+
+// WriteFlat writes one tab separated line per scalar value in v with path, bit offset, bit length, type and value.
+// Strings are quoted and raw bits are written as hex.
+func WriteFlat(w io.Writer, v *Value) error {
+	var err error
+	WalkFlat(v, func(path string, v *Value) {
+		if err != nil {
+			return
+		}
+		var typ, value string
+		if typ, value, err = flatTypeValue(v); err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(w, "%s\t%d\t%d\t%s\t%s\n", path, v.Range.Start, v.Range.Len, typ, value)
+	})
+	return err
+}
@@ -0,0 +1,52 @@
+package decode_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/wader/fq/pkg/decode"
+)
+
+func TestFlat(t *testing.T) {
+	dv := decodeBytes(t, []byte{0x01, 0x02, 0x41, 0x42, 0xf0, 0xab, 0xcd}, func(d *decode.D) {
+		d.FieldU8("a")
+		d.FieldStruct("b", func(d *decode.D) {
+			d.FieldArray("values", func(d *decode.D) {
+				d.FieldS8("value")
+				d.FieldStruct("value", func(d *decode.D) {
+					d.FieldUTF8("c.d", 2)
+				})
+			})
+			d.FieldBool("a \"b\"")
+			d.FieldU7("e")
+		})
+		d.FieldRawLen("raw", 16)
+	})
+
+	var paths []string
+	decode.WalkFlat(dv, func(path string, v *decode.Value) { paths = append(paths, path) })
+	expectedPaths := []string{".a", ".b.values[0]", `.b.values[1]."c.d"`, `.b."a \"b\""`, ".b.e", ".raw"}
+	if len(paths) != len(expectedPaths) {
+		t.Fatalf("expected %q, got %q", expectedPaths, paths)
+	}
+	for i := range paths {
+		if paths[i] != expectedPaths[i] {
+			t.Errorf("%d: expected %s, got %s", i, expectedPaths[i], paths[i])
+		}
+	}
+
+	b := &bytes.Buffer{}
+	if err := decode.WriteFlat(b, dv); err != nil {
+		t.Fatal(err)
+	}
+	expected := "" +
+		".a\t0\t8\tuint\t1\n" +
+		".b.values[0]\t8\t8\tint\t2\n" +
+		".b.values[1].\"c.d\"\t16\t16\tstring\t\"AB\"\n" +
+		".b.\"a \\\"b\\\"\"\t32\t1\tbool\ttrue\n" +
+		".b.e\t33\t7\tuint\t112\n" +
+		".raw\t40\t16\traw\tabcd\n"
+	if actual := b.String(); actual != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, actual)
+	}
+}
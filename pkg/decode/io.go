@@ -6,12 +6,13 @@ import (
 
 // TODO: move?
 // TODO: make generic replace reader? share with id3v2 unsync?
+// NALUnescapeReader removes emulation prevention bytes, 0x03 in 0x00 0x00 0x03
 type NALUnescapeReader struct {
 	io.Reader
 	lastTwoZeros [2]bool
 }
 
-func (r NALUnescapeReader) Read(p []byte) (n int, err error) {
+func (r *NALUnescapeReader) Read(p []byte) (n int, err error) {
 	n, err = r.Reader.Read(p)
 
 	ni := 0
@@ -0,0 +1,38 @@
+package decode_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/wader/fq/pkg/decode"
+)
+
+func TestNALUnescapeReader(t *testing.T) {
+	testCases := []struct {
+		input    []byte
+		expected []byte
+	}{
+		{[]byte{1, 2, 3}, []byte{1, 2, 3}},
+		{[]byte{0, 0, 3, 1}, []byte{0, 0, 1}},
+		{[]byte{0, 0, 3, 0, 0, 3}, []byte{0, 0, 0, 0}},
+		{[]byte{0, 0, 3, 3}, []byte{0, 0, 3}},
+		{[]byte{0, 3, 0, 3}, []byte{0, 3, 0, 3}},
+	}
+	for _, tC := range testCases {
+		// one byte reads makes sure state is kept between reads
+		for _, r := range []io.Reader{
+			bytes.NewReader(tC.input),
+			iotest.OneByteReader(bytes.NewReader(tC.input)),
+		} {
+			actual, err := io.ReadAll(&decode.NALUnescapeReader{Reader: r})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(tC.expected, actual) {
+				t.Errorf("%x: expected %x, got %x", tC.input, tC.expected, actual)
+			}
+		}
+	}
+}
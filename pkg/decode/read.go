@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math"
 
+	"github.com/wader/fq/internal/num"
 	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/scalar"
 	"golang.org/x/text/encoding"
 	"golang.org/x/text/encoding/unicode"
 )
@@ -15,15 +17,43 @@ func (d *D) tryUE(nBits int, endian Endian) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if endian == LittleEndian {
+	d.noteEncoding(Encoding{Type: EncodingU, Endian: endian})
+	if endian == LittleEndian && d.BitOrder == bitio.MSBFirst {
 		n = bitio.Uint64ReverseBytes(nBits, n)
 	}
 
 	return n, nil
 }
 
+func (d *D) noteEncoding(e Encoding) {
+	e.BitOrder = d.BitOrder
+	d.readEncoding = e
+	d.readEncodings++
+}
+
+var utf8BOMBytes = []byte{0xef, 0xbb, 0xbf}
+
+// noteTextEncoding notes encoding for text read from bs, a BOM is removed when decoding
+// so has to be noted to be encoded again
+func (d *D) noteTextEncoding(e encoding.Encoding, bs []byte) {
+	if e == UTF8BOM {
+		if bytes.HasPrefix(bs, utf8BOMBytes) {
+			d.noteEncoding(Encoding{Type: EncodingUTF8BOM})
+			return
+		}
+		d.noteEncoding(Encoding{Type: EncodingUTF8})
+		return
+	}
+	d.noteEncoding(Encoding{})
+}
+
 func (d *D) tryBitBuf(nBits int64) (*bitio.Buffer, error) {
-	return d.bitBuf.BitBufLen(nBits)
+	bb, err := d.bitBuf.BitBufLen(nBits)
+	if err != nil {
+		return nil, err
+	}
+	d.noteEncoding(Encoding{Type: EncodingRaw})
+	return bb, nil
 }
 
 func (d *D) trySE(nBits int, endian Endian) (int64, error) {
@@ -31,10 +61,11 @@ func (d *D) trySE(nBits int, endian Endian) (int64, error) {
 	if err != nil {
 		return 0, err
 	}
+	d.noteEncoding(Encoding{Type: EncodingS, Endian: endian})
 	if nBits == 0 {
 		return 0, nil
 	}
-	if endian == LittleEndian {
+	if endian == LittleEndian && d.BitOrder == bitio.MSBFirst {
 		n = bitio.Uint64ReverseBytes(nBits, n)
 	}
 	var s int64
@@ -53,10 +84,13 @@ func (d *D) tryFE(nBits int, endian Endian) (float64, error) {
 	if err != nil {
 		return 0, err
 	}
-	if endian == LittleEndian {
+	d.noteEncoding(Encoding{Type: EncodingF, Endian: endian})
+	if endian == LittleEndian && d.BitOrder == bitio.MSBFirst {
 		n = bitio.Uint64ReverseBytes(nBits, n)
 	}
 	switch nBits {
+	case 16:
+		return num.Float16(uint16(n)), nil
 	case 32:
 		return float64(math.Float32frombits(uint32(n))), nil
 	case 64:
@@ -66,15 +100,22 @@ func (d *D) tryFE(nBits int, endian Endian) (float64, error) {
 	}
 }
 
-func (d *D) tryFPE(nBits int, fBits int, endian Endian) (float64, error) {
-	n, err := d.bits(nBits)
+// tryFPScalarE reads a fixed-point number, actual is the raw integer and symbolic the number
+func (d *D) tryFPScalarE(nBits int, fBits int, endian Endian) (scalar.S, error) {
+	n, err := d.tryUE(nBits, endian)
 	if err != nil {
-		return 0, err
+		return scalar.S{}, err
 	}
-	if endian == LittleEndian {
-		n = bitio.Uint64ReverseBytes(nBits, n)
+	return scalar.S{Actual: n, Sym: float64(n) / float64(uint64(1<<fBits))}, nil
+}
+
+// trySFPScalarE reads a signed fixed-point number, actual is the raw integer and symbolic the number
+func (d *D) trySFPScalarE(nBits int, fBits int, endian Endian) (scalar.S, error) {
+	n, err := d.trySE(nBits, endian)
+	if err != nil {
+		return scalar.S{}, err
 	}
-	return float64(n) / float64(uint64(1<<fBits)), nil
+	return scalar.S{Actual: n, Sym: float64(n) / float64(uint64(1<<fBits))}, nil
 }
 
 var UTF8BOM = unicode.UTF8BOM
@@ -87,6 +128,7 @@ func (d *D) tryText(nBytes int, e encoding.Encoding) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	d.noteTextEncoding(e, bs)
 	return e.NewDecoder().String(string(bs))
 }
 
@@ -130,6 +172,7 @@ func (d *D) tryTextNull(nullBytes int, e encoding.Encoding) (string, error) {
 		d.SeekAbs(p)
 		return "", err
 	}
+	d.noteTextEncoding(e, bs)
 
 	return e.NewDecoder().String(string(bs[0 : n-nullBytes]))
 }
@@ -139,6 +182,7 @@ func (d *D) tryTextNullLen(fixedBytes int, e encoding.Encoding) (string, error)
 	if err != nil {
 		return "", err
 	}
+	d.noteTextEncoding(e, bs)
 	nullIndex := bytes.IndexByte(bs, 0)
 	if nullIndex != -1 {
 		bs = bs[:nullIndex]
@@ -165,10 +209,62 @@ func (d *D) tryUnary(ov uint64) (uint64, error) {
 	return n, nil
 }
 
+// unsigned LEB128, 7 bits per byte least significant group first, high bit set means more bytes follow
+func (d *D) tryULEB128() (uint64, error) {
+	p := d.Pos()
+	var n uint64
+	for shift := 0; ; shift += 7 {
+		b, err := d.bits(8)
+		if err != nil {
+			d.SeekAbs(p)
+			return 0, err
+		}
+		// 10th byte can only have the lowest bit left for a 64 bit value
+		if shift == 63 && b&0x7f > 1 || shift > 63 {
+			d.SeekAbs(p)
+			return 0, fmt.Errorf("uleb128 overflows 64 bits")
+		}
+		n |= (b & 0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+	}
+	return n, nil
+}
+
+// signed LEB128, same as unsigned but sign extended from the last byte
+func (d *D) trySLEB128() (int64, error) {
+	p := d.Pos()
+	var n int64
+	shift := 0
+	for {
+		b, err := d.bits(8)
+		if err != nil {
+			d.SeekAbs(p)
+			return 0, err
+		}
+		// 10th byte can only be sign extension of bit 63
+		if shift == 63 && b&0x7f != 0 && b&0x7f != 0x7f || shift > 63 {
+			d.SeekAbs(p)
+			return 0, fmt.Errorf("sleb128 overflows 64 bits")
+		}
+		n |= int64(b&0x7f) << shift
+		shift += 7
+		if b&0x80 == 0 {
+			if shift < 64 && b&0x40 != 0 {
+				n |= -1 << shift
+			}
+			break
+		}
+	}
+	return n, nil
+}
+
 func (d *D) tryBool() (bool, error) {
 	n, err := d.bits(1)
 	if err != nil {
 		return false, err
 	}
+	d.noteEncoding(Encoding{Type: EncodingBool})
 	return n == 1, nil
 }
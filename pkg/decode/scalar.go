@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"errors"
+	"fmt"
 
 	"github.com/wader/fq/pkg/bitio"
 	"github.com/wader/fq/pkg/scalar"
@@ -88,7 +89,7 @@ func assertBitBuf(s scalar.S, isErr bool, bss ...[]byte) (scalar.S, error) {
 	if isErr {
 		return s, errors.New("failed to validate raw")
 	}
-	return s, nil
+	return s, Annotation{Severity: SeverityWarning, Message: "failed to validate raw"}
 }
 
 func (d *D) AssertBitBuf(bss ...[]byte) scalar.Mapper {
@@ -103,6 +104,42 @@ func (d *D) ValidateBitBuf(bss ...[]byte) scalar.Mapper {
 	})
 }
 
+func (d *D) assertMagicBytes(expected []byte) scalar.Mapper {
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		ab, err := s.ActualBitBuf().Bytes()
+		if err != nil {
+			return s, err
+		}
+		if bytes.Equal(ab, expected) {
+			s.Description = "valid"
+			return s, nil
+		}
+		s.Description = "invalid"
+		err = fmt.Errorf("invalid magic, expected %x found %x", expected, ab)
+		if !d.Options.Force {
+			return s, err
+		}
+		return s, Annotation{Severity: SeverityError, Message: err.Error()}
+	})
+}
+
+func (d *D) assertMagicU(nBits int, expected uint64) scalar.Mapper {
+	return scalar.Fn(func(s scalar.S) (scalar.S, error) {
+		a := s.ActualU()
+		if a == expected {
+			s.Description = "valid"
+			return s, nil
+		}
+		s.Description = "invalid"
+		nDigits := (nBits + 3) / 4
+		err := fmt.Errorf("invalid magic, expected 0x%0*x found 0x%0*x", nDigits, expected, nDigits, a)
+		if !d.Options.Force {
+			return s, err
+		}
+		return s, Annotation{Severity: SeverityError, Message: err.Error()}
+	})
+}
+
 func assertUBytes(s scalar.S, isErr bool, endian Endian, bss ...[]byte) (scalar.S, error) {
 	var bo binary.ByteOrder
 	switch endian {
@@ -139,7 +176,7 @@ func assertUBytes(s scalar.S, isErr bool, endian Endian, bss ...[]byte) (scalar.
 	if isErr {
 		return s, errors.New("failed to validate raw")
 	}
-	return s, nil
+	return s, Annotation{Severity: SeverityWarning, Message: "failed to validate raw"}
 }
 
 func (d *D) AssertUBytes(bss ...[]byte) scalar.Mapper {
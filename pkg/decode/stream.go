@@ -0,0 +1,56 @@
+package decode
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/wader/fq/internal/rewindreadseeker"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/ranges"
+)
+
+// DecodeStream decodes group repeatedly from a forward only reader until end of stream
+// and calls fn with each decoded value. Each decode can see and rewind at most
+// rewindSize bytes so a decoded value must fit in it. Value ranges are stream offsets and
+// values can only be read from inside fn as the underlaying data is dropped after.
+func DecodeStream(ctx context.Context, r io.Reader, rewindSize int, group Group, opts Options, fn func(dv *Value, v interface{}) error) error {
+	rr := rewindreadseeker.New(r, rewindSize)
+	var offset int64
+
+	for {
+		if ctx != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		n, err := rr.Fill()
+		if err != nil {
+			return err
+		}
+		if n == 0 {
+			return nil
+		}
+
+		// length of buffer is end of currently buffered data
+		bb, err := bitio.NewBufferFromReadSeeker(rr)
+		if err != nil {
+			return err
+		}
+		opts.Range = ranges.Range{Start: offset * 8, Len: int64(n) * 8}
+		dv, v, err := decode(ctx, bb, group, opts)
+		if err != nil {
+			return err
+		}
+		if dv.Range.Len == 0 || dv.Range.Len%8 != 0 {
+			return fmt.Errorf("decode at offset %d did not consume whole bytes (%d bits)", offset, dv.Range.Len)
+		}
+		if err := fn(dv, v); err != nil {
+			return err
+		}
+
+		offset += dv.Range.Len / 8
+		if _, err := rr.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package decode_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/wader/fq/pkg/decode"
+)
+
+func TestDecodeStream(t *testing.T) {
+	// length prefixed records
+	recordFormat := decode.FormatFn(func(d *decode.D, in interface{}) interface{} {
+		l := d.FieldU8("length")
+		d.FieldUTF8("text", int(l))
+		return nil
+	})
+
+	pr, pw := io.Pipe()
+	go func() {
+		for _, s := range []string{"a", "bcd", "", "efghij"} {
+			_, _ = pw.Write(append([]byte{byte(len(s))}, s...))
+		}
+		pw.Close()
+	}()
+
+	var texts []string
+	var starts []int64
+	err := decode.DecodeStream(context.Background(), pr, 8, recordFormat, decode.Options{}, func(dv *decode.Value, v interface{}) error {
+		texts = append(texts, fieldScalar(t, dv, "text").ActualStr())
+		starts = append(starts, dv.Range.Start/8)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actual := strings.Join(texts, ","); actual != "a,bcd,,efghij" {
+		t.Errorf("expected a,bcd,,efghij, got %s", actual)
+	}
+	if len(starts) != 4 || starts[3] != 7 {
+		t.Errorf("expected last record at 7, got %v", starts)
+	}
+
+	// record larger than rewind size
+	err = decode.DecodeStream(context.Background(), strings.NewReader("\x09abcdefghi"), 8, recordFormat, decode.Options{}, func(dv *decode.Value, v interface{}) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("expected error for record larger than rewind size")
+	}
+}
@@ -0,0 +1,209 @@
+package decode
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+// UnmarshalError is returned when a decoded value can't be stored in a Go value
+type UnmarshalError struct {
+	Path string
+	Type reflect.Type
+	Err  error
+}
+
+func (e UnmarshalError) Error() string {
+	return fmt.Sprintf("%s: cannot unmarshal into %s: %s", e.Path, e.Type, e.Err)
+}
+
+func (e UnmarshalError) Unwrap() error { return e.Err }
+
+var valueType = reflect.TypeOf(Value{})
+var bitBufType = reflect.TypeOf(&bitio.Buffer{})
+
+// Unmarshal stores a decoded value tree in the value pointed to by target.
+// Structs are matched by `fq:"name"` field tags, untagged fields and fields
+// tagged with "-" are ignored. Arrays are stored in slices. Scalars are stored
+// using the actual value and if that is not possible the symbolic value.
+// Numbers are converted to other number types if they fit. A *bitio.Buffer or
+// []byte field can be used for raw bits and a *Value field gets the value itself.
+// Lazy values are materialized.
+func Unmarshal(v *Value, target interface{}) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return errors.New("target must be a non-nil pointer")
+	}
+	return unmarshal(v, rv.Elem(), "")
+}
+
+func unmarshal(v *Value, rv reflect.Value, path string) error {
+	if rv.Kind() == reflect.Ptr {
+		if rv.Type().Elem() == valueType {
+			rv.Set(reflect.ValueOf(v))
+			return nil
+		}
+		if rv.Type() != bitBufType {
+			if rv.IsNil() {
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			return unmarshal(v, rv.Elem(), path)
+		}
+	}
+
+	if c, ok := v.V.(*Compound); ok && c.lazyFn != nil {
+		v.Materialize()
+		if c.Err != nil {
+			return UnmarshalError{Path: path, Type: rv.Type(), Err: c.Err}
+		}
+	}
+
+	switch vv := v.V.(type) {
+	case *Compound:
+		if vv.IsArray {
+			return unmarshalArray(vv, rv, path)
+		}
+		return unmarshalStruct(vv, rv, path)
+	case *scalar.S:
+		if err := unmarshalScalar(vv, rv); err != nil {
+			return UnmarshalError{Path: path, Type: rv.Type(), Err: err}
+		}
+		return nil
+	default:
+		return UnmarshalError{Path: path, Type: rv.Type(), Err: fmt.Errorf("unknown value %T", v.V)}
+	}
+}
+
+func unmarshalArray(c *Compound, rv reflect.Value, path string) error {
+	if rv.Kind() != reflect.Slice {
+		return UnmarshalError{Path: path, Type: rv.Type(), Err: errors.New("array can only be unmarshaled into a slice")}
+	}
+	sv := reflect.MakeSlice(rv.Type(), len(c.Children), len(c.Children))
+	for i, cv := range c.Children {
+		if err := unmarshal(cv, sv.Index(i), path+"["+strconv.Itoa(i)+"]"); err != nil {
+			return err
+		}
+	}
+	rv.Set(sv)
+	return nil
+}
+
+func unmarshalStruct(c *Compound, rv reflect.Value, path string) error {
+	if rv.Kind() != reflect.Struct {
+		return UnmarshalError{Path: path, Type: rv.Type(), Err: errors.New("struct can only be unmarshaled into a struct")}
+	}
+
+	children := map[string]*Value{}
+	for _, cv := range c.Children {
+		children[cv.Name] = cv
+	}
+
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, ok := f.Tag.Lookup("fq")
+		if !ok || name == "-" || f.PkgPath != "" {
+			continue
+		}
+		cv, ok := children[name]
+		if !ok {
+			continue
+		}
+		if err := unmarshal(cv, rv.Field(i), path+"."+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalScalar(s *scalar.S, rv reflect.Value) error {
+	err := unmarshalAny(s.Actual, rv)
+	if err == nil || s.Sym == nil {
+		return err
+	}
+	if symErr := unmarshalAny(s.Sym, rv); symErr == nil {
+		return nil
+	}
+	return err
+}
+
+func unmarshalAny(a interface{}, rv reflect.Value) error {
+	switch a := a.(type) {
+	case uint64:
+		switch rv.Kind() {
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if rv.OverflowUint(a) {
+				return fmt.Errorf("%d overflows", a)
+			}
+			rv.SetUint(a)
+			return nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if a > math.MaxInt64 || rv.OverflowInt(int64(a)) {
+				return fmt.Errorf("%d overflows", a)
+			}
+			rv.SetInt(int64(a))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			rv.SetFloat(float64(a))
+			return nil
+		}
+	case int64:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if rv.OverflowInt(a) {
+				return fmt.Errorf("%d overflows", a)
+			}
+			rv.SetInt(a)
+			return nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+			if a < 0 || rv.OverflowUint(uint64(a)) {
+				return fmt.Errorf("%d overflows", a)
+			}
+			rv.SetUint(uint64(a))
+			return nil
+		case reflect.Float32, reflect.Float64:
+			rv.SetFloat(float64(a))
+			return nil
+		}
+	case float64:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			rv.SetFloat(a)
+			return nil
+		}
+	case string:
+		if rv.Kind() == reflect.String {
+			rv.SetString(a)
+			return nil
+		}
+	case bool:
+		if rv.Kind() == reflect.Bool {
+			rv.SetBool(a)
+			return nil
+		}
+	case *bitio.Buffer:
+		switch {
+		case rv.Type() == bitBufType:
+			rv.Set(reflect.ValueOf(a))
+			return nil
+		case rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() == reflect.Uint8:
+			b, err := a.Bytes()
+			if err != nil {
+				return err
+			}
+			rv.SetBytes(b)
+			return nil
+		}
+	}
+
+	if a == nil {
+		return errors.New("no value")
+	}
+	return fmt.Errorf("incompatible type %T", a)
+}
@@ -0,0 +1,169 @@
+package decode_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/wader/fq/format"
+	_ "github.com/wader/fq/format/all"
+	"github.com/wader/fq/format/registry"
+	"github.com/wader/fq/pkg/bitio"
+	"github.com/wader/fq/pkg/decode"
+	"github.com/wader/fq/pkg/scalar"
+)
+
+func TestUnmarshalMP4Ftyp(t *testing.T) {
+	type ftypBox struct {
+		Type         string   `fq:"type"`
+		MajorBrand   string   `fq:"major_brand"`
+		MinorVersion uint32   `fq:"minor_version"`
+		Brands       []string `fq:"brands"`
+	}
+	type mp4 struct {
+		Boxes []ftypBox `fq:"boxes"`
+	}
+
+	b, err := os.ReadFile("../../format/mp4/testdata/aac.mp4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dv, _, err := decode.Decode(
+		context.Background(),
+		bitio.NewBufferFromBytes(b, -1),
+		registry.Default.MustGroup(format.MP4),
+		decode.Options{IsRoot: true},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var m mp4
+	if err := decode.Unmarshal(dv, &m); err != nil {
+		t.Fatal(err)
+	}
+	expected := ftypBox{
+		Type:         "ftyp",
+		MajorBrand:   "isom",
+		MinorVersion: 512,
+		Brands:       []string{"isom", "iso2", "mp41"},
+	}
+	if len(m.Boxes) == 0 || !reflect.DeepEqual(expected, m.Boxes[0]) {
+		t.Errorf("expected %#+v, got %#+v", expected, m.Boxes)
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	type inner struct {
+		A int8 `fq:"a"`
+	}
+	type target struct {
+		U       uint16        `fq:"u"`
+		S       int           `fq:"s"`
+		F       float32       `fq:"f"`
+		Sym     string        `fq:"sym"`
+		Raw     []byte        `fq:"raw"`
+		RawBB   *bitio.Buffer `fq:"raw"`
+		Inner   *inner        `fq:"inner"`
+		Values  []uint8       `fq:"values"`
+		Value   *decode.Value `fq:"u"`
+		Ignored string
+		Skipped string `fq:"-"`
+	}
+
+	dv := decodeBytes(t, []byte{0x01, 0x02, 0xff, 0x3f, 0x80, 0x00, 0x00, 0x07, 0xab, 0xcd, 0x05, 0x0a, 0x0b}, func(d *decode.D) {
+		d.FieldU16("u")
+		d.FieldS8("s")
+		d.FieldF32("f")
+		d.FieldU8("sym", scalar.UToSymStr{7: "seven"})
+		d.FieldRawLen("raw", 16)
+		d.FieldStruct("inner", func(d *decode.D) { d.FieldS8("a") })
+		d.FieldArray("values", func(d *decode.D) {
+			d.FieldU8("value")
+			d.FieldU8("value")
+		})
+	})
+
+	var v target
+	if err := decode.Unmarshal(dv, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.U != 0x0102 || v.S != -1 || v.F != 1 || v.Sym != "seven" {
+		t.Errorf("unexpected scalars %#+v", v)
+	}
+	if !reflect.DeepEqual(v.Raw, []byte{0xab, 0xcd}) || v.RawBB == nil {
+		t.Errorf("unexpected raw %#+v %#+v", v.Raw, v.RawBB)
+	}
+	if v.Inner == nil || v.Inner.A != 5 {
+		t.Errorf("unexpected inner %#+v", v.Inner)
+	}
+	if !reflect.DeepEqual(v.Values, []uint8{0x0a, 0x0b}) {
+		t.Errorf("unexpected values %#+v", v.Values)
+	}
+	if v.Value == nil || v.Value.Name != "u" {
+		t.Errorf("unexpected value %#+v", v.Value)
+	}
+}
+
+func TestUnmarshalLazy(t *testing.T) {
+	type target struct {
+		Lazy struct {
+			A uint8 `fq:"a"`
+		} `fq:"lazy"`
+	}
+
+	dv := decodeBytes(t, []byte{0x01, 0x02}, func(d *decode.D) {
+		d.FieldU8("u")
+		d.FieldStructLazy("lazy", 8, 8, func(d *decode.D) { d.FieldU8("a") })
+	})
+
+	var v target
+	if err := decode.Unmarshal(dv, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Lazy.A != 2 {
+		t.Errorf("unexpected lazy %#+v", v.Lazy)
+	}
+}
+
+func TestUnmarshalErrors(t *testing.T) {
+	dv := decodeBytes(t, []byte{0x01, 0x02, 0xff}, func(d *decode.D) {
+		d.FieldU16("u")
+		d.FieldS8("s")
+	})
+
+	testCases := []struct {
+		name     string
+		target   interface{}
+		expected string
+	}{
+		{"overflow", &struct {
+			U uint8 `fq:"u"`
+		}{}, ".u: cannot unmarshal into uint8: 258 overflows"},
+		{"negative", &struct {
+			S uint `fq:"s"`
+		}{}, ".s: cannot unmarshal into uint: -1 overflows"},
+		{"incompatible", &struct {
+			U string `fq:"u"`
+		}{}, ".u: cannot unmarshal into string: incompatible type uint64"},
+		{"struct_into_slice", &[]int{}, ": cannot unmarshal into []int: struct can only be unmarshaled into a struct"},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			err := decode.Unmarshal(dv, tC.target)
+			var ue decode.UnmarshalError
+			if !errors.As(err, &ue) {
+				t.Fatalf("expected UnmarshalError, got %v", err)
+			}
+			if err.Error() != tC.expected {
+				t.Errorf("expected %q, got %q", tC.expected, err.Error())
+			}
+		})
+	}
+
+	if err := decode.Unmarshal(dv, struct{}{}); err == nil {
+		t.Error("expected non-pointer error")
+	}
+}
@@ -1,6 +1,6 @@
 package decode
 
-// TODO: Encoding, u16le, varint etc, encode?
+// TODO: varint etc encoding?
 // TODO: Value/Compound interface? can have per type and save memory
 
 import (
@@ -18,16 +18,70 @@ type Compound struct {
 	Description string
 	Format      *Format
 	Err         error
+
+	lazyFn func() // set until a lazy struct has been decoded
+}
+
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityError:
+		return "error"
+	default:
+		return "unknown"
+	}
+}
+
+// Annotation is a non-fatal problem found while decoding a value, ex a failed validation.
+// A mapper can return an Annotation as error to add it to the value without failing decode.
+type Annotation struct {
+	Severity Severity
+	Message  string
+}
+
+func (a Annotation) Error() string { return a.Severity.String() + ": " + a.Message }
+
+type EncodingType int
+
+const (
+	EncodingNone EncodingType = iota // unknown or not re-encodable
+	EncodingU
+	EncodingS
+	EncodingF
+	EncodingBool
+	EncodingUTF8    // zero padded to range
+	EncodingUTF8BOM // UTF-8 byte order mark followed by string zero padded to range
+	EncodingRaw
+)
+
+// Encoding describes how a scalar value was read so it can be encoded again
+type Encoding struct {
+	Type     EncodingType
+	Endian   Endian
+	BitOrder bitio.BitOrder
 }
 
 type Value struct {
-	Parent     *Value
-	Name       string
-	V          interface{} // scalar.S or Compound (array/struct)
-	Index      int         // index in parent array/struct
-	Range      ranges.Range
-	RootBitBuf *bitio.Buffer
-	IsRoot     bool // TODO: rework?
+	Parent      *Value
+	Name        string
+	V           interface{} // scalar.S or Compound (array/struct)
+	Index       int         // index in parent array/struct
+	Range       ranges.Range
+	RootBitBuf  *bitio.Buffer
+	IsRoot      bool // TODO: rework?
+	Annotations []Annotation
+	Encoding    Encoding
+
+	modified bool // actual value changed using SetActual
+	gap      bool // added by FillGaps for bits not decoded
 }
 
 type WalkFn func(v *Value, rootV *Value, depth int, rootDepth int) error
@@ -37,9 +91,10 @@ var ErrWalkBreak = errors.New("break")
 var ErrWalkStop = errors.New("stop")
 
 type WalkOpts struct {
-	PreOrder bool
-	OneRoot  bool
-	Fn       WalkFn
+	PreOrder    bool
+	OneRoot     bool
+	Materialize bool // decode lazy values before visiting them
+	Fn          WalkFn
 }
 
 func (v *Value) Walk(opts WalkOpts) error {
@@ -50,6 +105,10 @@ func (v *Value) Walk(opts WalkOpts) error {
 			return nil
 		}
 
+		if opts.Materialize {
+			wv.Materialize()
+		}
+
 		rootDepthDelta := 0
 		// only count switching to a new root
 		if wv.IsRoot && wv != rootV {
@@ -177,6 +236,17 @@ func (v *Value) Errors() []error {
 	return errs
 }
 
+// Warnings returns annotations with warning severity
+func (v *Value) Warnings() []Annotation {
+	var as []Annotation
+	for _, a := range v.Annotations {
+		if a.Severity == SeverityWarning {
+			as = append(as, a)
+		}
+	}
+	return as
+}
+
 func (v *Value) InnerRange() ranges.Range {
 	if v.IsRoot {
 		return ranges.Range{Start: 0, Len: v.Range.Len}
@@ -184,6 +254,24 @@ func (v *Value) InnerRange() ranges.Range {
 	return v.Range
 }
 
+// Materialize decodes v if it is a lazy value not yet decoded, decode errors are set as compound error
+func (v *Value) Materialize() {
+	c, ok := v.V.(*Compound)
+	if !ok || c.lazyFn == nil {
+		return
+	}
+	lazyFn := c.lazyFn
+	c.lazyFn = nil
+
+	r := v.Range
+	index := v.Index
+	lazyFn()
+	v.postProcess()
+	// keep range and index, decoded fields might not cover the whole range
+	v.Range = r
+	v.Index = index
+}
+
 func (v *Value) postProcess() {
 	if err := v.WalkRootPostOrder(func(v *Value, rootV *Value, depth int, rootDepth int) error {
 		switch vv := v.V.(type) {
@@ -58,6 +58,7 @@ func (i *Interp) _decode(c interface{}, a []interface{}) interface{} {
 	var opts struct {
 		Filename string                 `mapstructure:"filename"`
 		Force    bool                   `mapstructure:"force"`
+		MaxDepth int                    `mapstructure:"max_depth"`
 		Progress string                 `mapstructure:"_progress"`
 		Remain   map[string]interface{} `mapstructure:",remain"`
 	}
@@ -123,6 +124,7 @@ func (i *Interp) _decode(c interface{}, a []interface{}) interface{} {
 			IsRoot:        true,
 			FillGaps:      true,
 			Force:         opts.Force,
+			MaxDepth:      opts.MaxDepth,
 			Range:         bv.r,
 			Description:   opts.Filename,
 			FormatOptions: opts.Remain,
@@ -182,6 +184,8 @@ func toValue(optsFn func() Options, v interface{}) (interface{}, bool) {
 }
 
 func makeDecodeValue(dv *decode.Value) interface{} {
+	dv.Materialize()
+
 	switch vv := dv.V.(type) {
 	case *decode.Compound:
 		if vv.IsArray {
@@ -292,6 +296,8 @@ func (dvb decodeValueBase) ExtKeys() []string {
 		"_bits",
 		"_bytes",
 		"_unknown",
+		"_annotations",
+		"_warnings",
 		"_index", // TODO: only if parent is array?
 	}
 
@@ -422,6 +428,28 @@ func (dvb decodeValueBase) JQValueKey(name string) interface{} {
 		default:
 			return false
 		}
+	case "_annotations":
+		if len(dv.Annotations) == 0 {
+			return nil
+		}
+		var vs []interface{}
+		for _, a := range dv.Annotations {
+			vs = append(vs, map[string]interface{}{
+				"severity": a.Severity.String(),
+				"message":  a.Message,
+			})
+		}
+		return vs
+	case "_warnings":
+		ws := dv.Warnings()
+		if len(ws) == 0 {
+			return nil
+		}
+		var vs []interface{}
+		for _, a := range ws {
+			vs = append(vs, a.Message)
+		}
+		return vs
 	case "_index":
 		if dv.Index != -1 {
 			return dv.Index
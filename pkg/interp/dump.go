@@ -334,20 +334,28 @@ func dump(v *decode.Value, w io.Writer, opts Options) error {
 		}
 	}
 
-	_ = v.WalkPreOrder(makeWalkFn(func(v *decode.Value, rootV *decode.Value, depth int, rootDepth int) error {
-		maxAddrIndentWidth = num.MaxInt(
-			maxAddrIndentWidth,
-			rootDepth+num.DigitsInBase(bitio.BitsByteCount(v.InnerRange().Stop()), true, opts.AddrBase),
-		)
-		return nil
-	}))
+	_ = v.Walk(decode.WalkOpts{
+		PreOrder:    true,
+		Materialize: true,
+		Fn: makeWalkFn(func(v *decode.Value, rootV *decode.Value, depth int, rootDepth int) error {
+			maxAddrIndentWidth = num.MaxInt(
+				maxAddrIndentWidth,
+				rootDepth+num.DigitsInBase(bitio.BitsByteCount(v.InnerRange().Stop()), true, opts.AddrBase),
+			)
+			return nil
+		}),
+	})
 
 	cw := columnwriter.New(w, []int{maxAddrIndentWidth, 1, opts.LineBytes*3 - 1, 1, opts.LineBytes, 1, -1})
 	buf := make([]byte, 32*1024)
 
-	return v.WalkPreOrder(makeWalkFn(func(v *decode.Value, rootV *decode.Value, depth int, rootDepth int) error {
-		return dumpEx(v, buf, cw, depth, rootV, rootDepth, maxAddrIndentWidth-rootDepth, opts)
-	}))
+	return v.Walk(decode.WalkOpts{
+		PreOrder:    true,
+		Materialize: true,
+		Fn: makeWalkFn(func(v *decode.Value, rootV *decode.Value, depth int, rootDepth int) error {
+			return dumpEx(v, buf, cw, depth, rootV, rootDepth, maxAddrIndentWidth-rootDepth, opts)
+		}),
+	})
 }
 
 func hexdump(w io.Writer, bv Buffer, opts Options) error {
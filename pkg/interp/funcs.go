@@ -1,17 +1,22 @@
 package interp
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/md5"
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"hash"
 	"io"
+	"math"
+	"math/big"
 	"net/url"
 
+	"github.com/wader/fq/internal/gojqextra"
 	"github.com/wader/fq/pkg/bitio"
 	"github.com/wader/fq/pkg/decode"
 
@@ -22,6 +27,7 @@ func init() {
 	functionRegisterFns = append(functionRegisterFns, func(i *Interp) []Function {
 		return []Function{
 			{"_hexdump", 1, 1, nil, i._hexdump},
+			{"flat", 0, 0, nil, i.flat},
 
 			{"hex", 0, 0, makeStringBitBufTransformFn(
 				func(r io.Reader) (io.Reader, error) { return hex.NewDecoder(r), nil },
@@ -53,6 +59,14 @@ func init() {
 			{"path_escape", 0, 0, i.pathEscape, nil},
 			{"path_unescape", 0, 0, i.pathUnescape, nil},
 			{"aes_ctr", 1, 2, i.aesCtr, nil},
+			{"bits_as_float", 0, 1, i.bitsAsFloat, nil},
+			{"float_as_bits", 0, 1, i.floatAsBits, nil},
+			{"strings", 1, 2, nil, i.strings},
+			{"xor", 1, 1, makeKeyedByteTransformFn("xor", func(b, k byte) byte { return b ^ k }), nil},
+			{"mask", 1, 1, makeKeyedByteTransformFn("mask", func(b, k byte) byte { return b & k }), nil},
+			{"invert", 0, 0, makeBitBufTransformFn(func(r io.Reader) (io.Reader, error) {
+				return &keyedByteReader{r: r, key: []byte{0xff}, fn: func(b, k byte) byte { return b ^ k }}, nil
+			}), nil},
 		}
 	})
 }
@@ -153,6 +167,57 @@ func makeHashFn(fn func() (hash.Hash, error)) func(c interface{}, a []interface{
 	}
 }
 
+// applies fn to each byte with a repeating key
+type keyedByteReader struct {
+	r   io.Reader
+	key []byte
+	fn  func(b, k byte) byte
+	n   int
+}
+
+func (kr *keyedByteReader) Read(p []byte) (int, error) {
+	n, err := kr.r.Read(p)
+	for i := 0; i < n; i++ {
+		p[i] = kr.fn(p[i], kr.key[kr.n%len(kr.key)])
+		kr.n++
+	}
+	return n, err
+}
+
+// transform buffer using a key argument, number or byte string, that repeats
+func makeKeyedByteTransformFn(name string, fn func(b, k byte) byte) func(c interface{}, a []interface{}) interface{} {
+	return func(c interface{}, a []interface{}) interface{} {
+		var key []byte
+		switch a0 := a[0].(type) {
+		case int, float64, *big.Int:
+			bi, err := toBigInt(a0)
+			if err != nil {
+				return err
+			}
+			if bi.Sign() < 0 {
+				return fmt.Errorf("%s: key can't be negative", name)
+			}
+			key = bi.Bytes()
+			if len(key) == 0 {
+				key = []byte{0}
+			}
+		default:
+			var err error
+			key, err = toBytes(a0)
+			if err != nil {
+				return err
+			}
+		}
+		if len(key) == 0 {
+			return fmt.Errorf("%s: key can't be empty", name)
+		}
+
+		return makeBitBufTransformFn(func(r io.Reader) (io.Reader, error) {
+			return &keyedByteReader{r: r, key: key, fn: fn}, nil
+		})(c, nil)
+	}
+}
+
 func (i *Interp) queryEscape(c interface{}, a []interface{}) interface{} {
 	s, err := toString(c)
 	if err != nil {
@@ -237,6 +302,185 @@ func (i *Interp) aesCtr(c interface{}, a []interface{}) interface{} {
 	return newBufferFromBuffer(bitio.NewBufferFromBytes(buf.Bytes(), -1), 8)
 }
 
+// optional first argument is float size in bits, 32 or 64, default 64
+func floatBitsArg(a []interface{}) (int, error) {
+	if len(a) == 0 {
+		return 64, nil
+	}
+	bi, err := toBigInt(a[0])
+	if err != nil {
+		return 0, err
+	}
+	switch n := int(bi.Int64()); n {
+	case 32, 64:
+		return n, nil
+	default:
+		return 0, fmt.Errorf("bits should be 32 or 64, is %d", n)
+	}
+}
+
+// reinterpret integer bits as IEEE 754 float
+func (i *Interp) bitsAsFloat(c interface{}, a []interface{}) interface{} {
+	nBits, err := floatBitsArg(a)
+	if err != nil {
+		return err
+	}
+	if jv, ok := c.(gojq.JQValue); ok {
+		c = jv.JQValueToGoJQ()
+	}
+	bi, err := toBigInt(c)
+	if err != nil {
+		return err
+	}
+	if bi.Sign() < 0 || bi.BitLen() > nBits {
+		return fmt.Errorf("value does not fit in %d bits", nBits)
+	}
+
+	if nBits == 32 {
+		return float64(math.Float32frombits(uint32(bi.Uint64())))
+	}
+	return math.Float64frombits(bi.Uint64())
+}
+
+// reinterpret IEEE 754 float as integer bits
+func (i *Interp) floatAsBits(c interface{}, a []interface{}) interface{} {
+	nBits, err := floatBitsArg(a)
+	if err != nil {
+		return err
+	}
+	if jv, ok := c.(gojq.JQValue); ok {
+		c = jv.JQValueToGoJQ()
+	}
+	var f float64
+	switch c := c.(type) {
+	case int:
+		f = float64(c)
+	case float64:
+		f = c
+	case *big.Int:
+		f, _ = new(big.Float).SetInt(c).Float64()
+	default:
+		return fmt.Errorf("value is not a number")
+	}
+
+	if nBits == 32 {
+		return int(math.Float32bits(float32(f)))
+	}
+	u := math.Float64bits(f)
+	if u > math.MaxInt64 {
+		return new(big.Int).SetUint64(u)
+	}
+	return int(u)
+}
+
+func isPrintableByte(b byte) bool {
+	return b == '\t' || (b >= 0x20 && b <= 0x7e)
+}
+
+// find runs of printable characters, like strings(1)
+func (i *Interp) strings(c interface{}, a []interface{}) gojq.Iter {
+	minLen, ok := gojqextra.ToInt(a[0])
+	if !ok || minLen < 1 {
+		return gojq.NewIter(fmt.Errorf("strings: minlen must be a positive integer"))
+	}
+	encoding := "ascii"
+	if len(a) > 1 {
+		encoding, ok = a[1].(string)
+		if !ok {
+			return gojq.NewIter(gojqextra.FuncTypeNameError{Name: "strings", Typ: "string"})
+		}
+	}
+	unitSize := 2
+	var unitChar func(u []byte) byte
+	switch encoding {
+	case "ascii":
+		unitSize = 1
+		unitChar = func(u []byte) byte { return u[0] }
+	case "utf16le":
+		unitChar = func(u []byte) byte {
+			if u[1] != 0 {
+				return 0
+			}
+			return u[0]
+		}
+	case "utf16be":
+		unitChar = func(u []byte) byte {
+			if u[0] != 0 {
+				return 0
+			}
+			return u[1]
+		}
+	default:
+		return gojq.NewIter(fmt.Errorf("strings: unknown encoding %q, expected ascii, utf16le or utf16be", encoding))
+	}
+
+	bv, err := toBuffer(c)
+	if err != nil {
+		return gojq.NewIter(err)
+	}
+	bb, err := bv.toBuffer()
+	if err != nil {
+		return gojq.NewIter(err)
+	}
+
+	br := bufio.NewReader(bb)
+	unit := make([]byte, unitSize)
+	var run []byte
+	var runStart int64
+	var off int64
+	done := false
+
+	return iterFn(func() (interface{}, bool) {
+		for !done {
+			_, err := io.ReadFull(br, unit)
+			unitOff := off
+			var ch byte
+			if err == nil {
+				off += int64(unitSize)
+				ch = unitChar(unit)
+			} else if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				done = true
+			} else {
+				done = true
+				return err, true
+			}
+
+			if isPrintableByte(ch) {
+				if len(run) == 0 {
+					runStart = unitOff
+				}
+				run = append(run, ch)
+				continue
+			}
+
+			if len(run) >= minLen {
+				s := string(run)
+				run = run[:0]
+				return map[string]interface{}{
+					"start":  int(bv.r.Start + runStart*8),
+					"stop":   int(bv.r.Start + unitOff*8),
+					"string": s,
+				}, true
+			}
+			run = run[:0]
+		}
+
+		return nil, false
+	})
+}
+
+func (i *Interp) flat(c interface{}, a []interface{}) gojq.Iter {
+	dv, ok := c.(DecodeValue)
+	if !ok {
+		return gojq.NewIter(fmt.Errorf("%+#v: not a decode value", c))
+	}
+	if err := decode.WriteFlat(i.evalContext.output, dv.DecodeValue()); err != nil {
+		return gojq.NewIter(err)
+	}
+
+	return gojq.NewIter()
+}
+
 func (i *Interp) _hexdump(c interface{}, a []interface{}) gojq.Iter {
 	opts := i.Options(a[0])
 	bv, err := toBuffer(c)
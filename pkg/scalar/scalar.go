@@ -162,6 +162,32 @@ var RawHexReverse = Fn(func(s S) (S, error) {
 	})
 })
 
+// UEnum maps actual value to a symbolic string, values not found keep the raw value and are flagged and described as unknown
+func UEnum(m UToSymStr) Mapper {
+	return Fn(func(s S) (S, error) {
+		if t, ok := m[s.ActualU()]; ok {
+			s.Sym = t
+		} else {
+			s.Unknown = true
+			s.Description = "unknown"
+		}
+		return s, nil
+	})
+}
+
+// Lookup reverse maps symbolic string sym to actual value, lowest value if more than one has the same symbol
+func (m UToSymStr) Lookup(sym string) (uint64, bool) {
+	var found bool
+	var n uint64
+	for k, v := range m {
+		if v == sym && (!found || k < n) {
+			found = true
+			n = k
+		}
+	}
+	return n, found
+}
+
 type URangeToScalar map[[2]uint64]S
 
 func (m URangeToScalar) MapScalar(s S) (S, error) {
@@ -0,0 +1,67 @@
+package scalar_test
+
+import (
+	"testing"
+
+	"github.com/wader/fq/pkg/scalar"
+)
+
+var testNames = scalar.UToSymStr{
+	1: "one",
+	2: "two",
+	4: "dup",
+	5: "dup",
+	6: "dup",
+}
+
+func TestUEnum(t *testing.T) {
+	testCases := []struct {
+		actual              uint64
+		expectedSym         interface{}
+		expectedDescription string
+		expectedUnknown     bool
+	}{
+		{1, "one", "", false},
+		{2, "two", "", false},
+		{3, nil, "unknown", true},
+	}
+	for _, tC := range testCases {
+		s, err := scalar.UEnum(testNames).MapScalar(scalar.S{Actual: tC.actual})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if s.Actual != tC.actual {
+			t.Errorf("expected actual %v, got %v", tC.actual, s.Actual)
+		}
+		if s.Sym != tC.expectedSym {
+			t.Errorf("expected sym %v, got %v", tC.expectedSym, s.Sym)
+		}
+		if s.Description != tC.expectedDescription {
+			t.Errorf("expected description %q, got %q", tC.expectedDescription, s.Description)
+		}
+		if s.Unknown != tC.expectedUnknown {
+			t.Errorf("expected unknown %v, got %v", tC.expectedUnknown, s.Unknown)
+		}
+	}
+}
+
+func TestUToSymStrLookup(t *testing.T) {
+	testCases := []struct {
+		sym        string
+		expected   uint64
+		expectedOk bool
+	}{
+		{"one", 1, true},
+		{"two", 2, true},
+		{"three", 0, false},
+		{"dup", 4, true},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.sym, func(t *testing.T) {
+			actual, ok := testNames.Lookup(tC.sym)
+			if actual != tC.expected || ok != tC.expectedOk {
+				t.Errorf("expected %v %v, got %v %v", tC.expected, tC.expectedOk, actual, ok)
+			}
+		})
+	}
+}